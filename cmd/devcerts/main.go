@@ -0,0 +1,205 @@
+// devcerts 为本地开发生成CA和各服务的证书，用于离线测试TLS和双向TLS
+//
+//	go run ./cmd/devcerts -out certs
+//
+// 已存在的CA会被复用，重新运行只会签发新的服务证书，可用来验证证书热加载
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func main() {
+	out := flag.String("out", "certs", "output directory")
+	services := flag.String("services", "user,movie,rating,web,movieinfoctl", "comma-separated service names to issue certificates for")
+	hosts := flag.String("hosts", "localhost,127.0.0.1,::1", "comma-separated extra DNS names and IPs added to every certificate")
+	validFor := flag.Duration("valid-for", 365*24*time.Hour, "certificate lifetime")
+	newCA := flag.Bool("new-ca", false, "replace an existing CA")
+	flag.Parse()
+
+	if err := run(*out, splitList(*services), splitList(*hosts), *validFor, *newCA); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// run 生成或复用CA，并为每个服务签发证书
+func run(out string, services, hosts []string, validFor time.Duration, newCA bool) error {
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	caCertPath := filepath.Join(out, "ca.pem")
+	caKeyPath := filepath.Join(out, "ca-key.pem")
+
+	ca, caKey, err := loadCA(caCertPath, caKeyPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if ca == nil || newCA {
+		if ca, caKey, err = createCA(caCertPath, caKeyPath, validFor); err != nil {
+			return err
+		}
+		fmt.Printf("created CA %s\n", caCertPath)
+	}
+
+	for _, service := range services {
+		certPath := filepath.Join(out, service+".pem")
+		keyPath := filepath.Join(out, service+"-key.pem")
+		if err := issue(ca, caKey, service, hosts, validFor, certPath, keyPath); err != nil {
+			return fmt.Errorf("failed to issue certificate for %s: %w", service, err)
+		}
+		fmt.Printf("issued %s (spiffe://movieinfo/%s)\n", certPath, service)
+	}
+	return nil
+}
+
+// loadCA 读取已有的CA证书和私钥
+func loadCA(certPath, keyPath string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	pair, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		if _, statErr := os.Stat(certPath); errors.Is(statErr, os.ErrNotExist) {
+			return nil, nil, os.ErrNotExist
+		}
+		return nil, nil, fmt.Errorf("failed to load CA: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	key, ok := pair.PrivateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("CA key must be an ECDSA key")
+	}
+	return cert, key, nil
+}
+
+// createCA 创建自签名CA
+func createCA(certPath, keyPath string, validFor time.Duration) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+
+	template, err := newTemplate("movieinfo dev CA", validFor)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	if err := writeFiles(certPath, keyPath, der, key); err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	return cert, key, nil
+}
+
+// issue 签发服务证书，证书同时用于服务端和客户端身份
+func issue(ca *x509.Certificate, caKey *ecdsa.PrivateKey, service string, hosts []string, validFor time.Duration, certPath, keyPath string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	template, err := newTemplate(service, validFor)
+	if err != nil {
+		return err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	template.DNSNames = []string{service, service + "-service"}
+	template.URIs = []*url.URL{{Scheme: "spiffe", Host: "movieinfo", Path: "/" + service}}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return fmt.Errorf("failed to create certificate: %w", err)
+	}
+	return writeFiles(certPath, keyPath, der, key)
+}
+
+// newTemplate 创建证书模板
+func newTemplate(commonName string, validFor time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"movieinfo"}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validFor),
+	}, nil
+}
+
+// writeFiles 以PEM格式写入证书和私钥，私钥仅对当前用户可读
+// 先写临时文件再重命名，服务在轮换过程中不会读到写了一半的文件
+func writeFiles(certPath, keyPath string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	if err := writeAtomic(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		return err
+	}
+	return writeAtomic(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644)
+}
+
+// writeAtomic 原子地写入文件
+func writeAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// splitList 拆分逗号分隔的列表
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
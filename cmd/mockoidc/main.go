@@ -0,0 +1,56 @@
+// mockoidc 在本地运行模拟的OIDC身份提供方，用于离线测试第三方登录
+//
+//	go run ./cmd/mockoidc -addr :9400 -email alice@example.com
+//
+// 授权页面可以填写任意身份，加上 -auto-approve 时直接以命令行指定的身份登录
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/3inchtime/movieinfo/pkg/oidc/oidctest"
+)
+
+func main() {
+	addr := flag.String("addr", ":9400", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9400", "issuer URL, must match oidc.providers.<name>.issuer")
+	clientID := flag.String("client-id", "movieinfo", "client ID accepted by the provider")
+	clientSecret := flag.String("client-secret", "movieinfo-secret", "client secret accepted by the provider, empty to skip the check")
+	subject := flag.String("subject", "mock-user-1", "default subject (sub claim)")
+	email := flag.String("email", "alice@example.com", "default email claim")
+	emailVerified := flag.Bool("email-verified", true, "default email_verified claim")
+	name := flag.String("name", "Alice", "default name claim")
+	username := flag.String("username", "alice", "default preferred_username claim")
+	autoApprove := flag.Bool("auto-approve", false, "skip the authorization page and log in with the default identity")
+	flag.Parse()
+
+	provider, err := oidctest.NewProvider(&oidctest.Config{
+		Issuer:       *issuer,
+		ClientID:     *clientID,
+		ClientSecret: *clientSecret,
+		Identity: oidctest.Identity{
+			Subject:           *subject,
+			Email:             *email,
+			EmailVerified:     *emailVerified,
+			Name:              *name,
+			PreferredUsername: *username,
+		},
+		AutoApprove: *autoApprove,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("mock OIDC provider %s listening on %s\n", *issuer, *addr)
+	server := &http.Server{Addr: *addr, Handler: provider, ReadHeaderTimeout: 5 * time.Second}
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,525 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/3inchtime/movieinfo/internal/config"
+	"github.com/3inchtime/movieinfo/internal/handler"
+	"github.com/3inchtime/movieinfo/internal/handler/web"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/internal/service"
+	"github.com/3inchtime/movieinfo/pkg/apikey"
+	"github.com/3inchtime/movieinfo/pkg/app"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/database"
+	"github.com/3inchtime/movieinfo/pkg/eventbus"
+	"github.com/3inchtime/movieinfo/pkg/gateway"
+	grpcx "github.com/3inchtime/movieinfo/pkg/grpc"
+	"github.com/3inchtime/movieinfo/pkg/idempotency"
+	"github.com/3inchtime/movieinfo/pkg/lockout"
+	"github.com/3inchtime/movieinfo/pkg/mailer"
+	"github.com/3inchtime/movieinfo/pkg/metrics"
+	"github.com/3inchtime/movieinfo/pkg/oidc"
+	"github.com/3inchtime/movieinfo/pkg/onetimecode"
+	"github.com/3inchtime/movieinfo/pkg/password"
+	"github.com/3inchtime/movieinfo/pkg/ratelimit"
+	"github.com/3inchtime/movieinfo/pkg/rbac"
+	"github.com/3inchtime/movieinfo/pkg/redis"
+	"github.com/3inchtime/movieinfo/pkg/tracing"
+	"github.com/3inchtime/movieinfo/pkg/validation"
+	moviepb "github.com/3inchtime/movieinfo/proto/gen/movie"
+	ratingpb "github.com/3inchtime/movieinfo/proto/gen/rating"
+	userpb "github.com/3inchtime/movieinfo/proto/gen/user"
+)
+
+// backendServices 单进程模式下运行的后端gRPC服务
+var backendServices = []string{"user", "movie", "rating"}
+
+// rolePermissionsTTL 角色权限表的缓存时间，修改 role_permissions 表后最迟在此时间后生效
+const rolePermissionsTTL = time.Minute
+
+// allOptions all 子命令的参数
+type allOptions struct {
+	configPath     string
+	grpcConfigPath string
+	database       string
+	cache          string
+}
+
+// newAllCommand 创建 all 子命令
+func newAllCommand() *cobra.Command {
+	opts := &allOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "all",
+		Short: "Run web, user, movie and rating services in one process",
+		Long: `Run web, user, movie and rating services in one process.
+
+The services call each other over in-memory gRPC connections and no ports other
+than the web server and the metrics server are opened. By default the stack is
+backed by a SQLite file and in-process caches, so MySQL and Redis are not needed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAll(cmd.Context(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.configPath, "config", "configs/config.yaml", "application config file")
+	flags.StringVar(&opts.grpcConfigPath, "grpc-config", "configs/grpc.yaml", "gRPC config file")
+	flags.StringVar(&opts.database, "database", "movieinfo.db", `SQLite database file, ":memory:" for a throwaway database, empty to use the database from the config file`)
+	flags.StringVar(&opts.cache, "cache", "memory", "cache backend for the event bus, rate limiter and idempotency store: memory or redis")
+	return cmd
+}
+
+// runAll 加载配置并运行所有服务，直到收到退出信号
+func runAll(ctx context.Context, opts *allOptions) error {
+	s, err := newStack(opts)
+	if err != nil {
+		return err
+	}
+
+	// 按依赖顺序启动，收到退出信号后按相反顺序关闭
+	a := app.New("movieinfo", s.cfg.GetShutdownConfig())
+	a.Add(
+		app.Logger(s.cfg.GetLoggerConfig()),
+		s.tracing(),
+		s.database(),
+		s.cache(),
+	)
+	for _, name := range backendServices {
+		a.Add(s.grpcServer(a, name))
+	}
+	a.Add(s.clientFactory(), s.webServer())
+	if s.cfg.Metrics.Enabled {
+		a.Add(s.metricsServer())
+	}
+
+	return a.Run(ctx)
+}
+
+// newStack 加载配置并按单进程模式调整，组件在应用启动时依次创建
+func newStack(opts *allOptions) (*stack, error) {
+	// 通过环境变量覆盖数据库配置，使加载时的配置校验按SQLite进行
+	if opts.database != "" {
+		os.Setenv("MOVIEINFO_DATABASE_DRIVER", "sqlite")
+		os.Setenv("MOVIEINFO_DATABASE_DATABASE", opts.database)
+	}
+
+	cfg, err := config.NewConfig(opts.configPath)
+	if err != nil {
+		return nil, err
+	}
+	grpcConfig, err := grpcx.LoadConfig(opts.grpcConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyAllMode(cfg, grpcConfig, opts); err != nil {
+		return nil, err
+	}
+	return &stack{cfg: cfg, grpcConfig: grpcConfig, network: grpcx.NewInProcess()}, nil
+}
+
+// applyAllMode 将配置调整为单进程模式：关闭服务间TLS与服务发现，按参数替换缓存
+func applyAllMode(cfg *config.AppConfig, grpcConfig *grpcx.Config, opts *allOptions) error {
+	switch opts.cache {
+	case "memory":
+		cfg.EventBus.Driver = "memory"
+		grpcConfig.Server.RateLimit.Store = "memory"
+		grpcConfig.Server.Idempotency.Store = "memory"
+		cfg.PasswordReset.Store = "memory"
+		cfg.EmailVerification.Store = "memory"
+		cfg.JWT.Store = "memory"
+		cfg.LoginProtection.Store = "memory"
+		cfg.TwoFactor.Store = "memory"
+		cfg.OIDC.Store = "memory"
+	case "redis":
+	default:
+		return fmt.Errorf("unsupported cache backend: %s", opts.cache)
+	}
+	if cfg.Database.Driver == "sqlite" && grpcConfig.Server.Idempotency.Store == "mysql" {
+		grpcConfig.Server.Idempotency.Store = "memory"
+	}
+
+	grpcConfig.Server.TLS.Enabled = false
+	grpcConfig.Client.TLS.Enabled = false
+	grpcConfig.Client.Discovery.Enabled = false
+	return nil
+}
+
+// stack 单进程模式下各组件共享的资源，由组件的 Start 依次创建
+type stack struct {
+	cfg        *config.AppConfig
+	grpcConfig *grpcx.Config
+	network    *grpcx.InProcess
+
+	db         *sql.DB
+	redis      *goredis.Client
+	bus        eventbus.Bus
+	sessions   *auth.Sessions
+	authorizer *rbac.Authorizer
+	clients    *grpcx.ClientFactory
+
+	userService              service.UserService
+	authService              service.AuthService
+	emailVerificationService service.EmailVerificationService
+	passwordResetService     service.PasswordResetService
+	twoFactorService         service.TwoFactorService
+	oidcService              service.OIDCService
+	sessionService           service.SessionService
+	apiKeyService            service.APIKeyService
+	roleService              service.RoleService
+	movieService             service.MovieService
+	ratingService            service.RatingService
+}
+
+// tracing 链路追踪组件
+func (s *stack) tracing() app.Component {
+	var shutdown func(context.Context) error
+	return app.Component{
+		Name: "tracing",
+		Start: func(ctx context.Context) (err error) {
+			shutdown, err = tracing.Init(ctx, s.cfg.GetTracingConfig(), "movieinfo", s.cfg.App.Version)
+			return err
+		},
+		Stop: func(ctx context.Context) error {
+			return shutdown(ctx)
+		},
+	}
+}
+
+// database 数据库组件，SQLite数据库首次使用时创建表结构并写入初始数据
+func (s *stack) database() app.Component {
+	return app.Component{
+		Name: "database",
+		Start: func(ctx context.Context) (err error) {
+			if s.db, err = database.Open(s.cfg.GetDatabaseConfig()); err != nil {
+				return err
+			}
+			if s.cfg.Database.Driver == "sqlite" {
+				return repository.MigrateSQLite(ctx, s.db)
+			}
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return s.db.Close()
+		},
+	}
+}
+
+// cache 缓存组件，只有使用Redis时才建立连接，同时创建事件总线和业务服务
+func (s *stack) cache() app.Component {
+	return app.Component{
+		Name: "cache",
+		Start: func(ctx context.Context) (err error) {
+			if s.usesRedis() {
+				if s.redis, err = redis.NewClient(s.cfg.GetRedisConfig()); err != nil {
+					return err
+				}
+			}
+			if s.bus, err = eventbus.New(s.cfg.GetEventBusConfig(), s.redis); err != nil {
+				return err
+			}
+
+			if err := s.userServices(); err != nil {
+				return err
+			}
+			s.movieService = service.NewMovieService(repository.NewMovieRepository(s.db))
+			s.ratingService = service.NewRatingService(repository.NewRatingRepository(s.db), s.bus, s.emailVerificationService)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			s.bus.Close()
+			if s.redis != nil {
+				return s.redis.Close()
+			}
+			return nil
+		},
+	}
+}
+
+// userServices 创建用户相关的业务服务
+func (s *stack) userServices() error {
+	m, err := mailer.New(s.cfg.GetMailerConfig())
+	if err != nil {
+		return err
+	}
+	// 重置码和重发限流共用同一存储配置
+	passwordReset := s.cfg.GetPasswordResetConfig()
+	codeStore, err := onetimecode.NewStore(passwordReset.CodeConfig(), s.redis)
+	if err != nil {
+		return err
+	}
+	resetLimiter, err := ratelimit.NewLimiter(&ratelimit.Config{Store: passwordReset.Store, KeyPrefix: passwordReset.KeyPrefix}, s.redis)
+	if err != nil {
+		return err
+	}
+	roleRepo := repository.NewRoleRepository(s.db)
+	sessionStore := service.NewSessionStore(repository.NewSessionRepository(s.db))
+	if s.sessions, err = auth.NewSessions(s.cfg.GetJWTConfig(), s.redis, roleRepo, sessionStore); err != nil {
+		return err
+	}
+	s.authorizer = rbac.NewAuthorizer(service.MethodPermissions, roleRepo, rolePermissionsTTL)
+	lockoutStore, err := lockout.NewStore(s.cfg.GetLoginProtectionConfig(), s.redis)
+	if err != nil {
+		return err
+	}
+
+	// 邮箱验证令牌和重发限流共用同一存储配置
+	verification := s.cfg.GetEmailVerificationConfig()
+	verificationStore, err := onetimecode.NewStore(&onetimecode.Config{Store: verification.Store, KeyPrefix: verification.KeyPrefix}, s.redis)
+	if err != nil {
+		return err
+	}
+	resendLimiter, err := ratelimit.NewLimiter(&ratelimit.Config{Store: verification.Store, KeyPrefix: verification.KeyPrefix}, s.redis)
+	if err != nil {
+		return err
+	}
+	twoFactor := s.cfg.GetTwoFactorConfig()
+	challengeStore, err := onetimecode.NewStore(&onetimecode.Config{Store: twoFactor.Store, KeyPrefix: twoFactor.KeyPrefix}, s.redis)
+	if err != nil {
+		return err
+	}
+	oidcConfig := s.cfg.GetOIDCConfig()
+	oidcStore, err := oidc.NewStore(oidcConfig, s.redis)
+	if err != nil {
+		return err
+	}
+
+	passwordConfig := s.cfg.GetPasswordConfig()
+	hasher, err := password.NewHasher(&passwordConfig.Hash)
+	if err != nil {
+		return err
+	}
+	policy, err := password.NewPolicy(&passwordConfig.Policy)
+	if err != nil {
+		return err
+	}
+
+	userRepo := repository.NewUserRepository(s.db)
+	guard := lockout.New(s.cfg.GetLoginProtectionConfig(), lockoutStore)
+	s.emailVerificationService = service.NewEmailVerificationService(verification, userRepo,
+		onetimecode.New(&onetimecode.Config{TTL: verification.TTL}, verificationStore), resendLimiter, m)
+	s.twoFactorService = service.NewTwoFactorService(twoFactor, userRepo, repository.NewTOTPRepository(s.db),
+		onetimecode.New(&onetimecode.Config{TTL: twoFactor.ChallengeTTL, MaxAttempts: twoFactor.MaxChallengeAttempts}, challengeStore), guard)
+	s.oidcService = service.NewOIDCService(oidc.New(oidcConfig, oidcStore), userRepo, repository.NewIdentityRepository(s.db),
+		hasher, s.emailVerificationService)
+	s.userService = service.NewUserService(userRepo, s.emailVerificationService, hasher, policy, s.sessions.Revocations(),
+		s.authorizer)
+	s.authService, err = service.NewAuthService(userRepo, s.sessions, s.emailVerificationService, guard, hasher,
+		s.twoFactorService, s.oidcService)
+	if err != nil {
+		return err
+	}
+	s.passwordResetService = service.NewPasswordResetService(passwordReset, userRepo,
+		onetimecode.New(passwordReset.CodeConfig(), codeStore), resetLimiter, m, s.sessions.Revocations(), hasher, policy)
+	s.roleService = service.NewRoleService(userRepo, roleRepo, s.sessions.Revocations())
+	s.sessionService = service.NewSessionService(s.sessions)
+	s.apiKeyService = service.NewAPIKeyService(repository.NewAPIKeyRepository(s.db), userRepo, roleRepo)
+	return nil
+}
+
+// usesRedis 事件总线、会话、登录保护、限流、幂等、验证码、两步验证挑战或第三方登录状态存储任一使用Redis时返回 true
+func (s *stack) usesRedis() bool {
+	return s.cfg.EventBus.Driver == "redis" ||
+		s.cfg.JWT.Store == "redis" ||
+		s.cfg.LoginProtection.Store == "redis" ||
+		s.cfg.TwoFactor.Store == "redis" ||
+		s.cfg.OIDC.Store == "redis" ||
+		s.cfg.PasswordReset.Store == "redis" ||
+		s.cfg.EmailVerification.Store == "redis" ||
+		s.grpcConfig.Server.RateLimit.Store == "redis" ||
+		s.grpcConfig.Server.Idempotency.Store == "redis"
+}
+
+// grpcServer 后端gRPC服务组件，服务器在依赖的数据库和缓存启动后创建，监听进程内网络
+func (s *stack) grpcServer(a *app.App, name string) app.Component {
+	var server app.Component
+	return app.Component{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			grpcServer, err := s.newGRPCServer(name)
+			if err != nil {
+				return err
+			}
+			server = a.GRPCServer(name, grpcServer, s.network.Listen(name))
+			return nil
+		},
+		Run: func() error {
+			return server.Run()
+		},
+		Stop: func(ctx context.Context) error {
+			return server.Stop(ctx)
+		},
+	}
+}
+
+// newGRPCServer 创建后端服务的gRPC服务器，拦截器与独立部署时一致
+func (s *stack) newGRPCServer(name string) (*grpc.Server, error) {
+	config := s.grpcConfig.ForService(name)
+
+	limiter, err := ratelimit.NewLimiter(&config.Server.RateLimit, s.redis)
+	if err != nil {
+		return nil, err
+	}
+	store, err := idempotency.NewStore(&config.Server.Idempotency, s.redis, s.db)
+	if err != nil {
+		return nil, err
+	}
+	rateLimit := ratelimit.NewInterceptor(&config.Server.RateLimit, limiter)
+
+	apiKeys := apikey.NewInterceptor(s.apiKeyService, service.APIKeyScopes)
+
+	opts, err := grpcx.ServerOptions(&config.Server,
+		grpc.ChainUnaryInterceptor(
+			apiKeys.UnaryServerInterceptor(),
+			s.sessions.UnaryServerInterceptor(),
+			s.authorizer.UnaryServerInterceptor(),
+			rateLimit.UnaryServerInterceptor(),
+			validation.UnaryServerInterceptor(),
+			idempotency.NewInterceptor(&config.Server.Idempotency, store).UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			apiKeys.StreamServerInterceptor(),
+			s.sessions.StreamServerInterceptor(),
+			s.authorizer.StreamServerInterceptor(),
+			rateLimit.StreamServerInterceptor(),
+			validation.StreamServerInterceptor(),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s service server options: %w", name, err)
+	}
+
+	server := grpc.NewServer(opts...)
+	registerService(name, server, s)
+	return server, nil
+}
+
+// registerService 在gRPC服务器上注册服务实现，同时注册反射服务供 movieinfoctl 获取接口定义
+func registerService(name string, server *grpc.Server, s *stack) {
+	switch name {
+	case "user":
+		userpb.RegisterUserServiceServer(server, handler.NewUserServer(s.userService, s.authService,
+			s.emailVerificationService, s.passwordResetService, s.roleService, s.twoFactorService, s.oidcService, s.sessionService,
+			s.apiKeyService, s.cfg.GetPasswordResetConfig().TTL))
+	case "movie":
+		moviepb.RegisterMovieServiceServer(server, handler.NewMovieServer(s.movieService))
+	case "rating":
+		ratingpb.RegisterRatingServiceServer(server, handler.NewRatingServer(s.ratingService))
+	}
+	reflection.Register(server)
+}
+
+// clientFactory Web服务调用后端服务的客户端组件，所有连接走进程内网络
+func (s *stack) clientFactory() app.Component {
+	return app.Component{
+		Name: "clients",
+		Start: func(ctx context.Context) (err error) {
+			config := s.grpcConfig.Client
+			config.Targets = make(map[string]string, len(backendServices))
+			for _, name := range backendServices {
+				config.Targets[name] = s.network.Target(name)
+			}
+			s.clients, err = grpcx.NewClientFactory(&config, s.network.DialOption())
+			return err
+		},
+		Stop: func(ctx context.Context) error {
+			return s.clients.Close()
+		},
+	}
+}
+
+// webServer Web服务组件，HTTP/JSON网关通过进程内网络转发到后端服务
+func (s *stack) webServer() app.Component {
+	var server app.Component
+	return app.Component{
+		Name: "web",
+		Start: func(ctx context.Context) error {
+			streams := web.NewRatingStreamHandler(s.ratingService)
+			handler, err := s.webHandler(ctx, streams)
+			if err != nil {
+				return err
+			}
+
+			lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.cfg.App.Port))
+			if err != nil {
+				return fmt.Errorf("failed to listen on web port: %w", err)
+			}
+			srv := &http.Server{
+				Handler:           metrics.HTTPMiddleware("web", handler),
+				ReadHeaderTimeout: 5 * time.Second,
+			}
+			// 评分推送是长连接，关闭开始时主动结束，否则要等到 DrainTimeout 才能关闭
+			srv.RegisterOnShutdown(streams.Close)
+			server = app.HTTPServer("web", srv, lis)
+			return nil
+		},
+		Run: func() error {
+			return server.Run()
+		},
+		Stop: func(ctx context.Context) error {
+			return server.Stop(ctx)
+		},
+	}
+}
+
+// webHandler 页面与HTTP/JSON网关共用的处理器，网关到后端的连接在 ctx 结束后关闭
+func (s *stack) webHandler(ctx context.Context, streams *web.RatingStreamHandler) (http.Handler, error) {
+	services := []gateway.Service{
+		{Name: "user", Endpoint: s.network.Target("user"), Register: userpb.RegisterUserServiceHandlerFromEndpoint},
+		{Name: "movie", Endpoint: s.network.Target("movie"), Register: moviepb.RegisterMovieServiceHandlerFromEndpoint},
+		{Name: "rating", Endpoint: s.network.Target("rating"), Register: ratingpb.RegisterRatingServiceHandlerFromEndpoint},
+	}
+	api, err := gateway.NewHandler(ctx, services, []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		s.network.DialOption(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 单进程模式下页面直接调用进程内的业务服务
+	mux := http.NewServeMux()
+	mux.Handle("/verify-email", web.NewVerifyEmailPageHandler(s.emailVerificationService))
+	mux.Handle("/sessions", web.NewSessionsPageHandler(s.sessions, s.sessionService))
+	mux.Handle("/movies", web.NewMoviePageHandler(web.NewMoviePageLoader(s.movieService, s.ratingService), s.sessions))
+	mux.Handle("/movies/ratings/stream", streams)
+	mux.Handle("/", api)
+	return mux, nil
+}
+
+// metricsServer Prometheus指标服务组件
+func (s *stack) metricsServer() app.Component {
+	var server app.Component
+	return app.Component{
+		Name: "metrics",
+		Start: func(ctx context.Context) error {
+			srv := metrics.NewServer(s.cfg.GetMetricsConfig())
+			lis, err := net.Listen("tcp", srv.Addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on metrics address: %w", err)
+			}
+			server = app.HTTPServer("metrics", srv, lis)
+			return nil
+		},
+		Run: func() error {
+			return server.Run()
+		},
+		Stop: func(ctx context.Context) error {
+			return server.Stop(ctx)
+		},
+	}
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/3inchtime/movieinfo/internal/handler/web"
+	"github.com/3inchtime/movieinfo/pkg/app"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	commonpb "github.com/3inchtime/movieinfo/proto/gen/common"
+	moviepb "github.com/3inchtime/movieinfo/proto/gen/movie"
+	ratingpb "github.com/3inchtime/movieinfo/proto/gen/rating"
+	userpb "github.com/3inchtime/movieinfo/proto/gen/user"
+)
+
+// testStack 各测试共用的单进程服务，数据库连接池指标按数据库名注册，同一进程内只能启动一次
+var testStack *stack
+
+func TestMain(m *testing.M) {
+	// 配置中的文件路径相对于仓库根目录
+	if err := os.Chdir("../.."); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("MOVIEINFO_JWT_SECRET", "test-secret")
+
+	stop, err := startTestStack()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+// startTestStack 使用仓库中的配置和内存SQLite数据库，启动数据库、缓存和全部后端gRPC服务
+func startTestStack() (func(), error) {
+	s, err := newStack(&allOptions{
+		configPath:     "configs/config.yaml",
+		grpcConfigPath: "configs/grpc.yaml",
+		database:       ":memory:",
+		cache:          "memory",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	var stops []func()
+	stop := func() {
+		for i := len(stops) - 1; i >= 0; i-- {
+			stops[i]()
+		}
+	}
+	for _, component := range []app.Component{s.database(), s.cache()} {
+		component := component
+		if err := component.Start(ctx); err != nil {
+			stop()
+			return nil, fmt.Errorf("failed to start %s: %w", component.Name, err)
+		}
+		stops = append(stops, func() { component.Stop(ctx) })
+	}
+	for _, name := range backendServices {
+		server, err := s.newGRPCServer(name)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		go server.Serve(s.network.Listen(name))
+		stops = append(stops, server.Stop)
+	}
+
+	testStack = s
+	return stop, nil
+}
+
+// dial 通过进程内网络连接后端服务
+func (s *stack) dial(t *testing.T, name string) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.Dial(s.network.Target(name), s.network.DialOption(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial(%s) error = %v", name, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// login 为用户签发访问令牌，返回携带令牌的上下文
+func (s *stack) login(t *testing.T, userID int64) context.Context {
+	t.Helper()
+	pair, err := s.sessions.Create(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), auth.AuthorizationHeader, "Bearer "+pair.AccessToken)
+}
+
+func TestAllServesGRPC(t *testing.T) {
+	s := testStack
+	ctx := context.Background()
+	movies := moviepb.NewMovieServiceClient(s.dial(t, "movie"))
+	ratings := ratingpb.NewRatingServiceClient(s.dial(t, "rating"))
+	users := userpb.NewUserServiceClient(s.dial(t, "user"))
+
+	movie, err := movies.GetMovie(ctx, &moviepb.GetMovieRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("GetMovie() error = %v", err)
+	}
+	if movie.GetMovie().GetId() != 1 || movie.GetMovie().GetTitle() == "" || !movie.GetCommon().GetSuccess() {
+		t.Fatalf("GetMovie() = %v, want movie 1", movie)
+	}
+
+	stats, err := ratings.GetMovieAverageRating(ctx, &ratingpb.GetMovieAverageRatingRequest{MovieId: 1})
+	if err != nil {
+		t.Fatalf("GetMovieAverageRating() error = %v", err)
+	}
+	if stats.GetTotalRatings() == 0 {
+		t.Fatalf("GetMovieAverageRating() = %v, want seeded ratings", stats)
+	}
+
+	health, err := users.HealthCheck(ctx, &commonpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("HealthCheck() error = %v", err)
+	}
+	if health.GetStatus() != commonpb.HealthCheckResponse_SERVING {
+		t.Fatalf("HealthCheck() = %v, want SERVING", health.GetStatus())
+	}
+
+	// 签发令牌的会话标记为当前会话
+	sessions, err := users.ListSessions(s.login(t, 2), &userpb.ListSessionsRequest{UserId: 2})
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	current := 0
+	for _, session := range sessions.GetSessions() {
+		if session.GetCurrent() {
+			current++
+		}
+	}
+	if current != 1 {
+		t.Fatalf("ListSessions() marked %d sessions as current, want 1", current)
+	}
+}
+
+func TestAllGRPCErrors(t *testing.T) {
+	s := testStack
+	movies := moviepb.NewMovieServiceClient(s.dial(t, "movie"))
+	users := userpb.NewUserServiceClient(s.dial(t, "user"))
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{
+			name: "business error",
+			call: func() error {
+				_, err := movies.GetMovie(context.Background(), &moviepb.GetMovieRequest{Id: 9999})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "not implemented by the service",
+			call: func() error {
+				_, err := movies.ListMovies(context.Background(), &moviepb.ListMoviesRequest{})
+				return err
+			},
+			want: codes.Unimplemented,
+		},
+		{
+			name: "other user's sessions",
+			call: func() error {
+				_, err := users.ListSessions(s.login(t, 2), &userpb.ListSessionsRequest{UserId: 1})
+				return err
+			},
+			want: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.call()); code != tt.want {
+				t.Fatalf("code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllValidatesRequests(t *testing.T) {
+	s := testStack
+	movies := moviepb.NewMovieServiceClient(s.dial(t, "movie"))
+	ratings := ratingpb.NewRatingServiceClient(s.dial(t, "rating"))
+	admin := s.login(t, 1)
+
+	tests := []struct {
+		name      string
+		call      func() error
+		wantField string
+	}{
+		{
+			name: "non-positive id",
+			call: func() error {
+				_, err := movies.GetMovie(context.Background(), &moviepb.GetMovieRequest{Id: 0})
+				return err
+			},
+			wantField: "id",
+		},
+		{
+			name: "empty title",
+			call: func() error {
+				_, err := movies.CreateMovie(admin, &moviepb.CreateMovieRequest{Title: ""})
+				return err
+			},
+			wantField: "title",
+		},
+		{
+			name: "score out of range",
+			call: func() error {
+				_, err := ratings.CreateRating(s.login(t, 2), &ratingpb.CreateRatingRequest{UserId: 2, MovieId: 1, Score: 6})
+				return err
+			},
+			wantField: "score",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if code := status.Code(err); code != codes.InvalidArgument {
+				t.Fatalf("code = %v, want %v", code, codes.InvalidArgument)
+			}
+			details := apperror.Details(err)
+			if len(details) == 0 || details[0].Field != tt.wantField {
+				t.Fatalf("Details() = %+v, want field %q", details, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestAllAuthorizesMethods(t *testing.T) {
+	s := testStack
+	movies := moviepb.NewMovieServiceClient(s.dial(t, "movie"))
+	users := userpb.NewUserServiceClient(s.dial(t, "user"))
+	anonymous := context.Background()
+	admin := s.login(t, 1)
+	member := s.login(t, 2)
+
+	createMovie := func(ctx context.Context) func() error {
+		return func() error {
+			_, err := movies.CreateMovie(ctx, &moviepb.CreateMovieRequest{Title: "Authorized"})
+			return err
+		}
+	}
+	unlockUser := func(ctx context.Context) func() error {
+		return func() error {
+			_, err := users.UnlockUser(ctx, &userpb.UnlockUserRequest{UserId: 2})
+			return err
+		}
+	}
+	grantRole := func(ctx context.Context) func() error {
+		return func() error {
+			_, err := users.GrantRole(ctx, &userpb.GrantRoleRequest{UserId: 2, Role: "admin"})
+			return err
+		}
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{name: "create movie anonymously", call: createMovie(anonymous), want: codes.Unauthenticated},
+		{name: "create movie without permission", call: createMovie(member), want: codes.PermissionDenied},
+		{name: "create movie as admin", call: createMovie(admin), want: codes.OK},
+		{name: "unlock user without permission", call: unlockUser(member), want: codes.PermissionDenied},
+		{name: "unlock user as admin", call: unlockUser(admin), want: codes.OK},
+		{name: "grant role without permission", call: grantRole(member), want: codes.PermissionDenied},
+		{name: "read movie anonymously", call: func() error {
+			_, err := movies.GetMovie(anonymous, &moviepb.GetMovieRequest{Id: 1})
+			return err
+		}, want: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.call()); code != tt.want {
+				t.Fatalf("code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllServesREST(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handler, err := testStack.webHandler(ctx, web.NewRatingStreamHandler(testStack.ratingService))
+	if err != nil {
+		t.Fatalf("webHandler() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+		wantKey  string
+	}{
+		{name: "get movie", path: "/api/v1/movies/1", wantCode: http.StatusOK, wantKey: "movie"},
+		{name: "movie not found", path: "/api/v1/movies/9999", wantCode: http.StatusNotFound, wantKey: "code"},
+		{name: "health", path: "/api/v1/health/rating", wantCode: http.StatusOK, wantKey: "status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("GET %s status = %d, want %d, body %s", tt.path, rec.Code, tt.wantCode, rec.Body)
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+				t.Fatalf("GET %s Content-Type = %q, want application/json", tt.path, ct)
+			}
+			var body map[string]json.RawMessage
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("GET %s body is not JSON: %v", tt.path, err)
+			}
+			if _, ok := body[tt.wantKey]; !ok {
+				t.Fatalf("GET %s body = %s, want field %q", tt.path, rec.Body, tt.wantKey)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/movies/1", nil))
+	var resp struct {
+		Movie struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"movie"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if resp.Movie.ID != "1" || resp.Movie.Title == "" {
+		t.Fatalf("GET /api/v1/movies/1 movie = %+v, want movie 1 with title", resp.Movie)
+	}
+}
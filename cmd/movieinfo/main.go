@@ -0,0 +1,36 @@
+// movieinfo 电影信息服务启动入口
+//
+// all 子命令在一个进程内运行 web、user、movie、rating 服务，服务之间通过内存连接调用，
+// 默认使用SQLite和进程内缓存，无需MySQL和Redis：
+//
+//	movieinfo all
+//	movieinfo all --database :memory:
+//	movieinfo all --database "" --cache redis  # 使用 configs/config.yaml 中的MySQL和Redis
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// newRootCommand 创建根命令
+func newRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "movieinfo",
+		Short:         "Run the movieinfo services",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	root.AddCommand(newAllCommand())
+	return root
+}
@@ -0,0 +1,418 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// session 登录后保存的会话，后续命令自动携带其中的访问令牌，访问令牌过期后使用刷新令牌续期
+type session struct {
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token,omitempty"`
+	UserID           int64     `json:"user_id"`
+	Username         string    `json:"username"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at,omitempty"`
+}
+
+// expired 访问令牌是否已过期
+func (s *session) expired() bool {
+	return !s.ExpiresAt.IsZero() && time.Now().After(s.ExpiresAt)
+}
+
+// refreshable 访问令牌过期后能否使用刷新令牌续期
+func (s *session) refreshable() bool {
+	return s.RefreshToken != "" && (s.RefreshExpiresAt.IsZero() || time.Now().Before(s.RefreshExpiresAt))
+}
+
+// setTokens 根据登录或刷新响应更新令牌
+func (s *session) setTokens(resp *tokenResponse) {
+	s.AccessToken = resp.AccessToken
+	s.RefreshToken = resp.RefreshToken
+	s.ExpiresAt, s.RefreshExpiresAt = time.Time{}, time.Time{}
+	if seconds, _ := strconv.ParseInt(resp.ExpiresIn, 10, 64); seconds > 0 {
+		s.ExpiresAt = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	if seconds, _ := strconv.ParseInt(resp.RefreshExpiresIn, 10, 64); seconds > 0 {
+		s.RefreshExpiresAt = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+}
+
+// tokenResponse Login 和 RefreshToken 响应中的令牌字段，int64 在protojson中编码为字符串
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        string `json:"expires_in"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresIn string `json:"refresh_expires_in"`
+}
+
+// sessionPath 返回会话文件路径，默认位于用户配置目录下
+func sessionPath() (string, error) {
+	if path := os.Getenv("MOVIEINFOCTL_SESSION"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "movieinfoctl", "session.json"), nil
+}
+
+// loadSession 读取会话，未登录或访问令牌已过期且无法续期时返回 nil
+func loadSession() (*session, error) {
+	path, err := sessionPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var s session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to decode session %s: %w", path, err)
+	}
+	if s.expired() && !s.refreshable() {
+		return nil, nil
+	}
+	return &s, nil
+}
+
+// saveSession 保存会话，文件仅对当前用户可读
+func saveSession(s *session) error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// removeSession 删除会话文件
+func removeSession() error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove session: %w", err)
+	}
+	return nil
+}
+
+// loginResponse Login 和 VerifyTwoFactor 的响应
+type loginResponse struct {
+	tokenResponse
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	TwoFactorRequired      bool     `json:"two_factor_required"`
+	ChallengeToken         string   `json:"challenge_token"`
+	TwoFactorSetupRequired bool     `json:"two_factor_setup_required"`
+	RecoveryCodes          []string `json:"recovery_codes"`
+}
+
+// newLoginCommand 使用密码或身份提供方登录并保存访问令牌，账号启用两步验证时继续提交动态码
+func newLoginCommand(c *ctl) *cobra.Command {
+	var username, password, provider, code, qrFile string
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in and save the access token for later commands",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				data json.RawMessage
+				err  error
+			)
+			if provider != "" {
+				data, err = c.oidcLogin(cmd, provider)
+			} else {
+				data, err = c.passwordLogin(cmd, username, password)
+			}
+			if err != nil {
+				return err
+			}
+
+			var resp loginResponse
+			if err := json.Unmarshal(data, &resp); err != nil {
+				return fmt.Errorf("failed to decode login response: %w", err)
+			}
+			if resp.TwoFactorRequired {
+				if err := c.verifyTwoFactor(cmd, &resp, code, qrFile); err != nil {
+					return err
+				}
+			}
+			if resp.AccessToken == "" {
+				return errors.New("login response did not include an access token")
+			}
+
+			s := &session{Username: resp.User.Username}
+			s.UserID, _ = strconv.ParseInt(resp.User.ID, 10, 64)
+			s.setTokens(&resp.tokenResponse)
+			if err := saveSession(s); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Logged in as %s\n", s.Username)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&username, "username", "u", "", "username or email")
+	cmd.Flags().StringVarP(&password, "password", "p", "", "password, read from stdin when omitted")
+	cmd.Flags().StringVar(&provider, "provider", "", "log in with this identity provider in a browser instead of a password")
+	cmd.Flags().StringVar(&code, "code", "", "two-factor code or recovery code, read from stdin when required and omitted")
+	cmd.Flags().StringVar(&qrFile, "qr-file", "", "write the enrolment QR code PNG to this file when two-factor setup is required")
+	return cmd
+}
+
+// passwordLogin 使用用户名或邮箱和密码登录
+func (c *ctl) passwordLogin(cmd *cobra.Command, username, password string) (json.RawMessage, error) {
+	if username == "" {
+		return nil, errors.New("--username or --provider is required")
+	}
+	if password == "" {
+		var err error
+		if password, err = readPassword(cmd); err != nil {
+			return nil, err
+		}
+	}
+	return c.invoke(cmd.Context(), "user", "Login", map[string]interface{}{
+		"username": username,
+		"password": password,
+	})
+}
+
+// oidcLogin 通过身份提供方登录：在本机回环地址上接收回调，用户在浏览器中完成授权后提交授权码
+func (c *ctl) oidcLogin(cmd *cobra.Command, provider string) (json.RawMessage, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the login callback: %w", err)
+	}
+	defer lis.Close()
+
+	data, err := c.invoke(cmd.Context(), "user", "StartOIDCLogin", map[string]interface{}{
+		"provider":     provider,
+		"redirect_uri": fmt.Sprintf("http://%s/callback", lis.Addr()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var start struct {
+		AuthorizationURL string `json:"authorization_url"`
+		State            string `json:"state"`
+	}
+	if err := json.Unmarshal(data, &start); err != nil {
+		return nil, fmt.Errorf("failed to decode login response: %w", err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Open the following URL in a browser to log in with %s:\n\n  %s\n\n", provider, start.AuthorizationURL)
+	code, err := waitForCallback(cmd.Context(), lis, start.State)
+	if err != nil {
+		return nil, err
+	}
+	return c.invoke(cmd.Context(), "user", "CompleteOIDCLogin", map[string]interface{}{
+		"state": start.State,
+		"code":  code,
+	})
+}
+
+// oidcCallbackTimeout 等待浏览器完成授权的时间
+const oidcCallbackTimeout = 5 * time.Minute
+
+// waitForCallback 等待身份提供方把浏览器重定向到回调地址，校验 state 后返回授权码
+func waitForCallback(ctx context.Context, lis net.Listener, state string) (string, error) {
+	type callback struct {
+		code string
+		err  error
+	}
+	result := make(chan callback, 1)
+
+	server := &http.Server{
+		ReadHeaderTimeout: 5 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+			query := r.URL.Query()
+			var cb callback
+			switch {
+			case query.Get("error") != "":
+				cb.err = fmt.Errorf("identity provider returned %s: %s", query.Get("error"), query.Get("error_description"))
+			case query.Get("state") != state:
+				cb.err = errors.New("login callback state does not match")
+			case query.Get("code") == "":
+				cb.err = errors.New("login callback has no authorization code")
+			default:
+				cb.code = query.Get("code")
+			}
+			if cb.err != nil {
+				http.Error(w, "Login failed, see the terminal for details.", http.StatusBadRequest)
+			} else {
+				fmt.Fprintln(w, "Login complete, you can close this window and return to the terminal.")
+			}
+			select {
+			case result <- cb:
+			default:
+			}
+		}),
+	}
+	go server.Serve(lis)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, oidcCallbackTimeout)
+	defer cancel()
+	select {
+	case cb := <-result:
+		return cb.code, cb.err
+	case <-ctx.Done():
+		return "", errors.New("timed out waiting for the login callback")
+	}
+}
+
+// verifyTwoFactor 完成两步验证登录，resp 替换为 VerifyTwoFactor 的响应
+// 账号必须启用两步验证但尚未登记时，先凭挑战令牌登记并展示密钥，再用首个动态码确认
+func (c *ctl) verifyTwoFactor(cmd *cobra.Command, resp *loginResponse, code, qrFile string) error {
+	out := cmd.ErrOrStderr()
+	if resp.TwoFactorSetupRequired {
+		data, err := c.invoke(cmd.Context(), "user", "EnrollTOTP", map[string]interface{}{
+			"user_id":         resp.User.ID,
+			"challenge_token": resp.ChallengeToken,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "Two-factor authentication is required for this account.")
+		if err := showEnrollment(out, data, qrFile); err != nil {
+			return err
+		}
+	}
+
+	if code == "" {
+		var err error
+		if code, err = readLine(cmd, "Two-factor code: "); err != nil {
+			return err
+		}
+	}
+	data, err := c.invoke(cmd.Context(), "user", "VerifyTwoFactor", map[string]interface{}{
+		"challenge_token": resp.ChallengeToken,
+		"code":            code,
+	})
+	if err != nil {
+		return err
+	}
+
+	*resp = loginResponse{}
+	if err := json.Unmarshal(data, resp); err != nil {
+		return fmt.Errorf("failed to decode two-factor response: %w", err)
+	}
+	showRecoveryCodes(out, resp.RecoveryCodes)
+	return nil
+}
+
+// refreshSession 使用刷新令牌续期访问令牌并保存会话，刷新失败时删除本地会话
+func (c *ctl) refreshSession(ctx context.Context) error {
+	data, err := c.invoke(ctx, "user", "RefreshToken", map[string]interface{}{
+		"refresh_token": c.session.RefreshToken,
+	})
+	if err != nil {
+		c.session = nil
+		if rmErr := removeSession(); rmErr != nil {
+			return rmErr
+		}
+		return fmt.Errorf("session expired, run movieinfoctl login again: %w", err)
+	}
+
+	var resp tokenResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("failed to decode refresh response: %w", err)
+	}
+	c.session.setTokens(&resp)
+	c.token = c.session.AccessToken
+	return saveSession(c.session)
+}
+
+// newLogoutCommand 注销访问令牌并删除本地会话
+func newLogoutCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Log out and remove the saved access token",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if c.token != "" {
+				request := map[string]interface{}{"access_token": c.token}
+				if c.session != nil && c.session.AccessToken == c.token {
+					request["refresh_token"] = c.session.RefreshToken
+				}
+				if _, err := c.invoke(cmd.Context(), "user", "Logout", request); err != nil {
+					return err
+				}
+			}
+			if err := removeSession(); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "Logged out")
+			return nil
+		},
+	}
+}
+
+// currentUserID 返回登录用户的ID，未登录时返回错误
+func (c *ctl) currentUserID() (int64, error) {
+	if c.session == nil || c.session.UserID == 0 {
+		return 0, errors.New("not logged in, run movieinfoctl login or pass --user-id")
+	}
+	return c.session.UserID, nil
+}
+
+// readPassword 从标准输入读取密码
+func readPassword(cmd *cobra.Command) (string, error) {
+	return readLine(cmd, "Password: ")
+}
+
+// stdin 标准输入的缓冲读取器，多次提示输入时共用，避免前一次读取把后面的输入读进缓冲
+var stdin *bufio.Reader
+
+// readLine 输出提示后从标准输入读取一行
+func readLine(cmd *cobra.Command, prompt string) (string, error) {
+	if stdin == nil {
+		stdin = bufio.NewReader(cmd.InOrStdin())
+	}
+	fmt.Fprint(cmd.ErrOrStderr(), prompt)
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// newCallCommand 调用任意RPC，请求和响应均为JSON
+func newCallCommand(c *ctl) *cobra.Command {
+	var data, file string
+
+	cmd := &cobra.Command{
+		Use:   "call <service> <method>",
+		Short: "Call any RPC with a JSON request",
+		Long: `Call any RPC with a JSON request, resolving the method through server reflection.
+
+The service is user, movie, rating or a fully qualified service name. Client
+streaming methods take a JSON array or newline-delimited JSON objects, one
+message per element.`,
+		Example: `  movieinfoctl call movie GetMovie -d '{"id": 1}'
+  movieinfoctl call movie BulkCreateMovies -f movies.ndjson`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input, err := readCallInput(cmd, data, file)
+			if err != nil {
+				return err
+			}
+
+			_, md, err := c.method(cmd.Context(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			if !md.IsStreamingClient() && !md.IsStreamingServer() {
+				if len(input) > 1 {
+					return fmt.Errorf("%s expects exactly one request", md.FullName())
+				}
+				var request interface{}
+				if len(input) == 1 {
+					request = input[0]
+				}
+				resp, err := c.invoke(cmd.Context(), args[0], args[1], request)
+				if err != nil {
+					return err
+				}
+				return c.print(resp, tableSpec{})
+			}
+
+			requests, err := streamRequests(md, input)
+			if err != nil {
+				return err
+			}
+			return c.stream(cmd.Context(), args[0], args[1], requests, func(resp json.RawMessage) error {
+				return c.print(resp, tableSpec{})
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&data, "data", "d", "", "request as JSON")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "read the request from a file, - for stdin")
+	return cmd
+}
+
+// readCallInput 读取请求，返回其中的每个JSON值
+func readCallInput(cmd *cobra.Command, data, file string) ([]json.RawMessage, error) {
+	var raw []byte
+	switch {
+	case data != "" && file != "":
+		return nil, errors.New("pass either --data or --file, not both")
+	case data != "":
+		raw = []byte(data)
+	case file == "-":
+		var err error
+		if raw, err = io.ReadAll(cmd.InOrStdin()); err != nil {
+			return nil, fmt.Errorf("failed to read request: %w", err)
+		}
+	case file != "":
+		var err error
+		if raw, err = os.ReadFile(file); err != nil {
+			return nil, fmt.Errorf("failed to read request: %w", err)
+		}
+	default:
+		return nil, nil
+	}
+
+	var values []json.RawMessage
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	for {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			if errors.Is(err, io.EOF) {
+				return values, nil
+			}
+			return nil, fmt.Errorf("invalid request json: %w", err)
+		}
+		values = append(values, value)
+	}
+}
+
+// streamRequests 展开流式调用的请求，客户端流允许传入一个JSON数组
+func streamRequests(md protoreflect.MethodDescriptor, input []json.RawMessage) ([]interface{}, error) {
+	if md.IsStreamingClient() && len(input) == 1 && bytes.HasPrefix(bytes.TrimSpace(input[0]), []byte("[")) {
+		var elements []json.RawMessage
+		if err := json.Unmarshal(input[0], &elements); err != nil {
+			return nil, fmt.Errorf("invalid request json: %w", err)
+		}
+		input = elements
+	}
+	if !md.IsStreamingClient() && len(input) == 0 {
+		input = []json.RawMessage{json.RawMessage("{}")}
+	}
+
+	requests := make([]interface{}, len(input))
+	for i, value := range input {
+		requests[i] = value
+	}
+	return requests, nil
+}
+
+// newDescribeCommand 通过服务端反射查看服务和方法
+func newDescribeCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe [service] [method]",
+		Short: "Show the methods of a service or the request fields of a method",
+		Args:  cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch len(args) {
+			case 0:
+				names := make([]string, 0, len(services))
+				for short := range services {
+					names = append(names, short)
+				}
+				sort.Strings(names)
+
+				var rows []map[string]interface{}
+				for _, short := range names {
+					rows = append(rows, map[string]interface{}{"service": short, "name": services[short]})
+				}
+				return c.printValue(map[string]interface{}{"services": rows}, tableSpec{Field: "services", Columns: []string{"service", "name"}})
+			case 1:
+				short, full, err := resolveService(args[0])
+				if err != nil {
+					return err
+				}
+				conn, err := c.conn(short)
+				if err != nil {
+					return err
+				}
+				sd, err := describeService(cmd.Context(), conn, full)
+				if err != nil {
+					return err
+				}
+
+				var rows []map[string]interface{}
+				methods := sd.Methods()
+				for i := 0; i < methods.Len(); i++ {
+					md := methods.Get(i)
+					rows = append(rows, map[string]interface{}{
+						"method":    string(md.Name()),
+						"request":   string(md.Input().FullName()),
+						"response":  string(md.Output().FullName()),
+						"streaming": streamingKind(md),
+					})
+				}
+				return c.printValue(map[string]interface{}{"methods": rows},
+					tableSpec{Field: "methods", Columns: []string{"method", "request", "response", "streaming"}})
+			default:
+				_, md, err := c.method(cmd.Context(), args[0], args[1])
+				if err != nil {
+					return err
+				}
+
+				var rows []map[string]interface{}
+				fields := md.Input().Fields()
+				for i := 0; i < fields.Len(); i++ {
+					fd := fields.Get(i)
+					rows = append(rows, map[string]interface{}{
+						"field":    string(fd.Name()),
+						"type":     fieldType(fd),
+						"repeated": fd.IsList(),
+						"oneof":    oneofName(fd),
+					})
+				}
+				return c.printValue(map[string]interface{}{"request": string(md.Input().FullName()), "fields": rows},
+					tableSpec{Field: "fields", Columns: []string{"field", "type", "repeated", "oneof"}})
+			}
+		},
+	}
+}
+
+// printValue 打印由命令自行构建的值
+func (c *ctl) printValue(v interface{}, spec tableSpec) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return c.print(data, spec)
+}
+
+// streamingKind 返回方法的流式类型
+func streamingKind(md protoreflect.MethodDescriptor) string {
+	switch {
+	case md.IsStreamingClient() && md.IsStreamingServer():
+		return "bidi"
+	case md.IsStreamingClient():
+		return "client"
+	case md.IsStreamingServer():
+		return "server"
+	default:
+		return "-"
+	}
+}
+
+// fieldType 返回字段的类型名，消息和枚举使用完整名称
+func fieldType(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(fd.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(fd.Enum().FullName())
+	default:
+		return fd.Kind().String()
+	}
+}
+
+// oneofName 返回字段所属的 oneof 名称
+func oneofName(fd protoreflect.FieldDescriptor) string {
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		return string(od.Name())
+	}
+	return "-"
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	grpcpkg "github.com/3inchtime/movieinfo/pkg/grpc"
+)
+
+// services 服务简称到完整服务名的映射，简称同时是 grpc.client.targets 中的键
+var services = map[string]string{
+	"user":   "movieinfo.user.UserService",
+	"movie":  "movieinfo.movie.MovieService",
+	"rating": "movieinfo.rating.RatingService",
+}
+
+// ctl 命令行客户端的共享状态
+type ctl struct {
+	configPath string
+	output     string
+	target     string
+	token      string
+	apiKey     string
+	timeout    time.Duration
+
+	config  *grpcpkg.ClientConfig
+	session *session
+	clients *grpcpkg.ClientFactory
+}
+
+// init 加载配置和登录会话
+func (c *ctl) init() error {
+	switch c.output {
+	case formatTable, formatJSON, formatYAML:
+	default:
+		return fmt.Errorf("unsupported output format %q", c.output)
+	}
+
+	c.config = grpcpkg.DefaultClientConfig()
+	if _, err := os.Stat(c.configPath); err == nil {
+		config, err := grpcpkg.LoadConfig(c.configPath)
+		if err != nil {
+			return err
+		}
+		c.config = &config.ForService("movieinfoctl").Client
+	} else if c.configPath != "configs/grpc.yaml" {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	if c.target != "" {
+		for service := range services {
+			c.config.Targets[service] = c.target
+		}
+	}
+
+	session, err := loadSession()
+	if err != nil {
+		return err
+	}
+	c.session = session
+
+	// User-Agent 用于在登录会话列表中识别设备
+	c.clients, err = grpcpkg.NewClientFactory(c.config, grpc.WithUserAgent("movieinfoctl"))
+	if err != nil {
+		return err
+	}
+
+	if c.apiKey == "" {
+		c.apiKey = os.Getenv("MOVIEINFOCTL_API_KEY")
+	}
+	if c.apiKey != "" {
+		// 使用API密钥时不携带登录会话的访问令牌，服务端不接受同时携带两者的请求
+		if c.token != "" {
+			return errors.New("pass either --api-key or --token, not both")
+		}
+		return nil
+	}
+
+	if c.token == "" && session != nil {
+		// 访问令牌过期时先用刷新令牌续期，刷新请求本身不携带访问令牌；续期失败时以未登录状态继续
+		if session.expired() {
+			if err := c.refreshSession(context.Background()); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			}
+		}
+		if c.session != nil {
+			c.token = c.session.AccessToken
+		}
+	}
+	return nil
+}
+
+// close 关闭所有连接
+func (c *ctl) close() {
+	if c.clients != nil {
+		c.clients.Close()
+	}
+}
+
+// resolveService 解析服务名，支持简称和完整服务名，返回简称和完整服务名
+func resolveService(name string) (string, string, error) {
+	if full, ok := services[name]; ok {
+		return name, full, nil
+	}
+	for short, full := range services {
+		if strings.EqualFold(name, full) {
+			return short, full, nil
+		}
+	}
+	return "", "", fmt.Errorf("unknown service %q, expected one of user, movie, rating", name)
+}
+
+// conn 获取服务连接，超时、重试和熔断由 pkg/grpc 的客户端工厂处理
+func (c *ctl) conn(service string) (grpc.ClientConnInterface, error) {
+	return c.clients.Conn(service)
+}
+
+// method 通过服务端反射获取方法描述
+func (c *ctl) method(ctx context.Context, service, method string) (grpc.ClientConnInterface, protoreflect.MethodDescriptor, error) {
+	short, full, err := resolveService(service)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := c.conn(short)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sd, err := describeService(ctx, conn, full)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, nil, fmt.Errorf("service %s has no method %s", full, method)
+	}
+	return conn, md, nil
+}
+
+// context 创建带认证信息的请求上下文，未指定 --timeout 时使用配置中方法的默认超时
+func (c *ctl) context(ctx context.Context, stream bool) (context.Context, context.CancelFunc) {
+	if c.apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", c.apiKey)
+	} else if c.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+	}
+	if stream || c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// invoke 调用一元RPC，请求和响应均为protojson编码的JSON
+func (c *ctl) invoke(ctx context.Context, service, method string, request interface{}) (json.RawMessage, error) {
+	ctx, cancel := c.context(ctx, false)
+	defer cancel()
+
+	conn, md, err := c.method(ctx, service, method)
+	if err != nil {
+		return nil, err
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, fmt.Errorf("%s is a streaming method", md.FullName())
+	}
+
+	req, err := newRequest(md, request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dynamicpb.NewMessage(md.Output())
+	if err := conn.Invoke(ctx, methodPath(md), req, resp); err != nil {
+		return nil, err
+	}
+	return marshalMessage(resp)
+}
+
+// stream 调用流式RPC，依次发送 requests 后关闭发送端，每收到一条响应调用一次 handle
+func (c *ctl) stream(ctx context.Context, service, method string, requests []interface{}, handle func(json.RawMessage) error) error {
+	ctx, cancel := c.context(ctx, true)
+	defer cancel()
+
+	conn, md, err := c.method(ctx, service, method)
+	if err != nil {
+		return err
+	}
+	if !md.IsStreamingClient() && !md.IsStreamingServer() {
+		return fmt.Errorf("%s is not a streaming method", md.FullName())
+	}
+	if !md.IsStreamingClient() && len(requests) != 1 {
+		return fmt.Errorf("%s expects exactly one request", md.FullName())
+	}
+
+	desc := &grpc.StreamDesc{
+		StreamName:    string(md.Name()),
+		ServerStreams: md.IsStreamingServer(),
+		ClientStreams: md.IsStreamingClient(),
+	}
+	cs, err := conn.NewStream(ctx, desc, methodPath(md))
+	if err != nil {
+		return err
+	}
+	for _, request := range requests {
+		req, err := newRequest(md, request)
+		if err != nil {
+			return err
+		}
+		if err := cs.SendMsg(req); err != nil {
+			return err
+		}
+	}
+	if err := cs.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		resp := dynamicpb.NewMessage(md.Output())
+		if err := cs.RecvMsg(resp); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		data, err := marshalMessage(resp)
+		if err != nil {
+			return err
+		}
+		if err := handle(data); err != nil {
+			return err
+		}
+		if !md.IsStreamingServer() {
+			return nil
+		}
+	}
+}
+
+// newRequest 将请求转换为方法的输入消息，request 可以是JSON字节或可被JSON编码的值
+func newRequest(md protoreflect.MethodDescriptor, request interface{}) (*dynamicpb.Message, error) {
+	var data []byte
+	switch r := request.(type) {
+	case nil:
+		data = []byte("{}")
+	case []byte:
+		data = r
+	case json.RawMessage:
+		data = r
+	default:
+		var err error
+		if data, err = json.Marshal(r); err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	req := dynamicpb.NewMessage(md.Input())
+	if err := protojson.Unmarshal(data, req); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", md.Input().FullName(), err)
+	}
+	return req, nil
+}
+
+// marshalMessage 使用proto字段名编码响应
+func marshalMessage(m *dynamicpb.Message) (json.RawMessage, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode response: %w", err)
+	}
+	return data, nil
+}
+
+// methodPath 返回方法的gRPC路径，如 /movieinfo.movie.MovieService/GetMovie
+func methodPath(md protoreflect.MethodDescriptor) string {
+	return fmt.Sprintf("/%s/%s", md.Parent().FullName(), md.Name())
+}
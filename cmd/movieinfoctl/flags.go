@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/3inchtime/movieinfo/pkg/idempotency"
+)
+
+// pageFlags 分页参数
+type pageFlags struct {
+	page     int32
+	pageSize int32
+}
+
+// register 注册分页参数
+func (p *pageFlags) register(cmd *cobra.Command) {
+	cmd.Flags().Int32Var(&p.page, "page", 1, "page number, starting at 1")
+	cmd.Flags().Int32Var(&p.pageSize, "page-size", 10, "page size, at most 100")
+}
+
+// request 返回 movieinfo.common.PageRequest
+func (p *pageFlags) request() map[string]interface{} {
+	return map[string]interface{}{"page": p.page, "page_size": p.pageSize}
+}
+
+// idempotencyFlag 创建类命令的幂等键参数
+type idempotencyFlag struct {
+	key string
+}
+
+// register 注册幂等键参数
+func (f *idempotencyFlag) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.key, "idempotency-key", "", "reuse a key to safely repeat a create, generated when empty")
+}
+
+// context 在调用上下文中附加幂等键，未指定时生成新的键，使超时后的自动重试不会重复创建
+func (f *idempotencyFlag) context(ctx context.Context) context.Context {
+	key := f.key
+	if key == "" {
+		key = idempotency.NewKey()
+	}
+	return idempotency.WithKey(ctx, key)
+}
+
+// parseID 解析资源ID参数
+func parseID(arg string) (int64, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid id %q", arg)
+	}
+	return id, nil
+}
+
+// parseIDs 解析多个资源ID参数
+func parseIDs(args []string) ([]int64, error) {
+	ids := make([]int64, 0, len(args))
+	for _, arg := range args {
+		id, err := parseID(arg)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseDate 将 2006-01-02 格式的日期转换为 google.protobuf.Timestamp 的JSON表示
+func parseDate(value string) (string, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date.UTC().Format(time.RFC3339), nil
+}
+
+// readRequestFile 读取JSON请求文件，"-" 表示标准输入
+func readRequestFile(cmd *cobra.Command, path string) (map[string]interface{}, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request file: %w", err)
+	}
+
+	request := make(map[string]interface{})
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, fmt.Errorf("failed to decode request file: %w", err)
+	}
+	return request, nil
+}
+
+// changedPaths 返回被显式设置的参数对应的字段路径，用于构建 update_mask
+func changedPaths(cmd *cobra.Command, paths map[string]string) []string {
+	var changed []string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if path, ok := paths[f.Name]; ok {
+			changed = append(changed, path)
+		}
+	})
+	return changed
+}
+
+// joinPaths 将字段路径编码为 google.protobuf.FieldMask 的JSON表示（逗号分隔的小驼峰路径）
+func joinPaths(paths []string) string {
+	camel := make([]string, len(paths))
+	for i, path := range paths {
+		parts := strings.Split(path, "_")
+		for j := 1; j < len(parts); j++ {
+			if parts[j] != "" {
+				parts[j] = strings.ToUpper(parts[j][:1]) + parts[j][1:]
+			}
+		}
+		camel[i] = strings.Join(parts, "")
+	}
+	return strings.Join(camel, ",")
+}
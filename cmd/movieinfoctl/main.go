@@ -0,0 +1,65 @@
+// movieinfoctl 电影信息服务命令行客户端
+//
+// 通过服务端反射获取接口描述，支持用户、电影、评分服务的常用操作以及任意RPC调用：
+//
+//	movieinfoctl movies get 1
+//	movieinfoctl ratings create --movie-id 1 --score 5 -o json
+//	movieinfoctl call movie GetMovie -d '{"id": 1}'
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := newRootCommand().ExecuteContext(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// newRootCommand 创建根命令
+func newRootCommand() *cobra.Command {
+	c := &ctl{}
+
+	root := &cobra.Command{
+		Use:           "movieinfoctl",
+		Short:         "Command-line client for the movieinfo services",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return c.init()
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			c.close()
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&c.configPath, "config", "configs/grpc.yaml", "gRPC config file, the grpc.client section is used")
+	flags.StringVarP(&c.output, "output", "o", formatTable, "output format: table, json or yaml")
+	flags.StringVar(&c.target, "target", "", "override the service address from the config file")
+	flags.StringVar(&c.token, "token", "", "access token, defaults to the token saved by login")
+	flags.StringVar(&c.apiKey, "api-key", "", "personal API key sent instead of the access token, defaults to $MOVIEINFOCTL_API_KEY")
+	flags.DurationVar(&c.timeout, "timeout", 0, "request timeout, defaults to the per-method timeout in the grpc.client config")
+
+	root.AddCommand(
+		newLoginCommand(c),
+		newLogoutCommand(c),
+		newMoviesCommand(c),
+		newUsersCommand(c),
+		newRatingsCommand(c),
+		newCallCommand(c),
+		newDescribeCommand(c),
+	)
+	return root
+}
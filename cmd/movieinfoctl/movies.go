@@ -0,0 +1,278 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+// movieColumns 电影表格的列
+var movieColumns = []string{"id", "title", "release_date", "language", "genres", "average_rating", "rating_count"}
+
+// newMoviesCommand 电影服务命令
+func newMoviesCommand(c *ctl) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "movies",
+		Aliases: []string{"movie"},
+		Short:   "Manage movies",
+	}
+	cmd.AddCommand(
+		newMoviesGetCommand(c),
+		newMoviesListCommand(c),
+		newMoviesSearchCommand(c),
+		newMoviesCreateCommand(c),
+		newMoviesUpdateCommand(c),
+		newMoviesDeleteCommand(c),
+	)
+	return cmd
+}
+
+// newMoviesGetCommand 获取电影，传入多个ID时批量获取
+func newMoviesGetCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id> [id...]",
+		Short: "Get one or more movies by id",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := parseIDs(args)
+			if err != nil {
+				return err
+			}
+
+			if len(ids) == 1 {
+				data, err := c.invoke(cmd.Context(), "movie", "GetMovie", map[string]interface{}{"id": ids[0]})
+				if err != nil {
+					return err
+				}
+				return c.print(data, tableSpec{Field: "movie"})
+			}
+
+			data, err := c.invoke(cmd.Context(), "movie", "BatchGetMovies", map[string]interface{}{"ids": ids})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "movies", Columns: movieColumns})
+		},
+	}
+}
+
+// newMoviesListCommand 分页列出电影
+func newMoviesListCommand(c *ctl) *cobra.Command {
+	var (
+		page     pageFlags
+		genres   []string
+		language string
+		search   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List movies",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := c.invoke(cmd.Context(), "movie", "ListMovies", map[string]interface{}{
+				"page":     page.request(),
+				"genres":   genres,
+				"language": language,
+				"search":   search,
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "movies", Columns: movieColumns})
+		},
+	}
+
+	page.register(cmd)
+	cmd.Flags().StringSliceVar(&genres, "genre", nil, "filter by genre, repeatable")
+	cmd.Flags().StringVar(&language, "language", "", "filter by language")
+	cmd.Flags().StringVar(&search, "search", "", "filter by keyword")
+	return cmd
+}
+
+// newMoviesSearchCommand 搜索电影
+func newMoviesSearchCommand(c *ctl) *cobra.Command {
+	var page pageFlags
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search movies",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := c.invoke(cmd.Context(), "movie", "SearchMovies", map[string]interface{}{
+				"query": args[0],
+				"page":  page.request(),
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "movies", Columns: movieColumns})
+		},
+	}
+
+	page.register(cmd)
+	return cmd
+}
+
+// movieFlags 创建和更新电影共用的参数
+type movieFlags struct {
+	title       string
+	description string
+	posterURL   string
+	duration    int32
+	releaseDate string
+	language    string
+	genres      []string
+	directors   []string
+	actors      []string
+}
+
+// movieFlagPaths 参数名到电影字段路径的映射
+var movieFlagPaths = map[string]string{
+	"title":        "title",
+	"description":  "description",
+	"poster-url":   "poster_url",
+	"duration":     "duration",
+	"release-date": "release_date",
+	"language":     "language",
+	"genre":        "genres",
+	"director":     "directors",
+	"actor":        "actors",
+}
+
+// register 注册电影参数
+func (m *movieFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&m.title, "title", "", "movie title")
+	cmd.Flags().StringVar(&m.description, "description", "", "movie description")
+	cmd.Flags().StringVar(&m.posterURL, "poster-url", "", "poster URL")
+	cmd.Flags().Int32Var(&m.duration, "duration", 0, "duration in minutes")
+	cmd.Flags().StringVar(&m.releaseDate, "release-date", "", "release date, YYYY-MM-DD")
+	cmd.Flags().StringVar(&m.language, "language", "", "language")
+	cmd.Flags().StringSliceVar(&m.genres, "genre", nil, "genre, repeatable")
+	cmd.Flags().StringSliceVar(&m.directors, "director", nil, "director, repeatable")
+	cmd.Flags().StringSliceVar(&m.actors, "actor", nil, "actor, repeatable")
+}
+
+// apply 将显式设置的参数写入请求
+func (m *movieFlags) apply(cmd *cobra.Command, request map[string]interface{}) error {
+	values := map[string]interface{}{
+		"title":       m.title,
+		"description": m.description,
+		"poster_url":  m.posterURL,
+		"duration":    m.duration,
+		"language":    m.language,
+		"genres":      m.genres,
+		"directors":   m.directors,
+		"actors":      m.actors,
+	}
+	if m.releaseDate != "" {
+		date, err := parseDate(m.releaseDate)
+		if err != nil {
+			return err
+		}
+		values["release_date"] = date
+	}
+
+	for _, path := range changedPaths(cmd, movieFlagPaths) {
+		request[path] = values[path]
+	}
+	return nil
+}
+
+// newMoviesCreateCommand 创建电影
+func newMoviesCreateCommand(c *ctl) *cobra.Command {
+	var (
+		movie movieFlags
+		file  string
+		idem  idempotencyFlag
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a movie from flags or a JSON file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request := make(map[string]interface{})
+			if file != "" {
+				var err error
+				if request, err = readRequestFile(cmd, file); err != nil {
+					return err
+				}
+			}
+			if err := movie.apply(cmd, request); err != nil {
+				return err
+			}
+			if request["title"] == nil {
+				return errors.New("--title is required")
+			}
+
+			data, err := c.invoke(idem.context(cmd.Context()), "movie", "CreateMovie", request)
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "movie"})
+		},
+	}
+
+	movie.register(cmd)
+	cmd.Flags().StringVarP(&file, "file", "f", "", "CreateMovieRequest as JSON, - for stdin")
+	idem.register(cmd)
+	return cmd
+}
+
+// newMoviesUpdateCommand 更新电影，只修改显式设置的字段
+func newMoviesUpdateCommand(c *ctl) *cobra.Command {
+	var movie movieFlags
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Update the given fields of a movie",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			paths := changedPaths(cmd, movieFlagPaths)
+			if len(paths) == 0 {
+				return errors.New("nothing to update, set at least one field flag")
+			}
+
+			request := map[string]interface{}{"id": id, "update_mask": joinPaths(paths)}
+			if err := movie.apply(cmd, request); err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "movie", "UpdateMovie", request)
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "movie"})
+		},
+	}
+
+	movie.register(cmd)
+	return cmd
+}
+
+// newMoviesDeleteCommand 删除电影
+func newMoviesDeleteCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a movie",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "movie", "DeleteMovie", map[string]interface{}{"id": id})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "common", Columns: []string{"success", "message"}})
+		},
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 输出格式
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// tableSpec 表格输出的布局
+type tableSpec struct {
+	// Field 响应中要展示的字段，可以是对象或对象列表；为空时展示整个响应
+	Field string
+	// Columns 展示的列，为空时展示对象的所有字段
+	Columns []string
+}
+
+// print 按输出格式打印响应
+func (c *ctl) print(data json.RawMessage, spec tableSpec) error {
+	return printTo(os.Stdout, c.output, data, spec)
+}
+
+// printTo 按输出格式将响应写入 w
+func printTo(w io.Writer, format string, data json.RawMessage, spec tableSpec) error {
+	switch format {
+	case formatJSON:
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return fmt.Errorf("failed to format json: %w", err)
+		}
+		buf.WriteByte('\n')
+		_, err := w.Write(buf.Bytes())
+		return err
+	case formatYAML:
+		return printYAML(w, data)
+	default:
+		return printTable(w, data, spec)
+	}
+}
+
+// printYAML 输出YAML，字段顺序与JSON保持一致
+func printYAML(w io.Writer, data json.RawMessage) error {
+	// JSON 是 YAML 的子集，解析为节点后清除流式风格即可得到块风格的YAML
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("failed to format yaml: %w", err)
+	}
+	resetStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return fmt.Errorf("failed to format yaml: %w", err)
+	}
+	return enc.Close()
+}
+
+// resetStyle 清除节点的流式和引号风格
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// printTable 输出表格，对象列表每个元素一行，单个对象按字段逐行展示
+func printTable(w io.Writer, data json.RawMessage, spec tableSpec) error {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if spec.Field != "" {
+		if obj, ok := value.(map[string]interface{}); ok {
+			value = obj[spec.Field]
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	switch v := value.(type) {
+	case []interface{}:
+		columns := spec.Columns
+		if len(columns) == 0 && len(v) > 0 {
+			columns = orderedKeys(data, spec.Field)
+		}
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+		for _, item := range v {
+			obj, _ := item.(map[string]interface{})
+			cells := make([]string, len(columns))
+			for i, column := range columns {
+				cells[i] = formatCell(obj[column])
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+	case map[string]interface{}:
+		columns := spec.Columns
+		if len(columns) == 0 {
+			columns = orderedKeys(data, spec.Field)
+		}
+		for _, column := range columns {
+			fmt.Fprintf(tw, "%s\t%s\n", strings.ToUpper(column), formatCell(v[column]))
+		}
+	case nil:
+		fmt.Fprintln(tw, "No resources found.")
+	default:
+		fmt.Fprintln(tw, formatCell(v))
+	}
+	return tw.Flush()
+}
+
+// orderedKeys 按响应中的出现顺序返回对象（或对象列表第一个元素）的字段名
+func orderedKeys(data json.RawMessage, field string) []string {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil || len(node.Content) == 0 {
+		return nil
+	}
+
+	n := node.Content[0]
+	if field != "" {
+		n = mappingValue(n, field)
+	}
+	if n != nil && n.Kind == yaml.SequenceNode {
+		if len(n.Content) == 0 {
+			return nil
+		}
+		n = n.Content[0]
+	}
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	keys := make([]string, 0, len(n.Content)/2)
+	for i := 0; i < len(n.Content); i += 2 {
+		keys = append(keys, n.Content[i].Value)
+	}
+	return keys
+}
+
+// mappingValue 返回映射节点中键对应的值节点
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// formatCell 格式化单元格，字符串列表用逗号连接，嵌套对象输出紧凑JSON
+func formatCell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return compactJSON(v)
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return compactJSON(v)
+	}
+}
+
+// compactJSON 将值编码为紧凑JSON
+func compactJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
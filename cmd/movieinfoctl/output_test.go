@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintTo(t *testing.T) {
+	const movies = `{"movies":[{"id":"2","title":"Heat","genres":["Crime","Drama"]},{"id":"1","title":"Alien","genres":[]}],"total":"2"}`
+	tests := []struct {
+		name   string
+		format string
+		data   string
+		spec   tableSpec
+		want   string
+	}{
+		{
+			name:   "table of a list in response order",
+			format: formatTable,
+			data:   movies,
+			spec:   tableSpec{Field: "movies"},
+			want:   "ID  TITLE  GENRES\n2   Heat   Crime, Drama\n1   Alien  \n",
+		},
+		{
+			name:   "table with selected columns",
+			format: formatTable,
+			data:   movies,
+			spec:   tableSpec{Field: "movies", Columns: []string{"title"}},
+			want:   "TITLE\nHeat\nAlien\n",
+		},
+		{
+			name:   "table of an object",
+			format: formatTable,
+			data:   `{"movie":{"id":"1","title":"Alien","director":null}}`,
+			spec:   tableSpec{Field: "movie"},
+			want:   "ID        1\nTITLE     Alien\nDIRECTOR  -\n",
+		},
+		{
+			name:   "table of a missing field",
+			format: formatTable,
+			data:   `{}`,
+			spec:   tableSpec{Field: "movie"},
+			want:   "No resources found.\n",
+		},
+		{
+			name:   "json",
+			format: formatJSON,
+			data:   `{"id":"1","title":"Alien"}`,
+			want:   "{\n  \"id\": \"1\",\n  \"title\": \"Alien\"\n}\n",
+		},
+		{
+			name:   "yaml keeps field order",
+			format: formatYAML,
+			data:   `{"title":"Alien","id":"1","genres":["Horror"]}`,
+			want:   "title: Alien\nid: \"1\"\ngenres:\n  - Horror\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := printTo(&buf, tt.format, []byte(tt.data), tt.spec); err != nil {
+				t.Fatalf("printTo() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("printTo() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinPaths(t *testing.T) {
+	got := joinPaths([]string{"title", "poster_url", "release_date"})
+	if want := "title,posterUrl,releaseDate"; got != want {
+		t.Fatalf("joinPaths() = %q, want %q", got, want)
+	}
+}
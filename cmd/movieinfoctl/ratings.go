@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+// ratingColumns 评分表格的列
+var ratingColumns = []string{"id", "user_id", "movie_id", "score", "comment", "created_at"}
+
+// newRatingsCommand 评分服务命令
+func newRatingsCommand(c *ctl) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ratings",
+		Aliases: []string{"rating"},
+		Short:   "Manage ratings",
+	}
+	cmd.AddCommand(
+		newRatingsGetCommand(c),
+		newRatingsListCommand(c),
+		newRatingsCreateCommand(c),
+		newRatingsUpdateCommand(c),
+		newRatingsDeleteCommand(c),
+		newRatingsAverageCommand(c),
+		newRatingsWatchCommand(c),
+	)
+	return cmd
+}
+
+// newRatingsGetCommand 获取评分
+func newRatingsGetCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "Get a rating by id",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "rating", "GetRating", map[string]interface{}{"id": id})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "rating"})
+		},
+	}
+}
+
+// newRatingsListCommand 分页列出评分，指定 --movie 时获取用户对这些电影的评分
+func newRatingsListCommand(c *ctl) *cobra.Command {
+	var (
+		page     pageFlags
+		userID   int64
+		movieID  int64
+		movieIDs []int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List ratings by user or movie",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(movieIDs) > 0 {
+				if userID == 0 {
+					var err error
+					if userID, err = c.currentUserID(); err != nil {
+						return err
+					}
+				}
+
+				data, err := c.invoke(cmd.Context(), "rating", "BatchGetUserRatings", map[string]interface{}{
+					"user_id":   userID,
+					"movie_ids": movieIDs,
+				})
+				if err != nil {
+					return err
+				}
+				return c.print(data, tableSpec{Field: "ratings", Columns: ratingColumns})
+			}
+
+			data, err := c.invoke(cmd.Context(), "rating", "ListRatings", map[string]interface{}{
+				"page":     page.request(),
+				"user_id":  userID,
+				"movie_id": movieID,
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "ratings", Columns: ratingColumns})
+		},
+	}
+
+	page.register(cmd)
+	cmd.Flags().Int64Var(&userID, "user-id", 0, "filter by user")
+	cmd.Flags().Int64Var(&movieID, "movie-id", 0, "filter by movie")
+	cmd.Flags().Int64SliceVar(&movieIDs, "movie", nil, "get the user's ratings of these movies, repeatable")
+	return cmd
+}
+
+// newRatingsCreateCommand 为电影评分，默认使用当前登录用户
+func newRatingsCreateCommand(c *ctl) *cobra.Command {
+	var (
+		userID  int64
+		movieID int64
+		score   int32
+		comment string
+		idem    idempotencyFlag
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Rate a movie",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if movieID == 0 || score == 0 {
+				return errors.New("--movie-id and --score are required")
+			}
+			if userID == 0 {
+				var err error
+				if userID, err = c.currentUserID(); err != nil {
+					return err
+				}
+			}
+
+			data, err := c.invoke(idem.context(cmd.Context()), "rating", "CreateRating", map[string]interface{}{
+				"user_id":  userID,
+				"movie_id": movieID,
+				"score":    score,
+				"comment":  comment,
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "rating"})
+		},
+	}
+
+	cmd.Flags().Int64Var(&userID, "user-id", 0, "user id, defaults to the logged-in user")
+	cmd.Flags().Int64Var(&movieID, "movie-id", 0, "movie id")
+	cmd.Flags().Int32Var(&score, "score", 0, "score from 1 to 5")
+	cmd.Flags().StringVar(&comment, "comment", "", "comment")
+	idem.register(cmd)
+	return cmd
+}
+
+// newRatingsUpdateCommand 修改评分
+func newRatingsUpdateCommand(c *ctl) *cobra.Command {
+	var (
+		score   int32
+		comment string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Change the score and comment of a rating",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			if score == 0 {
+				return errors.New("--score is required")
+			}
+
+			data, err := c.invoke(cmd.Context(), "rating", "UpdateRating", map[string]interface{}{
+				"id":      id,
+				"score":   score,
+				"comment": comment,
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "rating"})
+		},
+	}
+
+	cmd.Flags().Int32Var(&score, "score", 0, "score from 1 to 5")
+	cmd.Flags().StringVar(&comment, "comment", "", "comment")
+	return cmd
+}
+
+// newRatingsDeleteCommand 删除评分
+func newRatingsDeleteCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a rating",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "rating", "DeleteRating", map[string]interface{}{"id": id})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "common", Columns: []string{"success", "message"}})
+		},
+	}
+}
+
+// newRatingsAverageCommand 获取电影平均评分
+func newRatingsAverageCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "average <movie_id>",
+		Short: "Show the average rating of a movie",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			movieID, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "rating", "GetMovieAverageRating", map[string]interface{}{"movie_id": movieID})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Columns: []string{"average_rating", "total_ratings"}})
+		},
+	}
+}
+
+// newRatingsWatchCommand 持续输出电影的评分变更事件，直到中断
+func newRatingsWatchCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch <movie_id>",
+		Short: "Stream rating changes of a movie",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			movieID, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			return c.stream(cmd.Context(), "rating", "WatchMovieRatings",
+				[]interface{}{map[string]interface{}{"movie_id": movieID}},
+				func(event json.RawMessage) error {
+					return c.print(event, tableSpec{Columns: []string{"type", "rating", "average_rating", "total_ratings", "occurred_at"}})
+				})
+		},
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// describeService 通过服务端反射获取服务描述
+// 服务端需要注册反射服务（reflection.Register），缺失的依赖文件会按文件名补充获取
+func describeService(ctx context.Context, conn grpc.ClientConnInterface, service string) (protoreflect.ServiceDescriptor, error) {
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open reflection stream: %w", err)
+	}
+	defer stream.CloseSend()
+
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	fetch := func(req *rpb.ServerReflectionRequest) error {
+		if err := stream.Send(req); err != nil {
+			return fmt.Errorf("failed to send reflection request: %w", err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("failed to receive reflection response: %w", err)
+		}
+		if e := resp.GetErrorResponse(); e != nil {
+			return fmt.Errorf("reflection error: %s", e.GetErrorMessage())
+		}
+		for _, b := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := new(descriptorpb.FileDescriptorProto)
+			if err := proto.Unmarshal(b, fd); err != nil {
+				return fmt.Errorf("failed to decode file descriptor: %w", err)
+			}
+			files[fd.GetName()] = fd
+		}
+		return nil
+	}
+
+	if err := fetch(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}); err != nil {
+		return nil, err
+	}
+	for {
+		missing := missingDependency(files)
+		if missing == "" {
+			break
+		}
+		if err := fetch(&rpb.ServerReflectionRequest{
+			MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: missing},
+		}); err != nil {
+			return nil, err
+		}
+		if _, ok := files[missing]; !ok {
+			return nil, fmt.Errorf("server did not return file %s", missing)
+		}
+	}
+
+	registry := new(protoregistry.Files)
+	for name := range files {
+		if err := registerFile(registry, files, name); err != nil {
+			return nil, err
+		}
+	}
+
+	desc, err := registry.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("service %s not found: %w", service, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", service)
+	}
+	return sd, nil
+}
+
+// missingDependency 返回第一个既未获取也未编译进程序的依赖文件
+func missingDependency(files map[string]*descriptorpb.FileDescriptorProto) string {
+	for _, fd := range files {
+		for _, dep := range fd.GetDependency() {
+			if _, ok := files[dep]; ok {
+				continue
+			}
+			if _, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+				continue
+			}
+			return dep
+		}
+	}
+	return ""
+}
+
+// registerFile 按依赖顺序注册文件描述，程序中已有的文件（如 google/protobuf/*）直接复用
+func registerFile(registry *protoregistry.Files, files map[string]*descriptorpb.FileDescriptorProto, name string) error {
+	if _, err := registry.FindFileByPath(name); err == nil {
+		return nil
+	}
+	if _, err := protoregistry.GlobalFiles.FindFileByPath(name); err == nil {
+		return nil
+	}
+
+	fd := files[name]
+	for _, dep := range fd.GetDependency() {
+		if err := registerFile(registry, files, dep); err != nil {
+			return err
+		}
+	}
+
+	file, err := protodesc.NewFile(fd, resolver{registry})
+	if err != nil {
+		return fmt.Errorf("failed to build descriptor for %s: %w", name, err)
+	}
+	return registry.RegisterFile(file)
+}
+
+// resolver 先查找反射获取的文件，再查找程序中已注册的文件
+type resolver struct {
+	local *protoregistry.Files
+}
+
+// FindFileByPath 实现 protodesc.Resolver
+func (r resolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.local.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+// FindDescriptorByName 实现 protodesc.Resolver
+func (r resolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.local.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
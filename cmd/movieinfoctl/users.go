@@ -0,0 +1,779 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// userColumns 用户表格的列
+var userColumns = []string{"id", "username", "email", "email_verified", "nickname", "status", "created_at"}
+
+// newUsersCommand 用户服务命令
+func newUsersCommand(c *ctl) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "users",
+		Aliases: []string{"user"},
+		Short:   "Manage users",
+	}
+	cmd.AddCommand(
+		newUsersGetCommand(c),
+		newUsersListCommand(c),
+		newUsersCreateCommand(c),
+		newUsersUpdateCommand(c),
+		newUsersDeleteCommand(c),
+		newUsersChangePasswordCommand(c),
+		newUsersSendResetCodeCommand(c),
+		newUsersVerifyResetCodeCommand(c),
+		newUsersResetPasswordCommand(c),
+		newUsersVerifyEmailCommand(c),
+		newUsersResendVerificationCommand(c),
+		newUsersEnrollTOTPCommand(c),
+		newUsersConfirmTOTPCommand(c),
+		newUsersDisableTOTPCommand(c),
+		newUsersRecoveryCodesCommand(c),
+		newUsersUnlockCommand(c),
+		newUsersGrantRoleCommand(c),
+		newUsersRevokeRoleCommand(c),
+		newUsersSessionsCommand(c),
+		newUsersRevokeSessionCommand(c),
+		newUsersRevokeOtherSessionsCommand(c),
+		newUsersCreateAPIKeyCommand(c),
+		newUsersAPIKeysCommand(c),
+		newUsersRevokeAPIKeyCommand(c),
+	)
+	return cmd
+}
+
+// newUsersGetCommand 根据ID或用户名获取用户
+func newUsersGetCommand(c *ctl) *cobra.Command {
+	var username string
+
+	cmd := &cobra.Command{
+		Use:   "get [id]",
+		Short: "Get a user by id or --username",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request := make(map[string]interface{})
+			switch {
+			case len(args) == 1 && username != "":
+				return errors.New("pass either an id or --username, not both")
+			case len(args) == 1:
+				id, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+				request["id"] = id
+			case username != "":
+				request["username"] = username
+			default:
+				return errors.New("an id or --username is required")
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "GetUser", request)
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "user"})
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "look the user up by username")
+	return cmd
+}
+
+// newUsersListCommand 分页列出用户
+func newUsersListCommand(c *ctl) *cobra.Command {
+	var (
+		page   pageFlags
+		status string
+		search string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List users",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request := map[string]interface{}{
+				"page":   page.request(),
+				"search": search,
+			}
+			if status != "" {
+				request["status"] = userStatus(status)
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "ListUsers", request)
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "users", Columns: userColumns})
+		},
+	}
+
+	page.register(cmd)
+	cmd.Flags().StringVar(&status, "status", "", "filter by status: active or inactive")
+	cmd.Flags().StringVar(&search, "search", "", "filter by keyword")
+	return cmd
+}
+
+// newUsersCreateCommand 创建用户
+func newUsersCreateCommand(c *ctl) *cobra.Command {
+	var (
+		username, email, password, nickname string
+		idem                                idempotencyFlag
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if username == "" || email == "" {
+				return errors.New("--username and --email are required")
+			}
+			if password == "" {
+				var err error
+				if password, err = readPassword(cmd); err != nil {
+					return err
+				}
+			}
+
+			data, err := c.invoke(idem.context(cmd.Context()), "user", "CreateUser", map[string]interface{}{
+				"username": username,
+				"email":    email,
+				"password": password,
+				"nickname": nickname,
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "user"})
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "username")
+	cmd.Flags().StringVar(&email, "email", "", "email")
+	cmd.Flags().StringVar(&password, "password", "", "password, read from stdin when omitted")
+	cmd.Flags().StringVar(&nickname, "nickname", "", "nickname")
+	idem.register(cmd)
+	return cmd
+}
+
+// userFlagPaths 参数名到用户字段路径的映射
+var userFlagPaths = map[string]string{
+	"nickname": "nickname",
+	"avatar":   "avatar",
+	"email":    "email",
+}
+
+// newUsersUpdateCommand 更新用户，只修改显式设置的字段
+func newUsersUpdateCommand(c *ctl) *cobra.Command {
+	var nickname, avatar, email string
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Update the given fields of a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			paths := changedPaths(cmd, userFlagPaths)
+			if len(paths) == 0 {
+				return errors.New("nothing to update, set --nickname, --avatar or --email")
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "UpdateUser", map[string]interface{}{
+				"id":          id,
+				"nickname":    nickname,
+				"avatar":      avatar,
+				"email":       email,
+				"update_mask": joinPaths(paths),
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "user"})
+		},
+	}
+
+	cmd.Flags().StringVar(&nickname, "nickname", "", "nickname")
+	cmd.Flags().StringVar(&avatar, "avatar", "", "avatar URL")
+	cmd.Flags().StringVar(&email, "email", "", "email, a verification email is sent to the new address")
+	return cmd
+}
+
+// newUsersDeleteCommand 删除用户
+func newUsersDeleteCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "DeleteUser", map[string]interface{}{"id": id})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "common", Columns: []string{"success", "message"}})
+		},
+	}
+}
+
+// newUsersChangePasswordCommand 修改当前登录用户的密码
+func newUsersChangePasswordCommand(c *ctl) *cobra.Command {
+	var userID int64
+	var oldPassword, newPassword string
+
+	cmd := &cobra.Command{
+		Use:   "change-password",
+		Short: "Change the password of the logged-in user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if userID == 0 {
+				var err error
+				if userID, err = c.currentUserID(); err != nil {
+					return err
+				}
+			}
+			if oldPassword == "" || newPassword == "" {
+				return errors.New("--old-password and --new-password are required")
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "ChangePassword", map[string]interface{}{
+				"user_id":      userID,
+				"old_password": oldPassword,
+				"new_password": newPassword,
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "common", Columns: []string{"success", "message"}})
+		},
+	}
+
+	cmd.Flags().Int64Var(&userID, "user-id", 0, "user id, defaults to the logged-in user")
+	cmd.Flags().StringVar(&oldPassword, "old-password", "", "current password")
+	cmd.Flags().StringVar(&newPassword, "new-password", "", "new password")
+	return cmd
+}
+
+// newUsersSendResetCodeCommand 发送找回密码的邮件重置码
+func newUsersSendResetCodeCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "send-reset-code <email>",
+		Short: "Email a password reset code",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := c.invoke(cmd.Context(), "user", "SendResetCode", map[string]interface{}{
+				"email": args[0],
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Columns: []string{"message", "expires_in"}})
+		},
+	}
+}
+
+// newUsersVerifyResetCodeCommand 校验重置码，不会使重置码失效
+func newUsersVerifyResetCodeCommand(c *ctl) *cobra.Command {
+	var email, code string
+
+	cmd := &cobra.Command{
+		Use:   "verify-reset-code",
+		Short: "Check a password reset code without using it",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if email == "" || code == "" {
+				return errors.New("--email and --code are required")
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "VerifyResetCode", map[string]interface{}{
+				"email": email,
+				"code":  code,
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "common", Columns: []string{"success", "message"}})
+		},
+	}
+
+	cmd.Flags().StringVar(&email, "email", "", "email the code was sent to")
+	cmd.Flags().StringVar(&code, "code", "", "reset code from the email")
+	return cmd
+}
+
+// newUsersResetPasswordCommand 使用重置码设置新密码
+func newUsersResetPasswordCommand(c *ctl) *cobra.Command {
+	var email, code, newPassword string
+
+	cmd := &cobra.Command{
+		Use:   "reset-password",
+		Short: "Set a new password with a reset code and sign out all sessions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if email == "" || code == "" {
+				return errors.New("--email and --code are required")
+			}
+			if newPassword == "" {
+				var err error
+				if newPassword, err = readPassword(cmd); err != nil {
+					return err
+				}
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "ResetPassword", map[string]interface{}{
+				"email":        email,
+				"code":         code,
+				"new_password": newPassword,
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "common", Columns: []string{"success", "message"}})
+		},
+	}
+
+	cmd.Flags().StringVar(&email, "email", "", "email the code was sent to")
+	cmd.Flags().StringVar(&code, "code", "", "reset code from the email")
+	cmd.Flags().StringVar(&newPassword, "new-password", "", "new password, read from stdin when omitted")
+	return cmd
+}
+
+// newUsersVerifyEmailCommand 使用验证邮件中的令牌验证邮箱
+func newUsersVerifyEmailCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify-email <token>",
+		Short: "Verify an email address with the token from the verification link",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := c.invoke(cmd.Context(), "user", "VerifyEmail", map[string]interface{}{
+				"token": args[0],
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "user", Columns: userColumns})
+		},
+	}
+}
+
+// newUsersResendVerificationCommand 重新发送邮箱验证邮件
+func newUsersResendVerificationCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resend-verification <email>",
+		Short: "Send the email verification link again",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := c.invoke(cmd.Context(), "user", "ResendVerificationEmail", map[string]interface{}{
+				"email": args[0],
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Columns: []string{"message"}})
+		},
+	}
+}
+
+// newUsersEnrollTOTPCommand 为当前登录用户登记TOTP两步验证
+func newUsersEnrollTOTPCommand(c *ctl) *cobra.Command {
+	var qrFile string
+
+	cmd := &cobra.Command{
+		Use:   "enroll-totp",
+		Short: "Start TOTP two-factor enrolment for the logged-in user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := c.currentUserID()
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "EnrollTOTP", map[string]interface{}{"user_id": userID})
+			if err != nil {
+				return err
+			}
+			if err := showEnrollment(cmd.OutOrStdout(), data, qrFile); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Run movieinfoctl users confirm-totp <code> with the first code from your authenticator app")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&qrFile, "qr-file", "", "write the QR code PNG to this file")
+	return cmd
+}
+
+// newUsersConfirmTOTPCommand 使用首个动态码确认登记，启用两步验证
+func newUsersConfirmTOTPCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "confirm-totp <code>",
+		Short: "Confirm TOTP enrolment with the first code and print recovery codes",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.twoFactorCodes(cmd, "ConfirmTOTP", args[0])
+		},
+	}
+}
+
+// newUsersDisableTOTPCommand 关闭当前登录用户的两步验证
+func newUsersDisableTOTPCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable-totp <code>",
+		Short: "Disable two-factor authentication with a current code or recovery code",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := c.currentUserID()
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "DisableTOTP", map[string]interface{}{
+				"user_id": userID,
+				"code":    args[0],
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "common", Columns: []string{"success", "message"}})
+		},
+	}
+}
+
+// newUsersRecoveryCodesCommand 重新生成恢复码
+func newUsersRecoveryCodesCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "recovery-codes <code>",
+		Short: "Regenerate recovery codes, invalidating the old ones",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.twoFactorCodes(cmd, "RegenerateRecoveryCodes", args[0])
+		},
+	}
+}
+
+// twoFactorCodes 调用 ConfirmTOTP 或 RegenerateRecoveryCodes 并展示返回的恢复码
+func (c *ctl) twoFactorCodes(cmd *cobra.Command, method, code string) error {
+	userID, err := c.currentUserID()
+	if err != nil {
+		return err
+	}
+
+	data, err := c.invoke(cmd.Context(), "user", method, map[string]interface{}{
+		"user_id": userID,
+		"code":    code,
+	})
+	if err != nil {
+		return err
+	}
+	if c.output == formatJSON || c.output == formatYAML {
+		return c.print(data, tableSpec{})
+	}
+
+	var resp struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	showRecoveryCodes(cmd.OutOrStdout(), resp.RecoveryCodes)
+	return nil
+}
+
+// showEnrollment 展示 EnrollTOTP 返回的密钥和 otpauth URI，指定 qrFile 时保存二维码图片
+func showEnrollment(w io.Writer, data json.RawMessage, qrFile string) error {
+	var resp struct {
+		Secret     string `json:"secret"`
+		OtpauthURI string `json:"otpauth_uri"`
+		QRCodePNG  []byte `json:"qr_code_png"` // protojson 中 bytes 编码为 base64
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("failed to decode enrolment response: %w", err)
+	}
+
+	fmt.Fprintf(w, "Add this account to your authenticator app:\n  secret: %s\n  uri:    %s\n", resp.Secret, resp.OtpauthURI)
+	if qrFile != "" {
+		if err := os.WriteFile(qrFile, resp.QRCodePNG, 0o600); err != nil {
+			return fmt.Errorf("failed to write qr code: %w", err)
+		}
+		fmt.Fprintf(w, "  qr code: %s\n", qrFile)
+	}
+	return nil
+}
+
+// showRecoveryCodes 展示恢复码，恢复码只返回一次
+func showRecoveryCodes(w io.Writer, codes []string) {
+	if len(codes) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Recovery codes (each can be used once, store them somewhere safe):")
+	for _, code := range codes {
+		fmt.Fprintf(w, "  %s\n", code)
+	}
+}
+
+// newUsersUnlockCommand 解除用户的登录失败锁定，需要 users:write 权限
+func newUsersUnlockCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unlock <id>",
+		Short: "Clear failed login attempts of a user and lift the lockout",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "UnlockUser", map[string]interface{}{"user_id": id})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "common", Columns: []string{"success", "message"}})
+		},
+	}
+}
+
+// newUsersGrantRoleCommand 授予用户角色，需要 roles:manage 权限
+func newUsersGrantRoleCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "grant-role <id> <role>",
+		Short: "Grant a role to a user, effective after the user's next token refresh",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.changeRole(cmd, "GrantRole", args)
+		},
+	}
+}
+
+// newUsersRevokeRoleCommand 撤销用户角色并注销该用户的全部会话，需要 roles:manage 权限
+func newUsersRevokeRoleCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-role <id> <role>",
+		Short: "Revoke a role from a user and sign out all of the user's sessions",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.changeRole(cmd, "RevokeRole", args)
+		},
+	}
+}
+
+// changeRole 调用 GrantRole 或 RevokeRole，args 为用户ID和角色名称
+func (c *ctl) changeRole(cmd *cobra.Command, method string, args []string) error {
+	id, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	data, err := c.invoke(cmd.Context(), "user", method, map[string]interface{}{
+		"user_id": id,
+		"role":    args[1],
+	})
+	if err != nil {
+		return err
+	}
+	return c.print(data, tableSpec{Columns: []string{"roles"}})
+}
+
+// newUsersSessionsCommand 列出当前登录用户已登录的设备
+func newUsersSessionsCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "sessions",
+		Short: "List active sessions of the logged-in user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := c.currentUserID()
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "ListSessions", map[string]interface{}{"user_id": userID})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{
+				Field:   "sessions",
+				Columns: []string{"session_id", "device", "ip_address", "created_at", "last_seen_at", "current"},
+			})
+		},
+	}
+}
+
+// newUsersRevokeSessionCommand 注销当前登录用户的指定会话
+func newUsersRevokeSessionCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-session <session-id>",
+		Short: "Sign out one session of the logged-in user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := c.currentUserID()
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "RevokeSession", map[string]interface{}{
+				"user_id":    userID,
+				"session_id": args[0],
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "common", Columns: []string{"success", "message"}})
+		},
+	}
+}
+
+// newUsersRevokeOtherSessionsCommand 注销当前登录用户除本机以外的全部会话
+func newUsersRevokeOtherSessionsCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-other-sessions",
+		Short: "Sign out all sessions of the logged-in user except this one",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := c.currentUserID()
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "RevokeAllOtherSessions", map[string]interface{}{"user_id": userID})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Columns: []string{"revoked_count"}})
+		},
+	}
+}
+
+// apiKeyColumns API密钥表格的列
+var apiKeyColumns = []string{"id", "name", "prefix", "scopes", "expires_at", "last_used_at", "last_used_ip", "created_at"}
+
+// newUsersCreateAPIKeyCommand 为当前登录用户创建API密钥，完整密钥只输出一次
+func newUsersCreateAPIKeyCommand(c *ctl) *cobra.Command {
+	var (
+		name      string
+		scopes    []string
+		expiresIn time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-api-key",
+		Short: "Create a personal API key for the logged-in user, the key is shown only once",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := c.currentUserID()
+			if err != nil {
+				return err
+			}
+			if name == "" {
+				return errors.New("--name is required")
+			}
+
+			request := map[string]interface{}{
+				"user_id": userID,
+				"name":    name,
+				"scopes":  scopes,
+			}
+			if expiresIn > 0 {
+				request["expires_at"] = time.Now().Add(expiresIn).UTC().Format(time.RFC3339)
+			}
+			data, err := c.invoke(cmd.Context(), "user", "CreateAPIKey", request)
+			if err != nil {
+				return err
+			}
+			if c.output == formatJSON || c.output == formatYAML {
+				return c.print(data, tableSpec{})
+			}
+
+			var resp struct {
+				Key string `json:"key"`
+			}
+			if err := json.Unmarshal(data, &resp); err != nil {
+				return fmt.Errorf("failed to decode CreateAPIKey response: %w", err)
+			}
+			if err := c.print(data, tableSpec{Field: "api_key", Columns: apiKeyColumns}); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "API key (shown only once, store it somewhere safe):\n  %s\n", resp.Key)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "name of the key, unique per user")
+	cmd.Flags().StringArrayVar(&scopes, "scope", []string{"read-only"}, "scope of the key, repeatable: read-only, catalogue-write or ratings-write")
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "lifetime of the key such as 720h, the key never expires when omitted")
+	return cmd
+}
+
+// newUsersAPIKeysCommand 列出当前登录用户的API密钥
+func newUsersAPIKeysCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "api-keys",
+		Short: "List personal API keys of the logged-in user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := c.currentUserID()
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "ListAPIKeys", map[string]interface{}{"user_id": userID})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "api_keys", Columns: apiKeyColumns})
+		},
+	}
+}
+
+// newUsersRevokeAPIKeyCommand 撤销当前登录用户的API密钥
+func newUsersRevokeAPIKeyCommand(c *ctl) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-api-key <id>",
+		Short: "Revoke a personal API key of the logged-in user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			userID, err := c.currentUserID()
+			if err != nil {
+				return err
+			}
+
+			data, err := c.invoke(cmd.Context(), "user", "RevokeAPIKey", map[string]interface{}{
+				"user_id": userID,
+				"id":      id,
+			})
+			if err != nil {
+				return err
+			}
+			return c.print(data, tableSpec{Field: "common", Columns: []string{"success", "message"}})
+		},
+	}
+}
+
+// userStatus 将 active 这样的简写转换为 USER_STATUS_ACTIVE
+func userStatus(status string) string {
+	status = strings.ToUpper(status)
+	if strings.HasPrefix(status, "USER_STATUS_") {
+		return status
+	}
+	return "USER_STATUS_" + status
+}
@@ -13,6 +13,9 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/3inchtime/movieinfo/pkg/app"
+	"github.com/3inchtime/movieinfo/pkg/logger"
 )
 
 // 模拟的用户服务实现
@@ -102,9 +105,15 @@ func main() {
 	fmt.Println("  - 日志记录")
 	fmt.Println("  - 错误处理")
 	fmt.Println("")
-	fmt.Println("按 Ctrl+C 停止服务器")
+	fmt.Println("按 Ctrl+C 停止服务器，进行中的请求完成后退出")
 
-	if err := server.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	// 按依赖顺序启动组件，收到退出信号后按相反顺序关闭
+	application := app.New("grpc-server-demo", app.DefaultConfig())
+	application.Add(
+		app.Logger(&logger.Config{Level: "info", Format: "text", Output: "stdout"}),
+		application.GRPCServer("grpc-server", server, lis),
+	)
+	if err := application.Run(context.Background()); err != nil {
+		log.Fatalf("Failed to run: %v", err)
 	}
 }
\ No newline at end of file
@@ -1,8 +1,20 @@
 package config
 
 import (
+	"github.com/3inchtime/movieinfo/internal/service"
+	"github.com/3inchtime/movieinfo/pkg/app"
+	"github.com/3inchtime/movieinfo/pkg/auth"
 	"github.com/3inchtime/movieinfo/pkg/config"
+	"github.com/3inchtime/movieinfo/pkg/database"
+	"github.com/3inchtime/movieinfo/pkg/eventbus"
+	"github.com/3inchtime/movieinfo/pkg/lockout"
 	"github.com/3inchtime/movieinfo/pkg/logger"
+	"github.com/3inchtime/movieinfo/pkg/mailer"
+	"github.com/3inchtime/movieinfo/pkg/metrics"
+	"github.com/3inchtime/movieinfo/pkg/oidc"
+	"github.com/3inchtime/movieinfo/pkg/password"
+	"github.com/3inchtime/movieinfo/pkg/redis"
+	"github.com/3inchtime/movieinfo/pkg/tracing"
 )
 
 // AppConfig 应用内部配置
@@ -25,6 +37,7 @@ func NewConfig(configPath string) (*AppConfig, error) {
 			Format: cfg.Log.Format,
 			Output: cfg.Log.Output,
 			File:   logger.FileConfig(cfg.Log.File),
+			Async:  logger.AsyncConfig(cfg.Log.Async),
 		},
 	}, nil
 }
@@ -33,3 +46,91 @@ func NewConfig(configPath string) (*AppConfig, error) {
 func (c *AppConfig) GetLoggerConfig() *logger.Config {
 	return c.Logger
 }
+
+// GetDatabaseConfig 获取数据库配置
+func (c *AppConfig) GetDatabaseConfig() *database.Config {
+	return (*database.Config)(&c.Config.Database)
+}
+
+// GetRedisConfig 获取Redis配置
+func (c *AppConfig) GetRedisConfig() *redis.Config {
+	return (*redis.Config)(&c.Config.Redis)
+}
+
+// GetEventBusConfig 获取事件总线配置
+func (c *AppConfig) GetEventBusConfig() *eventbus.Config {
+	return (*eventbus.Config)(&c.Config.EventBus)
+}
+
+// GetMetricsConfig 获取指标配置
+func (c *AppConfig) GetMetricsConfig() *metrics.Config {
+	return (*metrics.Config)(&c.Config.Metrics)
+}
+
+// GetTracingConfig 获取链路追踪配置
+func (c *AppConfig) GetTracingConfig() *tracing.Config {
+	return (*tracing.Config)(&c.Config.Tracing)
+}
+
+// GetShutdownConfig 获取优雅关闭配置
+func (c *AppConfig) GetShutdownConfig() *app.Config {
+	return (*app.Config)(&c.Config.Shutdown)
+}
+
+// GetMailerConfig 获取邮件发送配置
+func (c *AppConfig) GetMailerConfig() *mailer.Config {
+	mail := c.Config.Mail
+	return &mailer.Config{
+		Driver: mail.Driver,
+		From:   mail.From,
+		SMTP:   mailer.SMTPConfig(mail.SMTP),
+		Dir:    mail.Dir,
+	}
+}
+
+// GetPasswordResetConfig 获取找回密码配置
+func (c *AppConfig) GetPasswordResetConfig() *service.PasswordResetConfig {
+	return (*service.PasswordResetConfig)(&c.Config.PasswordReset)
+}
+
+// GetJWTConfig 获取JWT与会话配置
+func (c *AppConfig) GetJWTConfig() *auth.Config {
+	return (*auth.Config)(&c.Config.JWT)
+}
+
+// GetEmailVerificationConfig 获取邮箱验证配置
+func (c *AppConfig) GetEmailVerificationConfig() *service.EmailVerificationConfig {
+	return (*service.EmailVerificationConfig)(&c.Config.EmailVerification)
+}
+
+// GetLoginProtectionConfig 获取登录失败保护配置
+func (c *AppConfig) GetLoginProtectionConfig() *lockout.Config {
+	return (*lockout.Config)(&c.Config.LoginProtection)
+}
+
+// GetTwoFactorConfig 获取两步验证配置
+func (c *AppConfig) GetTwoFactorConfig() *service.TwoFactorConfig {
+	return (*service.TwoFactorConfig)(&c.Config.TwoFactor)
+}
+
+// GetPasswordConfig 获取密码哈希与密码策略配置
+func (c *AppConfig) GetPasswordConfig() *password.Config {
+	return &password.Config{
+		Hash:   password.HashConfig(c.Config.Password.Hash),
+		Policy: password.PolicyConfig(c.Config.Password.Policy),
+	}
+}
+
+// GetOIDCConfig 获取第三方身份登录配置
+func (c *AppConfig) GetOIDCConfig() *oidc.Config {
+	providers := make(map[string]oidc.ProviderConfig, len(c.Config.OIDC.Providers))
+	for name, provider := range c.Config.OIDC.Providers {
+		providers[name] = oidc.ProviderConfig(provider)
+	}
+	return &oidc.Config{
+		Store:     c.Config.OIDC.Store,
+		KeyPrefix: c.Config.OIDC.KeyPrefix,
+		StateTTL:  c.Config.OIDC.StateTTL,
+		Providers: providers,
+	}
+}
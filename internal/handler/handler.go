@@ -0,0 +1,92 @@
+// Package handler 将 proto 生成的gRPC接口适配到 internal/service 中的业务服务
+// 处理器只负责消息转换，参数校验、认证和权限由服务器拦截器完成，业务规则由业务服务负责
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+	commonpb "github.com/3inchtime/movieinfo/proto/gen/common"
+)
+
+// grpcError 将业务服务返回的错误转换为gRPC错误
+// 业务错误和gRPC状态错误（如流式接收失败）取出原始错误返回，上下文结束转换为对应的状态码；
+// 其他错误记录日志后返回通用的内部错误，避免向客户端暴露实现细节
+func grpcError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return grpcErr.(error)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	logger.Errorf("%s failed: %v", method, err)
+	return apperror.New(apperror.InternalError, "internal error")
+}
+
+// success 成功响应的通用信息
+func success() *commonpb.CommonResponse {
+	return &commonpb.CommonResponse{Success: true, Message: "ok", Timestamp: timestamppb.Now()}
+}
+
+// successMessage 携带提示信息的成功响应
+func successMessage(message string) *commonpb.CommonResponse {
+	common := success()
+	common.Message = message
+	return common
+}
+
+// timestamp 转换可为空的时间，nil 时返回 nil
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// optionalTime 转换可为空的时间戳，未设置时返回 nil
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// seconds 返回距离 t 的秒数，已过期时返回0
+func seconds(t time.Time) int64 {
+	if d := time.Until(t); d > 0 {
+		return int64(d.Round(time.Second) / time.Second)
+	}
+	return 0
+}
+
+// errorDetails 转换错误详情
+func errorDetails(details []apperror.ErrorDetail) []*commonpb.ErrorDetail {
+	result := make([]*commonpb.ErrorDetail, 0, len(details))
+	for _, d := range details {
+		result = append(result, &commonpb.ErrorDetail{
+			Code:    commonpb.ErrorCode(d.Code),
+			Message: d.Message,
+			Field:   d.Field,
+		})
+	}
+	return result
+}
+
+// healthCheck 进程能处理请求即视为健康，依赖的健康状态由 grpc.health.v1 服务报告
+func healthCheck(service string) *commonpb.HealthCheckResponse {
+	return &commonpb.HealthCheckResponse{
+		Status:  commonpb.HealthCheckResponse_SERVING,
+		Message: service + " service is serving",
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/service"
+	commonpb "github.com/3inchtime/movieinfo/proto/gen/common"
+	moviepb "github.com/3inchtime/movieinfo/proto/gen/movie"
+)
+
+// movieServer 电影服务的gRPC处理器
+// DeleteMovie、ListMovies、SearchMovies 在电影服务实现之前返回 UNIMPLEMENTED
+type movieServer struct {
+	moviepb.UnimplementedMovieServiceServer
+	movies service.MovieService
+}
+
+// NewMovieServer 创建电影服务的gRPC处理器
+func NewMovieServer(movies service.MovieService) moviepb.MovieServiceServer {
+	return &movieServer{movies: movies}
+}
+
+func (s *movieServer) CreateMovie(ctx context.Context, req *moviepb.CreateMovieRequest) (*moviepb.CreateMovieResponse, error) {
+	movie, err := s.movies.CreateMovie(ctx, createMovieModel(req))
+	if err != nil {
+		return nil, grpcError("CreateMovie", err)
+	}
+	return &moviepb.CreateMovieResponse{Common: success(), Movie: movieMessage(movie)}, nil
+}
+
+func (s *movieServer) GetMovie(ctx context.Context, req *moviepb.GetMovieRequest) (*moviepb.GetMovieResponse, error) {
+	movie, err := s.movies.GetMovie(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcError("GetMovie", err)
+	}
+	return &moviepb.GetMovieResponse{Common: success(), Movie: movieMessage(movie)}, nil
+}
+
+func (s *movieServer) UpdateMovie(ctx context.Context, req *moviepb.UpdateMovieRequest) (*moviepb.UpdateMovieResponse, error) {
+	movie := &models.Movie{
+		ID:          req.GetId(),
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		PosterURL:   req.GetPosterUrl(),
+		Duration:    req.GetDuration(),
+		ReleaseDate: optionalTime(req.GetReleaseDate()),
+		Language:    req.GetLanguage(),
+		Genres:      req.GetGenres(),
+		Directors:   req.GetDirectors(),
+		Actors:      req.GetActors(),
+	}
+	updated, err := s.movies.UpdateMovie(ctx, movie, req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, grpcError("UpdateMovie", err)
+	}
+	return &moviepb.UpdateMovieResponse{Common: success(), Movie: movieMessage(updated)}, nil
+}
+
+func (s *movieServer) BatchGetMovies(ctx context.Context, req *moviepb.BatchGetMoviesRequest) (*moviepb.BatchGetMoviesResponse, error) {
+	movies, missing, err := s.movies.BatchGetMovies(ctx, req.GetIds())
+	if err != nil {
+		return nil, grpcError("BatchGetMovies", err)
+	}
+	resp := &moviepb.BatchGetMoviesResponse{Common: success(), MissingIds: missing}
+	for _, movie := range movies {
+		resp.Movies = append(resp.Movies, movieMessage(movie))
+	}
+	return resp, nil
+}
+
+// BulkCreateMovies 逐条接收并创建电影，客户端关闭发送后返回汇总结果
+func (s *movieServer) BulkCreateMovies(stream moviepb.MovieService_BulkCreateMoviesServer) error {
+	result, err := s.movies.BulkCreateMovies(stream.Context(), func() (*models.Movie, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return createMovieModel(req.GetMovie()), nil
+	})
+	if err != nil {
+		// 接收出错（如客户端中断或消息未通过校验）时整个调用失败，已创建的电影保留
+		return grpcError("BulkCreateMovies", err)
+	}
+
+	resp := &moviepb.BulkCreateMoviesResponse{
+		Common:     success(),
+		Received:   int32(result.Received),
+		Created:    int32(len(result.CreatedIDs)),
+		Failed:     int32(len(result.Errors)),
+		CreatedIds: result.CreatedIDs,
+	}
+	for _, e := range result.Errors {
+		resp.Errors = append(resp.Errors, &moviepb.BulkCreateMovieError{
+			Index:  int32(e.Index),
+			Errors: errorDetails(e.Details),
+		})
+	}
+	return stream.SendAndClose(resp)
+}
+
+func (s *movieServer) HealthCheck(ctx context.Context, req *commonpb.HealthCheckRequest) (*commonpb.HealthCheckResponse, error) {
+	return healthCheck("movie"), nil
+}
+
+// createMovieModel 转换创建电影请求
+func createMovieModel(req *moviepb.CreateMovieRequest) *models.Movie {
+	return &models.Movie{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		PosterURL:   req.GetPosterUrl(),
+		Duration:    req.GetDuration(),
+		ReleaseDate: optionalTime(req.GetReleaseDate()),
+		Language:    req.GetLanguage(),
+		Genres:      req.GetGenres(),
+		Directors:   req.GetDirectors(),
+		Actors:      req.GetActors(),
+	}
+}
+
+// movieMessage 转换电影
+func movieMessage(movie *models.Movie) *moviepb.Movie {
+	return &moviepb.Movie{
+		Id:            movie.ID,
+		Title:         movie.Title,
+		Description:   movie.Description,
+		PosterUrl:     movie.PosterURL,
+		Duration:      movie.Duration,
+		ReleaseDate:   timestamp(movie.ReleaseDate),
+		Language:      movie.Language,
+		Genres:        movie.Genres,
+		Directors:     movie.Directors,
+		Actors:        movie.Actors,
+		AverageRating: movie.AverageRating,
+		RatingCount:   movie.RatingCount,
+		CreatedAt:     timestamppb.New(movie.CreatedAt),
+		UpdatedAt:     timestamppb.New(movie.UpdatedAt),
+	}
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/service"
+	commonpb "github.com/3inchtime/movieinfo/proto/gen/common"
+	ratingpb "github.com/3inchtime/movieinfo/proto/gen/rating"
+)
+
+// ratingEventTypes 评分事件类型与 proto 枚举的对应关系
+var ratingEventTypes = map[models.RatingEventType]ratingpb.RatingEventType{
+	models.RatingCreated: ratingpb.RatingEventType_RATING_EVENT_TYPE_CREATED,
+	models.RatingUpdated: ratingpb.RatingEventType_RATING_EVENT_TYPE_UPDATED,
+	models.RatingDeleted: ratingpb.RatingEventType_RATING_EVENT_TYPE_DELETED,
+}
+
+// ratingServer 评分服务的gRPC处理器
+// ListRatings 在评分服务实现之前返回 UNIMPLEMENTED
+type ratingServer struct {
+	ratingpb.UnimplementedRatingServiceServer
+	ratings service.RatingService
+}
+
+// NewRatingServer 创建评分服务的gRPC处理器
+func NewRatingServer(ratings service.RatingService) ratingpb.RatingServiceServer {
+	return &ratingServer{ratings: ratings}
+}
+
+func (s *ratingServer) CreateRating(ctx context.Context, req *ratingpb.CreateRatingRequest) (*ratingpb.CreateRatingResponse, error) {
+	rating, err := s.ratings.CreateRating(ctx, &models.Rating{
+		UserID:  req.GetUserId(),
+		MovieID: req.GetMovieId(),
+		Score:   req.GetScore(),
+		Comment: req.GetComment(),
+	})
+	if err != nil {
+		return nil, grpcError("CreateRating", err)
+	}
+	return &ratingpb.CreateRatingResponse{Common: success(), Rating: ratingMessage(rating)}, nil
+}
+
+func (s *ratingServer) GetRating(ctx context.Context, req *ratingpb.GetRatingRequest) (*ratingpb.GetRatingResponse, error) {
+	rating, err := s.ratings.GetRating(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcError("GetRating", err)
+	}
+	return &ratingpb.GetRatingResponse{Common: success(), Rating: ratingMessage(rating)}, nil
+}
+
+func (s *ratingServer) UpdateRating(ctx context.Context, req *ratingpb.UpdateRatingRequest) (*ratingpb.UpdateRatingResponse, error) {
+	rating, err := s.ratings.UpdateRating(ctx, req.GetId(), req.GetScore(), req.GetComment())
+	if err != nil {
+		return nil, grpcError("UpdateRating", err)
+	}
+	return &ratingpb.UpdateRatingResponse{Common: success(), Rating: ratingMessage(rating)}, nil
+}
+
+func (s *ratingServer) DeleteRating(ctx context.Context, req *ratingpb.DeleteRatingRequest) (*ratingpb.DeleteRatingResponse, error) {
+	if err := s.ratings.DeleteRating(ctx, req.GetId()); err != nil {
+		return nil, grpcError("DeleteRating", err)
+	}
+	return &ratingpb.DeleteRatingResponse{Common: success()}, nil
+}
+
+func (s *ratingServer) BatchGetUserRatings(ctx context.Context, req *ratingpb.BatchGetUserRatingsRequest) (*ratingpb.BatchGetUserRatingsResponse, error) {
+	ratings, err := s.ratings.BatchGetUserRatings(ctx, req.GetUserId(), req.GetMovieIds())
+	if err != nil {
+		return nil, grpcError("BatchGetUserRatings", err)
+	}
+	resp := &ratingpb.BatchGetUserRatingsResponse{Common: success()}
+	for _, rating := range ratings {
+		resp.Ratings = append(resp.Ratings, ratingMessage(rating))
+	}
+	return resp, nil
+}
+
+func (s *ratingServer) GetMovieAverageRating(ctx context.Context, req *ratingpb.GetMovieAverageRatingRequest) (*ratingpb.GetMovieAverageRatingResponse, error) {
+	stats, err := s.ratings.GetMovieAverageRating(ctx, req.GetMovieId())
+	if err != nil {
+		return nil, grpcError("GetMovieAverageRating", err)
+	}
+	return &ratingpb.GetMovieAverageRatingResponse{
+		Common:        success(),
+		AverageRating: stats.AverageRating,
+		TotalRatings:  stats.TotalRatings,
+	}, nil
+}
+
+// WatchMovieRatings 推送电影的评分变更事件，直到客户端取消或服务器关闭
+func (s *ratingServer) WatchMovieRatings(req *ratingpb.WatchMovieRatingsRequest, stream ratingpb.RatingService_WatchMovieRatingsServer) error {
+	events, err := s.ratings.WatchMovieRatings(stream.Context(), req.GetMovieId())
+	if err != nil {
+		return grpcError("WatchMovieRatings", err)
+	}
+	// 事件通道在调用的上下文结束后关闭，提前返回时订阅随调用结束一并取消
+	for event := range events {
+		if err := stream.Send(ratingEventMessage(event)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ratingServer) HealthCheck(ctx context.Context, req *commonpb.HealthCheckRequest) (*commonpb.HealthCheckResponse, error) {
+	return healthCheck("rating"), nil
+}
+
+// ratingMessage 转换评分
+func ratingMessage(rating *models.Rating) *ratingpb.Rating {
+	return &ratingpb.Rating{
+		Id:        rating.ID,
+		UserId:    rating.UserID,
+		MovieId:   rating.MovieID,
+		Score:     rating.Score,
+		Comment:   rating.Comment,
+		CreatedAt: timestamppb.New(rating.CreatedAt),
+		UpdatedAt: timestamppb.New(rating.UpdatedAt),
+	}
+}
+
+// ratingEventMessage 转换评分变更事件
+func ratingEventMessage(event *models.RatingEvent) *ratingpb.RatingEvent {
+	return &ratingpb.RatingEvent{
+		Type:          ratingEventTypes[event.Type],
+		Rating:        ratingMessage(event.Rating),
+		AverageRating: event.AverageRating,
+		TotalRatings:  event.TotalRatings,
+		OccurredAt:    timestamppb.New(event.OccurredAt),
+	}
+}
@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/service"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	commonpb "github.com/3inchtime/movieinfo/proto/gen/common"
+	userpb "github.com/3inchtime/movieinfo/proto/gen/user"
+)
+
+// userServer 用户服务的gRPC处理器
+// GetUser、DeleteUser、ListUsers 在用户服务实现之前返回 UNIMPLEMENTED
+type userServer struct {
+	userpb.UnimplementedUserServiceServer
+	users         service.UserService
+	auth          service.AuthService
+	verification  service.EmailVerificationService
+	passwordReset service.PasswordResetService
+	roles         service.RoleService
+	twoFactor     service.TwoFactorService
+	oidc          service.OIDCService
+	sessions      service.SessionService
+	apiKeys       service.APIKeyService
+	resetCodeTTL  time.Duration // 重置码有效期，随发送重置码的响应返回
+}
+
+// NewUserServer 创建用户服务的gRPC处理器
+func NewUserServer(users service.UserService, auth service.AuthService, verification service.EmailVerificationService,
+	passwordReset service.PasswordResetService, roles service.RoleService, twoFactor service.TwoFactorService,
+	oidc service.OIDCService, sessions service.SessionService, apiKeys service.APIKeyService,
+	resetCodeTTL time.Duration) userpb.UserServiceServer {
+	return &userServer{
+		users:         users,
+		auth:          auth,
+		verification:  verification,
+		passwordReset: passwordReset,
+		roles:         roles,
+		twoFactor:     twoFactor,
+		oidc:          oidc,
+		sessions:      sessions,
+		apiKeys:       apiKeys,
+		resetCodeTTL:  resetCodeTTL,
+	}
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *userpb.CreateUserRequest) (*userpb.CreateUserResponse, error) {
+	user, err := s.users.CreateUser(ctx, &models.User{
+		Username: req.GetUsername(),
+		Email:    req.GetEmail(),
+		Nickname: req.GetNickname(),
+	}, req.GetPassword())
+	if err != nil {
+		return nil, grpcError("CreateUser", err)
+	}
+	return &userpb.CreateUserResponse{Common: success(), User: userMessage(user)}, nil
+}
+
+func (s *userServer) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequest) (*userpb.UpdateUserResponse, error) {
+	user, err := s.users.UpdateUser(ctx, &models.User{
+		ID:        req.GetId(),
+		Email:     req.GetEmail(),
+		Nickname:  req.GetNickname(),
+		AvatarURL: req.GetAvatar(),
+	}, req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, grpcError("UpdateUser", err)
+	}
+	return &userpb.UpdateUserResponse{Common: success(), User: userMessage(user)}, nil
+}
+
+func (s *userServer) Login(ctx context.Context, req *userpb.LoginRequest) (*userpb.LoginResponse, error) {
+	result, err := s.auth.Login(ctx, req.GetUsername(), req.GetPassword())
+	if err != nil {
+		return nil, grpcError("Login", err)
+	}
+	return loginResponse(result), nil
+}
+
+func (s *userServer) RefreshToken(ctx context.Context, req *userpb.RefreshTokenRequest) (*userpb.RefreshTokenResponse, error) {
+	tokens, err := s.auth.RefreshToken(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, grpcError("RefreshToken", err)
+	}
+	return &userpb.RefreshTokenResponse{
+		Common:           success(),
+		AccessToken:      tokens.AccessToken,
+		ExpiresIn:        seconds(tokens.AccessExpiresAt),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresIn: seconds(tokens.RefreshExpiresAt),
+	}, nil
+}
+
+func (s *userServer) Logout(ctx context.Context, req *userpb.LogoutRequest) (*userpb.LogoutResponse, error) {
+	if err := s.auth.Logout(ctx, req.GetAccessToken(), req.GetRefreshToken()); err != nil {
+		return nil, grpcError("Logout", err)
+	}
+	return &userpb.LogoutResponse{Common: success()}, nil
+}
+
+func (s *userServer) ChangePassword(ctx context.Context, req *userpb.ChangePasswordRequest) (*userpb.ChangePasswordResponse, error) {
+	if err := s.users.ChangePassword(ctx, req.GetUserId(), req.GetOldPassword(), req.GetNewPassword()); err != nil {
+		return nil, grpcError("ChangePassword", err)
+	}
+	return &userpb.ChangePasswordResponse{Common: success()}, nil
+}
+
+func (s *userServer) ListSessions(ctx context.Context, req *userpb.ListSessionsRequest) (*userpb.ListSessionsResponse, error) {
+	sessions, err := s.sessions.ListSessions(ctx, req.GetUserId())
+	if err != nil {
+		return nil, grpcError("ListSessions", err)
+	}
+	current := auth.SessionIDFromContext(ctx)
+	resp := &userpb.ListSessionsResponse{Common: success()}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, &userpb.Session{
+			SessionId:  session.ID,
+			Device:     session.Device,
+			IpAddress:  session.IPAddress,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastSeenAt: timestamppb.New(session.LastSeenAt),
+			ExpiresAt:  timestamppb.New(session.ExpiresAt),
+			Current:    session.ID == current,
+		})
+	}
+	return resp, nil
+}
+
+func (s *userServer) RevokeSession(ctx context.Context, req *userpb.RevokeSessionRequest) (*userpb.RevokeSessionResponse, error) {
+	if err := s.sessions.RevokeSession(ctx, req.GetUserId(), req.GetSessionId()); err != nil {
+		return nil, grpcError("RevokeSession", err)
+	}
+	return &userpb.RevokeSessionResponse{Common: success()}, nil
+}
+
+func (s *userServer) RevokeAllOtherSessions(ctx context.Context, req *userpb.RevokeAllOtherSessionsRequest) (*userpb.RevokeAllOtherSessionsResponse, error) {
+	revoked, err := s.sessions.RevokeAllOtherSessions(ctx, req.GetUserId())
+	if err != nil {
+		return nil, grpcError("RevokeAllOtherSessions", err)
+	}
+	return &userpb.RevokeAllOtherSessionsResponse{Common: success(), RevokedCount: int32(revoked)}, nil
+}
+
+func (s *userServer) CreateAPIKey(ctx context.Context, req *userpb.CreateAPIKeyRequest) (*userpb.CreateAPIKeyResponse, error) {
+	key, secret, err := s.apiKeys.CreateAPIKey(ctx, req.GetUserId(), req.GetName(), req.GetScopes(), optionalTime(req.GetExpiresAt()))
+	if err != nil {
+		return nil, grpcError("CreateAPIKey", err)
+	}
+	return &userpb.CreateAPIKeyResponse{Common: success(), ApiKey: apiKeyMessage(key), Key: secret}, nil
+}
+
+func (s *userServer) ListAPIKeys(ctx context.Context, req *userpb.ListAPIKeysRequest) (*userpb.ListAPIKeysResponse, error) {
+	keys, err := s.apiKeys.ListAPIKeys(ctx, req.GetUserId())
+	if err != nil {
+		return nil, grpcError("ListAPIKeys", err)
+	}
+	resp := &userpb.ListAPIKeysResponse{Common: success()}
+	for _, key := range keys {
+		resp.ApiKeys = append(resp.ApiKeys, apiKeyMessage(key))
+	}
+	return resp, nil
+}
+
+func (s *userServer) RevokeAPIKey(ctx context.Context, req *userpb.RevokeAPIKeyRequest) (*userpb.RevokeAPIKeyResponse, error) {
+	if err := s.apiKeys.RevokeAPIKey(ctx, req.GetUserId(), req.GetId()); err != nil {
+		return nil, grpcError("RevokeAPIKey", err)
+	}
+	return &userpb.RevokeAPIKeyResponse{Common: success()}, nil
+}
+
+func (s *userServer) SendResetCode(ctx context.Context, req *userpb.SendResetCodeRequest) (*userpb.SendResetCodeResponse, error) {
+	if err := s.passwordReset.SendResetCode(ctx, req.GetEmail()); err != nil {
+		return nil, grpcError("SendResetCode", err)
+	}
+	const message = "if the email is registered, a reset code has been sent"
+	return &userpb.SendResetCodeResponse{
+		Common:    successMessage(message),
+		Message:   message,
+		ExpiresIn: int64(s.resetCodeTTL / time.Second),
+	}, nil
+}
+
+func (s *userServer) VerifyResetCode(ctx context.Context, req *userpb.VerifyResetCodeRequest) (*userpb.VerifyResetCodeResponse, error) {
+	if err := s.passwordReset.VerifyResetCode(ctx, req.GetEmail(), req.GetCode()); err != nil {
+		return nil, grpcError("VerifyResetCode", err)
+	}
+	return &userpb.VerifyResetCodeResponse{Common: success()}, nil
+}
+
+func (s *userServer) ResetPassword(ctx context.Context, req *userpb.ResetPasswordRequest) (*userpb.ResetPasswordResponse, error) {
+	if err := s.passwordReset.ResetPassword(ctx, req.GetEmail(), req.GetCode(), req.GetNewPassword()); err != nil {
+		return nil, grpcError("ResetPassword", err)
+	}
+	return &userpb.ResetPasswordResponse{Common: success()}, nil
+}
+
+func (s *userServer) VerifyEmail(ctx context.Context, req *userpb.VerifyEmailRequest) (*userpb.VerifyEmailResponse, error) {
+	user, err := s.verification.VerifyEmail(ctx, req.GetToken())
+	if err != nil {
+		return nil, grpcError("VerifyEmail", err)
+	}
+	return &userpb.VerifyEmailResponse{Common: success(), User: userMessage(user)}, nil
+}
+
+func (s *userServer) ResendVerificationEmail(ctx context.Context, req *userpb.ResendVerificationEmailRequest) (*userpb.ResendVerificationEmailResponse, error) {
+	if err := s.verification.ResendVerification(ctx, req.GetEmail()); err != nil {
+		return nil, grpcError("ResendVerificationEmail", err)
+	}
+	const message = "if the email is registered and not yet verified, a verification email has been sent"
+	return &userpb.ResendVerificationEmailResponse{Common: successMessage(message), Message: message}, nil
+}
+
+func (s *userServer) ListOIDCProviders(ctx context.Context, req *userpb.ListOIDCProvidersRequest) (*userpb.ListOIDCProvidersResponse, error) {
+	resp := &userpb.ListOIDCProvidersResponse{Common: success()}
+	for _, provider := range s.oidc.Providers() {
+		resp.Providers = append(resp.Providers, &userpb.OIDCProvider{
+			Name:        provider.Name,
+			DisplayName: provider.DisplayName,
+		})
+	}
+	return resp, nil
+}
+
+func (s *userServer) StartOIDCLogin(ctx context.Context, req *userpb.StartOIDCLoginRequest) (*userpb.StartOIDCLoginResponse, error) {
+	authReq, err := s.oidc.StartLogin(ctx, req.GetProvider(), req.GetRedirectUri())
+	if err != nil {
+		return nil, grpcError("StartOIDCLogin", err)
+	}
+	return &userpb.StartOIDCLoginResponse{
+		Common:           success(),
+		AuthorizationUrl: authReq.URL,
+		State:            authReq.State,
+		ExpiresIn:        int64(authReq.ExpiresIn / time.Second),
+	}, nil
+}
+
+func (s *userServer) CompleteOIDCLogin(ctx context.Context, req *userpb.CompleteOIDCLoginRequest) (*userpb.LoginResponse, error) {
+	result, err := s.auth.CompleteOIDCLogin(ctx, req.GetState(), req.GetCode())
+	if err != nil {
+		return nil, grpcError("CompleteOIDCLogin", err)
+	}
+	return loginResponse(result), nil
+}
+
+func (s *userServer) VerifyTwoFactor(ctx context.Context, req *userpb.VerifyTwoFactorRequest) (*userpb.LoginResponse, error) {
+	result, err := s.auth.VerifyTwoFactor(ctx, req.GetChallengeToken(), req.GetCode())
+	if err != nil {
+		return nil, grpcError("VerifyTwoFactor", err)
+	}
+	return loginResponse(result), nil
+}
+
+func (s *userServer) EnrollTOTP(ctx context.Context, req *userpb.EnrollTOTPRequest) (*userpb.EnrollTOTPResponse, error) {
+	enrollment, err := s.twoFactor.EnrollTOTP(ctx, req.GetUserId(), req.GetChallengeToken())
+	if err != nil {
+		return nil, grpcError("EnrollTOTP", err)
+	}
+	return &userpb.EnrollTOTPResponse{
+		Common:     success(),
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.URI,
+		QrCodePng:  enrollment.QRCode,
+	}, nil
+}
+
+func (s *userServer) ConfirmTOTP(ctx context.Context, req *userpb.ConfirmTOTPRequest) (*userpb.ConfirmTOTPResponse, error) {
+	codes, err := s.twoFactor.ConfirmTOTP(ctx, req.GetUserId(), req.GetCode())
+	if err != nil {
+		return nil, grpcError("ConfirmTOTP", err)
+	}
+	return &userpb.ConfirmTOTPResponse{Common: success(), RecoveryCodes: codes}, nil
+}
+
+func (s *userServer) DisableTOTP(ctx context.Context, req *userpb.DisableTOTPRequest) (*userpb.DisableTOTPResponse, error) {
+	if err := s.twoFactor.DisableTOTP(ctx, req.GetUserId(), req.GetCode()); err != nil {
+		return nil, grpcError("DisableTOTP", err)
+	}
+	return &userpb.DisableTOTPResponse{Common: success()}, nil
+}
+
+func (s *userServer) RegenerateRecoveryCodes(ctx context.Context, req *userpb.RegenerateRecoveryCodesRequest) (*userpb.RegenerateRecoveryCodesResponse, error) {
+	codes, err := s.twoFactor.RegenerateRecoveryCodes(ctx, req.GetUserId(), req.GetCode())
+	if err != nil {
+		return nil, grpcError("RegenerateRecoveryCodes", err)
+	}
+	return &userpb.RegenerateRecoveryCodesResponse{Common: success(), RecoveryCodes: codes}, nil
+}
+
+func (s *userServer) UnlockUser(ctx context.Context, req *userpb.UnlockUserRequest) (*userpb.UnlockUserResponse, error) {
+	if err := s.auth.UnlockUser(ctx, req.GetUserId()); err != nil {
+		return nil, grpcError("UnlockUser", err)
+	}
+	return &userpb.UnlockUserResponse{Common: success()}, nil
+}
+
+func (s *userServer) GrantRole(ctx context.Context, req *userpb.GrantRoleRequest) (*userpb.GrantRoleResponse, error) {
+	roles, err := s.roles.GrantRole(ctx, req.GetUserId(), req.GetRole())
+	if err != nil {
+		return nil, grpcError("GrantRole", err)
+	}
+	return &userpb.GrantRoleResponse{Common: success(), Roles: roles}, nil
+}
+
+func (s *userServer) RevokeRole(ctx context.Context, req *userpb.RevokeRoleRequest) (*userpb.RevokeRoleResponse, error) {
+	roles, err := s.roles.RevokeRole(ctx, req.GetUserId(), req.GetRole())
+	if err != nil {
+		return nil, grpcError("RevokeRole", err)
+	}
+	return &userpb.RevokeRoleResponse{Common: success(), Roles: roles}, nil
+}
+
+func (s *userServer) HealthCheck(ctx context.Context, req *commonpb.HealthCheckRequest) (*commonpb.HealthCheckResponse, error) {
+	return healthCheck("user"), nil
+}
+
+// loginResponse 转换登录结果，需要两步验证时只返回挑战令牌
+func loginResponse(result *service.LoginResult) *userpb.LoginResponse {
+	resp := &userpb.LoginResponse{Common: success(), RecoveryCodes: result.RecoveryCodes}
+	if result.Challenge != nil {
+		resp.TwoFactorRequired = true
+		resp.ChallengeToken = result.Challenge.Token
+		resp.ChallengeExpiresIn = int64(result.Challenge.ExpiresIn / time.Second)
+		resp.TwoFactorSetupRequired = result.Challenge.SetupRequired
+		return resp
+	}
+
+	resp.User = userMessage(result.User)
+	resp.AccessToken = result.Tokens.AccessToken
+	resp.ExpiresIn = seconds(result.Tokens.AccessExpiresAt)
+	resp.RefreshToken = result.Tokens.RefreshToken
+	resp.RefreshExpiresIn = seconds(result.Tokens.RefreshExpiresAt)
+	return resp
+}
+
+// userMessage 转换用户，不包含密码哈希
+func userMessage(user *models.User) *userpb.User {
+	status := userpb.UserStatus_USER_STATUS_INACTIVE
+	if user.Status == models.UserStatusActive {
+		status = userpb.UserStatus_USER_STATUS_ACTIVE
+	}
+	return &userpb.User{
+		Id:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Nickname:      user.Nickname,
+		Avatar:        user.AvatarURL,
+		Status:        status,
+		CreatedAt:     timestamppb.New(user.CreatedAt),
+		UpdatedAt:     timestamppb.New(user.UpdatedAt),
+		EmailVerified: user.EmailVerified,
+	}
+}
+
+// apiKeyMessage 转换API密钥，不包含密钥哈希
+func apiKeyMessage(key *models.APIKey) *userpb.APIKey {
+	return &userpb.APIKey{
+		Id:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  timestamp(key.ExpiresAt),
+		LastUsedAt: timestamp(key.LastUsedAt),
+		LastUsedIp: key.LastUsedIP,
+		CreatedAt:  timestamppb.New(key.CreatedAt),
+	}
+}
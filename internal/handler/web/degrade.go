@@ -0,0 +1,26 @@
+package web
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// retryAfterSeconds 下游不可用时建议客户端重试的等待秒数
+const retryAfterSeconds = "30"
+
+// isUnavailable 判断错误是否由下游服务不可用引起（宕机、熔断、超时）
+// 此类错误只影响依赖该服务的页面区块，页面其余部分应正常渲染
+func isUnavailable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
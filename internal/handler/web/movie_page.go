@@ -0,0 +1,196 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// MovieGetter 获取电影详情，由电影服务的gRPC客户端实现
+type MovieGetter interface {
+	GetMovie(ctx context.Context, id int64) (*models.Movie, error)
+}
+
+// RatingReader 读取评分数据，由评分服务的gRPC客户端实现
+type RatingReader interface {
+	GetMovieAverageRating(ctx context.Context, movieID int64) (*models.MovieRatingStats, error)
+	BatchGetUserRatings(ctx context.Context, userID int64, movieIDs []int64) ([]*models.Rating, error)
+}
+
+// MoviePage 电影详情页数据
+type MoviePage struct {
+	Movie      *models.Movie
+	Stats      *models.MovieRatingStats // 评分服务不可用时为 nil
+	UserRating *models.Rating           // 未登录、未评分或评分服务不可用时为 nil
+	// RatingsUnavailable 评分服务不可用，页面应隐藏评分区块并提示稍后再试
+	RatingsUnavailable bool
+}
+
+// MoviePageLoader 加载电影详情页数据
+// 电影服务是页面的必要依赖，评分服务不可用时页面降级为只展示电影信息
+type MoviePageLoader struct {
+	movies  MovieGetter
+	ratings RatingReader
+}
+
+// NewMoviePageLoader 创建电影详情页加载器
+func NewMoviePageLoader(movies MovieGetter, ratings RatingReader) *MoviePageLoader {
+	return &MoviePageLoader{
+		movies:  movies,
+		ratings: ratings,
+	}
+}
+
+// Load 加载电影详情页数据，userID 为 0 表示未登录
+func (l *MoviePageLoader) Load(ctx context.Context, movieID, userID int64) (*MoviePage, error) {
+	movie, err := l.movies.GetMovie(ctx, movieID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movie %d: %w", movieID, err)
+	}
+	page := &MoviePage{Movie: movie}
+
+	stats, err := l.ratings.GetMovieAverageRating(ctx, movieID)
+	if err != nil {
+		return l.degrade(page, err)
+	}
+	page.Stats = stats
+
+	if userID > 0 {
+		ratings, err := l.ratings.BatchGetUserRatings(ctx, userID, []int64{movieID})
+		if err != nil {
+			return l.degrade(page, err)
+		}
+		if len(ratings) > 0 {
+			page.UserRating = ratings[0]
+		}
+	}
+
+	return page, nil
+}
+
+// degrade 评分服务不可用时返回不含评分的页面，其他错误照常返回
+func (l *MoviePageLoader) degrade(page *MoviePage, err error) (*MoviePage, error) {
+	if !isUnavailable(err) {
+		return nil, fmt.Errorf("failed to load ratings of movie %d: %w", page.Movie.ID, err)
+	}
+
+	logger.Warnf("rating service unavailable, rendering movie %d without ratings: %v", page.Movie.ID, err)
+	page.Stats = nil
+	page.UserRating = nil
+	page.RatingsUnavailable = true
+	return page, nil
+}
+
+// moviePageTemplate 电影详情页，收到评分变更推送后刷新页面
+var moviePageTemplate = template.Must(template.New("movie").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head><meta charset="utf-8"><title>{{with .Page}}{{.Movie.Title}}{{else}}电影详情{{end}} - MovieInfo</title></head>
+<body>
+{{with .Page}}{{with .Movie}}
+<h1>{{.Title}}</h1>
+{{if .PosterURL}}<img src="{{.PosterURL}}" alt="{{.Title}}" width="240">{{end}}
+<p>{{if .ReleaseDate}}{{.ReleaseDate.Format "2006-01-02"}} · {{end}}{{if .Duration}}{{.Duration}} 分钟 · {{end}}{{.Language}}</p>
+{{if .Genres}}<p>类型：{{range $i, $g := .Genres}}{{if $i}} / {{end}}{{$g}}{{end}}</p>{{end}}
+{{if .Directors}}<p>导演：{{range $i, $d := .Directors}}{{if $i}} / {{end}}{{$d}}{{end}}</p>{{end}}
+{{if .Actors}}<p>主演：{{range $i, $a := .Actors}}{{if $i}} / {{end}}{{$a}}{{end}}</p>{{end}}
+<p>{{.Description}}</p>
+{{end}}
+<section>
+<h2>评分</h2>
+{{if .RatingsUnavailable}}<p>评分暂时不可用，请稍后再试。</p>
+{{else}}{{with .Stats}}<p>平均评分 {{printf "%.1f" .AverageRating}}（{{.TotalRatings}} 人评分）</p>{{end}}
+{{with .UserRating}}<p>我的评分：{{.Score}}{{if .Comment}}，{{.Comment}}{{end}}</p>{{end}}
+<script>
+var stream = new EventSource("/movies/ratings/stream?movie_id={{.Movie.ID}}");
+["rating.created", "rating.updated", "rating.deleted"].forEach(function (name) {
+  stream.addEventListener(name, function () { location.reload(); });
+});
+</script>
+{{end}}
+</section>
+{{else}}<p>{{.Message}}</p>{{end}}
+</body>
+</html>
+`))
+
+// moviePageData 电影详情页模板数据，Page 为 nil 时只展示 Message
+type moviePageData struct {
+	Page    *MoviePage
+	Message string
+}
+
+// MoviePageHandler 电影详情页，登录用户额外展示自己的评分
+// GET /movies?id=1，访问令牌从 Authorization 请求头或Cookie中读取，未登录也可以访问
+type MoviePageHandler struct {
+	loader        *MoviePageLoader
+	authenticator TokenAuthenticator
+}
+
+// NewMoviePageHandler 创建电影详情页处理器
+func NewMoviePageHandler(loader *MoviePageLoader, authenticator TokenAuthenticator) *MoviePageHandler {
+	return &MoviePageHandler{loader: loader, authenticator: authenticator}
+}
+
+// ServeHTTP 加载并渲染电影详情页，评分服务不可用时页面降级为只展示电影信息
+func (h *MoviePageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	movieID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || movieID <= 0 {
+		renderMoviePage(w, http.StatusBadRequest, &moviePageData{Message: "电影不存在。"})
+		return
+	}
+
+	// 令牌无效时按未登录处理，不影响浏览电影信息
+	var userID int64
+	claims, err := authenticate(h.authenticator, r)
+	switch {
+	case err == nil:
+		userID = claims.UserID
+	case !errors.Is(err, auth.ErrInvalidToken):
+		logger.Warnf("failed to authenticate movie page: %v", err)
+	}
+
+	page, err := h.loader.Load(r.Context(), movieID, userID)
+	if err != nil {
+		h.renderError(w, movieID, err)
+		return
+	}
+	renderMoviePage(w, http.StatusOK, &moviePageData{Page: page})
+}
+
+// renderError 按错误类型渲染页面，其他错误不暴露内部信息
+func (h *MoviePageHandler) renderError(w http.ResponseWriter, movieID int64, err error) {
+	switch {
+	case status.Code(err) == codes.NotFound:
+		renderMoviePage(w, http.StatusNotFound, &moviePageData{Message: "电影不存在。"})
+	case isUnavailable(err):
+		w.Header().Set("Retry-After", retryAfterSeconds)
+		renderMoviePage(w, http.StatusServiceUnavailable, &moviePageData{Message: "服务暂时不可用，请稍后再试。"})
+	default:
+		logger.Errorf("failed to load movie page %d: %v", movieID, err)
+		renderMoviePage(w, http.StatusInternalServerError, &moviePageData{Message: "服务出错，请稍后再试。"})
+	}
+}
+
+// renderMoviePage 渲染电影详情页
+func renderMoviePage(w http.ResponseWriter, code int, data *moviePageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	if err := moviePageTemplate.Execute(w, data); err != nil {
+		logger.Warnf("failed to render movie page: %v", err)
+	}
+}
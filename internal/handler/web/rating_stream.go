@@ -0,0 +1,123 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// heartbeatInterval SSE心跳间隔，防止代理因连接空闲而断开
+const heartbeatInterval = 15 * time.Second
+
+// RatingWatcher 电影评分变更订阅接口，由评分服务或其gRPC客户端实现
+type RatingWatcher interface {
+	WatchMovieRatings(ctx context.Context, movieID int64) (<-chan *models.RatingEvent, error)
+}
+
+// RatingStreamHandler 以 Server-Sent Events 向浏览器推送电影评分变更
+// 请求示例：GET /movies/ratings/stream?movie_id=1
+type RatingStreamHandler struct {
+	watcher   RatingWatcher
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewRatingStreamHandler 创建评分推送处理器
+func NewRatingStreamHandler(watcher RatingWatcher) *RatingStreamHandler {
+	return &RatingStreamHandler{watcher: watcher, done: make(chan struct{})}
+}
+
+// Close 结束所有推送连接并拒绝新的订阅
+// http.Server.Shutdown 只等待请求结束而不会取消长连接，需通过 RegisterOnShutdown 在关闭开始时调用
+func (h *RatingStreamHandler) Close() {
+	h.closeOnce.Do(func() { close(h.done) })
+}
+
+// ServeHTTP 处理SSE请求，连接保持到客户端断开或服务器关闭为止，结束时取消评分订阅
+func (h *RatingStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	movieID, err := strconv.ParseInt(r.URL.Query().Get("movie_id"), 10, 64)
+	if err != nil || movieID <= 0 {
+		http.Error(w, "invalid movie_id", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	select {
+	case <-h.done:
+		w.Header().Set("Retry-After", retryAfterSeconds)
+		http.Error(w, "server shutting down", http.StatusServiceUnavailable)
+		return
+	default:
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	events, err := h.watcher.WatchMovieRatings(ctx, movieID)
+	if err != nil {
+		// 评分服务不可用时返回503和 Retry-After，前端据此稍后重新订阅，而不是把整个页面标记为出错
+		if isUnavailable(err) {
+			logger.Warnf("rating service unavailable, cannot watch movie %d ratings: %v", movieID, err)
+			w.Header().Set("Retry-After", retryAfterSeconds)
+			http.Error(w, "ratings temporarily unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		logger.Errorf("failed to watch movie %d ratings: %v", movieID, err)
+		http.Error(w, "failed to watch ratings", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.done:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, event); err != nil {
+				logger.Warnf("failed to write rating event: %v", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvent 按SSE格式写入一条评分事件，事件名如 rating.created
+func writeEvent(w http.ResponseWriter, event *models.RatingEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: rating.%s\ndata: %s\n\n", event.Type, data)
+	return err
+}
@@ -0,0 +1,67 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+)
+
+// fakeWatcher 订阅上下文结束时关闭事件通道并通知 closed，模拟评分服务取消总线订阅
+type fakeWatcher struct {
+	closed chan struct{}
+}
+
+func (w *fakeWatcher) WatchMovieRatings(ctx context.Context, movieID int64) (<-chan *models.RatingEvent, error) {
+	events := make(chan *models.RatingEvent)
+	go func() {
+		<-ctx.Done()
+		close(events)
+		close(w.closed)
+	}()
+	return events, nil
+}
+
+func TestRatingStreamClosedOnShutdown(t *testing.T) {
+	watcher := &fakeWatcher{closed: make(chan struct{})}
+	streams := NewRatingStreamHandler(watcher)
+	server := httptest.NewUnstartedServer(streams)
+	server.Config.RegisterOnShutdown(streams.Close)
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/movies/ratings/stream?movie_id=1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	// 推送连接进行中时关闭服务器，应立即结束而不是等到超时
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := server.Config.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	select {
+	case <-watcher.closed:
+	case <-time.After(time.Second):
+		t.Fatal("rating subscription not cancelled after shutdown")
+	}
+	if _, err := bufio.NewReader(resp.Body).ReadString('\n'); err == nil {
+		t.Fatal("stream still open after shutdown")
+	}
+
+	// 关闭后不再接受新的订阅
+	rec := httptest.NewRecorder()
+	streams.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/movies/ratings/stream?movie_id=1", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status after Close() = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,192 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// AccessTokenCookie 页面读取访问令牌的Cookie名称，也可以通过 Authorization 请求头携带
+const AccessTokenCookie = "movieinfo_access_token"
+
+// TokenAuthenticator 校验访问令牌，由 auth.Sessions 实现
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, accessToken string) (*auth.Claims, error)
+}
+
+// SessionManager 登录会话管理接口，由用户服务或其gRPC客户端实现
+// 调用方为上下文中的已认证用户，当前会话取自上下文中的会话ID
+type SessionManager interface {
+	ListSessions(ctx context.Context, userID int64) ([]*models.UserSession, error)
+	RevokeSession(ctx context.Context, userID int64, sessionID string) error
+	RevokeAllOtherSessions(ctx context.Context, userID int64) (int, error)
+}
+
+// sessionsTemplate 登录会话页面
+var sessionsTemplate = template.Must(template.New("sessions").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head><meta charset="utf-8"><title>登录设备 - MovieInfo</title></head>
+<body>
+<h1>登录设备</h1>
+{{if .Message}}<p>{{.Message}}</p>{{end}}
+{{if .Sessions}}
+<table>
+<tr><th>设备</th><th>IP</th><th>登录时间</th><th>最近活动</th><th></th></tr>
+{{range .Sessions}}<tr>
+<td title="{{.UserAgent}}">{{if .Device}}{{.Device}}{{else}}未知设备{{end}}</td>
+<td>{{.IPAddress}}</td>
+<td>{{.CreatedAt.Format "2006-01-02 15:04"}}</td>
+<td>{{.LastSeenAt.Format "2006-01-02 15:04"}}</td>
+<td>{{if eq .ID $.Current}}当前设备{{else}}<form method="post" action="/sessions">
+<input type="hidden" name="action" value="revoke"><input type="hidden" name="session_id" value="{{.ID}}">
+<button type="submit">退出登录</button>
+</form>{{end}}</td>
+</tr>{{end}}
+</table>
+{{if gt (len .Sessions) 1}}<form method="post" action="/sessions">
+<input type="hidden" name="action" value="revoke-others">
+<button type="submit">退出其他所有设备</button>
+</form>{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+// sessionsData 登录会话页面数据
+type sessionsData struct {
+	Sessions []*models.UserSession
+	Current  string
+	Message  string
+}
+
+// SessionsPageHandler 登录会话页面，需要登录
+// GET /sessions 列出已登录的设备；POST /sessions 表单字段 action 为 revoke（需要 session_id）或 revoke-others
+type SessionsPageHandler struct {
+	authenticator TokenAuthenticator
+	sessions      SessionManager
+}
+
+// NewSessionsPageHandler 创建登录会话页面处理器
+func NewSessionsPageHandler(authenticator TokenAuthenticator, sessions SessionManager) *SessionsPageHandler {
+	return &SessionsPageHandler{authenticator: authenticator, sessions: sessions}
+}
+
+// ServeHTTP 校验访问令牌后展示或注销会话
+func (h *SessionsPageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	claims, err := authenticate(h.authenticator, r)
+	if err != nil {
+		if !errors.Is(err, auth.ErrInvalidToken) {
+			logger.Errorf("failed to authenticate sessions page: %v", err)
+		}
+		renderSessions(w, http.StatusUnauthorized, &sessionsData{Message: "请先登录。"})
+		return
+	}
+	ctx := auth.WithSessionID(auth.WithUserID(r.Context(), claims.UserID), claims.SessionID)
+
+	if r.Method == http.MethodPost {
+		// 访问令牌可能来自Cookie，拒绝其他站点提交的表单
+		if !sameOrigin(r) {
+			http.Error(w, "cross-origin request rejected", http.StatusForbidden)
+			return
+		}
+		if err := h.revoke(ctx, claims, r); err != nil {
+			h.renderError(w, err)
+			return
+		}
+		if r.PostFormValue("session_id") == claims.SessionID {
+			renderSessions(w, http.StatusOK, &sessionsData{Message: "当前设备已退出登录。"})
+			return
+		}
+		http.Redirect(w, r, "/sessions", http.StatusSeeOther)
+		return
+	}
+
+	sessions, err := h.sessions.ListSessions(ctx, claims.UserID)
+	if err != nil {
+		h.renderError(w, err)
+		return
+	}
+	renderSessions(w, http.StatusOK, &sessionsData{Sessions: sessions, Current: claims.SessionID})
+}
+
+// authenticate 从 Authorization 请求头或Cookie中读取并校验访问令牌
+func authenticate(authenticator TokenAuthenticator, r *http.Request) (*auth.Claims, error) {
+	token := ""
+	if scheme, value, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		token = strings.TrimSpace(value)
+	} else if cookie, err := r.Cookie(AccessTokenCookie); err == nil {
+		token = cookie.Value
+	}
+	if token == "" {
+		return nil, auth.ErrInvalidToken
+	}
+	return authenticator.Authenticate(r.Context(), token)
+}
+
+// revoke 按表单的 action 注销会话
+func (h *SessionsPageHandler) revoke(ctx context.Context, claims *auth.Claims, r *http.Request) error {
+	switch r.PostFormValue("action") {
+	case "revoke":
+		return h.sessions.RevokeSession(ctx, claims.UserID, r.PostFormValue("session_id"))
+	case "revoke-others":
+		_, err := h.sessions.RevokeAllOtherSessions(ctx, claims.UserID)
+		return err
+	default:
+		return status.Error(codes.InvalidArgument, "unknown action")
+	}
+}
+
+// renderError 按错误类型渲染页面，其他错误不暴露内部信息
+func (h *SessionsPageHandler) renderError(w http.ResponseWriter, err error) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		renderSessions(w, http.StatusBadRequest, &sessionsData{Message: "请求无效。"})
+	case codes.NotFound:
+		renderSessions(w, http.StatusNotFound, &sessionsData{Message: "该设备已退出登录或会话已过期。"})
+	case codes.Unauthenticated, codes.PermissionDenied:
+		renderSessions(w, http.StatusUnauthorized, &sessionsData{Message: "请先登录。"})
+	default:
+		if isUnavailable(err) {
+			w.Header().Set("Retry-After", retryAfterSeconds)
+			renderSessions(w, http.StatusServiceUnavailable, &sessionsData{Message: "服务暂时不可用，请稍后再试。"})
+			return
+		}
+		logger.Errorf("failed to handle sessions page: %v", err)
+		renderSessions(w, http.StatusInternalServerError, &sessionsData{Message: "服务出错，请稍后再试。"})
+	}
+}
+
+// sameOrigin 请求携带 Origin 时要求与页面同源，未携带时（非浏览器客户端）放行
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// renderSessions 渲染登录会话页面
+func renderSessions(w http.ResponseWriter, code int, data *sessionsData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	if err := sessionsTemplate.Execute(w, data); err != nil {
+		logger.Warnf("failed to render sessions page: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package web
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// EmailVerifier 邮箱验证接口，由用户服务或其gRPC客户端实现
+type EmailVerifier interface {
+	VerifyEmail(ctx context.Context, token string) (*models.User, error)
+	ResendVerification(ctx context.Context, email string) error
+}
+
+// verifyEmailTemplate 邮箱验证页面，验证失败时展示重新发送的表单
+var verifyEmailTemplate = template.Must(template.New("verify_email").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head><meta charset="utf-8"><title>邮箱验证 - MovieInfo</title></head>
+<body>
+<h1>邮箱验证</h1>
+{{if .Verified}}<p>{{.Username}}，你的邮箱 {{.Email}} 已验证成功。</p>
+<p><a href="/">返回首页</a></p>
+{{else}}{{if .Message}}<p>{{.Message}}</p>{{end}}
+<form method="post" action="/verify-email">
+<label>注册邮箱 <input type="email" name="email" required maxlength="100"></label>
+<button type="submit">重新发送验证邮件</button>
+</form>
+{{end}}
+</body>
+</html>
+`))
+
+// verifyEmailData 邮箱验证页面数据
+type verifyEmailData struct {
+	Verified bool
+	Username string
+	Email    string
+	Message  string
+}
+
+// VerifyEmailPageHandler 邮箱验证页面
+// GET /verify-email?token=... 打开邮件中的验证链接；POST /verify-email 表单字段 email，重新发送验证邮件
+type VerifyEmailPageHandler struct {
+	verifier EmailVerifier
+}
+
+// NewVerifyEmailPageHandler 创建邮箱验证页面处理器
+func NewVerifyEmailPageHandler(verifier EmailVerifier) *VerifyEmailPageHandler {
+	return &VerifyEmailPageHandler{verifier: verifier}
+}
+
+// ServeHTTP 处理验证链接和重新发送请求
+func (h *VerifyEmailPageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.verify(w, r)
+	case http.MethodPost:
+		h.resend(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// verify 校验链接中的令牌
+func (h *VerifyEmailPageHandler) verify(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		renderVerifyEmail(w, http.StatusOK, &verifyEmailData{Message: "输入注册邮箱，重新获取验证邮件。"})
+		return
+	}
+
+	user, err := h.verifier.VerifyEmail(r.Context(), token)
+	if err != nil {
+		h.renderError(w, err, "验证链接无效或已过期，请重新获取验证邮件。")
+		return
+	}
+	renderVerifyEmail(w, http.StatusOK, &verifyEmailData{Verified: true, Username: user.Username, Email: user.Email})
+}
+
+// resend 重新发送验证邮件，邮箱是否注册都展示相同的结果
+func (h *VerifyEmailPageHandler) resend(w http.ResponseWriter, r *http.Request) {
+	email := r.PostFormValue("email")
+	if email == "" {
+		renderVerifyEmail(w, http.StatusBadRequest, &verifyEmailData{Message: "请输入注册邮箱。"})
+		return
+	}
+
+	if err := h.verifier.ResendVerification(r.Context(), email); err != nil {
+		h.renderError(w, err, "发送过于频繁，请稍后再试。")
+		return
+	}
+	renderVerifyEmail(w, http.StatusOK, &verifyEmailData{Message: "如果该邮箱已注册且尚未验证，验证邮件已发送，请查收。"})
+}
+
+// renderError 按错误类型渲染页面，业务错误展示 message，其他错误不暴露内部信息
+func (h *VerifyEmailPageHandler) renderError(w http.ResponseWriter, err error, message string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		renderVerifyEmail(w, http.StatusBadRequest, &verifyEmailData{Message: message})
+	case codes.FailedPrecondition, codes.ResourceExhausted:
+		renderVerifyEmail(w, http.StatusTooManyRequests, &verifyEmailData{Message: message})
+	default:
+		if isUnavailable(err) {
+			w.Header().Set("Retry-After", retryAfterSeconds)
+			renderVerifyEmail(w, http.StatusServiceUnavailable, &verifyEmailData{Message: "服务暂时不可用，请稍后再试。"})
+			return
+		}
+		logger.Errorf("failed to handle email verification: %v", err)
+		renderVerifyEmail(w, http.StatusInternalServerError, &verifyEmailData{Message: "服务出错，请稍后再试。"})
+	}
+}
+
+// renderVerifyEmail 渲染邮箱验证页面
+func renderVerifyEmail(w http.ResponseWriter, code int, data *verifyEmailData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	if err := verifyEmailTemplate.Execute(w, data); err != nil {
+		logger.Warnf("failed to render email verification page: %v", err)
+	}
+}
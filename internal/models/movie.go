@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// directorSeparator 多位导演在 director 列中的分隔符
+const directorSeparator = " / "
+
+// Movie 电影模型，对应 movies 表
+type Movie struct {
+	ID            int64
+	Title         string
+	Description   string
+	PosterURL     string
+	Duration      int32
+	ReleaseDate   *time.Time
+	Language      string
+	Genres        []string // 分类名称，来自 movie_categories 关联表
+	Directors     []string // 存储在 director 列
+	Actors        []string // 存储在 actors 列，JSON数组
+	AverageRating float64
+	RatingCount   int64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// JoinDirectors 将导演列表合并为 director 列的存储格式
+func JoinDirectors(directors []string) string {
+	return strings.Join(directors, directorSeparator)
+}
+
+// SplitDirectors 将 director 列拆分为导演列表
+func SplitDirectors(director string) []string {
+	if director == "" {
+		return nil
+	}
+
+	parts := strings.Split(director, directorSeparator)
+	directors := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			directors = append(directors, p)
+		}
+	}
+	return directors
+}
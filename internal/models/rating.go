@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Rating 评分模型，对应 user_ratings 表
+type Rating struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	MovieID   int64     `json:"movie_id"`
+	Score     int32     `json:"score"` // 存储在 rating 列
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MovieRatingStats 电影评分统计
+type MovieRatingStats struct {
+	MovieID       int64
+	AverageRating float64
+	TotalRatings  int64
+}
+
+// RatingEventType 评分事件类型
+type RatingEventType string
+
+const (
+	RatingCreated RatingEventType = "created"
+	RatingUpdated RatingEventType = "updated"
+	RatingDeleted RatingEventType = "deleted"
+)
+
+// RatingEvent 评分变更事件，包含变更后的电影平均评分
+type RatingEvent struct {
+	Type          RatingEventType `json:"type"`
+	Rating        *Rating         `json:"rating"`
+	AverageRating float64         `json:"average_rating"`
+	TotalRatings  int64           `json:"total_ratings"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// UserStatus 用户状态，对应 users.status 列
+type UserStatus int8
+
+const (
+	UserStatusInactive UserStatus = 0 // 禁用
+	UserStatusActive   UserStatus = 1 // 正常
+)
+
+// User 用户模型，对应 users 表
+type User struct {
+	ID            int64
+	Username      string
+	Email         string
+	PasswordHash  string
+	Nickname      string
+	AvatarURL     string
+	Status        UserStatus
+	EmailVerified bool
+	LastLoginAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// UserTOTP 用户的TOTP两步验证设置，对应 user_totp 表
+type UserTOTP struct {
+	UserID       int64
+	Secret       string     // Base32 编码的密钥
+	Enabled      bool       // 首次动态码确认后启用，未启用时为待确认的登记
+	LastUsedStep int64      // 最近一次使用的动态码周期序号，防止同一动态码重复使用
+	EnabledAt    *time.Time // 启用时间
+	CreatedAt    time.Time
+}
+
+// UserIdentity 用户关联的第三方身份，对应 user_identities 表
+type UserIdentity struct {
+	ID          int64
+	UserID      int64
+	Provider    string // 身份提供方名称
+	Subject     string // 用户在身份提供方的唯一标识
+	Email       string // 最近一次登录时身份提供方返回的邮箱
+	LastLoginAt *time.Time
+	CreatedAt   time.Time
+}
+
+// UserSession 用户的登录会话记录，对应 user_sessions 表
+type UserSession struct {
+	ID         string // 会话ID，与刷新令牌中的会话ID相同
+	UserID     int64
+	Device     string // 根据 User-Agent 识别的设备名称
+	IPAddress  string // 最近一次活动的IP
+	UserAgent  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+}
+
+// APIKey 用户的个人API密钥，对应 api_keys 表，只保存密钥的前缀和哈希
+type APIKey struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	Prefix     string   // 密钥前缀，如 mik_0123456789ab，用于查找和展示
+	KeyHash    string   // 完整密钥的SHA-256哈希
+	Scopes     []string // 权限范围：read-only、catalogue-write、ratings-write
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	LastUsedIP string
+	CreatedAt  time.Time
+}
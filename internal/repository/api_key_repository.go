@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+)
+
+// apiKeySelectColumns 查询API密钥时读取的列
+const apiKeySelectColumns = "id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, last_used_ip, created_at"
+
+// scopeSeparator scopes 列中权限范围的分隔符
+const scopeSeparator = ","
+
+// apiKeyRepository API密钥仓储实现
+type apiKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository 创建API密钥仓储
+func NewAPIKeyRepository(db *sql.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// Create 保存密钥，名称或前缀重复时返回 ErrAlreadyExists
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	var expiresAt sql.NullTime
+	if key.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *key.ExpiresAt, Valid: true}
+	}
+	result, err := r.db.ExecContext(ctx,
+		"INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at) VALUES (?, ?, ?, ?, ?, ?)",
+		key.UserID, key.Name, key.Prefix, key.KeyHash, strings.Join(key.Scopes, scopeSeparator), expiresAt)
+	if isDuplicateEntry(err) {
+		return ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get api key id: %w", err)
+	}
+	key.ID = id
+	return nil
+}
+
+// GetByPrefix 按前缀获取密钥，是否过期由调用方判断
+func (r *apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+apiKeySelectColumns+" FROM api_keys WHERE prefix = ?", prefix)
+	key, err := scanAPIKey(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return key, nil
+}
+
+// ListByUser 返回用户的全部密钥，包括已过期的密钥
+func (r *apiKeyRepository) ListByUser(ctx context.Context, userID int64) ([]*models.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+apiKeySelectColumns+
+		" FROM api_keys WHERE user_id = ? ORDER BY created_at DESC, id DESC", userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*models.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Delete 删除密钥，只能删除属于该用户的密钥
+func (r *apiKeyRepository) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM api_keys WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete api key: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete api key: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RecordUse 按主键更新最近使用时间，条件更新使频繁调用的密钥在 since 之内只写一次
+func (r *apiKeyRepository) RecordUse(ctx context.Context, id int64, usedAt time.Time, ip string, since time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE api_keys SET last_used_at = ?, last_used_ip = ? WHERE id = ? AND (last_used_at IS NULL OR last_used_at < ?)",
+		usedAt, ip, id, since)
+	if err != nil {
+		return fmt.Errorf("failed to record api key use: %w", err)
+	}
+	return nil
+}
+
+// scanAPIKey 读取一行API密钥记录
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (*models.APIKey, error) {
+	var (
+		key        models.APIKey
+		scopes     string
+		expiresAt  sql.NullTime
+		lastUsedAt sql.NullTime
+	)
+	err := row.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.KeyHash, &scopes,
+		&expiresAt, &lastUsedAt, &key.LastUsedIP, &key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if scopes != "" {
+		key.Scopes = strings.Split(scopes, scopeSeparator)
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	return &key, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+)
+
+// identitySelectColumns 查询第三方身份时读取的列
+const identitySelectColumns = "id, user_id, provider, subject, email, last_login_at, created_at"
+
+// identityRepository 第三方身份关联仓储实现
+type identityRepository struct {
+	db *sql.DB
+}
+
+// NewIdentityRepository 创建第三方身份关联仓储
+func NewIdentityRepository(db *sql.DB) IdentityRepository {
+	return &identityRepository{db: db}
+}
+
+// Get 按身份提供方和用户标识获取关联
+func (r *identityRepository) Get(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+identitySelectColumns+" FROM user_identities WHERE provider = ? AND subject = ?",
+		provider, subject)
+	identity, err := scanIdentity(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user identity: %w", err)
+	}
+	return identity, nil
+}
+
+// Create 为已有用户关联第三方身份
+func (r *identityRepository) Create(ctx context.Context, identity *models.UserIdentity) error {
+	return insertIdentity(ctx, r.db, identity)
+}
+
+// CreateWithUser 创建用户并关联第三方身份，任一插入失败时都不保留
+func (r *identityRepository) CreateWithUser(ctx context.Context, user *models.User, identity *models.UserIdentity) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := insertUser(ctx, tx, user); err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return insertIdentity(ctx, tx, identity)
+	})
+}
+
+// RecordLogin 更新最近一次登录时间和邮箱
+func (r *identityRepository) RecordLogin(ctx context.Context, id int64, email string) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE user_identities SET last_login_at = CURRENT_TIMESTAMP, email = ? WHERE id = ?",
+		email, id)
+	if err != nil {
+		return fmt.Errorf("failed to record identity login: %w", err)
+	}
+	return nil
+}
+
+// insertIdentity 插入第三方身份关联并回填ID
+func insertIdentity(ctx context.Context, db execer, identity *models.UserIdentity) error {
+	result, err := db.ExecContext(ctx, "INSERT INTO user_identities (user_id, provider, subject, email) VALUES (?, ?, ?, ?)",
+		identity.UserID, identity.Provider, identity.Subject, identity.Email)
+	if isDuplicateEntry(err) {
+		return ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert user identity: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get user identity id: %w", err)
+	}
+	identity.ID = id
+	return nil
+}
+
+// scanIdentity 读取一行第三方身份记录
+func scanIdentity(row interface{ Scan(...interface{}) error }) (*models.UserIdentity, error) {
+	var (
+		identity    models.UserIdentity
+		lastLoginAt sql.NullTime
+	)
+	err := row.Scan(&identity.ID, &identity.UserID, &identity.Provider, &identity.Subject, &identity.Email,
+		&lastLoginAt, &identity.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if lastLoginAt.Valid {
+		identity.LastLoginAt = &lastLoginAt.Time
+	}
+	return &identity, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+)
+
+var (
+	// ErrNotFound 记录不存在
+	ErrNotFound = errors.New("record not found")
+	// ErrAlreadyExists 记录已存在（违反唯一约束）
+	ErrAlreadyExists = errors.New("record already exists")
+	// ErrUnknownGenre 电影分类名称不存在
+	ErrUnknownGenre = errors.New("unknown genre")
+)
+
+// MovieRepository 电影仓储接口
+type MovieRepository interface {
+	GetByID(ctx context.Context, id int64) (*models.Movie, error)
+	// GetByIDs 批量获取电影，不存在的ID会被忽略
+	GetByIDs(ctx context.Context, ids []int64) ([]*models.Movie, error)
+	Create(ctx context.Context, movie *models.Movie) error
+	// UpdateColumns 只更新指定的列，其余列保持不变
+	UpdateColumns(ctx context.Context, movie *models.Movie, columns []string) error
+}
+
+// UserRepository 用户仓储接口
+type UserRepository interface {
+	GetByID(ctx context.Context, id int64) (*models.User, error)
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	// Create 创建用户，用户名或邮箱已被使用时返回 ErrAlreadyExists
+	Create(ctx context.Context, user *models.User) error
+	// UpdateColumns 只更新指定的列，其余列保持不变
+	UpdateColumns(ctx context.Context, user *models.User, columns []string) error
+	// RecordLogin 更新最后登录时间并写入一条登录历史
+	RecordLogin(ctx context.Context, userID int64, ip, userAgent string) error
+}
+
+// RoleRepository 角色仓储接口
+type RoleRepository interface {
+	// RolePermissions 返回全部角色及其拥有的权限
+	RolePermissions(ctx context.Context) (map[string][]string, error)
+	// GetUserRoles 返回用户拥有的角色名称
+	GetUserRoles(ctx context.Context, userID int64) ([]string, error)
+	// Grant 授予用户角色，角色不存在时返回 ErrNotFound，用户已拥有该角色时返回 ErrAlreadyExists
+	Grant(ctx context.Context, userID int64, role string, grantedBy int64) error
+	// Revoke 撤销用户角色，用户没有该角色时返回 ErrNotFound
+	Revoke(ctx context.Context, userID int64, role string) error
+}
+
+// TOTPRepository TOTP两步验证仓储接口
+type TOTPRepository interface {
+	// Get 获取用户的TOTP设置，未登记时返回 ErrNotFound
+	Get(ctx context.Context, userID int64) (*models.UserTOTP, error)
+	// SavePending 保存待确认的密钥，已启用时返回 ErrAlreadyExists
+	SavePending(ctx context.Context, userID int64, secret string) error
+	// Enable 启用待确认的登记并替换恢复码，没有待确认的登记时返回 ErrNotFound
+	Enable(ctx context.Context, userID, step int64, codeHashes []string) error
+	// UseStep 记录已使用的动态码周期，周期不晚于上次使用的周期时返回 false
+	UseStep(ctx context.Context, userID, step int64) (bool, error)
+	// UseRecoveryCode 使用一个恢复码，恢复码不存在或已使用时返回 false
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+	// CountRecoveryCodes 返回未使用的恢复码数量
+	CountRecoveryCodes(ctx context.Context, userID int64) (int, error)
+	// ReplaceRecoveryCodes 替换用户的全部恢复码
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+	// Delete 删除TOTP设置和恢复码，未登记时返回 ErrNotFound
+	Delete(ctx context.Context, userID int64) error
+}
+
+// IdentityRepository 第三方身份关联仓储接口
+type IdentityRepository interface {
+	// Get 按身份提供方和用户标识获取关联，未关联时返回 ErrNotFound
+	Get(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	// Create 为已有用户关联第三方身份，身份已关联时返回 ErrAlreadyExists
+	Create(ctx context.Context, identity *models.UserIdentity) error
+	// CreateWithUser 在同一事务中创建用户并关联第三方身份，用户名、邮箱或身份已存在时返回 ErrAlreadyExists
+	CreateWithUser(ctx context.Context, user *models.User, identity *models.UserIdentity) error
+	// RecordLogin 更新通过该身份登录的时间和身份提供方返回的邮箱
+	RecordLogin(ctx context.Context, id int64, email string) error
+}
+
+// SessionRepository 登录会话仓储接口，作为缓存中会话记录的持久化备份
+type SessionRepository interface {
+	// Create 保存会话记录，同时清理该用户已过期的会话
+	Create(ctx context.Context, session *models.UserSession) error
+	// Get 获取未过期的会话记录，不存在时返回 ErrNotFound
+	Get(ctx context.Context, id string) (*models.UserSession, error)
+	// ListByUser 返回用户未过期的会话记录，按最近活动时间倒序
+	ListByUser(ctx context.Context, userID int64) ([]*models.UserSession, error)
+	// Touch 更新最近活动时间和IP，会话不存在时返回 ErrNotFound
+	Touch(ctx context.Context, id string, seenAt time.Time, ip string) error
+	// Delete 删除会话记录
+	Delete(ctx context.Context, id string) error
+}
+
+// APIKeyRepository API密钥仓储接口
+type APIKeyRepository interface {
+	// Create 保存新的密钥并回填ID，同一用户的密钥名称重复时返回 ErrAlreadyExists
+	Create(ctx context.Context, key *models.APIKey) error
+	// GetByPrefix 按前缀获取密钥，不存在时返回 ErrNotFound
+	GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	// ListByUser 返回用户的全部密钥，按创建时间倒序
+	ListByUser(ctx context.Context, userID int64) ([]*models.APIKey, error)
+	// Delete 删除用户的密钥，不存在时返回 ErrNotFound
+	Delete(ctx context.Context, userID, id int64) error
+	// RecordUse 记录密钥的使用时间和IP，上次记录晚于 since 时不更新
+	RecordUse(ctx context.Context, id int64, usedAt time.Time, ip string, since time.Time) error
+}
+
+// RatingRepository 评分仓储接口
+type RatingRepository interface {
+	GetByID(ctx context.Context, id int64) (*models.Rating, error)
+	// Create 创建评分，同一用户对同一电影重复评分时返回 ErrAlreadyExists
+	Create(ctx context.Context, rating *models.Rating) error
+	Update(ctx context.Context, rating *models.Rating) error
+	Delete(ctx context.Context, rating *models.Rating) error
+	GetMovieStats(ctx context.Context, movieID int64) (*models.MovieRatingStats, error)
+	// GetByUserAndMovies 获取用户对多部电影的评分，未评分的电影不返回
+	GetByUserAndMovies(ctx context.Context, userID int64, movieIDs []int64) ([]*models.Rating, error)
+}
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+)
+
+// MovieGenresColumn 电影分类的虚拟列，更新时替换 movie_categories 关联记录
+const MovieGenresColumn = "genres"
+
+// movieSelectColumns 查询电影时读取的列
+const movieSelectColumns = `id, title, description, poster_url, duration, release_date, language,
+	director, actors, rating_average, rating_count, created_at, updated_at`
+
+// movieRepository 电影仓储实现
+type movieRepository struct {
+	db *sql.DB
+}
+
+// NewMovieRepository 创建电影仓储
+func NewMovieRepository(db *sql.DB) MovieRepository {
+	return &movieRepository{db: db}
+}
+
+// GetByID 根据ID获取电影
+func (r *movieRepository) GetByID(ctx context.Context, id int64) (*models.Movie, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+movieSelectColumns+" FROM movies WHERE id = ?", id)
+	movie, err := scanMovie(row)
+	if err != nil {
+		return nil, err
+	}
+
+	genres, err := r.getGenres(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+	movie.Genres = genres[id]
+
+	return movie, nil
+}
+
+// GetByIDs 根据ID列表批量获取电影，不存在的ID会被忽略，返回顺序不保证与ids一致
+func (r *movieRepository) GetByIDs(ctx context.Context, ids []int64) ([]*models.Movie, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM movies WHERE id IN (%s)", movieSelectColumns, placeholders(len(ids)))
+	rows, err := r.db.QueryContext(ctx, query, int64Args(ids)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query movies: %w", err)
+	}
+	defer rows.Close()
+
+	var movies []*models.Movie
+	for rows.Next() {
+		movie, err := scanMovie(rows)
+		if err != nil {
+			return nil, err
+		}
+		movies = append(movies, movie)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate movies: %w", err)
+	}
+
+	genres, err := r.getGenres(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, movie := range movies {
+		movie.Genres = genres[movie.ID]
+	}
+
+	return movies, nil
+}
+
+// Create 创建电影，成功后回填 movie.ID
+func (r *movieRepository) Create(ctx context.Context, movie *models.Movie) error {
+	columns := []string{"title", "description", "poster_url", "duration", "release_date", "language", "director", "actors"}
+	args := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		value, err := movieColumnValue(movie, column)
+		if err != nil {
+			return err
+		}
+		args = append(args, value)
+	}
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("INSERT INTO movies (%s) VALUES (%s)", strings.Join(columns, ", "), placeholders(len(columns)))
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to insert movie: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get movie id: %w", err)
+		}
+		movie.ID = id
+
+		return replaceGenres(ctx, tx, movie.ID, movie.Genres)
+	})
+}
+
+// UpdateColumns 只更新指定的列，其余列保持不变
+func (r *movieRepository) UpdateColumns(ctx context.Context, movie *models.Movie, columns []string) error {
+	var (
+		setColumns   []string
+		args         []interface{}
+		updateGenres bool
+	)
+	for _, column := range columns {
+		if column == MovieGenresColumn {
+			updateGenres = true
+			continue
+		}
+
+		value, err := movieColumnValue(movie, column)
+		if err != nil {
+			return err
+		}
+		setColumns = append(setColumns, column)
+		args = append(args, value)
+	}
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if len(setColumns) > 0 {
+			query := fmt.Sprintf("UPDATE movies SET %s WHERE id = ?", buildSetClause(setColumns))
+			if _, err := tx.ExecContext(ctx, query, append(args, movie.ID)...); err != nil {
+				return fmt.Errorf("failed to update movie: %w", err)
+			}
+		}
+
+		if updateGenres {
+			return replaceGenres(ctx, tx, movie.ID, movie.Genres)
+		}
+		return nil
+	})
+}
+
+// getGenres 获取多部电影的分类名称，按电影ID分组
+func (r *movieRepository) getGenres(ctx context.Context, movieIDs []int64) (map[int64][]string, error) {
+	query := fmt.Sprintf(`SELECT mc.movie_id, c.name FROM movie_categories mc
+		JOIN categories c ON c.id = mc.category_id
+		WHERE mc.movie_id IN (%s) ORDER BY c.sort_order`, placeholders(len(movieIDs)))
+	rows, err := r.db.QueryContext(ctx, query, int64Args(movieIDs)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query movie genres: %w", err)
+	}
+	defer rows.Close()
+
+	genres := make(map[int64][]string)
+	for rows.Next() {
+		var (
+			movieID int64
+			name    string
+		)
+		if err := rows.Scan(&movieID, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan movie genre: %w", err)
+		}
+		genres[movieID] = append(genres[movieID], name)
+	}
+	return genres, rows.Err()
+}
+
+// replaceGenres 用给定的分类名称替换电影的分类关联
+func replaceGenres(ctx context.Context, tx *sql.Tx, movieID int64, genres []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM movie_categories WHERE movie_id = ?", movieID); err != nil {
+		return fmt.Errorf("failed to clear movie genres: %w", err)
+	}
+	if len(genres) == 0 {
+		return nil
+	}
+
+	names := uniqueStrings(genres)
+	args := make([]interface{}, 0, len(names)+1)
+	args = append(args, movieID)
+	for _, name := range names {
+		args = append(args, name)
+	}
+
+	query := fmt.Sprintf(`INSERT INTO movie_categories (movie_id, category_id)
+		SELECT ?, id FROM categories WHERE name IN (%s)`, placeholders(len(names)))
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to insert movie genres: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to insert movie genres: %w", err)
+	}
+	if int(affected) != len(names) {
+		return ErrUnknownGenre
+	}
+	return nil
+}
+
+// movieColumnValue 返回电影模型中与列对应的值
+func movieColumnValue(movie *models.Movie, column string) (interface{}, error) {
+	switch column {
+	case "title":
+		return movie.Title, nil
+	case "description":
+		return nullString(movie.Description), nil
+	case "poster_url":
+		return nullString(movie.PosterURL), nil
+	case "duration":
+		if movie.Duration == 0 {
+			return nil, nil
+		}
+		return movie.Duration, nil
+	case "release_date":
+		if movie.ReleaseDate == nil {
+			return nil, nil
+		}
+		return *movie.ReleaseDate, nil
+	case "language":
+		return nullString(movie.Language), nil
+	case "director":
+		return nullString(models.JoinDirectors(movie.Directors)), nil
+	case "actors":
+		if len(movie.Actors) == 0 {
+			return nil, nil
+		}
+		data, err := json.Marshal(movie.Actors)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode actors: %w", err)
+		}
+		return string(data), nil
+	default:
+		return nil, fmt.Errorf("unsupported movie column: %s", column)
+	}
+}
+
+// scanMovie 扫描一行电影数据
+func scanMovie(row interface{ Scan(...interface{}) error }) (*models.Movie, error) {
+	var (
+		movie       models.Movie
+		description sql.NullString
+		posterURL   sql.NullString
+		duration    sql.NullInt32
+		releaseDate sql.NullTime
+		language    sql.NullString
+		director    sql.NullString
+		actors      sql.NullString
+	)
+
+	err := row.Scan(&movie.ID, &movie.Title, &description, &posterURL, &duration, &releaseDate, &language,
+		&director, &actors, &movie.AverageRating, &movie.RatingCount, &movie.CreatedAt, &movie.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan movie: %w", err)
+	}
+
+	movie.Description = description.String
+	movie.PosterURL = posterURL.String
+	movie.Duration = duration.Int32
+	if releaseDate.Valid {
+		movie.ReleaseDate = &releaseDate.Time
+	}
+	movie.Language = language.String
+	movie.Directors = models.SplitDirectors(director.String)
+	if actors.String != "" {
+		if err := json.Unmarshal([]byte(actors.String), &movie.Actors); err != nil {
+			return nil, fmt.Errorf("failed to decode actors: %w", err)
+		}
+	}
+
+	return &movie, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+)
+
+// ratingSelectColumns 查询评分时读取的列
+const ratingSelectColumns = "id, user_id, movie_id, rating, comment, created_at, updated_at"
+
+// ratingRepository 评分仓储实现
+type ratingRepository struct {
+	db *sql.DB
+}
+
+// NewRatingRepository 创建评分仓储
+func NewRatingRepository(db *sql.DB) RatingRepository {
+	return &ratingRepository{db: db}
+}
+
+// GetByID 根据ID获取评分
+func (r *ratingRepository) GetByID(ctx context.Context, id int64) (*models.Rating, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+ratingSelectColumns+" FROM user_ratings WHERE id = ?", id)
+	return scanRating(row)
+}
+
+// Create 创建评分并刷新电影评分统计，成功后回填 rating.ID
+func (r *ratingRepository) Create(ctx context.Context, rating *models.Rating) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "INSERT INTO user_ratings (user_id, movie_id, rating, comment) VALUES (?, ?, ?, ?)",
+			rating.UserID, rating.MovieID, rating.Score, nullString(rating.Comment))
+		if isDuplicateEntry(err) {
+			return ErrAlreadyExists
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert rating: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get rating id: %w", err)
+		}
+		rating.ID = id
+
+		return refreshMovieStats(ctx, tx, rating.MovieID)
+	})
+}
+
+// Update 更新评分分数和评价内容，并刷新电影评分统计
+func (r *ratingRepository) Update(ctx context.Context, rating *models.Rating) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "UPDATE user_ratings SET rating = ?, comment = ? WHERE id = ?",
+			rating.Score, nullString(rating.Comment), rating.ID)
+		if err != nil {
+			return fmt.Errorf("failed to update rating: %w", err)
+		}
+		return refreshMovieStats(ctx, tx, rating.MovieID)
+	})
+}
+
+// Delete 删除评分并刷新电影评分统计
+func (r *ratingRepository) Delete(ctx context.Context, rating *models.Rating) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_ratings WHERE id = ?", rating.ID); err != nil {
+			return fmt.Errorf("failed to delete rating: %w", err)
+		}
+		return refreshMovieStats(ctx, tx, rating.MovieID)
+	})
+}
+
+// GetMovieStats 获取电影评分统计
+func (r *ratingRepository) GetMovieStats(ctx context.Context, movieID int64) (*models.MovieRatingStats, error) {
+	stats := &models.MovieRatingStats{MovieID: movieID}
+	err := r.db.QueryRowContext(ctx, "SELECT COALESCE(AVG(rating), 0), COUNT(*) FROM user_ratings WHERE movie_id = ?", movieID).
+		Scan(&stats.AverageRating, &stats.TotalRatings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query movie rating stats: %w", err)
+	}
+	return stats, nil
+}
+
+// GetByUserAndMovies 获取用户对多部电影的评分，未评分的电影不返回
+func (r *ratingRepository) GetByUserAndMovies(ctx context.Context, userID int64, movieIDs []int64) ([]*models.Rating, error) {
+	if len(movieIDs) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM user_ratings WHERE user_id = ? AND movie_id IN (%s)",
+		ratingSelectColumns, placeholders(len(movieIDs)))
+	args := append([]interface{}{userID}, int64Args(movieIDs)...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ratings: %w", err)
+	}
+	defer rows.Close()
+
+	var ratings []*models.Rating
+	for rows.Next() {
+		rating, err := scanRating(rows)
+		if err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, rating)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate ratings: %w", err)
+	}
+	return ratings, nil
+}
+
+// scanRating 扫描一行评分数据
+func scanRating(row interface{ Scan(...interface{}) error }) (*models.Rating, error) {
+	var (
+		rating  models.Rating
+		comment sql.NullString
+	)
+
+	err := row.Scan(&rating.ID, &rating.UserID, &rating.MovieID, &rating.Score, &comment,
+		&rating.CreatedAt, &rating.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan rating: %w", err)
+	}
+
+	rating.Comment = comment.String
+	return &rating, nil
+}
+
+// refreshMovieStats 重新计算 movies 表中冗余的评分统计
+func refreshMovieStats(ctx context.Context, tx *sql.Tx, movieID int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE movies SET
+		rating_average = (SELECT COALESCE(AVG(rating), 0) FROM user_ratings WHERE movie_id = ?),
+		rating_count = (SELECT COUNT(*) FROM user_ratings WHERE movie_id = ?)
+		WHERE id = ?`, movieID, movieID, movieID)
+	if err != nil {
+		return fmt.Errorf("failed to refresh movie rating stats: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// roleRepository 角色仓储实现
+type roleRepository struct {
+	db *sql.DB
+}
+
+// NewRoleRepository 创建角色仓储
+func NewRoleRepository(db *sql.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+// RolePermissions 返回全部角色及其权限，没有权限的角色对应空列表
+func (r *roleRepository) RolePermissions(ctx context.Context) (map[string][]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT r.name, p.name FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN permissions p ON p.id = rp.permission_id
+		ORDER BY r.name, p.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query role permissions: %w", err)
+	}
+	defer rows.Close()
+
+	permissions := make(map[string][]string)
+	for rows.Next() {
+		var (
+			role       string
+			permission sql.NullString
+		)
+		if err := rows.Scan(&role, &permission); err != nil {
+			return nil, fmt.Errorf("failed to scan role permission: %w", err)
+		}
+		if _, ok := permissions[role]; !ok {
+			permissions[role] = []string{}
+		}
+		if permission.Valid {
+			permissions[role] = append(permissions[role], permission.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate role permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+// GetUserRoles 返回用户拥有的角色名称，按名称排序
+func (r *roleRepository) GetUserRoles(ctx context.Context, userID int64) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT r.name FROM user_roles ur
+		JOIN roles r ON r.id = ur.role_id
+		WHERE ur.user_id = ? ORDER BY r.name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, fmt.Errorf("failed to scan user role: %w", err)
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate user roles: %w", err)
+	}
+	return roles, nil
+}
+
+// Grant 授予用户角色
+func (r *roleRepository) Grant(ctx context.Context, userID int64, role string, grantedBy int64) error {
+	result, err := r.db.ExecContext(ctx, `INSERT INTO user_roles (user_id, role_id, granted_by)
+		SELECT ?, id, ? FROM roles WHERE name = ?`,
+		userID, sql.NullInt64{Int64: grantedBy, Valid: grantedBy > 0}, role)
+	if isDuplicateEntry(err) {
+		return ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Revoke 撤销用户角色
+func (r *roleRepository) Revoke(ctx context.Context, userID int64, role string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_roles
+		WHERE user_id = ? AND role_id = (SELECT id FROM roles WHERE name = ?)`, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+)
+
+// sessionSelectColumns 查询会话记录时读取的列
+const sessionSelectColumns = "id, user_id, device, ip_address, user_agent, created_at, last_seen_at, expires_at"
+
+// maxDeviceLength user_sessions.device 列的长度
+const maxDeviceLength = 100
+
+// sessionRepository 登录会话仓储实现
+type sessionRepository struct {
+	db *sql.DB
+}
+
+// NewSessionRepository 创建登录会话仓储
+func NewSessionRepository(db *sql.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// Create 在同一事务中清理过期会话并插入新会话，过长的设备名称和 User-Agent 会被截断
+func (r *sessionRepository) Create(ctx context.Context, session *models.UserSession) error {
+	device, userAgent := session.Device, session.UserAgent
+	if len(device) > maxDeviceLength {
+		device = device[:maxDeviceLength]
+	}
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_sessions WHERE user_id = ? AND expires_at <= ?",
+			session.UserID, time.Now()); err != nil {
+			return fmt.Errorf("failed to delete expired sessions: %w", err)
+		}
+		_, err := tx.ExecContext(ctx, "INSERT INTO user_sessions ("+sessionSelectColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			session.ID, session.UserID, device, session.IPAddress, userAgent,
+			session.CreatedAt, session.LastSeenAt, session.ExpiresAt)
+		if isDuplicateEntry(err) {
+			return ErrAlreadyExists
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert session: %w", err)
+		}
+		return nil
+	})
+}
+
+// Get 获取未过期的会话记录
+func (r *sessionRepository) Get(ctx context.Context, id string) (*models.UserSession, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+sessionSelectColumns+" FROM user_sessions WHERE id = ? AND expires_at > ?",
+		id, time.Now())
+	session, err := scanSession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+// ListByUser 返回用户未过期的会话记录
+func (r *sessionRepository) ListByUser(ctx context.Context, userID int64) ([]*models.UserSession, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+sessionSelectColumns+
+		" FROM user_sessions WHERE user_id = ? AND expires_at > ? ORDER BY last_seen_at DESC", userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*models.UserSession
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// Touch 按主键更新最近活动时间和IP
+func (r *sessionRepository) Touch(ctx context.Context, id string, seenAt time.Time, ip string) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE user_sessions SET last_seen_at = ?, ip_address = ? WHERE id = ? AND expires_at > ?",
+		seenAt, ip, id, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	// MySQL 在值未变化时同样返回0，需要确认会话是否存在
+	if affected == 0 {
+		_, err := r.Get(ctx, id)
+		return err
+	}
+	return nil
+}
+
+// Delete 删除会话记录
+func (r *sessionRepository) Delete(ctx context.Context, id string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM user_sessions WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// scanSession 读取一行会话记录
+func scanSession(row interface{ Scan(...interface{}) error }) (*models.UserSession, error) {
+	var session models.UserSession
+	err := row.Scan(&session.ID, &session.UserID, &session.Device, &session.IPAddress, &session.UserAgent,
+		&session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
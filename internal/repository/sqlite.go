@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+)
+
+var (
+	//go:embed schema/sqlite_tables.sql
+	sqliteTables string
+
+	//go:embed schema/sqlite_initial_data.sql
+	sqliteInitialData string
+)
+
+// MigrateSQLite 在空的SQLite数据库中创建数据表并写入初始数据，已初始化的数据库保持不变
+// 供单进程开发模式和集成测试使用，生产环境的MySQL表结构见 scripts/database
+func MigrateSQLite(ctx context.Context, db *sql.DB) error {
+	var count int
+	err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'users'").Scan(&count)
+	if err != nil {
+		return fmt.Errorf("failed to inspect sqlite schema: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	return withTx(ctx, db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, sqliteTables); err != nil {
+			return fmt.Errorf("failed to create sqlite tables: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, sqliteInitialData); err != nil {
+			return fmt.Errorf("failed to insert sqlite initial data: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+)
+
+// newTestDB 返回已迁移的内存SQLite数据库
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", "file::memory:?_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	if err := MigrateSQLite(context.Background(), db); err != nil {
+		t.Fatalf("MigrateSQLite() error = %v", err)
+	}
+	return db
+}
+
+func TestMigrateSQLiteIsIdempotent(t *testing.T) {
+	db := newTestDB(t)
+	if err := MigrateSQLite(context.Background(), db); err != nil {
+		t.Fatalf("second MigrateSQLite() error = %v", err)
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM movies").Scan(&count); err != nil {
+		t.Fatalf("count movies: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("movies = %d, want the 3 seeded movies", count)
+	}
+}
+
+func TestMovieRepositorySQLite(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMovieRepository(newTestDB(t))
+
+	movies, err := repo.GetByIDs(ctx, []int64{2, 1, 99})
+	if err != nil {
+		t.Fatalf("GetByIDs() error = %v", err)
+	}
+	var ids []int64
+	for _, movie := range movies {
+		ids = append(ids, movie.ID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if !reflect.DeepEqual(ids, []int64{1, 2}) {
+		t.Fatalf("GetByIDs() ids = %v, want [1 2]", ids)
+	}
+
+	movie := &models.Movie{Title: "Arrival", Duration: 116, Genres: []string{"科幻", "剧情"}, Actors: []string{"Amy Adams"}}
+	if err := repo.Create(ctx, movie); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	movie.Title = "降临"
+	movie.Genres = []string{"科幻"}
+	if err := repo.UpdateColumns(ctx, movie, []string{"title", MovieGenresColumn}); err != nil {
+		t.Fatalf("UpdateColumns() error = %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, movie.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Title != "降临" || got.Duration != 116 || !reflect.DeepEqual(got.Genres, []string{"科幻"}) || !reflect.DeepEqual(got.Actors, []string{"Amy Adams"}) {
+		t.Fatalf("GetByID() = %+v, want updated title and genres with other columns unchanged", got)
+	}
+
+	movie.Genres = []string{"武侠"}
+	if err := repo.UpdateColumns(ctx, movie, []string{MovieGenresColumn}); !errors.Is(err, ErrUnknownGenre) {
+		t.Fatalf("UpdateColumns() with unknown genre error = %v, want ErrUnknownGenre", err)
+	}
+
+	if _, err := repo.GetByID(ctx, 99); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetByID(99) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+)
+
+// totpRepository TOTP两步验证仓储实现
+type totpRepository struct {
+	db *sql.DB
+}
+
+// NewTOTPRepository 创建TOTP两步验证仓储
+func NewTOTPRepository(db *sql.DB) TOTPRepository {
+	return &totpRepository{db: db}
+}
+
+// Get 获取用户的TOTP设置
+func (r *totpRepository) Get(ctx context.Context, userID int64) (*models.UserTOTP, error) {
+	var (
+		totp      models.UserTOTP
+		enabledAt sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx, `SELECT user_id, secret, enabled, last_used_step, enabled_at, created_at
+		FROM user_totp WHERE user_id = ?`, userID).
+		Scan(&totp.UserID, &totp.Secret, &totp.Enabled, &totp.LastUsedStep, &enabledAt, &totp.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user totp: %w", err)
+	}
+	if enabledAt.Valid {
+		totp.EnabledAt = &enabledAt.Time
+	}
+	return &totp, nil
+}
+
+// SavePending 保存待确认的密钥，替换之前未确认的登记
+func (r *totpRepository) SavePending(ctx context.Context, userID int64, secret string) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_totp WHERE user_id = ? AND enabled = FALSE", userID); err != nil {
+			return fmt.Errorf("failed to delete pending totp: %w", err)
+		}
+		_, err := tx.ExecContext(ctx, "INSERT INTO user_totp (user_id, secret) VALUES (?, ?)", userID, secret)
+		if isDuplicateEntry(err) {
+			return ErrAlreadyExists
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save pending totp: %w", err)
+		}
+		return nil
+	})
+}
+
+// Enable 启用待确认的登记，记录确认时使用的周期并替换恢复码
+func (r *totpRepository) Enable(ctx context.Context, userID, step int64, codeHashes []string) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `UPDATE user_totp
+			SET enabled = TRUE, enabled_at = CURRENT_TIMESTAMP, last_used_step = ?
+			WHERE user_id = ? AND enabled = FALSE`, step, userID)
+		if err != nil {
+			return fmt.Errorf("failed to enable totp: %w", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if n == 0 {
+			return ErrNotFound
+		}
+		return replaceRecoveryCodes(ctx, tx, userID, codeHashes)
+	})
+}
+
+// UseStep 记录已使用的动态码周期，只有周期晚于上次使用的周期时才成功，并发校验同一动态码时只有一个成功
+func (r *totpRepository) UseStep(ctx context.Context, userID, step int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE user_totp SET last_used_step = ?
+		WHERE user_id = ? AND enabled = TRUE AND last_used_step < ?`, step, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to use totp step: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return n > 0, nil
+}
+
+// UseRecoveryCode 将未使用的恢复码标记为已使用，恢复码不存在或已使用时返回 false
+func (r *totpRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE user_recovery_codes SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = ? AND code_hash = ? AND used_at IS NULL`, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return n > 0, nil
+}
+
+// CountRecoveryCodes 返回用户未使用的恢复码数量
+func (r *totpRepository) CountRecoveryCodes(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = ? AND used_at IS NULL", userID).
+		Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return count, nil
+}
+
+// ReplaceRecoveryCodes 删除用户的全部恢复码并写入新的恢复码
+func (r *totpRepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		return replaceRecoveryCodes(ctx, tx, userID, codeHashes)
+	})
+}
+
+// Delete 删除用户的TOTP设置和全部恢复码
+func (r *totpRepository) Delete(ctx context.Context, userID int64) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = ?", userID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+		result, err := tx.ExecContext(ctx, "DELETE FROM user_totp WHERE user_id = ?", userID)
+		if err != nil {
+			return fmt.Errorf("failed to delete totp: %w", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if n == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+// replaceRecoveryCodes 在事务中替换用户的全部恢复码
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID int64, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = ?", userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(codeHashes))
+	args := make([]interface{}, 0, len(codeHashes)*2)
+	for i, hash := range codeHashes {
+		values[i] = "(?, ?)"
+		args = append(args, userID, hash)
+	}
+	query := "INSERT INTO user_recovery_codes (user_id, code_hash) VALUES " + strings.Join(values, ", ")
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert recovery codes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+)
+
+// userSelectColumns 查询用户时读取的列
+const userSelectColumns = `id, username, email, password_hash, nickname, avatar_url, status,
+	email_verified, last_login_at, created_at, updated_at`
+
+// maxUserAgentLength user_login_history.user_agent 列的长度
+const maxUserAgentLength = 255
+
+// userRepository 用户仓储实现
+type userRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository 创建用户仓储
+func NewUserRepository(db *sql.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+// GetByID 根据ID获取用户
+func (r *userRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+userSelectColumns+" FROM users WHERE id = ?", id)
+	return scanUser(row)
+}
+
+// GetByUsername 根据用户名获取用户
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+userSelectColumns+" FROM users WHERE username = ?", username)
+	return scanUser(row)
+}
+
+// GetByEmail 根据邮箱获取用户
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+userSelectColumns+" FROM users WHERE email = ?", email)
+	return scanUser(row)
+}
+
+// Create 创建用户
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	return insertUser(ctx, r.db, user)
+}
+
+// insertUser 插入用户并回填ID，用户名或邮箱已被使用时返回 ErrAlreadyExists
+func insertUser(ctx context.Context, db execer, user *models.User) error {
+	columns := []string{"username", "email", "password_hash", "nickname", "avatar_url", "status", "email_verified"}
+	args := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		value, err := userColumnValue(user, column)
+		if err != nil {
+			return err
+		}
+		args = append(args, value)
+	}
+
+	query := fmt.Sprintf("INSERT INTO users (%s) VALUES (%s)", strings.Join(columns, ", "), placeholders(len(columns)))
+	result, err := db.ExecContext(ctx, query, args...)
+	if isDuplicateEntry(err) {
+		return ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get user id: %w", err)
+	}
+	user.ID = id
+	return nil
+}
+
+// UpdateColumns 只更新指定的列，其余列保持不变，邮箱已被使用时返回 ErrAlreadyExists
+func (r *userRepository) UpdateColumns(ctx context.Context, user *models.User, columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		value, err := userColumnValue(user, column)
+		if err != nil {
+			return err
+		}
+		args = append(args, value)
+	}
+	args = append(args, user.ID)
+
+	query := fmt.Sprintf("UPDATE users SET %s WHERE id = ?", buildSetClause(columns))
+	_, err := r.db.ExecContext(ctx, query, args...)
+	if isDuplicateEntry(err) {
+		return ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	return nil
+}
+
+// RecordLogin 在同一事务中更新最后登录时间并写入登录历史，过长的 User-Agent 会被截断
+func (r *userRepository) RecordLogin(ctx context.Context, userID int64, ip, userAgent string) error {
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "UPDATE users SET last_login_at = CURRENT_TIMESTAMP WHERE id = ?", userID); err != nil {
+			return fmt.Errorf("failed to update last login time: %w", err)
+		}
+		_, err := tx.ExecContext(ctx, "INSERT INTO user_login_history (user_id, ip_address, user_agent) VALUES (?, ?, ?)",
+			userID, ip, userAgent)
+		if err != nil {
+			return fmt.Errorf("failed to insert login history: %w", err)
+		}
+		return nil
+	})
+}
+
+// userColumnValue 返回用户模型中与列对应的值
+func userColumnValue(user *models.User, column string) (interface{}, error) {
+	switch column {
+	case "username":
+		return user.Username, nil
+	case "email":
+		return user.Email, nil
+	case "nickname":
+		return nullString(user.Nickname), nil
+	case "avatar_url":
+		return nullString(user.AvatarURL), nil
+	case "password_hash":
+		return user.PasswordHash, nil
+	case "status":
+		return user.Status, nil
+	case "email_verified":
+		return user.EmailVerified, nil
+	default:
+		return nil, fmt.Errorf("unsupported user column: %s", column)
+	}
+}
+
+// scanUser 扫描一行用户数据
+func scanUser(row interface{ Scan(...interface{}) error }) (*models.User, error) {
+	var (
+		user        models.User
+		nickname    sql.NullString
+		avatarURL   sql.NullString
+		lastLoginAt sql.NullTime
+	)
+
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.PasswordHash, &nickname, &avatarURL,
+		&user.Status, &user.EmailVerified, &lastLoginAt, &user.CreatedAt, &user.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan user: %w", err)
+	}
+
+	user.Nickname = nickname.String
+	user.AvatarURL = avatarURL.String
+	if lastLoginAt.Valid {
+		user.LastLoginAt = &lastLoginAt.Time
+	}
+
+	return &user, nil
+}
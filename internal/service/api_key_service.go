@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apikey"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+const (
+	// maxAPIKeyNameLength api_keys.name 列的长度
+	maxAPIKeyNameLength = 100
+	// apiKeyUseInterval 同一密钥两次记录使用时间的最小间隔，避免每次调用都写数据库
+	apiKeyUseInterval = time.Minute
+)
+
+// APIKeyScopes 使用API密钥调用各方法所需的权限范围，未列出的方法不允许使用密钥调用
+// 写操作同时要求密钥所属用户的角色拥有 MethodPermissions 中的权限
+var APIKeyScopes = apikey.Policy{
+	"/movieinfo.movie.MovieService/GetMovie":                apikey.ScopeReadOnly,
+	"/movieinfo.movie.MovieService/ListMovies":              apikey.ScopeReadOnly,
+	"/movieinfo.movie.MovieService/BatchGetMovies":          apikey.ScopeReadOnly,
+	"/movieinfo.movie.MovieService/SearchMovies":            apikey.ScopeReadOnly,
+	"/movieinfo.movie.MovieService/HealthCheck":             apikey.ScopeReadOnly,
+	"/movieinfo.movie.MovieService/CreateMovie":             apikey.ScopeCatalogueWrite,
+	"/movieinfo.movie.MovieService/UpdateMovie":             apikey.ScopeCatalogueWrite,
+	"/movieinfo.movie.MovieService/DeleteMovie":             apikey.ScopeCatalogueWrite,
+	"/movieinfo.movie.MovieService/BulkCreateMovies":        apikey.ScopeCatalogueWrite,
+	"/movieinfo.rating.RatingService/GetRating":             apikey.ScopeReadOnly,
+	"/movieinfo.rating.RatingService/ListRatings":           apikey.ScopeReadOnly,
+	"/movieinfo.rating.RatingService/BatchGetUserRatings":   apikey.ScopeReadOnly,
+	"/movieinfo.rating.RatingService/GetMovieAverageRating": apikey.ScopeReadOnly,
+	"/movieinfo.rating.RatingService/WatchMovieRatings":     apikey.ScopeReadOnly,
+	"/movieinfo.rating.RatingService/HealthCheck":           apikey.ScopeReadOnly,
+	"/movieinfo.rating.RatingService/CreateRating":          apikey.ScopeRatingsWrite,
+	"/movieinfo.rating.RatingService/UpdateRating":          apikey.ScopeRatingsWrite,
+	"/movieinfo.rating.RatingService/DeleteRating":          apikey.ScopeRatingsWrite,
+	"/movieinfo.user.UserService/GetUser":                   apikey.ScopeReadOnly,
+	"/movieinfo.user.UserService/HealthCheck":               apikey.ScopeReadOnly,
+}
+
+// APIKeyService API密钥服务接口，用户只能管理自己的密钥
+type APIKeyService interface {
+	apikey.Authenticator
+	// CreateAPIKey 创建密钥，返回密钥记录和完整密钥，完整密钥只在创建时返回一次
+	// expiresAt 为 nil 时密钥永不过期
+	CreateAPIKey(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (*models.APIKey, string, error)
+	// ListAPIKeys 返回用户的全部密钥，包括已过期的密钥
+	ListAPIKeys(ctx context.Context, userID int64) ([]*models.APIKey, error)
+	// RevokeAPIKey 撤销密钥，之后使用该密钥的请求立即失败
+	RevokeAPIKey(ctx context.Context, userID, id int64) error
+}
+
+// apiKeyService API密钥服务实现
+type apiKeyService struct {
+	keyRepo  repository.APIKeyRepository
+	userRepo repository.UserRepository
+	roleRepo repository.RoleRepository
+}
+
+// NewAPIKeyService 创建API密钥服务
+func NewAPIKeyService(keyRepo repository.APIKeyRepository, userRepo repository.UserRepository, roleRepo repository.RoleRepository) APIKeyService {
+	return &apiKeyService{
+		keyRepo:  keyRepo,
+		userRepo: userRepo,
+		roleRepo: roleRepo,
+	}
+}
+
+// CreateAPIKey 创建密钥，使用API密钥认证的请求不能创建密钥
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (*models.APIKey, string, error) {
+	if err := selfAPIKeys(ctx, userID); err != nil {
+		return nil, "", err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxAPIKeyNameLength {
+		return nil, "", apperror.New(apperror.InvalidArgument, "invalid api key name").
+			WithField("name", fmt.Sprintf("must be 1 to %d characters", maxAPIKeyNameLength))
+	}
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", apperror.New(apperror.InvalidArgument, "invalid expiry").
+			WithField("expires_at", "must be in the future")
+	}
+
+	key, prefix, err := apikey.Generate()
+	if err != nil {
+		return nil, "", err
+	}
+	record := &models.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   apikey.Hash(key),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+	err = s.keyRepo.Create(ctx, record)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return nil, "", apperror.Newf(apperror.AlreadyExists, "api key %s already exists", name).
+			WithField("name", "name already in use")
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create api key for user %d: %w", userID, err)
+	}
+
+	logger.Infof("api key %d (%s) with scopes %v created for user %d", record.ID, prefix, scopes, userID)
+	return record, key, nil
+}
+
+// ListAPIKeys 列出密钥
+func (s *apiKeyService) ListAPIKeys(ctx context.Context, userID int64) ([]*models.APIKey, error) {
+	if err := selfAPIKeys(ctx, userID); err != nil {
+		return nil, err
+	}
+	keys, err := s.keyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys of user %d: %w", userID, err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey 撤销密钥，密钥记录直接删除
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, userID, id int64) error {
+	if err := selfAPIKeys(ctx, userID); err != nil {
+		return err
+	}
+	err := s.keyRepo.Delete(ctx, userID, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.Newf(apperror.NotFound, "api key %d not found", id).WithField("id", "unknown api key")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key %d of user %d: %w", id, userID, err)
+	}
+	logger.Infof("api key %d of user %d revoked", id, userID)
+	return nil
+}
+
+// Authenticate 校验密钥，返回密钥所属用户及其当前的角色
+// 密钥不存在、已过期或用户已被禁用时都返回 apikey.ErrInvalidKey，不区分具体原因
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (*apikey.Identity, error) {
+	prefix, err := apikey.Prefix(key)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.keyRepo.GetByPrefix(ctx, prefix)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apikey.ErrInvalidKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key %s: %w", prefix, err)
+	}
+	if subtle.ConstantTimeCompare([]byte(apikey.Hash(key)), []byte(record.KeyHash)) != 1 {
+		return nil, apikey.ErrInvalidKey
+	}
+	now := time.Now()
+	if record.ExpiresAt != nil && !now.Before(*record.ExpiresAt) {
+		return nil, apikey.ErrInvalidKey
+	}
+
+	user, err := s.userRepo.GetByID(ctx, record.UserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apikey.ErrInvalidKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner of api key %d: %w", record.ID, err)
+	}
+	if user.Status != models.UserStatusActive {
+		logger.Warnf("api key %d of disabled user %d rejected", record.ID, user.ID)
+		return nil, apikey.ErrInvalidKey
+	}
+	roles, err := s.roleRepo.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles of user %d: %w", user.ID, err)
+	}
+
+	// 使用记录只用于展示，写入失败不影响本次调用
+	if err := s.keyRepo.RecordUse(ctx, record.ID, now, auth.ClientIP(ctx), now.Add(-apiKeyUseInterval)); err != nil {
+		logger.Warnf("failed to record use of api key %d: %v", record.ID, err)
+	}
+
+	return &apikey.Identity{
+		KeyID:  record.ID,
+		UserID: user.ID,
+		Roles:  roles,
+		Scopes: record.Scopes,
+	}, nil
+}
+
+// selfAPIKeys 只能管理自己的密钥，且必须使用访问令牌认证，避免泄露的密钥被用来创建新的密钥
+func selfAPIKeys(ctx context.Context, userID int64) error {
+	if _, ok := apikey.KeyIDFromContext(ctx); ok {
+		return apperror.New(apperror.PermissionDenied, "api keys cannot manage api keys")
+	}
+	if caller, _ := auth.UserIDFromContext(ctx); caller != userID {
+		return apperror.New(apperror.PermissionDenied, "can only manage your own api keys")
+	}
+	return nil
+}
+
+// normalizeScopes 校验权限范围并去重，至少需要一个权限范围
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, apperror.New(apperror.InvalidArgument, "invalid scopes").
+			WithField("scopes", "at least one scope is required")
+	}
+	result := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if !apikey.ValidScope(scope) {
+			return nil, apperror.Newf(apperror.InvalidArgument, "invalid scope %s", scope).
+				WithField("scopes", "must be one of "+strings.Join(apikey.Scopes, ", "))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result, nil
+}
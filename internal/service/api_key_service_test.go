@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apikey"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+)
+
+// apiKeyFixture API密钥服务及其仓储
+type apiKeyFixture struct {
+	service  APIKeyService
+	keyRepo  repository.APIKeyRepository
+	userRepo repository.UserRepository
+}
+
+func newAPIKeyFixture(t *testing.T) *apiKeyFixture {
+	t.Helper()
+	db := newTestDB(t)
+	f := &apiKeyFixture{
+		keyRepo:  repository.NewAPIKeyRepository(db),
+		userRepo: repository.NewUserRepository(db),
+	}
+	f.service = NewAPIKeyService(f.keyRepo, f.userRepo, repository.NewRoleRepository(db))
+	return f
+}
+
+// createKey 为测试用户创建拥有 scopes 的密钥，返回密钥记录和完整密钥，每个测试只调用一次
+func (f *apiKeyFixture) createKey(t *testing.T, scopes ...string) (*models.APIKey, string) {
+	t.Helper()
+	record, key, err := f.service.CreateAPIKey(callerContext(testUserID), testUserID, "test", scopes, nil)
+	if err != nil {
+		t.Fatalf("CreateAPIKey() error = %v", err)
+	}
+	return record, key
+}
+
+func TestCreateAPIKeyScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		want   []string
+		code   string
+	}{
+		{name: "single scope", scopes: []string{apikey.ScopeReadOnly}, want: []string{apikey.ScopeReadOnly}},
+		{
+			name:   "duplicates removed",
+			scopes: []string{apikey.ScopeRatingsWrite, apikey.ScopeReadOnly, apikey.ScopeRatingsWrite},
+			want:   []string{apikey.ScopeRatingsWrite, apikey.ScopeReadOnly},
+		},
+		{name: "no scopes", code: apperror.InvalidArgument.String()},
+		{name: "unknown scope", scopes: []string{apikey.ScopeReadOnly, "admin"}, code: apperror.InvalidArgument.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAPIKeyFixture(t)
+			record, _, err := f.service.CreateAPIKey(callerContext(testUserID), testUserID, "ci", tt.scopes, nil)
+			if got := errCode(err); got != tt.code {
+				t.Fatalf("CreateAPIKey() = %q, want %q", got, tt.code)
+			}
+			if err == nil && !reflect.DeepEqual(record.Scopes, tt.want) {
+				t.Fatalf("scopes = %v, want %v", record.Scopes, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKeyManagementSelfOnly(t *testing.T) {
+	f := newAPIKeyFixture(t)
+	_, key := f.createKey(t, apikey.Scopes...)
+	identity, err := f.service.Authenticate(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "own keys with access token", ctx: callerContext(testUserID)},
+		{name: "other user's keys", ctx: callerContext(adminUserID, "admin"), want: apperror.PermissionDenied.String()},
+		{name: "anonymous", ctx: context.Background(), want: apperror.PermissionDenied.String()},
+		{
+			name: "own keys with api key",
+			ctx:  apikey.WithKeyID(callerContext(testUserID), identity.KeyID),
+			want: apperror.PermissionDenied.String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := f.service.CreateAPIKey(tt.ctx, testUserID, tt.name, []string{apikey.ScopeReadOnly}, nil)
+			if got := errCode(err); got != tt.want {
+				t.Fatalf("CreateAPIKey() = %q, want %q", got, tt.want)
+			}
+			_, err = f.service.ListAPIKeys(tt.ctx, testUserID)
+			if got := errCode(err); got != tt.want {
+				t.Fatalf("ListAPIKeys() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuthenticate(t *testing.T) {
+	tests := []struct {
+		name string
+		// key 准备并返回要校验的密钥
+		key     func(t *testing.T, f *apiKeyFixture) string
+		wantErr error
+	}{
+		{
+			name: "valid key",
+			key: func(t *testing.T, f *apiKeyFixture) string {
+				_, key := f.createKey(t, apikey.ScopeRatingsWrite)
+				return key
+			},
+		},
+		{
+			name: "wrong secret",
+			key: func(t *testing.T, f *apiKeyFixture) string {
+				_, key := f.createKey(t, apikey.ScopeRatingsWrite)
+				return key + "x"
+			},
+			wantErr: apikey.ErrInvalidKey,
+		},
+		{
+			name: "revoked key",
+			key: func(t *testing.T, f *apiKeyFixture) string {
+				record, key := f.createKey(t, apikey.ScopeRatingsWrite)
+				if err := f.service.RevokeAPIKey(callerContext(testUserID), testUserID, record.ID); err != nil {
+					t.Fatalf("RevokeAPIKey() error = %v", err)
+				}
+				return key
+			},
+			wantErr: apikey.ErrInvalidKey,
+		},
+		{
+			name: "expired key",
+			key: func(t *testing.T, f *apiKeyFixture) string {
+				key, prefix, err := apikey.Generate()
+				if err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+				expired := time.Now().Add(-time.Minute)
+				record := &models.APIKey{UserID: testUserID, Name: "expired", Prefix: prefix, KeyHash: apikey.Hash(key),
+					Scopes: []string{apikey.ScopeRatingsWrite}, ExpiresAt: &expired, CreatedAt: time.Now().Add(-time.Hour)}
+				if err := f.keyRepo.Create(context.Background(), record); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				return key
+			},
+			wantErr: apikey.ErrInvalidKey,
+		},
+		{
+			name: "owner disabled",
+			key: func(t *testing.T, f *apiKeyFixture) string {
+				_, key := f.createKey(t, apikey.ScopeRatingsWrite)
+				user := &models.User{ID: testUserID, Status: models.UserStatusInactive}
+				if err := f.userRepo.UpdateColumns(context.Background(), user, []string{"status"}); err != nil {
+					t.Fatalf("UpdateColumns() error = %v", err)
+				}
+				return key
+			},
+			wantErr: apikey.ErrInvalidKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAPIKeyFixture(t)
+			identity, err := f.service.Authenticate(context.Background(), tt.key(t, f))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if identity.UserID != testUserID || !reflect.DeepEqual(identity.Scopes, []string{apikey.ScopeRatingsWrite}) {
+				t.Fatalf("Authenticate() identity = %+v", identity)
+			}
+		})
+	}
+}
+
+// TestAPIKeyScopes 按 APIKeyScopes 检查各权限范围的密钥能调用的方法
+func TestAPIKeyScopes(t *testing.T) {
+	const (
+		getMovie       = "/movieinfo.movie.MovieService/GetMovie"
+		createMovie    = "/movieinfo.movie.MovieService/CreateMovie"
+		createRating   = "/movieinfo.rating.RatingService/CreateRating"
+		changePassword = "/movieinfo.user.UserService/ChangePassword"
+	)
+	denied := apperror.PermissionDenied.String()
+	tests := []struct {
+		name   string
+		scopes []string
+		// want 各方法的期望结果
+		want map[string]string
+	}{
+		{
+			name:   "read-only",
+			scopes: []string{apikey.ScopeReadOnly},
+			want:   map[string]string{getMovie: "", createMovie: denied, createRating: denied, changePassword: denied},
+		},
+		{
+			name:   "catalogue-write",
+			scopes: []string{apikey.ScopeCatalogueWrite},
+			want:   map[string]string{getMovie: "", createMovie: "", createRating: denied, changePassword: denied},
+		},
+		{
+			name:   "ratings-write",
+			scopes: []string{apikey.ScopeRatingsWrite},
+			want:   map[string]string{getMovie: "", createMovie: denied, createRating: "", changePassword: denied},
+		},
+		{
+			name:   "all scopes",
+			scopes: apikey.Scopes,
+			want:   map[string]string{getMovie: "", createMovie: "", createRating: "", changePassword: denied},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAPIKeyFixture(t)
+			_, key := f.createKey(t, tt.scopes...)
+			interceptor := apikey.NewInterceptor(f.service, APIKeyScopes).UnaryServerInterceptor()
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(apikey.Header, key))
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+
+			for method, want := range tt.want {
+				_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+				if got := errCode(err); got != want {
+					t.Errorf("%s: got %q, want %q", method, got, want)
+				}
+			}
+		})
+	}
+}
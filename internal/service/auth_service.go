@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/lockout"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+	"github.com/3inchtime/movieinfo/pkg/password"
+)
+
+// LoginResult 登录结果，需要两步验证时只返回 Challenge，不签发令牌
+type LoginResult struct {
+	User          *models.User
+	Tokens        *auth.TokenPair
+	Challenge     *TwoFactorChallenge
+	RecoveryCodes []string // 登录时完成两步验证登记生成的恢复码
+}
+
+// AuthService 认证服务接口
+type AuthService interface {
+	// Login 使用用户名或邮箱和密码登录，签发访问令牌和刷新令牌
+	Login(ctx context.Context, username, password string) (*LoginResult, error)
+	// VerifyTwoFactor 提交登录挑战令牌和动态码或恢复码，完成两步验证登录
+	VerifyTwoFactor(ctx context.Context, challengeToken, code string) (*LoginResult, error)
+	// CompleteOIDCLogin 使用身份提供方回调中的 state 和授权码登录，与密码登录一样检查邮箱验证和两步验证
+	CompleteOIDCLogin(ctx context.Context, state, code string) (*LoginResult, error)
+	// RefreshToken 使用刷新令牌换取新的令牌对，旧的刷新令牌随之失效
+	RefreshToken(ctx context.Context, refreshToken string) (*auth.TokenPair, error)
+	// Logout 注销访问令牌和刷新令牌所在的会话
+	Logout(ctx context.Context, accessToken, refreshToken string) error
+	// UnlockUser 清除用户的密码和两步验证动态码失败记录，解除锁定
+	UnlockUser(ctx context.Context, userID int64) error
+}
+
+// authService 认证服务实现
+type authService struct {
+	userRepo     repository.UserRepository
+	sessions     *auth.Sessions
+	verification EmailVerificationService
+	guard        *lockout.Guard
+	hasher       *password.Hasher
+	twoFactor    TwoFactorService
+	oidc         OIDCService
+	// dummyHash 用户不存在时用于比较的密码哈希，使用当前参数生成，使登录耗时与用户存在时一致
+	dummyHash string
+}
+
+// NewAuthService 创建认证服务
+func NewAuthService(userRepo repository.UserRepository, sessions *auth.Sessions, verification EmailVerificationService,
+	guard *lockout.Guard, hasher *password.Hasher, twoFactor TwoFactorService, oidc OIDCService) (AuthService, error) {
+	dummyHash, err := hasher.Hash("movieinfo-dummy-password")
+	if err != nil {
+		return nil, err
+	}
+	return &authService{
+		userRepo:     userRepo,
+		sessions:     sessions,
+		verification: verification,
+		guard:        guard,
+		hasher:       hasher,
+		twoFactor:    twoFactor,
+		oidc:         oidc,
+		dummyHash:    dummyHash,
+	}, nil
+}
+
+// Login 登录，用户不存在和密码错误返回相同的错误，避免暴露用户名是否存在
+// 失败次数按账号和客户端IP统计，不存在的用户名同样计数和锁定，锁定期间不校验密码；
+// 尝试在校验密码之前占用，并发请求不能越过失败次数限制
+func (s *authService) Login(ctx context.Context, username, password string) (*LoginResult, error) {
+	user, err := s.findUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := auth.ClientIP(ctx)
+	account := loginAccount(user, username)
+	if wait := s.guard.Reserve(ctx, account, ip); wait > 0 {
+		return nil, errTooManyAttempts(wait)
+	}
+
+	hash := s.dummyHash
+	if user != nil {
+		hash = user.PasswordHash
+	}
+	ok, needsRehash, err := s.hasher.Verify(password, hash)
+	if err != nil && user != nil {
+		// 哈希格式无法识别，按密码错误处理
+		logger.Errorf("failed to verify password of user %d: %v", user.ID, err)
+	}
+	if !ok || user == nil {
+		return nil, errInvalidCredentials()
+	}
+	if err := s.guard.Succeed(ctx, account, ip); err != nil {
+		logger.Warnf("failed to reset login failures of user %d: %v", user.ID, err)
+	}
+	if needsRehash {
+		s.rehash(ctx, user, password)
+	}
+	return s.authenticated(ctx, user)
+}
+
+// CompleteOIDCLogin 第三方身份登录，不计入密码失败次数
+func (s *authService) CompleteOIDCLogin(ctx context.Context, state, code string) (*LoginResult, error) {
+	user, err := s.oidc.Authenticate(ctx, state, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.authenticated(ctx, user)
+}
+
+// authenticated 身份校验通过后检查用户状态和邮箱验证，启用两步验证时签发挑战，否则创建会话
+func (s *authService) authenticated(ctx context.Context, user *models.User) (*LoginResult, error) {
+	if user.Status != models.UserStatusActive {
+		return nil, apperror.New(apperror.PermissionDenied, "user is disabled")
+	}
+	if err := s.verification.RequireVerified(ctx, user.ID, ActionLogin); err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.twoFactor.Challenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &LoginResult{User: user, Challenge: challenge}, nil
+	}
+	return s.completeLogin(ctx, user)
+}
+
+// VerifyTwoFactor 两步验证登录，挑战签发后被禁用的用户不能完成登录
+func (s *authService) VerifyTwoFactor(ctx context.Context, challengeToken, code string) (*LoginResult, error) {
+	user, recoveryCodes, err := s.twoFactor.VerifyChallenge(ctx, challengeToken, code)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != models.UserStatusActive {
+		return nil, apperror.New(apperror.PermissionDenied, "user is disabled")
+	}
+
+	result, err := s.completeLogin(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	result.RecoveryCodes = recoveryCodes
+	return result, nil
+}
+
+// completeLogin 创建会话并记录登录历史
+func (s *authService) completeLogin(ctx context.Context, user *models.User) (*LoginResult, error) {
+	tokens, err := s.sessions.Create(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	// 登录历史写入失败不影响登录
+	if err := s.userRepo.RecordLogin(ctx, user.ID, auth.ClientIP(ctx), auth.UserAgent(ctx)); err != nil {
+		logger.Warnf("failed to record login of user %d: %v", user.ID, err)
+	}
+	return &LoginResult{User: user, Tokens: tokens}, nil
+}
+
+// rehash 密码哈希的算法或参数不是当前配置时使用当前配置重新哈希，失败不影响登录，下次登录时重试
+func (s *authService) rehash(ctx context.Context, user *models.User, password string) {
+	hash, err := s.hasher.Hash(password)
+	if err == nil {
+		user.PasswordHash = hash
+		err = s.userRepo.UpdateColumns(ctx, user, []string{"password_hash"})
+	}
+	if err != nil {
+		logger.Warnf("failed to rehash password of user %d: %v", user.ID, err)
+		return
+	}
+	logger.Infof("password hash of user %d upgraded to current parameters", user.ID)
+}
+
+// RefreshToken 刷新令牌，用户已被禁用时注销新签发的令牌
+func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*auth.TokenPair, error) {
+	tokens, err := s.sessions.Refresh(ctx, refreshToken)
+	switch {
+	case errors.Is(err, auth.ErrTokenReused):
+		return nil, apperror.New(apperror.Unauthenticated, "refresh token reuse detected, please log in again")
+	case errors.Is(err, auth.ErrInvalidToken):
+		return nil, apperror.New(apperror.Unauthenticated, "invalid or expired refresh token")
+	case err != nil:
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, tokens.UserID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if user == nil || user.Status != models.UserStatusActive {
+		if err := s.sessions.Logout(ctx, tokens.AccessToken, tokens.RefreshToken); err != nil {
+			return nil, fmt.Errorf("failed to revoke session of disabled user %d: %w", tokens.UserID, err)
+		}
+		return nil, apperror.New(apperror.PermissionDenied, "user is disabled")
+	}
+	return tokens, nil
+}
+
+// Logout 登出，访问令牌签名无效时返回错误
+func (s *authService) Logout(ctx context.Context, accessToken, refreshToken string) error {
+	err := s.sessions.Logout(ctx, accessToken, refreshToken)
+	if errors.Is(err, auth.ErrInvalidToken) {
+		return apperror.New(apperror.Unauthenticated, "invalid access token")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to log out: %w", err)
+	}
+	return nil
+}
+
+// UnlockUser 解除用户的登录锁定，客户端IP的失败记录不受影响
+func (s *authService) UnlockUser(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return userError(err)
+	}
+	if err := s.guard.Reset(ctx, loginAccount(user, "")); err != nil {
+		return err
+	}
+	if err := s.guard.Reset(ctx, twoFactorAccount(user.ID)); err != nil {
+		return err
+	}
+
+	operator, _ := auth.UserIDFromContext(ctx)
+	logger.Infof("login lockout of user %d cleared by user %d", userID, operator)
+	return nil
+}
+
+// findUser 按用户名或邮箱查找用户，不存在时返回 nil
+func (s *authService) findUser(ctx context.Context, username string) (*models.User, error) {
+	var (
+		user *models.User
+		err  error
+	)
+	if strings.Contains(username, "@") {
+		user, err = s.userRepo.GetByEmail(ctx, normalizeEmail(username))
+	} else {
+		user, err = s.userRepo.GetByUsername(ctx, username)
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// errInvalidCredentials 用户名或密码错误
+func errInvalidCredentials() error {
+	return apperror.New(apperror.Unauthenticated, "invalid username or password")
+}
+
+// errTooManyAttempts 登录失败次数过多，账号存在与否返回相同的错误
+func errTooManyAttempts(wait time.Duration) error {
+	seconds := int64((wait + time.Second - 1) / time.Second)
+	return apperror.Newf(apperror.TooManyAttempts, "too many failed login attempts, retry after %ds", seconds)
+}
+
+// loginAccount 登录失败计数的账号键，用户存在时按用户ID计数，用户名和邮箱登录共用同一计数
+func loginAccount(user *models.User, username string) string {
+	if user != nil {
+		return "user:" + strconv.FormatInt(user.ID, 10)
+	}
+	return "name:" + strings.ToLower(username)
+}
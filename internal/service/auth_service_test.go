@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/lockout"
+	"github.com/3inchtime/movieinfo/pkg/onetimecode"
+)
+
+// newTestAuth 创建使用内存会话、不限制登录失败次数的认证服务
+func newTestAuth(t *testing.T, db *sql.DB) AuthService {
+	t.Helper()
+	sessions, err := auth.NewSessions(&auth.Config{
+		Secret:            "test-secret",
+		ExpireTime:        time.Minute,
+		Issuer:            "movieinfo",
+		RefreshExpireTime: time.Hour,
+		Store:             "memory",
+	}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewSessions() error = %v", err)
+	}
+	repo := repository.NewUserRepository(db)
+	guard := lockout.New(&lockout.Config{}, lockout.NewMemoryStore())
+	twoFactor := NewTwoFactorService(&TwoFactorConfig{Skew: 1, RecoveryCodes: 3}, repo, repository.NewTOTPRepository(db),
+		onetimecode.New(&onetimecode.Config{}, onetimecode.NewMemoryStore()), guard)
+	// 密码登录不经过第三方登录服务
+	s, err := NewAuthService(repo, sessions, &fakeVerification{}, guard, newTestHasher(t), twoFactor, nil)
+	if err != nil {
+		t.Fatalf("NewAuthService() error = %v", err)
+	}
+	return s
+}
+
+// setPassword 将用户密码设置为给定的哈希
+func setPassword(t *testing.T, repo repository.UserRepository, userID int64, hash string) {
+	t.Helper()
+	user, err := repo.GetByID(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	user.PasswordHash = hash
+	if err := repo.UpdateColumns(context.Background(), user, []string{"password_hash"}); err != nil {
+		t.Fatalf("UpdateColumns() error = %v", err)
+	}
+}
+
+func TestLoginRehashesBcrypt(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := repository.NewUserRepository(db)
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+	setPassword(t, repo, testUserID, string(bcryptHash))
+	s := newTestAuth(t, db)
+
+	if _, err := s.Login(ctx, "testuser", "wrong password"); errCode(err) != apperror.Unauthenticated.String() {
+		t.Fatalf("Login() with wrong password = %q, want %q", errCode(err), apperror.Unauthenticated)
+	}
+	if user, _ := repo.GetByID(ctx, testUserID); user.PasswordHash != string(bcryptHash) {
+		t.Fatal("failed login changed the password hash")
+	}
+
+	if _, err := s.Login(ctx, testEmail, "password123"); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	user, err := repo.GetByID(ctx, testUserID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !strings.HasPrefix(user.PasswordHash, "$argon2id$") {
+		t.Fatalf("password hash after login = %q, want argon2id", user.PasswordHash)
+	}
+
+	// 升级后的哈希可以继续登录，且不再重新哈希
+	upgraded := user.PasswordHash
+	if _, err := s.Login(ctx, "testuser", "password123"); err != nil {
+		t.Fatalf("Login() after rehash error = %v", err)
+	}
+	if user, _ := repo.GetByID(ctx, testUserID); user.PasswordHash != upgraded {
+		t.Fatal("current argon2id hash was rehashed again")
+	}
+}
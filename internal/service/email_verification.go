@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+	"github.com/3inchtime/movieinfo/pkg/mailer"
+	"github.com/3inchtime/movieinfo/pkg/onetimecode"
+	"github.com/3inchtime/movieinfo/pkg/ratelimit"
+)
+
+// emailVerificationPurpose 邮箱验证令牌的用途
+const emailVerificationPurpose = "email_verification"
+
+// 可以要求邮箱已验证的操作，对应 email_verification.require_verified_for
+const (
+	ActionLogin  = "login"
+	ActionRating = "rating"
+)
+
+// EmailVerificationConfig 邮箱验证配置
+type EmailVerificationConfig struct {
+	Store              string        `yaml:"store" validate:"omitempty,oneof=memory redis"` // 验证令牌和重发限流的存储
+	KeyPrefix          string        `yaml:"key_prefix"`
+	TTL                time.Duration `yaml:"ttl"`                                                     // 验证链接有效期
+	VerifyURL          string        `yaml:"verify_url" validate:"omitempty,url"`                     // 邮件中验证页面的地址
+	ResendInterval     time.Duration `yaml:"resend_interval"`                                         // 重发邮件的平均间隔
+	ResendBurst        int           `yaml:"resend_burst" validate:"min=0"`                           // 允许连续重发的次数
+	RequireVerifiedFor []string      `yaml:"require_verified_for" validate:"dive,oneof=login rating"` // 邮箱验证前禁止的操作
+}
+
+// EmailVerificationService 邮箱验证服务
+type EmailVerificationService interface {
+	// SendVerification 向用户当前的邮箱发送验证链接，之前发送的链接随之失效
+	SendVerification(ctx context.Context, user *models.User) error
+	// ResendVerification 重新发送验证邮件，按邮箱限制频率
+	// 邮箱未注册、账号已禁用或邮箱已验证时不发送但同样返回成功
+	ResendVerification(ctx context.Context, email string) error
+	// VerifyEmail 使用验证链接中的令牌验证邮箱，返回更新后的用户
+	VerifyEmail(ctx context.Context, token string) (*models.User, error)
+	// RequireVerified 配置要求 action 前验证邮箱时，检查用户邮箱是否已验证
+	RequireVerified(ctx context.Context, userID int64, action string) error
+}
+
+// emailVerificationService 邮箱验证服务实现
+type emailVerificationService struct {
+	config   *EmailVerificationConfig
+	userRepo repository.UserRepository
+	tokens   *onetimecode.Codes
+	limiter  ratelimit.Limiter
+	mailer   mailer.Mailer
+}
+
+// NewEmailVerificationService 创建邮箱验证服务
+func NewEmailVerificationService(config *EmailVerificationConfig, userRepo repository.UserRepository,
+	tokens *onetimecode.Codes, limiter ratelimit.Limiter, m mailer.Mailer) EmailVerificationService {
+	return &emailVerificationService{
+		config:   config,
+		userRepo: userRepo,
+		tokens:   tokens,
+		limiter:  limiter,
+		mailer:   m,
+	}
+}
+
+// SendVerification 签发验证令牌并发送验证邮件
+// 令牌绑定用户ID和当前邮箱，邮箱变更后旧邮箱收到的链接不再有效
+func (s *emailVerificationService) SendVerification(ctx context.Context, user *models.User) error {
+	secret, err := s.tokens.IssueToken(ctx, emailVerificationPurpose, verificationSubject(user))
+	if err != nil {
+		return fmt.Errorf("failed to issue verification token: %w", err)
+	}
+
+	link, err := s.verifyLink(strconv.FormatInt(user.ID, 10) + "." + secret)
+	if err != nil {
+		return err
+	}
+
+	msg := &mailer.Message{
+		To:      user.Email,
+		Subject: "MovieInfo 邮箱验证",
+		Body: fmt.Sprintf("%s，你好：\n\n请打开下面的链接验证你的邮箱，链接 %d 小时内有效：\n%s\n\n如果不是你本人注册，请忽略这封邮件。\n",
+			user.Username, int(s.tokens.TTL().Hours()), link),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
+
+// ResendVerification 重新发送验证邮件
+func (s *emailVerificationService) ResendVerification(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+
+	// 按邮箱限流，与邮箱是否注册无关，避免通过限流结果判断邮箱是否存在
+	rate := 1 / s.config.ResendInterval.Seconds()
+	allowed, wait, err := s.limiter.Allow(ctx, "resend:"+email, rate, s.config.ResendBurst)
+	if err != nil {
+		return fmt.Errorf("failed to check resend limit: %w", err)
+	}
+	if !allowed {
+		return apperror.Newf(apperror.ResourceExhausted, "verification email sent recently, retry after %ds",
+			int64((wait+time.Second-1)/time.Second))
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, repository.ErrNotFound) {
+		logger.Infof("verification email requested for unknown email")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.Status != models.UserStatusActive || user.EmailVerified {
+		logger.Infof("verification email requested for inactive or verified user %d", user.ID)
+		return nil
+	}
+
+	return s.SendVerification(ctx, user)
+}
+
+// VerifyEmail 验证邮箱，令牌格式为 <用户ID>.<随机串>
+func (s *emailVerificationService) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	id, secret, ok := strings.Cut(token, ".")
+	userID, err := strconv.ParseInt(id, 10, 64)
+	if !ok || err != nil || userID <= 0 || secret == "" {
+		return nil, verificationTokenError(onetimecode.ErrInvalid)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, verificationTokenError(onetimecode.ErrInvalid)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokens.Consume(ctx, emailVerificationPurpose, verificationSubject(user), secret); err != nil {
+		return nil, verificationTokenError(err)
+	}
+
+	if !user.EmailVerified {
+		user.EmailVerified = true
+		if err := s.userRepo.UpdateColumns(ctx, user, []string{"email_verified"}); err != nil {
+			return nil, fmt.Errorf("failed to mark email of user %d verified: %w", user.ID, err)
+		}
+		logger.Infof("email of user %d verified", user.ID)
+	}
+	return user, nil
+}
+
+// RequireVerified 检查用户邮箱是否已验证，未配置限制的操作直接放行
+func (s *emailVerificationService) RequireVerified(ctx context.Context, userID int64, action string) error {
+	if !s.requires(action) {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return userError(err)
+	}
+	if !user.EmailVerified {
+		return apperror.Newf(apperror.PermissionDenied, "email must be verified before %s", action).
+			WithField("email_verified", "email is not verified")
+	}
+	return nil
+}
+
+// requires 判断配置是否要求 action 前验证邮箱
+func (s *emailVerificationService) requires(action string) bool {
+	for _, a := range s.config.RequireVerifiedFor {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyLink 将令牌作为 token 参数追加到验证页面地址
+func (s *emailVerificationService) verifyLink(token string) (string, error) {
+	u, err := url.Parse(s.config.VerifyURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid verify url: %w", err)
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// verificationSubject 验证令牌绑定的对象，包含用户ID和邮箱
+func verificationSubject(user *models.User) string {
+	return strconv.FormatInt(user.ID, 10) + ":" + normalizeEmail(user.Email)
+}
+
+// verificationTokenError 将令牌错误转换为业务错误
+func verificationTokenError(err error) error {
+	if errors.Is(err, onetimecode.ErrInvalid) || errors.Is(err, onetimecode.ErrTooManyAttempts) {
+		return apperror.New(apperror.InvalidArgument, "invalid or expired verification link").
+			WithField("token", "invalid or expired verification link")
+	}
+	return err
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/onetimecode"
+	"github.com/3inchtime/movieinfo/pkg/ratelimit"
+)
+
+// verifyLinkPattern 邮件正文中的验证链接
+var verifyLinkPattern = regexp.MustCompile(`https?://\S+`)
+
+// newTestEmailVerification 创建使用内存存储的邮箱验证服务，测试用户的邮箱初始为未验证
+func newTestEmailVerification(t *testing.T, requireFor ...string) (*emailVerificationService, repository.UserRepository, *fakeMailer) {
+	t.Helper()
+	config := &EmailVerificationConfig{
+		TTL:                time.Hour,
+		VerifyURL:          "http://localhost:8080/verify-email",
+		ResendInterval:     time.Hour,
+		ResendBurst:        2,
+		RequireVerifiedFor: requireFor,
+	}
+	repo := repository.NewUserRepository(newTestDB(t))
+	user, err := repo.GetByID(context.Background(), testUserID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	user.EmailVerified = false
+	if err := repo.UpdateColumns(context.Background(), user, []string{"email_verified"}); err != nil {
+		t.Fatalf("UpdateColumns() error = %v", err)
+	}
+
+	m := &fakeMailer{}
+	s := NewEmailVerificationService(config, repo, onetimecode.New(&onetimecode.Config{TTL: config.TTL},
+		onetimecode.NewMemoryStore()), ratelimit.NewMemoryLimiter(), m)
+	return s.(*emailVerificationService), repo, m
+}
+
+// verificationToken 从最近一封验证邮件的链接中读取令牌
+func verificationToken(t *testing.T, m *fakeMailer) string {
+	t.Helper()
+	link, err := url.Parse(verifyLinkPattern.FindString(m.last(t).Body))
+	if err != nil {
+		t.Fatalf("invalid verify link: %v", err)
+	}
+	token := link.Query().Get("token")
+	if token == "" {
+		t.Fatalf("no token in mail body %q", m.last(t).Body)
+	}
+	return token
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	s, repo, m := newTestEmailVerification(t)
+	user, _ := repo.GetByID(ctx, testUserID)
+
+	if err := s.SendVerification(ctx, user); err != nil {
+		t.Fatalf("SendVerification() error = %v", err)
+	}
+	token := verificationToken(t, m)
+
+	invalid := apperror.InvalidArgument.String()
+	for _, bad := range []string{"", "abc", "2.", "0." + token, "99." + token[2:]} {
+		if _, err := s.VerifyEmail(ctx, bad); errCode(err) != invalid {
+			t.Fatalf("VerifyEmail(%q) = %q, want %q", bad, errCode(err), invalid)
+		}
+	}
+
+	verified, err := s.VerifyEmail(ctx, token)
+	if err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if !verified.EmailVerified {
+		t.Fatal("VerifyEmail() did not mark the email verified")
+	}
+	if _, err := s.VerifyEmail(ctx, token); errCode(err) != invalid {
+		t.Fatalf("reused VerifyEmail() = %q, want %q", errCode(err), invalid)
+	}
+}
+
+func TestVerifyEmailAfterEmailChange(t *testing.T) {
+	ctx := context.Background()
+	s, repo, m := newTestEmailVerification(t)
+	user, _ := repo.GetByID(ctx, testUserID)
+
+	if err := s.SendVerification(ctx, user); err != nil {
+		t.Fatalf("SendVerification() error = %v", err)
+	}
+	token := verificationToken(t, m)
+
+	// 旧邮箱收到的链接在修改邮箱后失效
+	user.Email = "ripley@example.com"
+	if err := repo.UpdateColumns(ctx, user, []string{"email"}); err != nil {
+		t.Fatalf("UpdateColumns() error = %v", err)
+	}
+	if _, err := s.VerifyEmail(ctx, token); errCode(err) != apperror.InvalidArgument.String() {
+		t.Fatalf("VerifyEmail() with old email link = %q, want %q", errCode(err), apperror.InvalidArgument)
+	}
+}
+
+func TestResendVerificationThrottle(t *testing.T) {
+	tests := []struct {
+		name      string
+		emails    []string
+		want      []string
+		wantMails int
+	}{
+		{
+			name:      "unverified email within burst",
+			emails:    []string{testEmail, " TEST@movieinfo.com"},
+			want:      []string{"", ""},
+			wantMails: 2,
+		},
+		{
+			name:      "unverified email over burst",
+			emails:    []string{testEmail, testEmail, testEmail},
+			want:      []string{"", "", apperror.ResourceExhausted.String()},
+			wantMails: 2,
+		},
+		{
+			name:      "unknown and verified emails are throttled the same way",
+			emails:    []string{"admin@movieinfo.com", "admin@movieinfo.com", "admin@movieinfo.com"},
+			want:      []string{"", "", apperror.ResourceExhausted.String()},
+			wantMails: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, m := newTestEmailVerification(t)
+			for i, email := range tt.emails {
+				if got := errCode(s.ResendVerification(context.Background(), email)); got != tt.want[i] {
+					t.Fatalf("ResendVerification(%q) #%d = %q, want %q", email, i+1, got, tt.want[i])
+				}
+			}
+			if got := m.count(); got != tt.wantMails {
+				t.Fatalf("sent %d mails, want %d", got, tt.wantMails)
+			}
+		})
+	}
+}
+
+func TestRequireVerified(t *testing.T) {
+	ctx := context.Background()
+	s, repo, _ := newTestEmailVerification(t, ActionRating)
+
+	denied := apperror.PermissionDenied.String()
+	if got := errCode(s.RequireVerified(ctx, testUserID, ActionRating)); got != denied {
+		t.Fatalf("RequireVerified(rating) = %q, want %q", got, denied)
+	}
+	if got := errCode(s.RequireVerified(ctx, testUserID, ActionLogin)); got != "" {
+		t.Fatalf("RequireVerified(login) = %q, want success when not required", got)
+	}
+
+	user := &models.User{ID: testUserID, EmailVerified: true}
+	if err := repo.UpdateColumns(ctx, user, []string{"email_verified"}); err != nil {
+		t.Fatalf("UpdateColumns() error = %v", err)
+	}
+	if got := errCode(s.RequireVerified(ctx, testUserID, ActionRating)); got != "" {
+		t.Fatalf("RequireVerified(rating) after verification = %q, want success", got)
+	}
+}
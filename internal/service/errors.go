@@ -0,0 +1,25 @@
+package service
+
+import "github.com/3inchtime/movieinfo/pkg/apperror"
+
+// maxBatchSize 批量接口单次请求允许的最大ID数量
+const maxBatchSize = 100
+
+// errorDetails 将错误转换为 ErrorDetail 列表，非业务错误不暴露内部信息
+func errorDetails(err error) []apperror.ErrorDetail {
+	appErr, ok := apperror.As(err)
+	if !ok {
+		return []apperror.ErrorDetail{{
+			Code:    apperror.InternalError,
+			Message: "internal error",
+		}}
+	}
+
+	if len(appErr.Details) > 0 {
+		return appErr.Details
+	}
+	return []apperror.ErrorDetail{{
+		Code:    appErr.Code,
+		Message: appErr.Message,
+	}}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/mailer"
+	"github.com/3inchtime/movieinfo/pkg/password"
+)
+
+// 初始数据中的示例用户，admin 拥有管理员角色
+const (
+	adminUserID = int64(1)
+	testUserID  = int64(2)
+	testEmail   = "test@movieinfo.com"
+)
+
+// newTestDB 创建写入了初始数据的内存SQLite数据库
+// 不经过 database.Open，避免每个测试重复注册同名的连接池指标
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file::memory:?_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	// 每个内存数据库连接都是独立的数据库，只保留一个连接
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+	t.Cleanup(func() { db.Close() })
+	if err := repository.MigrateSQLite(context.Background(), db); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	return db
+}
+
+// newTestHasher 使用最低开销的参数，避免测试变慢
+func newTestHasher(t *testing.T) *password.Hasher {
+	t.Helper()
+	hasher, err := password.NewHasher(&password.HashConfig{Memory: 1024, Iterations: 1, Parallelism: 1})
+	if err != nil {
+		t.Fatalf("failed to create hasher: %v", err)
+	}
+	return hasher
+}
+
+// newTestPolicy 只要求最小长度的密码策略
+func newTestPolicy(t *testing.T) *password.Policy {
+	t.Helper()
+	policy, err := password.NewPolicy(&password.PolicyConfig{MinLength: 8})
+	if err != nil {
+		t.Fatalf("failed to create password policy: %v", err)
+	}
+	return policy
+}
+
+// callerContext 返回以 userID 身份、携带 roles 角色调用的上下文
+func callerContext(userID int64, roles ...string) context.Context {
+	return auth.WithRoles(auth.WithUserID(context.Background(), userID), roles)
+}
+
+// errCode 返回错误中业务错误的代码，成功时返回空字符串，其他错误返回错误信息
+func errCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	if appErr, ok := apperror.As(err); ok {
+		return appErr.Code.String()
+	}
+	return "unexpected error: " + err.Error()
+}
+
+// errFields 返回业务错误详情中的字段
+func errFields(err error) []string {
+	appErr, ok := apperror.As(err)
+	if !ok {
+		return nil
+	}
+	var fields []string
+	for _, detail := range appErr.Details {
+		fields = append(fields, detail.Field)
+	}
+	return fields
+}
+
+// fakeMailer 记录发送的邮件
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []*mailer.Message
+}
+
+func (m *fakeMailer) Send(ctx context.Context, msg *mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// count 返回已发送的邮件数
+func (m *fakeMailer) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sent)
+}
+
+// last 返回最近发送的邮件
+func (m *fakeMailer) last(t *testing.T) *mailer.Message {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.sent) == 0 {
+		t.Fatal("no mail sent")
+	}
+	return m.sent[len(m.sent)-1]
+}
+
+// fakeRevocations 记录注销全部会话的用户
+type fakeRevocations struct {
+	mu      sync.Mutex
+	revoked map[int64]int
+}
+
+func (r *fakeRevocations) RevokeAll(ctx context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.revoked == nil {
+		r.revoked = make(map[int64]int)
+	}
+	r.revoked[userID]++
+	return nil
+}
+
+func (r *fakeRevocations) RevokedAt(ctx context.Context, userID int64) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+// count 返回用户被注销全部会话的次数
+func (r *fakeRevocations) count(userID int64) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.revoked[userID]
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/fieldmask"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// movieUpdatePolicy UpdateMovieRequest.update_mask 的字段掩码策略
+var movieUpdatePolicy = fieldmask.Policy{
+	Columns: map[string]string{
+		"title":        "title",
+		"description":  "description",
+		"poster_url":   "poster_url",
+		"duration":     "duration",
+		"release_date": "release_date",
+		"language":     "language",
+		"genres":       repository.MovieGenresColumn,
+		"directors":    "director",
+		"actors":       "actors",
+	},
+	Immutable: []string{"id", "average_rating", "rating_count", "created_at", "updated_at"},
+}
+
+// bulkProgressInterval 批量创建时每处理多少部电影记录一次进度
+const bulkProgressInterval = 100
+
+// MovieSource 批量创建时的电影来源，读取完毕时返回 io.EOF
+type MovieSource func() (*models.Movie, error)
+
+// BulkCreateResult 批量创建电影的结果
+type BulkCreateResult struct {
+	Received   int               // 收到的电影数量
+	CreatedIDs []int64           // 创建成功的电影ID
+	Errors     []BulkCreateError // 创建失败的电影
+}
+
+// BulkCreateError 单部电影创建失败的信息
+type BulkCreateError struct {
+	Index   int // 在流中的序号，从0开始
+	Details []apperror.ErrorDetail
+}
+
+// MovieService 电影服务接口
+type MovieService interface {
+	GetMovie(ctx context.Context, id int64) (*models.Movie, error)
+	CreateMovie(ctx context.Context, movie *models.Movie) (*models.Movie, error)
+	// UpdateMovie 更新电影，paths 为 update_mask 中的字段路径
+	UpdateMovie(ctx context.Context, movie *models.Movie, paths []string) (*models.Movie, error)
+	// BatchGetMovies 批量获取电影，返回的电影与 ids 顺序一致，并返回不存在的ID
+	BatchGetMovies(ctx context.Context, ids []int64) ([]*models.Movie, []int64, error)
+	// BulkCreateMovies 逐条读取并创建电影，单条失败不影响其余电影
+	BulkCreateMovies(ctx context.Context, next MovieSource) (*BulkCreateResult, error)
+}
+
+// movieService 电影服务实现
+type movieService struct {
+	movieRepo repository.MovieRepository
+}
+
+// NewMovieService 创建电影服务
+func NewMovieService(movieRepo repository.MovieRepository) MovieService {
+	return &movieService{movieRepo: movieRepo}
+}
+
+// CreateMovie 创建电影
+func (s *movieService) CreateMovie(ctx context.Context, movie *models.Movie) (*models.Movie, error) {
+	if err := validateMovie(movie); err != nil {
+		return nil, err
+	}
+
+	if err := s.movieRepo.Create(ctx, movie); err != nil {
+		if errors.Is(err, repository.ErrUnknownGenre) {
+			return nil, apperror.New(apperror.InvalidArgument, "invalid movie").
+				WithField("genres", "unknown genre")
+		}
+		return nil, fmt.Errorf("failed to create movie: %w", err)
+	}
+
+	created, err := s.movieRepo.GetByID(ctx, movie.ID)
+	if err != nil {
+		return nil, movieError(err)
+	}
+	return created, nil
+}
+
+// GetMovie 获取电影
+func (s *movieService) GetMovie(ctx context.Context, id int64) (*models.Movie, error) {
+	movie, err := s.movieRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, movieError(err)
+	}
+	return movie, nil
+}
+
+// UpdateMovie 更新电影
+// 携带字段掩码时只更新掩码中的字段，允许将字段清空；
+// 未携带时只更新非零值字段，与旧客户端保持兼容
+func (s *movieService) UpdateMovie(ctx context.Context, movie *models.Movie, paths []string) (*models.Movie, error) {
+	if len(paths) == 0 {
+		paths = nonZeroMoviePaths(movie)
+	}
+
+	columns, err := movieUpdatePolicy.Resolve(paths)
+	if err != nil {
+		return nil, err
+	}
+	// 字段掩码允许清空字段，写入前按创建时的规则校验每个将要写入的列
+	if err := validateMovieColumns(movie, columns); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.movieRepo.GetByID(ctx, movie.ID); err != nil {
+		return nil, movieError(err)
+	}
+
+	if err := s.movieRepo.UpdateColumns(ctx, movie, columns); err != nil {
+		if errors.Is(err, repository.ErrUnknownGenre) {
+			return nil, apperror.New(apperror.InvalidArgument, "invalid movie").
+				WithField("genres", "unknown genre")
+		}
+		return nil, fmt.Errorf("failed to update movie %d: %w", movie.ID, err)
+	}
+
+	updated, err := s.movieRepo.GetByID(ctx, movie.ID)
+	if err != nil {
+		return nil, movieError(err)
+	}
+	return updated, nil
+}
+
+// BatchGetMovies 批量获取电影，返回的电影与 ids 顺序一致，并返回不存在的ID
+func (s *movieService) BatchGetMovies(ctx context.Context, ids []int64) ([]*models.Movie, []int64, error) {
+	if len(ids) > maxBatchSize {
+		return nil, nil, apperror.New(apperror.InvalidArgument, "too many ids").
+			WithField("ids", fmt.Sprintf("at most %d ids are allowed", maxBatchSize))
+	}
+
+	found, err := s.movieRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to batch get movies: %w", err)
+	}
+
+	byID := make(map[int64]*models.Movie, len(found))
+	for _, movie := range found {
+		byID[movie.ID] = movie
+	}
+
+	movies := make([]*models.Movie, 0, len(ids))
+	var missing []int64
+	for _, id := range ids {
+		if movie, ok := byID[id]; ok {
+			movies = append(movies, movie)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return movies, missing, nil
+}
+
+// BulkCreateMovies 逐条读取并创建电影，单条失败不影响其余电影
+// 读取来源出错（如客户端流中断）时终止并返回错误
+func (s *movieService) BulkCreateMovies(ctx context.Context, next MovieSource) (*BulkCreateResult, error) {
+	result := &BulkCreateResult{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		movie, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to receive movie: %w", err)
+		}
+
+		index := result.Received
+		result.Received++
+
+		created, err := s.CreateMovie(ctx, movie)
+		if err != nil {
+			result.Errors = append(result.Errors, BulkCreateError{
+				Index:   index,
+				Details: errorDetails(err),
+			})
+		} else {
+			result.CreatedIDs = append(result.CreatedIDs, created.ID)
+		}
+
+		if result.Received%bulkProgressInterval == 0 {
+			logger.Infof("bulk create movies: received %d, created %d, failed %d",
+				result.Received, len(result.CreatedIDs), len(result.Errors))
+		}
+	}
+
+	logger.Infof("bulk create movies finished: received %d, created %d, failed %d",
+		result.Received, len(result.CreatedIDs), len(result.Errors))
+	return result, nil
+}
+
+// validatedMovieColumns 有校验规则的电影列，创建电影时全部校验
+var validatedMovieColumns = []string{"title", "duration"}
+
+// validateMovie 校验电影字段
+func validateMovie(movie *models.Movie) error {
+	return validateMovieColumns(movie, validatedMovieColumns)
+}
+
+// validateMovieColumns 校验电影中将要写入 columns 的字段，每个不合法的字段对应一条 ErrorDetail
+func validateMovieColumns(movie *models.Movie, columns []string) error {
+	appErr := apperror.New(apperror.InvalidArgument, "invalid movie")
+	for _, column := range columns {
+		switch column {
+		case "title":
+			if movie.Title == "" {
+				appErr.WithField("title", "title is required")
+			} else if utf8.RuneCountInString(movie.Title) > 200 {
+				appErr.WithField("title", "title must be at most 200 characters")
+			}
+		case "duration":
+			if movie.Duration < 0 {
+				appErr.WithField("duration", "duration must not be negative")
+			}
+		}
+	}
+
+	if len(appErr.Details) > 0 {
+		return appErr
+	}
+	return nil
+}
+
+// nonZeroMoviePaths 返回电影中非零值字段的路径
+func nonZeroMoviePaths(movie *models.Movie) []string {
+	var paths []string
+	if movie.Title != "" {
+		paths = append(paths, "title")
+	}
+	if movie.Description != "" {
+		paths = append(paths, "description")
+	}
+	if movie.PosterURL != "" {
+		paths = append(paths, "poster_url")
+	}
+	if movie.Duration != 0 {
+		paths = append(paths, "duration")
+	}
+	if movie.ReleaseDate != nil {
+		paths = append(paths, "release_date")
+	}
+	if movie.Language != "" {
+		paths = append(paths, "language")
+	}
+	if len(movie.Genres) > 0 {
+		paths = append(paths, "genres")
+	}
+	if len(movie.Directors) > 0 {
+		paths = append(paths, "directors")
+	}
+	if len(movie.Actors) > 0 {
+		paths = append(paths, "actors")
+	}
+	return paths
+}
+
+// movieError 将仓储错误转换为业务错误
+func movieError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.New(apperror.NotFound, "movie not found")
+	}
+	return err
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+)
+
+// fakeMovieRepo 内存中的电影仓储，只实现测试用到的方法
+type fakeMovieRepo struct {
+	repository.MovieRepository
+	movies map[int64]models.Movie
+	// columns 最近一次 UpdateColumns 写入的列
+	columns []string
+}
+
+func newFakeMovieRepo(movies ...models.Movie) *fakeMovieRepo {
+	r := &fakeMovieRepo{movies: make(map[int64]models.Movie)}
+	for _, movie := range movies {
+		r.movies[movie.ID] = movie
+	}
+	return r
+}
+
+func (r *fakeMovieRepo) GetByID(ctx context.Context, id int64) (*models.Movie, error) {
+	movie, ok := r.movies[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &movie, nil
+}
+
+// GetByIDs 按ID倒序返回，调用方需要自行恢复请求中的顺序
+func (r *fakeMovieRepo) GetByIDs(ctx context.Context, ids []int64) ([]*models.Movie, error) {
+	var movies []*models.Movie
+	for _, id := range ids {
+		if movie, ok := r.movies[id]; ok {
+			movies = append(movies, &movie)
+		}
+	}
+	sort.Slice(movies, func(i, j int) bool { return movies[i].ID > movies[j].ID })
+	return movies, nil
+}
+
+func (r *fakeMovieRepo) Create(ctx context.Context, movie *models.Movie) error {
+	movie.ID = int64(len(r.movies) + 1)
+	r.movies[movie.ID] = *movie
+	return nil
+}
+
+func (r *fakeMovieRepo) UpdateColumns(ctx context.Context, movie *models.Movie, columns []string) error {
+	r.columns = columns
+	current := r.movies[movie.ID]
+	for _, column := range columns {
+		switch column {
+		case "title":
+			current.Title = movie.Title
+		case "description":
+			current.Description = movie.Description
+		case "duration":
+			current.Duration = movie.Duration
+		}
+	}
+	r.movies[movie.ID] = current
+	return nil
+}
+
+func TestUpdateMovie(t *testing.T) {
+	alien := models.Movie{ID: 1, Title: "Alien", Description: "In space no one can hear you scream", Duration: 117}
+	invalid := apperror.InvalidArgument.String()
+	tests := []struct {
+		name  string
+		movie models.Movie
+		paths []string
+		code  string
+		// wantFields 期望报告的错误字段
+		wantFields []string
+		// wantColumns 期望写入的列
+		wantColumns []string
+		want        models.Movie
+	}{
+		{
+			name:        "masked field cleared",
+			movie:       models.Movie{ID: 1, Title: "Alien"},
+			paths:       []string{"description"},
+			wantColumns: []string{"description"},
+			want:        models.Movie{ID: 1, Title: "Alien", Duration: 117},
+		},
+		{
+			name:        "unmasked fields kept",
+			movie:       models.Movie{ID: 1, Title: "Aliens", Duration: 137},
+			paths:       []string{"title"},
+			wantColumns: []string{"title"},
+			want:        models.Movie{ID: 1, Title: "Aliens", Description: alien.Description, Duration: 117},
+		},
+		{
+			name:        "no mask updates non-zero fields",
+			movie:       models.Movie{ID: 1, Duration: 120},
+			wantColumns: []string{"duration"},
+			want:        models.Movie{ID: 1, Title: "Alien", Description: alien.Description, Duration: 120},
+		},
+		{name: "unknown path", movie: models.Movie{ID: 1}, paths: []string{"rating"}, code: invalid, wantFields: []string{"rating"}},
+		{
+			name:       "immutable path",
+			movie:      models.Movie{ID: 1},
+			paths:      []string{"title", "rating_count"},
+			code:       invalid,
+			wantFields: []string{"rating_count"},
+		},
+		{name: "masked title cleared", movie: models.Movie{ID: 1}, paths: []string{"title"}, code: invalid, wantFields: []string{"title"}},
+		{
+			name:       "masked fields invalid",
+			movie:      models.Movie{ID: 1, Title: strings.Repeat("a", 201), Duration: -1},
+			paths:      []string{"title", "duration"},
+			code:       invalid,
+			wantFields: []string{"title", "duration"},
+		},
+		{name: "movie not found", movie: models.Movie{ID: 2, Title: "Heat"}, paths: []string{"title"}, code: apperror.NotFound.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeMovieRepo(alien)
+			s := NewMovieService(repo)
+
+			movie := tt.movie
+			got, err := s.UpdateMovie(context.Background(), &movie, tt.paths)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("UpdateMovie() = %q, want %q", code, tt.code)
+			}
+			if fields := errFields(err); !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Fatalf("error fields = %v, want %v", fields, tt.wantFields)
+			}
+			if err != nil {
+				if repo.columns != nil {
+					t.Fatalf("columns %v written for a rejected update", repo.columns)
+				}
+				return
+			}
+			if !reflect.DeepEqual(repo.columns, tt.wantColumns) {
+				t.Fatalf("columns = %v, want %v", repo.columns, tt.wantColumns)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Fatalf("UpdateMovie() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchGetMovies(t *testing.T) {
+	repo := newFakeMovieRepo(models.Movie{ID: 1, Title: "Alien"}, models.Movie{ID: 2, Title: "Heat"}, models.Movie{ID: 3, Title: "Ran"})
+	s := NewMovieService(repo)
+	tooMany := make([]int64, maxBatchSize+1)
+	tests := []struct {
+		name        string
+		ids         []int64
+		wantIDs     []int64
+		wantMissing []int64
+		code        string
+	}{
+		{name: "request order kept", ids: []int64{2, 3, 1}, wantIDs: []int64{2, 3, 1}},
+		{name: "missing ids reported", ids: []int64{4, 1, 9, 3}, wantIDs: []int64{1, 3}, wantMissing: []int64{4, 9}},
+		{name: "all missing", ids: []int64{7}, wantMissing: []int64{7}},
+		{name: "too many ids", ids: tooMany, code: apperror.InvalidArgument.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movies, missing, err := s.BatchGetMovies(context.Background(), tt.ids)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("BatchGetMovies() = %q, want %q", code, tt.code)
+			}
+			var ids []int64
+			for _, movie := range movies {
+				ids = append(ids, movie.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) || !reflect.DeepEqual(missing, tt.wantMissing) {
+				t.Fatalf("BatchGetMovies() = (%v, %v), want (%v, %v)", ids, missing, tt.wantIDs, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestBulkCreateMovies(t *testing.T) {
+	movies := []*models.Movie{
+		{Title: "Alien"},
+		{Title: ""},
+		{Title: "Heat"},
+		{Title: "Ran", Duration: -1},
+	}
+	repo := newFakeMovieRepo()
+	s := NewMovieService(repo)
+
+	next := 0
+	result, err := s.BulkCreateMovies(context.Background(), func() (*models.Movie, error) {
+		if next == len(movies) {
+			return nil, io.EOF
+		}
+		next++
+		return movies[next-1], nil
+	})
+	if err != nil {
+		t.Fatalf("BulkCreateMovies() error = %v", err)
+	}
+	if result.Received != 4 || !reflect.DeepEqual(result.CreatedIDs, []int64{1, 2}) {
+		t.Fatalf("BulkCreateMovies() received %d, created %v", result.Received, result.CreatedIDs)
+	}
+	var failed []int
+	for _, e := range result.Errors {
+		failed = append(failed, e.Index)
+	}
+	if !reflect.DeepEqual(failed, []int{1, 3}) {
+		t.Fatalf("failed indexes = %v, want [1 3]", failed)
+	}
+	if field := result.Errors[1].Details[0].Field; field != "duration" {
+		t.Fatalf("error field = %s, want duration", field)
+	}
+}
+
+func TestBulkCreateMoviesSourceError(t *testing.T) {
+	s := NewMovieService(newFakeMovieRepo())
+	broken := errors.New("stream broken")
+	received := 0
+	result, err := s.BulkCreateMovies(context.Background(), func() (*models.Movie, error) {
+		if received == 2 {
+			return nil, broken
+		}
+		received++
+		return &models.Movie{Title: "Alien"}, nil
+	})
+	if !errors.Is(err, broken) {
+		t.Fatalf("BulkCreateMovies() error = %v, want %v", err, broken)
+	}
+	if len(result.CreatedIDs) != 2 {
+		t.Fatalf("created %v before the source failed, want 2 movies", result.CreatedIDs)
+	}
+}
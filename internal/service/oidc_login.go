@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+	"github.com/3inchtime/movieinfo/pkg/oidc"
+	"github.com/3inchtime/movieinfo/pkg/password"
+)
+
+const (
+	// minUsernameLength、maxUsernameLength 用户名长度限制，与 CreateUserRequest.username 的校验规则一致
+	minUsernameLength = 3
+	maxUsernameLength = 50
+	// maxNicknameLength users.nickname 列的长度
+	maxNicknameLength = 50
+	// maxAvatarURLLength users.avatar_url 列的长度
+	maxAvatarURLLength = 255
+	// usernameAttempts 生成的用户名已被占用时追加随机后缀重试的次数
+	usernameAttempts = 5
+)
+
+// OIDCService 第三方身份登录服务
+type OIDCService interface {
+	// Providers 返回可供登录的身份提供方
+	Providers() []oidc.Provider
+	// StartLogin 生成跳转到身份提供方的授权地址，redirectURI 为空时使用配置的回调地址
+	StartLogin(ctx context.Context, provider, redirectURI string) (*oidc.AuthRequest, error)
+	// Authenticate 使用回调中的 state 和授权码校验身份，返回关联的用户
+	// 身份未关联时，身份提供方已验证的邮箱关联到同一邮箱的已有用户，没有该邮箱的用户时注册新用户
+	Authenticate(ctx context.Context, state, code string) (*models.User, error)
+}
+
+// oidcService 第三方身份登录服务实现
+type oidcService struct {
+	rp           *oidc.RelyingParty
+	userRepo     repository.UserRepository
+	identityRepo repository.IdentityRepository
+	hasher       *password.Hasher
+	verification EmailVerificationService
+}
+
+// NewOIDCService 创建第三方身份登录服务
+func NewOIDCService(rp *oidc.RelyingParty, userRepo repository.UserRepository, identityRepo repository.IdentityRepository,
+	hasher *password.Hasher, verification EmailVerificationService) OIDCService {
+	return &oidcService{
+		rp:           rp,
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		hasher:       hasher,
+		verification: verification,
+	}
+}
+
+// Providers 返回已配置的身份提供方
+func (s *oidcService) Providers() []oidc.Provider {
+	return s.rp.Providers()
+}
+
+// StartLogin 开始第三方登录
+func (s *oidcService) StartLogin(ctx context.Context, provider, redirectURI string) (*oidc.AuthRequest, error) {
+	request, err := s.rp.AuthCodeURL(ctx, provider, redirectURI)
+	if err != nil {
+		return nil, oidcError(err)
+	}
+	return request, nil
+}
+
+// Authenticate 校验第三方身份并返回关联的用户，用户状态、邮箱验证和两步验证由登录流程检查
+func (s *oidcService) Authenticate(ctx context.Context, state, code string) (*models.User, error) {
+	identity, err := s.rp.Exchange(ctx, state, code)
+	if err != nil {
+		return nil, oidcError(err)
+	}
+	email := normalizeEmail(identity.Email)
+
+	user, err := s.linkedUser(ctx, identity, email)
+	if err != nil || user != nil {
+		return user, err
+	}
+	if email == "" {
+		return nil, apperror.Newf(apperror.BusinessError, "identity provider %s did not return an email address", identity.Provider)
+	}
+
+	existing, err := s.userRepo.GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		return s.link(ctx, existing, identity, email)
+	case errors.Is(err, repository.ErrNotFound):
+		return s.register(ctx, identity, email)
+	default:
+		return nil, err
+	}
+}
+
+// linkedUser 返回已关联该身份的用户并记录登录，未关联时返回 nil
+func (s *oidcService) linkedUser(ctx context.Context, identity *oidc.Identity, email string) (*models.User, error) {
+	linked, err := s.identityRepo.Get(ctx, identity.Provider, identity.Subject)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, linked.UserID)
+	if err != nil {
+		return nil, userError(err)
+	}
+	// 登录时间写入失败不影响登录
+	if err := s.identityRepo.RecordLogin(ctx, linked.ID, email); err != nil {
+		logger.Warnf("failed to record %s identity login of user %d: %v", identity.Provider, user.ID, err)
+	}
+	return user, nil
+}
+
+// link 将身份关联到同一邮箱的已有用户
+// 双方的邮箱都必须已验证：身份提供方未验证时无法证明邮箱归属；
+// 本地未验证时账号可能由他人抢先用该邮箱注册，关联后对方仍可用密码登录
+func (s *oidcService) link(ctx context.Context, user *models.User, identity *oidc.Identity, email string) (*models.User, error) {
+	if !identity.EmailVerified {
+		return nil, apperror.New(apperror.AlreadyExists,
+			"email already registered and not verified by the identity provider, log in with your password")
+	}
+	if !user.EmailVerified {
+		return nil, apperror.New(apperror.BusinessError,
+			"email already registered but not verified, log in with your password and verify the email first")
+	}
+
+	err := s.identityRepo.Create(ctx, &models.UserIdentity{
+		UserID:   user.ID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    email,
+	})
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		// 同一身份的另一个登录请求已完成关联
+		return s.linkedUser(ctx, identity, email)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to link %s identity to user %d: %w", identity.Provider, user.ID, err)
+	}
+	logger.Infof("%s identity linked to user %d by verified email", identity.Provider, user.ID)
+	return user, nil
+}
+
+// register 使用第三方身份注册新用户，设置随机密码，需要密码登录时可通过找回密码设置
+// 身份提供方未验证邮箱时与注册一样发送验证邮件
+func (s *oidcService) register(ctx context.Context, identity *oidc.Identity, email string) (*models.User, error) {
+	username, err := s.availableUsername(ctx, identity, email)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := s.randomPasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Username:      username,
+		Email:         email,
+		PasswordHash:  hash,
+		Nickname:      truncate(identity.Name, maxNicknameLength),
+		Status:        models.UserStatusActive,
+		EmailVerified: identity.EmailVerified,
+	}
+	if len(identity.Picture) <= maxAvatarURLLength {
+		user.AvatarURL = identity.Picture
+	}
+	err = s.identityRepo.CreateWithUser(ctx, user, &models.UserIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    email,
+	})
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return nil, apperror.New(apperror.AlreadyExists, "username or email already registered, please try again")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to register %s identity: %w", identity.Provider, err)
+	}
+
+	created, err := s.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		return nil, userError(err)
+	}
+	logger.Infof("user %d registered with %s identity", created.ID, identity.Provider)
+	if !created.EmailVerified {
+		if err := s.verification.SendVerification(ctx, created); err != nil {
+			logger.Warnf("failed to send verification email to user %d: %v", created.ID, err)
+		}
+	}
+	return created, nil
+}
+
+// availableUsername 根据身份提供方的用户名或邮箱生成未被占用的用户名
+func (s *oidcService) availableUsername(ctx context.Context, identity *oidc.Identity, email string) (string, error) {
+	base := identity.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(email, "@")
+	}
+	base = sanitizeUsername(base)
+
+	candidate := base
+	for i := 0; i < usernameAttempts; i++ {
+		_, err := s.userRepo.GetByUsername(ctx, candidate)
+		if errors.Is(err, repository.ErrNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		n, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate username suffix: %w", err)
+		}
+		candidate = fmt.Sprintf("%s_%04d", truncate(base, maxUsernameLength-5), n)
+	}
+	return "", apperror.New(apperror.AlreadyExists, "could not find an available username, please try again")
+}
+
+// randomPasswordHash 生成随机密码的哈希，随机密码不告知任何人
+func (s *oidcService) randomPasswordHash() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate password: %w", err)
+	}
+	return s.hasher.Hash(base64.RawURLEncoding.EncodeToString(b))
+}
+
+// sanitizeUsername 将字母、数字和下划线以外的字符替换为下划线，长度不足时加前缀
+func sanitizeUsername(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}, name)
+	name = strings.Trim(name, "_")
+	if len(name) < minUsernameLength {
+		name = "user_" + name
+	}
+	return truncate(strings.TrimRight(name, "_"), maxUsernameLength)
+}
+
+// truncate 按字符截断字符串
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
+
+// oidcError 将第三方登录错误转换为业务错误
+func oidcError(err error) error {
+	switch {
+	case errors.Is(err, oidc.ErrUnknownProvider):
+		return apperror.New(apperror.NotFound, "unknown identity provider").
+			WithField("provider", "identity provider not configured")
+	case errors.Is(err, oidc.ErrRedirectURI):
+		return apperror.New(apperror.InvalidArgument, "redirect uri not allowed").
+			WithField("redirect_uri", "must be the configured callback or a loopback address")
+	case errors.Is(err, oidc.ErrInvalidState):
+		return apperror.New(apperror.InvalidArgument, "invalid or expired login state, please start again").
+			WithField("state", "invalid or expired login state")
+	case errors.Is(err, oidc.ErrAuthentication):
+		logger.Warnf("oidc authentication failed: %v", err)
+		return apperror.New(apperror.Unauthenticated, "identity provider authentication failed")
+	default:
+		return err
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/oidc"
+	"github.com/3inchtime/movieinfo/pkg/oidc/oidctest"
+)
+
+// oidcFixture 以 identity 自动授权的模拟身份提供方和第三方登录服务
+type oidcFixture struct {
+	service      OIDCService
+	userRepo     repository.UserRepository
+	identityRepo repository.IdentityRepository
+	verification *fakeVerification
+}
+
+func newOIDCFixture(t *testing.T, identity oidctest.Identity) *oidcFixture {
+	t.Helper()
+	var mock *oidctest.Provider
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mock.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	var err error
+	mock, err = oidctest.NewProvider(&oidctest.Config{
+		Issuer:      server.URL,
+		ClientID:    "movieinfo",
+		Identity:    identity,
+		AutoApprove: true,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	rp := oidc.New(&oidc.Config{Providers: map[string]oidc.ProviderConfig{
+		"mock": {Issuer: server.URL, ClientID: "movieinfo", RedirectURL: "http://localhost:8080/oidc/callback"},
+	}}, oidc.NewMemoryStore())
+
+	db := newTestDB(t)
+	f := &oidcFixture{
+		userRepo:     repository.NewUserRepository(db),
+		identityRepo: repository.NewIdentityRepository(db),
+		verification: &fakeVerification{},
+	}
+	f.service = NewOIDCService(rp, f.userRepo, f.identityRepo, newTestHasher(t), f.verification)
+	return f
+}
+
+// login 完成一次第三方登录：访问授权地址，使用回调中的授权码登录
+func (f *oidcFixture) login(t *testing.T) (*models.User, error) {
+	t.Helper()
+	ctx := context.Background()
+	request, err := f.service.StartLogin(ctx, "mock", "")
+	if err != nil {
+		t.Fatalf("StartLogin() error = %v", err)
+	}
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(request.URL)
+	if err != nil {
+		t.Fatalf("authorize request error = %v", err)
+	}
+	resp.Body.Close()
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid callback %q: %v", resp.Header.Get("Location"), err)
+	}
+	return f.service.Authenticate(ctx, request.State, location.Query().Get("code"))
+}
+
+func TestOIDCAccountLinking(t *testing.T) {
+	const newEmail = "alice@example.com"
+	tests := []struct {
+		name     string
+		identity oidctest.Identity
+		// setup 登录前准备本地数据
+		setup func(t *testing.T, f *oidcFixture)
+		want  string
+		// wantUser 登录得到的用户，为0时期望注册新用户
+		wantUser     int64
+		wantVerified bool
+		wantMail     bool
+	}{
+		{
+			name:     "verified email links existing user",
+			identity: oidctest.Identity{Subject: "sub-1", Email: testEmail, EmailVerified: true},
+			wantUser: testUserID, wantVerified: true,
+		},
+		{
+			name:     "email matched case-insensitively",
+			identity: oidctest.Identity{Subject: "sub-1", Email: " Test@MovieInfo.com", EmailVerified: true},
+			wantUser: testUserID, wantVerified: true,
+		},
+		{
+			name:     "unverified provider email not linked",
+			identity: oidctest.Identity{Subject: "sub-1", Email: testEmail},
+			want:     apperror.AlreadyExists.String(),
+		},
+		{
+			name:     "unverified local email not linked",
+			identity: oidctest.Identity{Subject: "sub-1", Email: testEmail, EmailVerified: true},
+			setup: func(t *testing.T, f *oidcFixture) {
+				user := &models.User{ID: testUserID, EmailVerified: false}
+				if err := f.userRepo.UpdateColumns(context.Background(), user, []string{"email_verified"}); err != nil {
+					t.Fatalf("UpdateColumns() error = %v", err)
+				}
+			},
+			want: apperror.BusinessError.String(),
+		},
+		{
+			name:     "linked identity logs in regardless of email",
+			identity: oidctest.Identity{Subject: "sub-1", Email: "changed@example.com"},
+			setup: func(t *testing.T, f *oidcFixture) {
+				identity := &models.UserIdentity{UserID: adminUserID, Provider: "mock", Subject: "sub-1", Email: "admin@movieinfo.com"}
+				if err := f.identityRepo.Create(context.Background(), identity); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+			},
+			wantUser: adminUserID, wantVerified: true,
+		},
+		{
+			name:         "new verified email registers",
+			identity:     oidctest.Identity{Subject: "sub-1", Email: newEmail, EmailVerified: true, PreferredUsername: "testuser"},
+			wantVerified: true,
+		},
+		{
+			name:     "new unverified email registers and sends verification",
+			identity: oidctest.Identity{Subject: "sub-1", Email: newEmail, PreferredUsername: "alice"},
+			wantMail: true,
+		},
+		{
+			name:     "missing email",
+			identity: oidctest.Identity{Subject: "sub-1"},
+			want:     apperror.BusinessError.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOIDCFixture(t, tt.identity)
+			if tt.setup != nil {
+				tt.setup(t, f)
+			}
+
+			user, err := f.login(t)
+			if got := errCode(err); got != tt.want {
+				t.Fatalf("Authenticate() = %q, want %q", got, tt.want)
+			}
+			if tt.want != "" {
+				if _, err := f.identityRepo.Get(context.Background(), "mock", tt.identity.Subject); !errors.Is(err, repository.ErrNotFound) {
+					t.Fatalf("identity linked after failed login, Get() error = %v", err)
+				}
+				return
+			}
+
+			if tt.wantUser != 0 && user.ID != tt.wantUser {
+				t.Fatalf("logged in as user %d, want %d", user.ID, tt.wantUser)
+			}
+			if tt.wantUser == 0 && (user.ID == adminUserID || user.ID == testUserID || user.Email != newEmail) {
+				t.Fatalf("registered user = %+v, want a new user with email %s", user, newEmail)
+			}
+			if user.EmailVerified != tt.wantVerified {
+				t.Fatalf("EmailVerified = %v, want %v", user.EmailVerified, tt.wantVerified)
+			}
+			if sent := len(f.verification.sent) > 0; sent != tt.wantMail {
+				t.Fatalf("verification sent = %v, want %v", sent, tt.wantMail)
+			}
+
+			// 再次登录使用已关联的身份，得到同一用户
+			again, err := f.login(t)
+			if err != nil {
+				t.Fatalf("second Authenticate() error = %v", err)
+			}
+			if again.ID != user.ID {
+				t.Fatalf("second login as user %d, want %d", again.ID, user.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+	"github.com/3inchtime/movieinfo/pkg/mailer"
+	"github.com/3inchtime/movieinfo/pkg/onetimecode"
+	"github.com/3inchtime/movieinfo/pkg/password"
+	"github.com/3inchtime/movieinfo/pkg/ratelimit"
+)
+
+// resetCodePurpose 找回密码验证码的用途
+const resetCodePurpose = "password_reset"
+
+// PasswordResetConfig 找回密码配置
+type PasswordResetConfig struct {
+	Store          string        `yaml:"store" validate:"omitempty,oneof=memory redis"` // 重置码和重发限流的存储，memory（单实例）| redis
+	KeyPrefix      string        `yaml:"key_prefix"`
+	TTL            time.Duration `yaml:"ttl"`                            // 有效期
+	MaxAttempts    int           `yaml:"max_attempts" validate:"min=0"`  // 最多允许输错的次数
+	Length         int           `yaml:"length" validate:"min=0,max=10"` // 数字位数
+	ResendInterval time.Duration `yaml:"resend_interval"`                // 重发重置码的平均间隔
+	ResendBurst    int           `yaml:"resend_burst" validate:"min=0"`  // 允许连续重发的次数
+}
+
+// CodeConfig 返回重置码的签发配置
+func (c *PasswordResetConfig) CodeConfig() *onetimecode.Config {
+	return &onetimecode.Config{
+		Store:       c.Store,
+		KeyPrefix:   c.KeyPrefix,
+		TTL:         c.TTL,
+		MaxAttempts: c.MaxAttempts,
+		Length:      c.Length,
+	}
+}
+
+// PasswordResetService 找回密码服务
+type PasswordResetService interface {
+	// SendResetCode 向邮箱发送重置码，按邮箱限制频率，邮箱未注册或账号已禁用时不发送但同样返回成功
+	SendResetCode(ctx context.Context, email string) error
+	// VerifyResetCode 校验重置码，不会使重置码失效
+	VerifyResetCode(ctx context.Context, email, code string) error
+	// ResetPassword 使用重置码设置新密码，并注销该用户的所有会话，重置码只能使用一次
+	ResetPassword(ctx context.Context, email, code, newPassword string) error
+}
+
+// passwordResetService 找回密码服务实现
+type passwordResetService struct {
+	config   *PasswordResetConfig
+	userRepo repository.UserRepository
+	codes    *onetimecode.Codes
+	limiter  ratelimit.Limiter
+	mailer   mailer.Mailer
+	sessions auth.Revocations
+	hasher   *password.Hasher
+	policy   *password.Policy
+}
+
+// NewPasswordResetService 创建找回密码服务
+func NewPasswordResetService(config *PasswordResetConfig, userRepo repository.UserRepository, codes *onetimecode.Codes,
+	limiter ratelimit.Limiter, m mailer.Mailer, sessions auth.Revocations, hasher *password.Hasher,
+	policy *password.Policy) PasswordResetService {
+	return &passwordResetService{
+		config:   config,
+		userRepo: userRepo,
+		codes:    codes,
+		limiter:  limiter,
+		mailer:   m,
+		sessions: sessions,
+		hasher:   hasher,
+		policy:   policy,
+	}
+}
+
+// SendResetCode 签发重置码并通过邮件发送，新的重置码会使之前发送的重置码失效
+func (s *passwordResetService) SendResetCode(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+
+	// 每次签发都会重置输错次数，按邮箱限流避免通过反复重发绕过次数限制
+	// 限流与邮箱是否注册无关，避免通过限流结果判断邮箱是否存在
+	rate := 1 / s.config.ResendInterval.Seconds()
+	allowed, wait, err := s.limiter.Allow(ctx, "resend:"+email, rate, s.config.ResendBurst)
+	if err != nil {
+		return fmt.Errorf("failed to check resend limit: %w", err)
+	}
+	if !allowed {
+		return apperror.Newf(apperror.ResourceExhausted, "reset code sent recently, retry after %ds",
+			int64((wait+time.Second-1)/time.Second))
+	}
+
+	user, err := s.activeUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		logger.Infof("password reset requested for unknown or inactive email")
+		return nil
+	}
+
+	code, err := s.codes.Issue(ctx, resetCodePurpose, email)
+	if err != nil {
+		return fmt.Errorf("failed to issue reset code: %w", err)
+	}
+
+	msg := &mailer.Message{
+		To:      user.Email,
+		Subject: "MovieInfo 密码重置验证码",
+		Body: fmt.Sprintf("%s，你好：\n\n你的密码重置验证码是 %s，%d 分钟内有效。\n如果不是你本人操作，请忽略这封邮件，你的密码不会被修改。\n",
+			user.Username, code, int(s.codes.TTL().Minutes())),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.codes.Revoke(ctx, resetCodePurpose, email)
+		return fmt.Errorf("failed to send reset code: %w", err)
+	}
+	return nil
+}
+
+// VerifyResetCode 校验重置码
+func (s *passwordResetService) VerifyResetCode(ctx context.Context, email, code string) error {
+	if err := s.codes.Verify(ctx, resetCodePurpose, normalizeEmail(email), code); err != nil {
+		return resetCodeError(err)
+	}
+	return nil
+}
+
+// ResetPassword 先校验新密码，再原子地消费重置码，并发请求使用同一重置码时只有一个能成功
+// 新密码不符合要求时重置码不会失效，可以修改密码后重试
+func (s *passwordResetService) ResetPassword(ctx context.Context, email, code, newPassword string) error {
+	email = normalizeEmail(email)
+	hash, err := hashNewPassword(s.hasher, s.policy, "new_password", newPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.codes.Consume(ctx, resetCodePurpose, email, code); err != nil {
+		return resetCodeError(err)
+	}
+
+	user, err := s.activeUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return resetCodeError(onetimecode.ErrInvalid)
+	}
+
+	user.PasswordHash = hash
+	if err := s.userRepo.UpdateColumns(ctx, user, []string{"password_hash"}); err != nil {
+		return fmt.Errorf("failed to update password of user %d: %w", user.ID, err)
+	}
+	if err := s.sessions.RevokeAll(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions of user %d: %w", user.ID, err)
+	}
+
+	logger.Infof("password of user %d reset, all sessions revoked", user.ID)
+	return nil
+}
+
+// activeUserByEmail 获取状态正常的用户，用户不存在或已禁用时返回 nil
+func (s *passwordResetService) activeUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != models.UserStatusActive {
+		return nil, nil
+	}
+	return user, nil
+}
+
+// normalizeEmail 统一邮箱格式，重置码按邮箱存储
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// resetCodeError 将验证码错误转换为业务错误
+func resetCodeError(err error) error {
+	switch {
+	case errors.Is(err, onetimecode.ErrInvalid):
+		return apperror.New(apperror.InvalidArgument, "invalid or expired reset code").
+			WithField("code", "invalid or expired reset code")
+	case errors.Is(err, onetimecode.ErrTooManyAttempts):
+		return apperror.New(apperror.BusinessError, "too many attempts, request a new reset code")
+	default:
+		return err
+	}
+}
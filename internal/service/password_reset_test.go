@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/onetimecode"
+	"github.com/3inchtime/movieinfo/pkg/ratelimit"
+)
+
+// resetCodePattern 邮件正文中的6位重置码
+var resetCodePattern = regexp.MustCompile(`[0-9]{6}`)
+
+// newTestPasswordReset 创建使用内存存储的找回密码服务
+func newTestPasswordReset(t *testing.T) (*passwordResetService, *fakeMailer, *fakeRevocations) {
+	t.Helper()
+	config := &PasswordResetConfig{
+		TTL:            time.Minute,
+		MaxAttempts:    3,
+		Length:         6,
+		ResendInterval: time.Hour,
+		ResendBurst:    2,
+	}
+	m := &fakeMailer{}
+	sessions := &fakeRevocations{}
+	service := NewPasswordResetService(config, repository.NewUserRepository(newTestDB(t)),
+		onetimecode.New(config.CodeConfig(), onetimecode.NewMemoryStore()), ratelimit.NewMemoryLimiter(), m,
+		sessions, newTestHasher(t), newTestPolicy(t))
+	return service.(*passwordResetService), m, sessions
+}
+
+// sendResetCode 发送重置码并从邮件中读取
+func sendResetCode(t *testing.T, s *passwordResetService, m *fakeMailer) string {
+	t.Helper()
+	if err := s.SendResetCode(context.Background(), testEmail); err != nil {
+		t.Fatalf("SendResetCode() error = %v", err)
+	}
+	code := resetCodePattern.FindString(m.last(t).Body)
+	if code == "" {
+		t.Fatalf("no reset code in mail body %q", m.last(t).Body)
+	}
+	return code
+}
+
+func TestSendResetCodeThrottle(t *testing.T) {
+	tests := []struct {
+		name      string
+		emails    []string
+		want      []string
+		wantMails int
+	}{
+		{
+			name:      "registered email within burst",
+			emails:    []string{testEmail, testEmail},
+			want:      []string{"", ""},
+			wantMails: 2,
+		},
+		{
+			name:      "registered email over burst",
+			emails:    []string{testEmail, testEmail, testEmail},
+			want:      []string{"", "", apperror.ResourceExhausted.String()},
+			wantMails: 2,
+		},
+		{
+			name:      "email is normalized before throttling",
+			emails:    []string{testEmail, " TEST@movieinfo.com", "Test@MovieInfo.com "},
+			want:      []string{"", "", apperror.ResourceExhausted.String()},
+			wantMails: 2,
+		},
+		{
+			name:      "unknown email is throttled the same way",
+			emails:    []string{"nobody@example.com", "nobody@example.com", "nobody@example.com"},
+			want:      []string{"", "", apperror.ResourceExhausted.String()},
+			wantMails: 0,
+		},
+		{
+			name:      "emails are throttled separately",
+			emails:    []string{"nobody@example.com", "nobody@example.com", testEmail},
+			want:      []string{"", "", ""},
+			wantMails: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m, _ := newTestPasswordReset(t)
+			for i, email := range tt.emails {
+				if got := errCode(s.SendResetCode(context.Background(), email)); got != tt.want[i] {
+					t.Fatalf("SendResetCode(%q) #%d = %q, want %q", email, i+1, got, tt.want[i])
+				}
+			}
+			if got := m.count(); got != tt.wantMails {
+				t.Fatalf("sent %d mails, want %d", got, tt.wantMails)
+			}
+		})
+	}
+}
+
+// resetStep 一次 ResetPassword 调用，code 为空时使用邮件中的重置码
+type resetStep struct {
+	code     string
+	password string
+	want     string
+}
+
+func TestResetPassword(t *testing.T) {
+	const newPassword = "correct horse battery"
+
+	tests := []struct {
+		name        string
+		steps       []resetStep
+		wantRevoked int
+	}{
+		{
+			name: "valid code resets password once",
+			steps: []resetStep{
+				{password: newPassword},
+				{password: newPassword, want: apperror.InvalidArgument.String()},
+			},
+			wantRevoked: 1,
+		},
+		{
+			name: "weak password keeps the code usable",
+			steps: []resetStep{
+				{password: "short", want: apperror.InvalidArgument.String()},
+				{password: newPassword},
+			},
+			wantRevoked: 1,
+		},
+		{
+			name: "wrong codes exhaust attempts",
+			steps: []resetStep{
+				{code: "000000", password: newPassword, want: apperror.InvalidArgument.String()},
+				{code: "000000", password: newPassword, want: apperror.InvalidArgument.String()},
+				{code: "000000", password: newPassword, want: apperror.BusinessError.String()},
+				{password: newPassword, want: apperror.BusinessError.String()},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m, sessions := newTestPasswordReset(t)
+			code := sendResetCode(t, s, m)
+			for i, step := range tt.steps {
+				input := step.code
+				if input == "" {
+					input = code
+				} else if input == code {
+					t.Skip("issued code collides with the wrong code")
+				}
+				got := errCode(s.ResetPassword(context.Background(), testEmail, input, step.password))
+				if got != step.want {
+					t.Fatalf("ResetPassword() #%d = %q, want %q", i+1, got, step.want)
+				}
+			}
+			if got := sessions.count(testUserID); got != tt.wantRevoked {
+				t.Fatalf("sessions revoked %d times, want %d", got, tt.wantRevoked)
+			}
+		})
+	}
+}
+
+func TestResetPasswordConcurrentUse(t *testing.T) {
+	s, m, sessions := newTestPasswordReset(t)
+	code := sendResetCode(t, s, m)
+
+	const callers = 5
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s.ResetPassword(context.Background(), testEmail, code, "correct horse battery") == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Fatalf("%d concurrent resets succeeded, want 1", succeeded)
+	}
+	if got := sessions.count(testUserID); got != 1 {
+		t.Fatalf("sessions revoked %d times, want 1", got)
+	}
+}
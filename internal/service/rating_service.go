@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/eventbus"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// 评分分数范围
+const (
+	minScore = 1
+	maxScore = 5
+)
+
+// RatingService 评分服务接口
+type RatingService interface {
+	// CreateRating 以当前登录用户的身份创建评分，rating.UserID 必须与调用方一致
+	CreateRating(ctx context.Context, rating *models.Rating) (*models.Rating, error)
+	GetRating(ctx context.Context, id int64) (*models.Rating, error)
+	// UpdateRating 更新评分，只能更新自己的评分
+	UpdateRating(ctx context.Context, id int64, score int32, comment string) (*models.Rating, error)
+	// DeleteRating 删除评分，只能删除自己的评分
+	DeleteRating(ctx context.Context, id int64) error
+	GetMovieAverageRating(ctx context.Context, movieID int64) (*models.MovieRatingStats, error)
+	// BatchGetUserRatings 获取用户对多部电影的评分，顺序与 movieIDs 一致，未评分的电影不返回
+	BatchGetUserRatings(ctx context.Context, userID int64, movieIDs []int64) ([]*models.Rating, error)
+	// WatchMovieRatings 订阅电影的评分变更事件，ctx 结束后通道关闭
+	WatchMovieRatings(ctx context.Context, movieID int64) (<-chan *models.RatingEvent, error)
+}
+
+// VerifiedGate 检查用户能否执行需要邮箱验证的操作，由 EmailVerificationService 实现
+type VerifiedGate interface {
+	RequireVerified(ctx context.Context, userID int64, action string) error
+}
+
+// ratingService 评分服务实现
+type ratingService struct {
+	ratingRepo repository.RatingRepository
+	bus        eventbus.Bus
+	verified   VerifiedGate
+}
+
+// NewRatingService 创建评分服务，verified 为 nil 时不检查邮箱验证状态
+func NewRatingService(ratingRepo repository.RatingRepository, bus eventbus.Bus, verified VerifiedGate) RatingService {
+	return &ratingService{
+		ratingRepo: ratingRepo,
+		bus:        bus,
+		verified:   verified,
+	}
+}
+
+// CreateRating 创建评分
+func (s *ratingService) CreateRating(ctx context.Context, rating *models.Rating) (*models.Rating, error) {
+	appErr := apperror.New(apperror.InvalidArgument, "invalid rating")
+	if rating.UserID <= 0 {
+		appErr.WithField("user_id", "user_id is required")
+	}
+	if rating.MovieID <= 0 {
+		appErr.WithField("movie_id", "movie_id is required")
+	}
+	if err := validateScore(rating.Score); err != nil {
+		appErr.WithField("score", err.Error())
+	}
+	if len(appErr.Details) > 0 {
+		return nil, appErr
+	}
+	// 邮箱验证检查的是调用方本人，不能以其他用户的身份评分
+	if err := requireCaller(ctx, rating.UserID, "can only rate as yourself"); err != nil {
+		return nil, err
+	}
+	if s.verified != nil {
+		if err := s.verified.RequireVerified(ctx, rating.UserID, ActionRating); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.ratingRepo.Create(ctx, rating); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, apperror.New(apperror.AlreadyExists, "movie already rated by user")
+		}
+		return nil, fmt.Errorf("failed to create rating: %w", err)
+	}
+
+	created, err := s.ratingRepo.GetByID(ctx, rating.ID)
+	if err != nil {
+		return nil, ratingError(err)
+	}
+
+	s.publish(ctx, models.RatingCreated, created)
+	return created, nil
+}
+
+// GetRating 获取评分
+func (s *ratingService) GetRating(ctx context.Context, id int64) (*models.Rating, error) {
+	rating, err := s.ratingRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ratingError(err)
+	}
+	return rating, nil
+}
+
+// UpdateRating 更新评分
+func (s *ratingService) UpdateRating(ctx context.Context, id int64, score int32, comment string) (*models.Rating, error) {
+	if err := validateScore(score); err != nil {
+		return nil, apperror.New(apperror.InvalidArgument, "invalid rating").WithField("score", err.Error())
+	}
+
+	rating, err := s.ratingRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ratingError(err)
+	}
+	if err := requireCaller(ctx, rating.UserID, "can only update your own rating"); err != nil {
+		return nil, err
+	}
+
+	rating.Score = score
+	rating.Comment = comment
+	if err := s.ratingRepo.Update(ctx, rating); err != nil {
+		return nil, fmt.Errorf("failed to update rating %d: %w", id, err)
+	}
+
+	updated, err := s.ratingRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ratingError(err)
+	}
+
+	s.publish(ctx, models.RatingUpdated, updated)
+	return updated, nil
+}
+
+// DeleteRating 删除评分
+func (s *ratingService) DeleteRating(ctx context.Context, id int64) error {
+	rating, err := s.ratingRepo.GetByID(ctx, id)
+	if err != nil {
+		return ratingError(err)
+	}
+	if err := requireCaller(ctx, rating.UserID, "can only delete your own rating"); err != nil {
+		return err
+	}
+
+	if err := s.ratingRepo.Delete(ctx, rating); err != nil {
+		return fmt.Errorf("failed to delete rating %d: %w", id, err)
+	}
+
+	s.publish(ctx, models.RatingDeleted, rating)
+	return nil
+}
+
+// GetMovieAverageRating 获取电影平均评分
+func (s *ratingService) GetMovieAverageRating(ctx context.Context, movieID int64) (*models.MovieRatingStats, error) {
+	stats, err := s.ratingRepo.GetMovieStats(ctx, movieID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movie %d rating stats: %w", movieID, err)
+	}
+	return stats, nil
+}
+
+// BatchGetUserRatings 获取用户对多部电影的评分，顺序与 movieIDs 一致，未评分的电影不返回
+func (s *ratingService) BatchGetUserRatings(ctx context.Context, userID int64, movieIDs []int64) ([]*models.Rating, error) {
+	if userID <= 0 {
+		return nil, apperror.New(apperror.InvalidArgument, "invalid request").
+			WithField("user_id", "user_id is required")
+	}
+	if len(movieIDs) > maxBatchSize {
+		return nil, apperror.New(apperror.InvalidArgument, "too many movie ids").
+			WithField("movie_ids", fmt.Sprintf("at most %d movie ids are allowed", maxBatchSize))
+	}
+
+	found, err := s.ratingRepo.GetByUserAndMovies(ctx, userID, movieIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get user ratings: %w", err)
+	}
+
+	byMovie := make(map[int64]*models.Rating, len(found))
+	for _, rating := range found {
+		byMovie[rating.MovieID] = rating
+	}
+
+	ratings := make([]*models.Rating, 0, len(found))
+	for _, movieID := range movieIDs {
+		if rating, ok := byMovie[movieID]; ok {
+			ratings = append(ratings, rating)
+		}
+	}
+	return ratings, nil
+}
+
+// WatchMovieRatings 订阅电影的评分变更事件，ctx 结束后通道关闭
+func (s *ratingService) WatchMovieRatings(ctx context.Context, movieID int64) (<-chan *models.RatingEvent, error) {
+	if movieID <= 0 {
+		return nil, apperror.New(apperror.InvalidArgument, "invalid request").
+			WithField("movie_id", "movie_id is required")
+	}
+
+	sub, err := s.bus.Subscribe(ctx, ratingTopic(movieID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch movie %d ratings: %w", movieID, err)
+	}
+
+	events := make(chan *models.RatingEvent)
+	go func() {
+		defer close(events)
+		defer sub.Close()
+
+		for payload := range sub.Messages() {
+			var event models.RatingEvent
+			if err := json.Unmarshal(payload, &event); err != nil {
+				logger.Warnf("failed to decode rating event: %v", err)
+				continue
+			}
+
+			select {
+			case events <- &event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// publish 发布评分变更事件，失败时只记录日志，不影响评分写入结果
+func (s *ratingService) publish(ctx context.Context, eventType models.RatingEventType, rating *models.Rating) {
+	stats, err := s.ratingRepo.GetMovieStats(ctx, rating.MovieID)
+	if err != nil {
+		logger.Warnf("failed to load stats for rating event of movie %d: %v", rating.MovieID, err)
+		return
+	}
+
+	payload, err := json.Marshal(&models.RatingEvent{
+		Type:          eventType,
+		Rating:        rating,
+		AverageRating: stats.AverageRating,
+		TotalRatings:  stats.TotalRatings,
+		OccurredAt:    time.Now(),
+	})
+	if err != nil {
+		logger.Warnf("failed to encode rating event: %v", err)
+		return
+	}
+
+	if err := s.bus.Publish(ctx, ratingTopic(rating.MovieID), payload); err != nil {
+		logger.Warnf("failed to publish rating event of movie %d: %v", rating.MovieID, err)
+	}
+}
+
+// ratingTopic 电影评分事件的主题
+func ratingTopic(movieID int64) string {
+	return fmt.Sprintf("ratings.movie.%d", movieID)
+}
+
+// validateScore 校验评分分数
+func validateScore(score int32) error {
+	if score < minScore || score > maxScore {
+		return fmt.Errorf("score must be between %d and %d", minScore, maxScore)
+	}
+	return nil
+}
+
+// ratingError 将仓储错误转换为业务错误
+func ratingError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.New(apperror.NotFound, "rating not found")
+	}
+	return err
+}
+
+// requireCaller 要求请求已认证且调用方为 userID，未登录时返回 Unauthenticated，其他用户返回 PermissionDenied
+func requireCaller(ctx context.Context, userID int64, message string) error {
+	caller, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return apperror.New(apperror.Unauthenticated, "login required")
+	}
+	if caller != userID {
+		return apperror.New(apperror.PermissionDenied, message)
+	}
+	return nil
+}
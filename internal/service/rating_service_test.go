@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/eventbus"
+)
+
+// fakeRatingRepo 内存中的评分仓储，只实现测试用到的方法
+type fakeRatingRepo struct {
+	repository.RatingRepository
+	ratings []models.Rating
+}
+
+func (r *fakeRatingRepo) GetByID(ctx context.Context, id int64) (*models.Rating, error) {
+	for _, rating := range r.ratings {
+		if rating.ID == id {
+			return &rating, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *fakeRatingRepo) Create(ctx context.Context, rating *models.Rating) error {
+	rating.ID = int64(len(r.ratings) + 1)
+	r.ratings = append(r.ratings, *rating)
+	return nil
+}
+
+func (r *fakeRatingRepo) Update(ctx context.Context, rating *models.Rating) error {
+	for i := range r.ratings {
+		if r.ratings[i].ID == rating.ID {
+			r.ratings[i] = *rating
+		}
+	}
+	return nil
+}
+
+func (r *fakeRatingRepo) Delete(ctx context.Context, rating *models.Rating) error {
+	for i := range r.ratings {
+		if r.ratings[i].ID == rating.ID {
+			r.ratings = append(r.ratings[:i], r.ratings[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *fakeRatingRepo) GetMovieStats(ctx context.Context, movieID int64) (*models.MovieRatingStats, error) {
+	stats := &models.MovieRatingStats{MovieID: movieID}
+	var total int32
+	for _, rating := range r.ratings {
+		if rating.MovieID == movieID {
+			stats.TotalRatings++
+			total += rating.Score
+		}
+	}
+	if stats.TotalRatings > 0 {
+		stats.AverageRating = float64(total) / float64(stats.TotalRatings)
+	}
+	return stats, nil
+}
+
+// GetByUserAndMovies 按评分的存储顺序返回，调用方需要自行恢复请求中的顺序
+func (r *fakeRatingRepo) GetByUserAndMovies(ctx context.Context, userID int64, movieIDs []int64) ([]*models.Rating, error) {
+	var found []*models.Rating
+	for i := range r.ratings {
+		rating := r.ratings[i]
+		if rating.UserID != userID {
+			continue
+		}
+		for _, movieID := range movieIDs {
+			if rating.MovieID == movieID {
+				found = append(found, &rating)
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+func TestBatchGetUserRatings(t *testing.T) {
+	repo := &fakeRatingRepo{ratings: []models.Rating{
+		{ID: 1, UserID: 2, MovieID: 1, Score: 5},
+		{ID: 2, UserID: 2, MovieID: 2, Score: 3},
+		{ID: 3, UserID: 1, MovieID: 3, Score: 4},
+		{ID: 4, UserID: 2, MovieID: 4, Score: 1},
+	}}
+	s := NewRatingService(repo, eventbus.NewMemoryBus(1), nil)
+	tests := []struct {
+		name     string
+		userID   int64
+		movieIDs []int64
+		want     []int64 // 期望返回的评分ID
+		code     string
+	}{
+		{name: "request order kept", userID: 2, movieIDs: []int64{4, 1, 2}, want: []int64{4, 1, 2}},
+		{name: "unrated movies skipped", userID: 2, movieIDs: []int64{3, 2, 9}, want: []int64{2}},
+		{name: "other users' ratings skipped", userID: 1, movieIDs: []int64{1, 3}, want: []int64{3}},
+		{name: "missing user", movieIDs: []int64{1}, code: apperror.InvalidArgument.String()},
+		{name: "too many movie ids", userID: 2, movieIDs: make([]int64, maxBatchSize+1), code: apperror.InvalidArgument.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ratings, err := s.BatchGetUserRatings(context.Background(), tt.userID, tt.movieIDs)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("BatchGetUserRatings() = %q, want %q", code, tt.code)
+			}
+			var ids []int64
+			for _, rating := range ratings {
+				ids = append(ids, rating.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Fatalf("BatchGetUserRatings() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatingOwnership(t *testing.T) {
+	unauthenticated := apperror.Unauthenticated.String()
+	denied := apperror.PermissionDenied.String()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		// wantCreate 以用户2的身份评分的期望结果，wantChange 修改和删除用户2的评分的期望结果
+		wantCreate string
+		wantChange string
+	}{
+		{name: "owner", ctx: callerContext(2)},
+		{name: "other user", ctx: callerContext(1), wantCreate: denied, wantChange: denied},
+		{name: "anonymous", ctx: context.Background(), wantCreate: unauthenticated, wantChange: unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRatingRepo{ratings: []models.Rating{{ID: 1, UserID: 2, MovieID: 1, Score: 3}}}
+			s := NewRatingService(repo, eventbus.NewMemoryBus(1), nil)
+
+			_, err := s.CreateRating(tt.ctx, &models.Rating{UserID: 2, MovieID: 2, Score: 4})
+			if code := errCode(err); code != tt.wantCreate {
+				t.Fatalf("CreateRating() = %q, want %q", code, tt.wantCreate)
+			}
+			_, err = s.UpdateRating(tt.ctx, 1, 5, "changed")
+			if code := errCode(err); code != tt.wantChange {
+				t.Fatalf("UpdateRating() = %q, want %q", code, tt.wantChange)
+			}
+			if tt.wantChange != "" && repo.ratings[0].Score != 3 {
+				t.Fatalf("rating changed by a rejected update: %+v", repo.ratings[0])
+			}
+			err = s.DeleteRating(tt.ctx, 1)
+			if code := errCode(err); code != tt.wantChange {
+				t.Fatalf("DeleteRating() = %q, want %q", code, tt.wantChange)
+			}
+			if deleted := len(repo.ratings) == 0 || repo.ratings[0].ID != 1; deleted != (tt.wantChange == "") {
+				t.Fatalf("rating deleted = %v, want %v", deleted, tt.wantChange == "")
+			}
+		})
+	}
+}
+
+func TestWatchMovieRatings(t *testing.T) {
+	repo := &fakeRatingRepo{ratings: []models.Rating{{ID: 1, UserID: 1, MovieID: 1, Score: 3}}}
+	s := NewRatingService(repo, eventbus.NewMemoryBus(4), nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := s.WatchMovieRatings(ctx, 1)
+	if err != nil {
+		t.Fatalf("WatchMovieRatings() error = %v", err)
+	}
+	// 其他电影的评分不会出现在订阅中
+	if _, err := s.CreateRating(callerContext(2), &models.Rating{UserID: 2, MovieID: 2, Score: 1}); err != nil {
+		t.Fatalf("CreateRating() error = %v", err)
+	}
+	if _, err := s.CreateRating(callerContext(2), &models.Rating{UserID: 2, MovieID: 1, Score: 5}); err != nil {
+		t.Fatalf("CreateRating() error = %v", err)
+	}
+
+	select {
+	case event := <-events:
+		if event.Type != models.RatingCreated || event.Rating.MovieID != 1 || event.TotalRatings != 2 || event.AverageRating != 4 {
+			t.Fatalf("event = %+v, want created rating of movie 1 with average 4 over 2 ratings", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no rating event received")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("unexpected event after the watch ended")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("events channel not closed after ctx ended")
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+	"github.com/3inchtime/movieinfo/pkg/rbac"
+)
+
+// 权限名称，对应 permissions 表中的 name
+const (
+	PermissionMoviesWrite = "movies:write"
+	PermissionUsersRead   = "users:read"
+	PermissionUsersWrite  = "users:write"
+	PermissionRolesManage = "roles:manage"
+)
+
+// MethodPermissions 调用管理类方法所需的权限，未列出的方法不要求权限
+var MethodPermissions = rbac.Policy{
+	"/movieinfo.movie.MovieService/CreateMovie":      PermissionMoviesWrite,
+	"/movieinfo.movie.MovieService/UpdateMovie":      PermissionMoviesWrite,
+	"/movieinfo.movie.MovieService/DeleteMovie":      PermissionMoviesWrite,
+	"/movieinfo.movie.MovieService/BulkCreateMovies": PermissionMoviesWrite,
+	"/movieinfo.user.UserService/ListUsers":          PermissionUsersRead,
+	"/movieinfo.user.UserService/DeleteUser":         PermissionUsersWrite,
+	"/movieinfo.user.UserService/UnlockUser":         PermissionUsersWrite,
+	"/movieinfo.user.UserService/GrantRole":          PermissionRolesManage,
+	"/movieinfo.user.UserService/RevokeRole":         PermissionRolesManage,
+}
+
+// PermissionChecker 判断角色是否拥有权限，由 rbac.Authorizer 实现
+// 供需要在方法内部按资源判断权限的服务使用，如管理员修改其他用户的资料
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, roles []string, permission string) (bool, error)
+}
+
+// callerHasPermission 判断上下文中已认证的调用方是否拥有权限
+func callerHasPermission(ctx context.Context, checker PermissionChecker, permission string) (bool, error) {
+	if _, ok := auth.UserIDFromContext(ctx); !ok {
+		return false, nil
+	}
+	allowed, err := checker.HasPermission(ctx, auth.RolesFromContext(ctx), permission)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permission %s: %w", permission, err)
+	}
+	return allowed, nil
+}
+
+// RoleService 角色管理服务接口
+type RoleService interface {
+	// GrantRole 授予用户角色，新角色在用户下次刷新令牌后生效，返回用户当前的全部角色
+	GrantRole(ctx context.Context, userID int64, role string) ([]string, error)
+	// RevokeRole 撤销用户角色并注销其全部会话，返回用户当前的全部角色
+	RevokeRole(ctx context.Context, userID int64, role string) ([]string, error)
+}
+
+// roleService 角色管理服务实现
+type roleService struct {
+	userRepo repository.UserRepository
+	roleRepo repository.RoleRepository
+	sessions auth.Revocations
+}
+
+// NewRoleService 创建角色管理服务
+func NewRoleService(userRepo repository.UserRepository, roleRepo repository.RoleRepository, sessions auth.Revocations) RoleService {
+	return &roleService{
+		userRepo: userRepo,
+		roleRepo: roleRepo,
+		sessions: sessions,
+	}
+}
+
+// GrantRole 授予角色，操作人取自请求上下文中已认证的用户
+func (s *roleService) GrantRole(ctx context.Context, userID int64, role string) ([]string, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, userError(err)
+	}
+
+	operator, _ := auth.UserIDFromContext(ctx)
+	err := s.roleRepo.Grant(ctx, userID, role, operator)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return nil, apperror.Newf(apperror.NotFound, "role %s not found", role).WithField("role", "unknown role")
+	case errors.Is(err, repository.ErrAlreadyExists):
+		return nil, apperror.Newf(apperror.AlreadyExists, "user %d already has role %s", userID, role)
+	case err != nil:
+		return nil, fmt.Errorf("failed to grant role %s to user %d: %w", role, userID, err)
+	}
+
+	logger.Infof("role %s granted to user %d by user %d", role, userID, operator)
+	return s.roleRepo.GetUserRoles(ctx, userID)
+}
+
+// RevokeRole 撤销角色
+// 已签发的访问令牌仍然携带被撤销的角色，因此同时注销用户的全部会话，用户重新登录后生效
+// 不能撤销自己的角色，避免管理员误操作后无人可以管理角色
+func (s *roleService) RevokeRole(ctx context.Context, userID int64, role string) ([]string, error) {
+	operator, _ := auth.UserIDFromContext(ctx)
+	if operator == userID {
+		return nil, apperror.New(apperror.BusinessError, "cannot revoke your own role")
+	}
+
+	err := s.roleRepo.Revoke(ctx, userID, role)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.Newf(apperror.NotFound, "user %d does not have role %s", userID, role)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke role %s from user %d: %w", role, userID, err)
+	}
+	if err := s.sessions.RevokeAll(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions of user %d: %w", userID, err)
+	}
+
+	logger.Infof("role %s revoked from user %d by user %d, all sessions revoked", role, userID, operator)
+	return s.roleRepo.GetUserRoles(ctx, userID)
+}
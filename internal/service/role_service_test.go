@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+)
+
+func TestRevokeRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		caller int64
+		user   int64
+		role   string
+		want   string
+		// wantRoles 操作后用户的角色
+		wantRoles   []string
+		wantRevoked bool
+	}{
+		{
+			name:   "revoke own admin role",
+			caller: adminUserID, user: adminUserID, role: "admin",
+			want:      apperror.BusinessError.String(),
+			wantRoles: []string{"admin"},
+		},
+		{
+			name:   "revoke own role not held",
+			caller: adminUserID, user: adminUserID, role: "editor",
+			want:      apperror.BusinessError.String(),
+			wantRoles: []string{"admin"},
+		},
+		{
+			name:   "revoke role of another user",
+			caller: adminUserID, user: testUserID, role: "editor",
+			wantRoles:   []string{},
+			wantRevoked: true,
+		},
+		{
+			name:   "revoke role the user does not have",
+			caller: adminUserID, user: testUserID, role: "admin",
+			want:      apperror.NotFound.String(),
+			wantRoles: []string{"editor"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			roleRepo := repository.NewRoleRepository(db)
+			sessions := &fakeRevocations{}
+			s := NewRoleService(repository.NewUserRepository(db), roleRepo, sessions)
+			// 测试用户初始没有角色，先授予编辑角色
+			if _, err := s.GrantRole(callerContext(adminUserID, "admin"), testUserID, "editor"); err != nil {
+				t.Fatalf("GrantRole() error = %v", err)
+			}
+
+			_, err := s.RevokeRole(callerContext(tt.caller, "admin"), tt.user, tt.role)
+			if got := errCode(err); got != tt.want {
+				t.Fatalf("RevokeRole() = %q, want %q", got, tt.want)
+			}
+
+			roles, err := roleRepo.GetUserRoles(context.Background(), tt.user)
+			if err != nil {
+				t.Fatalf("GetUserRoles() error = %v", err)
+			}
+			if len(roles) != len(tt.wantRoles) || len(roles) > 0 && !reflect.DeepEqual(roles, tt.wantRoles) {
+				t.Fatalf("roles = %v, want %v", roles, tt.wantRoles)
+			}
+			if revoked := sessions.count(tt.user) > 0; revoked != tt.wantRevoked {
+				t.Fatalf("sessions revoked = %v, want %v", revoked, tt.wantRevoked)
+			}
+		})
+	}
+}
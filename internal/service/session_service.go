@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// SessionService 登录会话管理服务接口，只能管理自己的会话
+type SessionService interface {
+	// ListSessions 返回用户当前有效的会话，按最近活动时间倒序
+	// 发起请求的会话ID可以通过 auth.SessionIDFromContext 获取
+	ListSessions(ctx context.Context, userID int64) ([]*models.UserSession, error)
+	// RevokeSession 注销指定会话，该会话的访问令牌和刷新令牌立即失效
+	RevokeSession(ctx context.Context, userID int64, sessionID string) error
+	// RevokeAllOtherSessions 注销除当前会话以外的全部会话，返回注销的会话数量
+	RevokeAllOtherSessions(ctx context.Context, userID int64) (int, error)
+}
+
+// sessionService 登录会话管理服务实现
+type sessionService struct {
+	sessions *auth.Sessions
+}
+
+// NewSessionService 创建登录会话管理服务
+func NewSessionService(sessions *auth.Sessions) SessionService {
+	return &sessionService{sessions: sessions}
+}
+
+// ListSessions 列出会话，已注销的会话不返回
+func (s *sessionService) ListSessions(ctx context.Context, userID int64) ([]*models.UserSession, error) {
+	if err := selfSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessions.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions of user %d: %w", userID, err)
+	}
+	result := make([]*models.UserSession, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, sessionModel(session))
+	}
+	return result, nil
+}
+
+// RevokeSession 注销会话，也可以注销当前会话
+func (s *sessionService) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	if err := selfSessions(ctx, userID); err != nil {
+		return err
+	}
+
+	err := s.sessions.Revoke(ctx, userID, sessionID)
+	if errors.Is(err, auth.ErrSessionNotFound) {
+		return apperror.New(apperror.NotFound, "session not found").WithField("session_id", "unknown session")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to revoke session of user %d: %w", userID, err)
+	}
+	logger.Infof("session %s of user %d revoked", sessionID, userID)
+	return nil
+}
+
+// RevokeAllOtherSessions 注销其他会话，请求需要携带访问令牌以确定当前会话
+func (s *sessionService) RevokeAllOtherSessions(ctx context.Context, userID int64) (int, error) {
+	if err := selfSessions(ctx, userID); err != nil {
+		return 0, err
+	}
+	current := auth.SessionIDFromContext(ctx)
+	if current == "" {
+		return 0, apperror.New(apperror.Unauthenticated, "access token is required to identify the current session")
+	}
+
+	revoked, err := s.sessions.RevokeOthers(ctx, userID, current)
+	if err != nil {
+		return revoked, fmt.Errorf("failed to revoke other sessions of user %d: %w", userID, err)
+	}
+	logger.Infof("%d other sessions of user %d revoked", revoked, userID)
+	return revoked, nil
+}
+
+// selfSessions 只能管理自己的会话
+func selfSessions(ctx context.Context, userID int64) error {
+	if caller, _ := auth.UserIDFromContext(ctx); caller != userID {
+		return apperror.New(apperror.PermissionDenied, "can only manage your own sessions")
+	}
+	return nil
+}
+
+// sessionStore 基于会话仓储的会话记录存储，作为缓存中会话记录的持久化备份
+type sessionStore struct {
+	repo repository.SessionRepository
+}
+
+// NewSessionStore 将会话仓储适配为 auth.SessionStore
+func NewSessionStore(repo repository.SessionRepository) auth.SessionStore {
+	return &sessionStore{repo: repo}
+}
+
+func (s *sessionStore) Create(ctx context.Context, session *auth.SessionInfo) error {
+	err := s.repo.Create(ctx, sessionModel(session))
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return nil
+	}
+	return err
+}
+
+func (s *sessionStore) Get(ctx context.Context, sessionID string) (*auth.SessionInfo, error) {
+	session, err := s.repo.Get(ctx, sessionID)
+	if err != nil {
+		return nil, sessionStoreError(err)
+	}
+	return sessionInfo(session), nil
+}
+
+func (s *sessionStore) List(ctx context.Context, userID int64) ([]*auth.SessionInfo, error) {
+	sessions, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*auth.SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, sessionInfo(session))
+	}
+	return result, nil
+}
+
+func (s *sessionStore) Touch(ctx context.Context, sessionID string, seenAt time.Time, ip string) error {
+	return sessionStoreError(s.repo.Touch(ctx, sessionID, seenAt, ip))
+}
+
+func (s *sessionStore) Delete(ctx context.Context, userID int64, sessionID string) error {
+	return s.repo.Delete(ctx, sessionID)
+}
+
+// sessionStoreError 将仓储的 ErrNotFound 转换为 auth.ErrSessionNotFound
+func sessionStoreError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return auth.ErrSessionNotFound
+	}
+	return err
+}
+
+// sessionModel 将会话记录转换为模型
+func sessionModel(session *auth.SessionInfo) *models.UserSession {
+	return &models.UserSession{
+		ID:         session.ID,
+		UserID:     session.UserID,
+		Device:     session.Device,
+		IPAddress:  session.IP,
+		UserAgent:  session.UserAgent,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
+
+// sessionInfo 将模型转换为会话记录
+func sessionInfo(session *models.UserSession) *auth.SessionInfo {
+	return &auth.SessionInfo{
+		ID:         session.ID,
+		UserID:     session.UserID,
+		Device:     session.Device,
+		IP:         session.IPAddress,
+		UserAgent:  session.UserAgent,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
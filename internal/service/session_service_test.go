@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+)
+
+// newTestSessionService 创建会话记录持久化到SQLite的会话管理服务，并为测试用户登录 n 次
+func newTestSessionService(t *testing.T, n int) (SessionService, []*auth.TokenPair) {
+	t.Helper()
+	sessions, err := auth.NewSessions(&auth.Config{
+		Secret:            "test-secret",
+		ExpireTime:        time.Minute,
+		Issuer:            "movieinfo",
+		RefreshExpireTime: time.Hour,
+		Store:             "memory",
+	}, nil, nil, NewSessionStore(repository.NewSessionRepository(newTestDB(t))))
+	if err != nil {
+		t.Fatalf("NewSessions() error = %v", err)
+	}
+
+	pairs := make([]*auth.TokenPair, 0, n)
+	for i := 0; i < n; i++ {
+		pair, err := sessions.Create(context.Background(), testUserID)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		pairs = append(pairs, pair)
+	}
+	return NewSessionService(sessions), pairs
+}
+
+// listSessionIDs 返回测试用户当前有效会话的编号
+func listSessionIDs(t *testing.T, s SessionService) []string {
+	t.Helper()
+	sessions, err := s.ListSessions(callerContext(testUserID), testUserID)
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	return ids
+}
+
+func TestRevokeSession(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		sessionID func(pairs []*auth.TokenPair) string
+		code      string
+	}{
+		{
+			name:      "own session",
+			ctx:       callerContext(testUserID),
+			sessionID: func(pairs []*auth.TokenPair) string { return pairs[0].SessionID },
+		},
+		{
+			name:      "unknown session",
+			ctx:       callerContext(testUserID),
+			sessionID: func(pairs []*auth.TokenPair) string { return "unknown" },
+			code:      apperror.NotFound.String(),
+		},
+		{
+			name:      "other user's session",
+			ctx:       callerContext(adminUserID, "admin"),
+			sessionID: func(pairs []*auth.TokenPair) string { return pairs[0].SessionID },
+			code:      apperror.PermissionDenied.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, pairs := newTestSessionService(t, 2)
+			err := s.RevokeSession(tt.ctx, testUserID, tt.sessionID(pairs))
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("RevokeSession() = %q, want %q", code, tt.code)
+			}
+
+			want := []string{pairs[1].SessionID, pairs[0].SessionID}
+			if err == nil {
+				want = want[:1]
+			}
+			if ids := listSessionIDs(t, s); !sameElements(ids, want) {
+				t.Fatalf("ListSessions() = %v, want %v", ids, want)
+			}
+		})
+	}
+}
+
+func TestRevokeAllOtherSessions(t *testing.T) {
+	s, pairs := newTestSessionService(t, 3)
+
+	// 没有访问令牌时无法确定当前会话
+	_, err := s.RevokeAllOtherSessions(callerContext(testUserID), testUserID)
+	if code, want := errCode(err), apperror.Unauthenticated.String(); code != want {
+		t.Fatalf("RevokeAllOtherSessions() without session = %q, want %q", code, want)
+	}
+
+	current := pairs[2].SessionID
+	ctx := auth.WithSessionID(callerContext(testUserID), current)
+	revoked, err := s.RevokeAllOtherSessions(ctx, testUserID)
+	if err != nil {
+		t.Fatalf("RevokeAllOtherSessions() error = %v", err)
+	}
+	if revoked != 2 {
+		t.Fatalf("RevokeAllOtherSessions() = %d, want 2", revoked)
+	}
+	if ids, want := listSessionIDs(t, s), []string{current}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ListSessions() = %v, want %v", ids, want)
+	}
+}
+
+// sameElements 判断两个切片是否包含相同的元素，不考虑顺序
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		counts[v]--
+		if counts[v] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,462 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/lockout"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+	"github.com/3inchtime/movieinfo/pkg/onetimecode"
+	"github.com/3inchtime/movieinfo/pkg/totp"
+)
+
+// twoFactorChallengePurpose 两步验证登录挑战令牌的用途
+const twoFactorChallengePurpose = "two_factor_challenge"
+
+// recoveryCodeLength 恢复码的字符数，展示时每5个字符用 - 分隔
+const recoveryCodeLength = 10
+
+// recoveryCodeAlphabet 恢复码使用的字符（Crockford Base32），不含容易混淆的 i、l、o、u
+const recoveryCodeAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// TwoFactorConfig 两步验证配置
+type TwoFactorConfig struct {
+	Store                string        `yaml:"store" validate:"omitempty,oneof=memory redis"` // 登录挑战令牌的存储
+	KeyPrefix            string        `yaml:"key_prefix"`
+	Issuer               string        `yaml:"issuer"`                                  // 验证器应用中显示的发行方
+	Digits               int           `yaml:"digits" validate:"omitempty,oneof=6 8"`   // 动态码位数
+	Period               time.Duration `yaml:"period"`                                  // 动态码有效周期
+	Skew                 int           `yaml:"skew" validate:"min=0,max=2"`             // 允许前后偏差的周期数
+	ChallengeTTL         time.Duration `yaml:"challenge_ttl"`                           // 登录挑战令牌有效期
+	MaxChallengeAttempts int           `yaml:"max_challenge_attempts" validate:"min=0"` // 每个登录挑战最多允许输错动态码的次数
+	RecoveryCodes        int           `yaml:"recovery_codes" validate:"min=1,max=20"`  // 每次生成的恢复码数量
+	RequiredUsers        []string      `yaml:"required_users"`                          // 必须启用两步验证的用户名
+}
+
+// TOTPEnrollment TOTP登记信息，用户在验证器应用中添加账号后提交首个动态码确认
+type TOTPEnrollment struct {
+	Secret string // Base32 编码的密钥，无法扫码时手动输入
+	URI    string // otpauth URI
+	QRCode []byte // otpauth URI 的二维码，PNG格式
+}
+
+// TwoFactorChallenge 密码校验通过后需要提交动态码才能完成登录
+type TwoFactorChallenge struct {
+	Token         string        // 挑战令牌，格式为 <用户ID>.<随机串>
+	ExpiresIn     time.Duration // 有效期
+	SetupRequired bool          // 账号必须启用两步验证但尚未登记，需要先凭挑战令牌登记
+}
+
+// TwoFactorService 两步验证服务
+type TwoFactorService interface {
+	// EnrollTOTP 生成新的密钥作为待确认的登记，之前未确认的登记随之失效
+	// 已登录用户只能为自己登记；必须启用两步验证的用户在登录时凭挑战令牌登记
+	EnrollTOTP(ctx context.Context, userID int64, challengeToken string) (*TOTPEnrollment, error)
+	// ConfirmTOTP 使用首个动态码确认登记并启用两步验证，返回恢复码
+	ConfirmTOTP(ctx context.Context, userID int64, code string) ([]string, error)
+	// DisableTOTP 校验动态码或恢复码后关闭两步验证
+	DisableTOTP(ctx context.Context, userID int64, code string) error
+	// RegenerateRecoveryCodes 校验动态码或恢复码后重新生成恢复码，旧的恢复码全部失效
+	RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) ([]string, error)
+	// Challenge 用户已启用或必须启用两步验证时签发登录挑战，否则返回 nil
+	Challenge(ctx context.Context, user *models.User) (*TwoFactorChallenge, error)
+	// VerifyChallenge 校验挑战令牌和动态码或恢复码，成功后挑战令牌失效
+	// 凭挑战令牌登记的用户在此完成确认，同时返回生成的恢复码
+	VerifyChallenge(ctx context.Context, challengeToken, code string) (*models.User, []string, error)
+}
+
+// twoFactorService 两步验证服务实现
+type twoFactorService struct {
+	config     *TwoFactorConfig
+	userRepo   repository.UserRepository
+	totpRepo   repository.TOTPRepository
+	totp       *totp.TOTP
+	challenges *onetimecode.Codes
+	guard      *lockout.Guard
+}
+
+// NewTwoFactorService 创建两步验证服务
+func NewTwoFactorService(config *TwoFactorConfig, userRepo repository.UserRepository, totpRepo repository.TOTPRepository,
+	challenges *onetimecode.Codes, guard *lockout.Guard) TwoFactorService {
+	return &twoFactorService{
+		config:   config,
+		userRepo: userRepo,
+		totpRepo: totpRepo,
+		totp: totp.New(&totp.Config{
+			Issuer: config.Issuer,
+			Digits: config.Digits,
+			Period: config.Period,
+			Skew:   config.Skew,
+		}),
+		challenges: challenges,
+		guard:      guard,
+	}
+}
+
+// EnrollTOTP 登记TOTP，已启用时需要先关闭
+func (s *twoFactorService) EnrollTOTP(ctx context.Context, userID int64, challengeToken string) (*TOTPEnrollment, error) {
+	var (
+		user *models.User
+		err  error
+	)
+	if challengeToken != "" {
+		user, _, err = s.challengeUser(ctx, challengeToken)
+		if err == nil && user.ID != userID {
+			err = errInvalidChallenge()
+		}
+	} else {
+		user, err = s.self(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	err = s.totpRepo.SavePending(ctx, userID, secret)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return nil, apperror.New(apperror.AlreadyExists, "two-factor authentication already enabled")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save totp enrollment of user %d: %w", userID, err)
+	}
+
+	uri := s.totp.URI(user.Username, secret)
+	qrCode, err := totp.QRCode(uri)
+	if err != nil {
+		return nil, err
+	}
+	return &TOTPEnrollment{Secret: secret, URI: uri, QRCode: qrCode}, nil
+}
+
+// ConfirmTOTP 确认登记
+func (s *twoFactorService) ConfirmTOTP(ctx context.Context, userID int64, code string) ([]string, error) {
+	if _, err := s.self(ctx, userID); err != nil {
+		return nil, err
+	}
+	current, err := s.getTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil || current.Enabled {
+		return nil, apperror.New(apperror.BusinessError, "no pending two-factor enrollment")
+	}
+
+	codes, err := s.verifyCode(ctx, current, code)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("two-factor authentication enabled for user %d", userID)
+	return codes, nil
+}
+
+// DisableTOTP 关闭两步验证，必须启用两步验证的用户不能关闭
+func (s *twoFactorService) DisableTOTP(ctx context.Context, userID int64, code string) error {
+	user, err := s.self(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if s.required(user) {
+		return apperror.New(apperror.BusinessError, "two-factor authentication is required for this account")
+	}
+	if err := s.verifyEnabled(ctx, userID, code); err != nil {
+		return err
+	}
+
+	if err := s.totpRepo.Delete(ctx, userID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to disable totp of user %d: %w", userID, err)
+	}
+	logger.Infof("two-factor authentication disabled for user %d", userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+func (s *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) ([]string, error) {
+	if _, err := s.self(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := s.verifyEnabled(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := s.newRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.totpRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to replace recovery codes of user %d: %w", userID, err)
+	}
+	logger.Infof("recovery codes of user %d regenerated", userID)
+	return codes, nil
+}
+
+// Challenge 签发登录挑战，同一用户新的挑战令牌使之前的令牌失效
+func (s *twoFactorService) Challenge(ctx context.Context, user *models.User) (*TwoFactorChallenge, error) {
+	current, err := s.getTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	enabled := current != nil && current.Enabled
+	if !enabled && !s.required(user) {
+		return nil, nil
+	}
+
+	secret, err := s.challenges.IssueToken(ctx, twoFactorChallengePurpose, challengeSubject(user.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue two-factor challenge: %w", err)
+	}
+	return &TwoFactorChallenge{
+		Token:         challengeSubject(user.ID) + "." + secret,
+		ExpiresIn:     s.challenges.TTL(),
+		SetupRequired: !enabled,
+	}, nil
+}
+
+// VerifyChallenge 校验登录挑战，动态码错误时挑战令牌仍然有效，可以重试，错误次数达到上限后需要重新登录
+func (s *twoFactorService) VerifyChallenge(ctx context.Context, challengeToken, code string) (*models.User, []string, error) {
+	user, secret, err := s.challengeUser(ctx, challengeToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	current, err := s.getTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if current == nil {
+		return nil, nil, apperror.New(apperror.BusinessError, "two-factor authentication must be enrolled before login")
+	}
+
+	codes, err := s.verifyCode(ctx, current, code)
+	if appErr, ok := apperror.As(err); ok && appErr.Code == apperror.InvalidArgument {
+		// 每个挑战令牌允许输错的次数独立于按账号和IP的限制，达到上限后挑战令牌失效，需要重新校验密码
+		if err := s.challenges.Fail(ctx, twoFactorChallengePurpose, challengeSubject(user.ID)); err != nil {
+			return nil, nil, challengeAttemptsError(err)
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.challenges.Consume(ctx, twoFactorChallengePurpose, challengeSubject(user.ID), secret); err != nil {
+		return nil, nil, challengeError(err)
+	}
+	if codes != nil {
+		logger.Infof("two-factor authentication enabled for user %d during login", user.ID)
+	}
+	return user, codes, nil
+}
+
+// verifyEnabled 校验已启用两步验证的用户提交的动态码或恢复码
+func (s *twoFactorService) verifyEnabled(ctx context.Context, userID int64, code string) error {
+	current, err := s.getTOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if current == nil || !current.Enabled {
+		return apperror.New(apperror.BusinessError, "two-factor authentication is not enabled")
+	}
+	_, err = s.verifyCode(ctx, current, code)
+	return err
+}
+
+// verifyCode 校验动态码，失败次数与登录失败一样按账号和IP限制
+// 已启用时接受动态码或恢复码，每个动态码和恢复码只能使用一次；
+// 待确认的登记只接受动态码，确认成功后启用并返回生成的恢复码
+func (s *twoFactorService) verifyCode(ctx context.Context, current *models.UserTOTP, code string) ([]string, error) {
+	account := twoFactorAccount(current.UserID)
+	ip := auth.ClientIP(ctx)
+	if wait := s.guard.Reserve(ctx, account, ip); wait > 0 {
+		return nil, errTooManyAttempts(wait)
+	}
+
+	var (
+		codes []string
+		ok    bool
+		err   error
+	)
+	switch {
+	case !current.Enabled:
+		codes, ok, err = s.enable(ctx, current, code)
+	case len(normalizeRecoveryCode(code)) == recoveryCodeLength:
+		ok, err = s.totpRepo.UseRecoveryCode(ctx, current.UserID, hashRecoveryCode(current.UserID, code))
+		if ok {
+			s.warnRecoveryCodes(ctx, current.UserID)
+		}
+	default:
+		step, valid := s.totp.Validate(current.Secret, code, time.Now())
+		if valid {
+			ok, err = s.totpRepo.UseStep(ctx, current.UserID, step)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, apperror.New(apperror.InvalidArgument, "invalid two-factor code").
+			WithField("code", "invalid or already used code")
+	}
+
+	if err := s.guard.Succeed(ctx, account, ip); err != nil {
+		logger.Warnf("failed to reset two-factor failures of user %d: %v", current.UserID, err)
+	}
+	return codes, nil
+}
+
+// enable 使用首个动态码确认待确认的登记
+func (s *twoFactorService) enable(ctx context.Context, current *models.UserTOTP, code string) ([]string, bool, error) {
+	step, valid := s.totp.Validate(current.Secret, code, time.Now())
+	if !valid {
+		return nil, false, nil
+	}
+
+	codes, hashes, err := s.newRecoveryCodes(current.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+	err = s.totpRepo.Enable(ctx, current.UserID, step, hashes)
+	if errors.Is(err, repository.ErrNotFound) {
+		// 并发确认时另一个请求已经启用
+		return nil, false, apperror.New(apperror.BusinessError, "no pending two-factor enrollment")
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to enable totp of user %d: %w", current.UserID, err)
+	}
+	return codes, true, nil
+}
+
+// warnRecoveryCodes 恢复码用完时记录日志，用户需要重新生成恢复码
+func (s *twoFactorService) warnRecoveryCodes(ctx context.Context, userID int64) {
+	remaining, err := s.totpRepo.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		logger.Warnf("failed to count recovery codes of user %d: %v", userID, err)
+		return
+	}
+	logger.Infof("recovery code of user %d used, %d remaining", userID, remaining)
+}
+
+// self 获取当前登录的用户，只能操作自己的两步验证设置
+func (s *twoFactorService) self(ctx context.Context, userID int64) (*models.User, error) {
+	if caller, _ := auth.UserIDFromContext(ctx); caller != userID {
+		return nil, apperror.New(apperror.PermissionDenied, "can only manage your own two-factor authentication")
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, userError(err)
+	}
+	return user, nil
+}
+
+// getTOTP 获取用户的TOTP设置，未登记时返回 nil
+func (s *twoFactorService) getTOTP(ctx context.Context, userID int64) (*models.UserTOTP, error) {
+	current, err := s.totpRepo.Get(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// challengeUser 校验挑战令牌，返回挑战对应的用户和令牌中的随机串，令牌仍然有效
+func (s *twoFactorService) challengeUser(ctx context.Context, challengeToken string) (*models.User, string, error) {
+	id, secret, ok := strings.Cut(challengeToken, ".")
+	userID, err := strconv.ParseInt(id, 10, 64)
+	if !ok || err != nil || userID <= 0 || secret == "" {
+		return nil, "", errInvalidChallenge()
+	}
+	if err := s.challenges.Verify(ctx, twoFactorChallengePurpose, challengeSubject(userID), secret); err != nil {
+		return nil, "", challengeError(err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, "", errInvalidChallenge()
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return user, secret, nil
+}
+
+// required 判断用户是否必须启用两步验证
+func (s *twoFactorService) required(user *models.User) bool {
+	for _, username := range s.config.RequiredUsers {
+		if strings.EqualFold(username, user.Username) {
+			return true
+		}
+	}
+	return false
+}
+
+// newRecoveryCodes 生成恢复码，返回展示给用户的恢复码和保存的哈希
+func (s *twoFactorService) newRecoveryCodes(userID int64) ([]string, []string, error) {
+	codes := make([]string, s.config.RecoveryCodes)
+	hashes := make([]string, s.config.RecoveryCodes)
+	for i := range codes {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+		codes[i] = string(b[:recoveryCodeLength/2]) + "-" + string(b[recoveryCodeLength/2:])
+		hashes[i] = hashRecoveryCode(userID, codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode 统一恢复码格式，忽略大小写、空格和分隔符
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// hashRecoveryCode 计算恢复码的哈希，加入用户ID，相同的恢复码在不同用户下哈希不同
+func hashRecoveryCode(userID int64, code string) string {
+	sum := sha256.Sum256([]byte(strconv.FormatInt(userID, 10) + "\x00" + normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// challengeSubject 挑战令牌绑定的对象
+func challengeSubject(userID int64) string {
+	return strconv.FormatInt(userID, 10)
+}
+
+// twoFactorAccount 动态码失败计数的账号键，与密码失败分开计数，密码正确不会清除动态码的失败次数
+func twoFactorAccount(userID int64) string {
+	return "2fa:" + strconv.FormatInt(userID, 10)
+}
+
+// challengeError 将挑战令牌错误转换为业务错误
+func challengeError(err error) error {
+	if errors.Is(err, onetimecode.ErrInvalid) || errors.Is(err, onetimecode.ErrTooManyAttempts) {
+		return errInvalidChallenge()
+	}
+	return fmt.Errorf("failed to verify two-factor challenge: %w", err)
+}
+
+// challengeAttemptsError 将记录动态码错误时的挑战令牌错误转换为业务错误
+func challengeAttemptsError(err error) error {
+	if errors.Is(err, onetimecode.ErrTooManyAttempts) {
+		return apperror.New(apperror.Unauthenticated, "too many invalid two-factor codes, please log in again")
+	}
+	return challengeError(err)
+}
+
+// errInvalidChallenge 挑战令牌无效或已过期，需要重新登录
+func errInvalidChallenge() error {
+	return apperror.New(apperror.Unauthenticated, "invalid or expired two-factor challenge, please log in again")
+}
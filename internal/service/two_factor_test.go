@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/lockout"
+	"github.com/3inchtime/movieinfo/pkg/onetimecode"
+	"github.com/3inchtime/movieinfo/pkg/totp"
+)
+
+// newTestTwoFactor 创建两步验证服务，登录保护关闭，避免错误尝试影响后续步骤
+func newTestTwoFactor(t *testing.T) *twoFactorService {
+	t.Helper()
+	db := newTestDB(t)
+	config := &TwoFactorConfig{Skew: 1, RecoveryCodes: 3}
+	service := NewTwoFactorService(config, repository.NewUserRepository(db), repository.NewTOTPRepository(db),
+		onetimecode.New(&onetimecode.Config{}, onetimecode.NewMemoryStore()),
+		lockout.New(&lockout.Config{}, lockout.NewMemoryStore()))
+	return service.(*twoFactorService)
+}
+
+// enrolledTOTP 为测试用户登记并启用两步验证，返回密钥和恢复码，确认时使用上一个周期的动态码
+func enrolledTOTP(t *testing.T, s *twoFactorService) (string, []string) {
+	t.Helper()
+	// 动态码按当前时间计算，临近周期切换时等到下一个周期，避免测试过程中跨越周期
+	if left := 30*time.Second - time.Duration(time.Now().UnixNano())%(30*time.Second); left < 2*time.Second {
+		time.Sleep(left)
+	}
+	ctx := callerContext(testUserID)
+	enrollment, err := s.EnrollTOTP(ctx, testUserID, "")
+	if err != nil {
+		t.Fatalf("EnrollTOTP() error = %v", err)
+	}
+	code, err := s.totp.Code(enrollment.Secret, time.Now().Add(-30*time.Second))
+	if err != nil {
+		t.Fatalf("Code() error = %v", err)
+	}
+	recoveryCodes, err := s.ConfirmTOTP(ctx, testUserID, code)
+	if err != nil {
+		t.Fatalf("ConfirmTOTP() error = %v", err)
+	}
+	return enrollment.Secret, recoveryCodes
+}
+
+func TestTwoFactorCodeReuse(t *testing.T) {
+	// code 根据密钥和恢复码生成一次提交的动态码或恢复码
+	type code func(t *testing.T, tp *totp.TOTP, secret string, recovery []string) string
+	stepCode := func(offset int) code {
+		return func(t *testing.T, tp *totp.TOTP, secret string, recovery []string) string {
+			c, err := tp.Code(secret, time.Now().Add(time.Duration(offset)*30*time.Second))
+			if err != nil {
+				t.Fatalf("Code() error = %v", err)
+			}
+			return c
+		}
+	}
+	recoveryCode := func(i int, format func(string) string) code {
+		return func(t *testing.T, tp *totp.TOTP, secret string, recovery []string) string {
+			return format(recovery[i])
+		}
+	}
+	same := func(c string) string { return c }
+	compact := func(c string) string { return strings.ToUpper(strings.ReplaceAll(c, "-", "")) }
+
+	invalid := apperror.InvalidArgument.String()
+	tests := []struct {
+		name  string
+		codes []code
+		want  []string
+	}{
+		{
+			name:  "step used for confirmation is rejected",
+			codes: []code{stepCode(-1)},
+			want:  []string{invalid},
+		},
+		{
+			name:  "later step accepted once",
+			codes: []code{stepCode(0), stepCode(0)},
+			want:  []string{"", invalid},
+		},
+		{
+			name:  "earlier step rejected after a later one",
+			codes: []code{stepCode(1), stepCode(0)},
+			want:  []string{"", invalid},
+		},
+		{
+			name:  "step outside skew rejected",
+			codes: []code{stepCode(2)},
+			want:  []string{invalid},
+		},
+		{
+			name:  "recovery code accepted once",
+			codes: []code{recoveryCode(0, same), recoveryCode(0, same), recoveryCode(1, same)},
+			want:  []string{"", invalid, ""},
+		},
+		{
+			name:  "recovery code ignores case and separators",
+			codes: []code{recoveryCode(2, compact), recoveryCode(2, same)},
+			want:  []string{"", invalid},
+		},
+		{
+			name: "wrong recovery code",
+			codes: []code{func(*testing.T, *totp.TOTP, string, []string) string {
+				return "00000-00000"
+			}},
+			want: []string{invalid},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestTwoFactor(t)
+			secret, recovery := enrolledTOTP(t, s)
+			for i, c := range tt.codes {
+				err := s.verifyEnabled(context.Background(), testUserID, c(t, s.totp, secret, recovery))
+				if got := errCode(err); got != tt.want[i] {
+					t.Fatalf("code #%d: got %q, want %q", i+1, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyChallengeAttempts(t *testing.T) {
+	s := newTestTwoFactor(t)
+	secret, _ := enrolledTOTP(t, s)
+	ctx := context.Background()
+	user, err := s.userRepo.GetByID(ctx, testUserID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	challenge, err := s.Challenge(ctx, user)
+	if err != nil {
+		t.Fatalf("Challenge() error = %v", err)
+	}
+
+	// 第5次输错后挑战令牌失效，正确的动态码也不再接受
+	const wrong = "00000-00000"
+	invalid := apperror.InvalidArgument.String()
+	unauthenticated := apperror.Unauthenticated.String()
+	want := []string{invalid, invalid, invalid, invalid, unauthenticated}
+	for i, w := range want {
+		_, _, err := s.VerifyChallenge(ctx, challenge.Token, wrong)
+		if got := errCode(err); got != w {
+			t.Fatalf("VerifyChallenge() #%d = %q, want %q", i+1, got, w)
+		}
+	}
+	code, err := s.totp.Code(secret, time.Now().Add(30*time.Second))
+	if err != nil {
+		t.Fatalf("Code() error = %v", err)
+	}
+	if _, _, err := s.VerifyChallenge(ctx, challenge.Token, code); errCode(err) != unauthenticated {
+		t.Fatalf("VerifyChallenge() with exhausted challenge = %q, want %q", errCode(err), unauthenticated)
+	}
+
+	// 重新登录签发的挑战令牌重新计数
+	challenge, err = s.Challenge(ctx, user)
+	if err != nil {
+		t.Fatalf("Challenge() error = %v", err)
+	}
+	if _, _, err := s.VerifyChallenge(ctx, challenge.Token, code); err != nil {
+		t.Fatalf("VerifyChallenge() with new challenge error = %v", err)
+	}
+}
+
+func TestRegenerateRecoveryCodes(t *testing.T) {
+	s := newTestTwoFactor(t)
+	ctx := callerContext(testUserID)
+	_, old := enrolledTOTP(t, s)
+
+	fresh, err := s.RegenerateRecoveryCodes(ctx, testUserID, old[0])
+	if err != nil {
+		t.Fatalf("RegenerateRecoveryCodes() error = %v", err)
+	}
+	if len(fresh) != 3 {
+		t.Fatalf("got %d recovery codes, want 3", len(fresh))
+	}
+
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{name: "old code invalidated", code: old[1], want: apperror.InvalidArgument.String()},
+		{name: "new code accepted", code: fresh[0]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errCode(s.verifyEnabled(ctx, testUserID, tt.code)); got != tt.want {
+				t.Fatalf("verifyEnabled() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTwoFactorSelfOnly(t *testing.T) {
+	s := newTestTwoFactor(t)
+	tests := []struct {
+		name   string
+		caller int64
+		want   string
+	}{
+		{name: "own account", caller: testUserID},
+		{name: "other account", caller: adminUserID, want: apperror.PermissionDenied.String()},
+		{name: "anonymous", want: apperror.PermissionDenied.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.caller != 0 {
+				ctx = callerContext(tt.caller)
+			}
+			_, err := s.EnrollTOTP(ctx, testUserID, "")
+			if got := errCode(err); got != tt.want {
+				t.Fatalf("EnrollTOTP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/fieldmask"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+	"github.com/3inchtime/movieinfo/pkg/password"
+)
+
+// userUpdatePolicy UpdateUserRequest.update_mask 的字段掩码策略
+var userUpdatePolicy = fieldmask.Policy{
+	Columns: map[string]string{
+		"nickname": "nickname",
+		"avatar":   "avatar_url",
+		"email":    "email",
+	},
+	Immutable: []string{"id", "username", "status", "email_verified", "created_at", "updated_at"},
+}
+
+// UserService 用户服务接口
+type UserService interface {
+	// CreateUser 注册用户并发送邮箱验证邮件
+	CreateUser(ctx context.Context, user *models.User, password string) (*models.User, error)
+	// UpdateUser 更新用户资料，只能更新自己的资料，拥有 users:write 权限的管理员可以更新任意用户
+	// paths 为 update_mask 中的字段路径，修改邮箱后需要重新验证
+	UpdateUser(ctx context.Context, user *models.User, paths []string) (*models.User, error)
+	// ChangePassword 校验旧密码后修改当前登录用户的密码，并注销该用户的所有会话
+	ChangePassword(ctx context.Context, userID int64, oldPassword, newPassword string) error
+}
+
+// userService 用户服务实现
+type userService struct {
+	userRepo     repository.UserRepository
+	verification EmailVerificationService
+	hasher       *password.Hasher
+	policy       *password.Policy
+	sessions     auth.Revocations
+	permissions  PermissionChecker
+}
+
+// NewUserService 创建用户服务
+func NewUserService(userRepo repository.UserRepository, verification EmailVerificationService,
+	hasher *password.Hasher, policy *password.Policy, sessions auth.Revocations, permissions PermissionChecker) UserService {
+	return &userService{
+		userRepo:     userRepo,
+		verification: verification,
+		hasher:       hasher,
+		policy:       policy,
+		sessions:     sessions,
+		permissions:  permissions,
+	}
+}
+
+// CreateUser 注册用户，验证邮件发送失败不影响注册，用户可以稍后重新发送
+func (s *userService) CreateUser(ctx context.Context, user *models.User, password string) (*models.User, error) {
+	hash, err := hashNewPassword(s.hasher, s.policy, "password", password)
+	if err != nil {
+		return nil, err
+	}
+	user.Email = normalizeEmail(user.Email)
+	user.PasswordHash = hash
+	user.Status = models.UserStatusActive
+	user.EmailVerified = false
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, apperror.New(apperror.AlreadyExists, "username or email already registered")
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	created, err := s.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		return nil, userError(err)
+	}
+	s.sendVerification(ctx, created)
+	return created, nil
+}
+
+// UpdateUser 更新用户资料
+// 修改邮箱后可以通过找回密码接管账号，因此在处理任何字段之前先检查调用方
+// 携带字段掩码时只更新掩码中的字段，允许将字段清空；
+// 未携带时只更新非零值字段，与旧客户端保持兼容
+func (s *userService) UpdateUser(ctx context.Context, user *models.User, paths []string) (*models.User, error) {
+	if err := s.authorizeUpdate(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		paths = nonZeroUserPaths(user)
+	}
+
+	columns, err := userUpdatePolicy.Resolve(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		return nil, userError(err)
+	}
+
+	emailChanged, columns, err := resolveEmailChange(current, user, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateColumns(ctx, user, columns); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, apperror.New(apperror.AlreadyExists, "email already registered").
+				WithField("email", "email already registered")
+		}
+		return nil, fmt.Errorf("failed to update user %d: %w", user.ID, err)
+	}
+
+	updated, err := s.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		return nil, userError(err)
+	}
+	if emailChanged {
+		s.sendVerification(ctx, updated)
+	}
+	return updated, nil
+}
+
+// authorizeUpdate 只能更新自己的资料，拥有 users:write 权限的管理员除外
+func (s *userService) authorizeUpdate(ctx context.Context, userID int64) error {
+	if caller, _ := auth.UserIDFromContext(ctx); caller == userID {
+		return nil
+	}
+	admin, err := callerHasPermission(ctx, s.permissions, PermissionUsersWrite)
+	if err != nil {
+		return err
+	}
+	if !admin {
+		return apperror.New(apperror.PermissionDenied, "can only update your own profile")
+	}
+	caller, _ := auth.UserIDFromContext(ctx)
+	logger.Infof("profile of user %d updated by admin %d", userID, caller)
+	return nil
+}
+
+// ChangePassword 修改密码，只能修改自己的密码
+// 已签发的令牌可能已经泄露，修改成功后注销该用户的所有会话，需要重新登录
+func (s *userService) ChangePassword(ctx context.Context, userID int64, oldPassword, newPassword string) error {
+	if caller, _ := auth.UserIDFromContext(ctx); caller != userID {
+		return apperror.New(apperror.PermissionDenied, "can only change your own password")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return userError(err)
+	}
+	ok, _, err := s.hasher.Verify(oldPassword, user.PasswordHash)
+	if err != nil {
+		return fmt.Errorf("failed to verify password of user %d: %w", userID, err)
+	}
+	if !ok {
+		return apperror.New(apperror.InvalidArgument, "invalid password").
+			WithField("old_password", "incorrect password")
+	}
+	if oldPassword == newPassword {
+		return apperror.New(apperror.InvalidArgument, "invalid password").
+			WithField("new_password", "must differ from the current password")
+	}
+
+	hash, err := hashNewPassword(s.hasher, s.policy, "new_password", newPassword)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+	if err := s.userRepo.UpdateColumns(ctx, user, []string{"password_hash"}); err != nil {
+		return fmt.Errorf("failed to update password of user %d: %w", userID, err)
+	}
+	if err := s.sessions.RevokeAll(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions of user %d: %w", userID, err)
+	}
+
+	logger.Infof("password of user %d changed, all sessions revoked", userID)
+	return nil
+}
+
+// hashNewPassword 检查新密码是否满足密码策略并生成哈希，不满足时每条规则对应 field 上的一个错误详情
+func hashNewPassword(hasher *password.Hasher, policy *password.Policy, field, newPassword string) (string, error) {
+	var policyErr *password.PolicyError
+	if err := policy.Validate(newPassword); errors.As(err, &policyErr) {
+		appErr := apperror.New(apperror.InvalidArgument, "password does not meet policy")
+		for _, reason := range policyErr.Reasons {
+			appErr = appErr.WithField(field, "password "+reason)
+		}
+		return "", appErr
+	}
+
+	hash, err := hasher.Hash(newPassword)
+	if err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// resolveEmailChange 处理更新中的邮箱字段：邮箱未变化时不更新，变化时同时将邮箱标记为未验证
+func resolveEmailChange(current, user *models.User, columns []string) (bool, []string, error) {
+	i := indexOf(columns, "email")
+	if i < 0 {
+		return false, columns, nil
+	}
+
+	user.Email = normalizeEmail(user.Email)
+	if user.Email == "" {
+		return false, nil, apperror.New(apperror.InvalidArgument, "invalid user").
+			WithField("email", "email cannot be empty")
+	}
+
+	columns = append(columns[:i:i], columns[i+1:]...)
+	if user.Email == normalizeEmail(current.Email) {
+		return false, columns, nil
+	}
+	user.EmailVerified = false
+	return true, append(columns, "email", "email_verified"), nil
+}
+
+// sendVerification 发送验证邮件，失败时只记录日志
+func (s *userService) sendVerification(ctx context.Context, user *models.User) {
+	if err := s.verification.SendVerification(ctx, user); err != nil {
+		logger.Warnf("failed to send verification email to user %d: %v", user.ID, err)
+	}
+}
+
+// indexOf 返回 value 在 values 中的位置，不存在时返回 -1
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// nonZeroUserPaths 返回用户资料中非零值字段的路径
+func nonZeroUserPaths(user *models.User) []string {
+	var paths []string
+	if user.Nickname != "" {
+		paths = append(paths, "nickname")
+	}
+	if user.AvatarURL != "" {
+		paths = append(paths, "avatar")
+	}
+	if user.Email != "" {
+		paths = append(paths, "email")
+	}
+	return paths
+}
+
+// userError 将仓储错误转换为业务错误
+func userError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.New(apperror.NotFound, "user not found")
+	}
+	return err
+}
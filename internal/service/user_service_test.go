@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/3inchtime/movieinfo/internal/models"
+	"github.com/3inchtime/movieinfo/internal/repository"
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+)
+
+// fakeUserRepo 内存中的用户仓储，只实现测试用到的方法
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[int64]models.User
+}
+
+func newFakeUserRepo(users ...models.User) *fakeUserRepo {
+	r := &fakeUserRepo{users: make(map[int64]models.User)}
+	for _, user := range users {
+		r.users[user.ID] = user
+	}
+	return r
+}
+
+func (r *fakeUserRepo) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *fakeUserRepo) UpdateColumns(ctx context.Context, user *models.User, columns []string) error {
+	current := r.users[user.ID]
+	for _, column := range columns {
+		switch column {
+		case "nickname":
+			current.Nickname = user.Nickname
+		case "avatar_url":
+			current.AvatarURL = user.AvatarURL
+		case "email":
+			current.Email = user.Email
+		case "email_verified":
+			current.EmailVerified = user.EmailVerified
+		}
+	}
+	r.users[user.ID] = current
+	return nil
+}
+
+// fakeVerification 记录发送验证邮件的用户
+type fakeVerification struct {
+	EmailVerificationService
+	sent []string
+}
+
+func (v *fakeVerification) SendVerification(ctx context.Context, user *models.User) error {
+	v.sent = append(v.sent, user.Email)
+	return nil
+}
+
+func (v *fakeVerification) RequireVerified(ctx context.Context, userID int64, action string) error {
+	return nil
+}
+
+// fakePermissions 按角色授予权限
+type fakePermissions map[string][]string
+
+func (p fakePermissions) HasPermission(ctx context.Context, roles []string, permission string) (bool, error) {
+	for _, role := range roles {
+		for _, granted := range p[role] {
+			if granted == permission {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func TestUpdateUser(t *testing.T) {
+	ripley := models.User{ID: 2, Username: "ripley", Email: "ripley@example.com", EmailVerified: true,
+		Nickname: "Ellen", AvatarURL: "https://example.com/ripley.png"}
+	invalid := apperror.InvalidArgument.String()
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		user       models.User
+		paths      []string
+		code       string
+		wantFields []string
+		want       models.User
+		wantSent   []string
+	}{
+		{
+			name:  "own profile",
+			ctx:   callerContext(2),
+			user:  models.User{ID: 2, Nickname: "Ripley"},
+			paths: []string{"nickname", "avatar"},
+			want: models.User{ID: 2, Username: "ripley", Email: ripley.Email, EmailVerified: true,
+				Nickname: "Ripley"},
+		},
+		{
+			name: "no mask updates non-zero fields",
+			ctx:  callerContext(2),
+			user: models.User{ID: 2, Nickname: "Ripley"},
+			want: models.User{ID: 2, Username: "ripley", Email: ripley.Email, EmailVerified: true,
+				Nickname: "Ripley", AvatarURL: ripley.AvatarURL},
+		},
+		{
+			name:  "changed email needs verification",
+			ctx:   callerContext(2),
+			user:  models.User{ID: 2, Email: " Ellen@Example.com"},
+			paths: []string{"email"},
+			want: models.User{ID: 2, Username: "ripley", Email: "ellen@example.com", Nickname: "Ellen",
+				AvatarURL: ripley.AvatarURL},
+			wantSent: []string{"ellen@example.com"},
+		},
+		{
+			name:  "unchanged email stays verified",
+			ctx:   callerContext(2),
+			user:  models.User{ID: 2, Email: "RIPLEY@example.com", Nickname: "Ripley"},
+			paths: []string{"email", "nickname"},
+			want: models.User{ID: 2, Username: "ripley", Email: ripley.Email, EmailVerified: true,
+				Nickname: "Ripley", AvatarURL: ripley.AvatarURL},
+		},
+		{
+			name:       "empty email",
+			ctx:        callerContext(2),
+			user:       models.User{ID: 2},
+			paths:      []string{"email"},
+			code:       invalid,
+			wantFields: []string{"email"},
+		},
+		{
+			name:       "immutable paths",
+			ctx:        callerContext(2),
+			user:       models.User{ID: 2, Username: "newt"},
+			paths:      []string{"username", "nickname", "status", "email_verified"},
+			code:       invalid,
+			wantFields: []string{"username", "status", "email_verified"},
+		},
+		{
+			name:       "unknown path",
+			ctx:        callerContext(2),
+			user:       models.User{ID: 2},
+			paths:      []string{"password"},
+			code:       invalid,
+			wantFields: []string{"password"},
+		},
+		{
+			name:  "other user's profile",
+			ctx:   callerContext(1),
+			user:  models.User{ID: 2, Nickname: "Newt"},
+			paths: []string{"nickname"},
+			code:  apperror.PermissionDenied.String(),
+		},
+		{
+			name:  "admin updates another user's profile",
+			ctx:   callerContext(1, "admin"),
+			user:  models.User{ID: 2, Nickname: "Newt"},
+			paths: []string{"nickname"},
+			want: models.User{ID: 2, Username: "ripley", Email: ripley.Email, EmailVerified: true,
+				Nickname: "Newt", AvatarURL: ripley.AvatarURL},
+		},
+		{
+			name:  "role without users:write",
+			ctx:   callerContext(1, "editor"),
+			user:  models.User{ID: 2, Nickname: "Newt"},
+			paths: []string{"nickname"},
+			code:  apperror.PermissionDenied.String(),
+		},
+		{
+			name:  "other user's email",
+			ctx:   callerContext(1),
+			user:  models.User{ID: 2, Email: "attacker@example.com"},
+			paths: []string{"email"},
+			code:  apperror.PermissionDenied.String(),
+		},
+		{
+			name:  "anonymous",
+			ctx:   context.Background(),
+			user:  models.User{ID: 2, Nickname: "Newt"},
+			paths: []string{"nickname"},
+			code:  apperror.PermissionDenied.String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeUserRepo(ripley)
+			verification := &fakeVerification{}
+			permissions := fakePermissions{"admin": {PermissionUsersWrite}, "editor": {"movies:write"}}
+			s := NewUserService(repo, verification, nil, nil, nil, permissions)
+
+			user := tt.user
+			got, err := s.UpdateUser(tt.ctx, &user, tt.paths)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("UpdateUser() = %q, want %q", code, tt.code)
+			}
+			if fields := errFields(err); !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Fatalf("error fields = %v, want %v", fields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(verification.sent, tt.wantSent) {
+				t.Fatalf("verification sent to %v, want %v", verification.sent, tt.wantSent)
+			}
+			if err != nil {
+				if stored := repo.users[ripley.ID]; stored != ripley {
+					t.Fatalf("user changed by a rejected update: %+v", stored)
+				}
+				return
+			}
+			if *got != tt.want {
+				t.Fatalf("UpdateUser() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	const oldPassword = "password123"
+	invalid := apperror.InvalidArgument.String()
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		oldPassword string
+		newPassword string
+		code        string
+		wantFields  []string
+	}{
+		{name: "valid change", ctx: callerContext(testUserID), oldPassword: oldPassword, newPassword: "correct horse battery"},
+		{
+			name: "wrong old password", ctx: callerContext(testUserID), oldPassword: "wrong", newPassword: "correct horse battery",
+			code: invalid, wantFields: []string{"old_password"},
+		},
+		{
+			name: "same password", ctx: callerContext(testUserID), oldPassword: oldPassword, newPassword: oldPassword,
+			code: invalid, wantFields: []string{"new_password"},
+		},
+		{
+			name: "weak new password", ctx: callerContext(testUserID), oldPassword: oldPassword, newPassword: "short",
+			code: invalid, wantFields: []string{"new_password"},
+		},
+		{
+			name: "other user's password", ctx: callerContext(adminUserID, "admin"), oldPassword: oldPassword,
+			newPassword: "correct horse battery", code: apperror.PermissionDenied.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := repository.NewUserRepository(newTestDB(t))
+			hasher := newTestHasher(t)
+			hash, err := hasher.Hash(oldPassword)
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			setPassword(t, repo, testUserID, hash)
+			sessions := &fakeRevocations{}
+			s := NewUserService(repo, &fakeVerification{}, hasher, newTestPolicy(t), sessions, fakePermissions{})
+
+			err = s.ChangePassword(tt.ctx, testUserID, tt.oldPassword, tt.newPassword)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("ChangePassword() = %q, want %q", code, tt.code)
+			}
+			if fields := errFields(err); !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Fatalf("error fields = %v, want %v", fields, tt.wantFields)
+			}
+
+			user, _ := repo.GetByID(ctx, testUserID)
+			want := oldPassword
+			if err == nil {
+				want = tt.newPassword
+			}
+			if ok, _, _ := hasher.Verify(want, user.PasswordHash); !ok {
+				t.Fatalf("stored password does not match %q", want)
+			}
+			if revoked := sessions.count(testUserID) > 0; revoked != (err == nil) {
+				t.Fatalf("sessions revoked = %v, want %v", revoked, err == nil)
+			}
+		})
+	}
+}
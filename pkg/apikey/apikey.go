@@ -0,0 +1,139 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Header 携带API密钥的元数据键，经HTTP网关转发时对应 X-Api-Key 请求头
+const Header = "x-api-key"
+
+// 密钥格式为 mik_<12位十六进制标识>_<随机串>，前缀 mik_<标识> 明文保存用于查找，完整密钥只保存哈希
+const (
+	keyPrefix   = "mik_"
+	idBytes     = 6
+	secretBytes = 32
+)
+
+// 密钥的权限范围
+const (
+	ScopeReadOnly       = "read-only"       // 只读接口，所有密钥都可以调用
+	ScopeCatalogueWrite = "catalogue-write" // 创建、修改、删除电影
+	ScopeRatingsWrite   = "ratings-write"   // 创建、修改、删除评分
+)
+
+// Scopes 全部可用的权限范围
+var Scopes = []string{ScopeReadOnly, ScopeCatalogueWrite, ScopeRatingsWrite}
+
+// ErrInvalidKey 密钥格式错误、不存在、已过期或已撤销
+var ErrInvalidKey = errors.New("invalid api key")
+
+// Identity 密钥校验通过后的调用方身份
+type Identity struct {
+	KeyID  int64
+	UserID int64
+	Roles  []string // 密钥所属用户当前的角色，写操作仍需角色拥有对应权限
+	Scopes []string
+}
+
+// Authenticator 校验API密钥，密钥无效时返回 ErrInvalidKey
+type Authenticator interface {
+	Authenticate(ctx context.Context, key string) (*Identity, error)
+}
+
+// Generate 生成新的密钥，返回完整密钥和用于查找的前缀
+func Generate() (key, prefix string, err error) {
+	id := make([]byte, idBytes)
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	prefix = keyPrefix + hex.EncodeToString(id)
+	return prefix + "_" + base64.RawURLEncoding.EncodeToString(secret), prefix, nil
+}
+
+// Prefix 返回密钥的前缀，格式不正确时返回 ErrInvalidKey
+func Prefix(key string) (string, error) {
+	rest, ok := strings.CutPrefix(key, keyPrefix)
+	if !ok {
+		return "", ErrInvalidKey
+	}
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(id) != 2*idBytes || secret == "" {
+		return "", ErrInvalidKey
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return "", ErrInvalidKey
+	}
+	return keyPrefix + id, nil
+}
+
+// Hash 计算密钥的哈希，密钥本身是高熵随机串，使用SHA-256即可
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidScope 判断权限范围是否有效
+func ValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy 方法到所需权限范围的映射，键的格式与 rbac.Policy 相同
+// 未列出的方法（如账号管理、密钥管理）不允许使用API密钥调用
+type Policy map[string]string
+
+// Scope 返回调用方法所需的权限范围，不允许使用密钥调用时返回空字符串
+func (p Policy) Scope(fullMethod string) string {
+	if scope, ok := p[fullMethod]; ok {
+		return scope
+	}
+	service := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(service, "/"); i >= 0 {
+		service = service[:i]
+	}
+	return p[service]
+}
+
+// Allows 判断拥有 scopes 的密钥能否调用需要 scope 的方法，只读接口对所有密钥开放
+func Allows(scopes []string, scope string) bool {
+	if scope == "" {
+		return false
+	}
+	if scope == ScopeReadOnly {
+		return true
+	}
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type keyIDKey struct{}
+
+// WithKeyID 将调用所用的密钥ID写入上下文
+func WithKeyID(ctx context.Context, keyID int64) context.Context {
+	return context.WithValue(ctx, keyIDKey{}, keyID)
+}
+
+// KeyIDFromContext 读取调用所用的密钥ID，未使用密钥时返回 false
+func KeyIDFromContext(ctx context.Context) (int64, bool) {
+	keyID, ok := ctx.Value(keyIDKey{}).(int64)
+	return keyID, ok && keyID > 0
+}
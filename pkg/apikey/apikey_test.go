@@ -0,0 +1,98 @@
+package apikey
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGeneratePrefix(t *testing.T) {
+	key, prefix, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.HasPrefix(key, prefix+"_") {
+		t.Fatalf("key %s does not start with prefix %s", key, prefix)
+	}
+	if got, err := Prefix(key); err != nil || got != prefix {
+		t.Fatalf("Prefix() = (%s, %v), want %s", got, err, prefix)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr error
+	}{
+		{name: "valid", key: "mik_0123456789ab_secret", want: "mik_0123456789ab"},
+		{name: "secret containing underscore", key: "mik_0123456789ab_sec_ret", want: "mik_0123456789ab"},
+		{name: "missing prefix", key: "0123456789ab_secret", wantErr: ErrInvalidKey},
+		{name: "other prefix", key: "sk_0123456789ab_secret", wantErr: ErrInvalidKey},
+		{name: "short id", key: "mik_0123_secret", wantErr: ErrInvalidKey},
+		{name: "non hex id", key: "mik_0123456789xz_secret", wantErr: ErrInvalidKey},
+		{name: "missing secret", key: "mik_0123456789ab_", wantErr: ErrInvalidKey},
+		{name: "missing separator", key: "mik_0123456789ab", wantErr: ErrInvalidKey},
+		{name: "empty", key: "", wantErr: ErrInvalidKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Prefix(tt.key)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Fatalf("Prefix(%q) = (%q, %v), want (%q, %v)", tt.key, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyScope(t *testing.T) {
+	policy := Policy{
+		"/movieinfo.movie.MovieService/GetMovie":    ScopeReadOnly,
+		"/movieinfo.movie.MovieService/CreateMovie": ScopeCatalogueWrite,
+		"movieinfo.rating.RatingService":            ScopeRatingsWrite,
+		"/movieinfo.rating.RatingService/GetRating": ScopeReadOnly,
+	}
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{method: "/movieinfo.movie.MovieService/GetMovie", want: ScopeReadOnly},
+		{method: "/movieinfo.movie.MovieService/CreateMovie", want: ScopeCatalogueWrite},
+		{method: "/movieinfo.movie.MovieService/DeleteMovie", want: ""},
+		{method: "/movieinfo.rating.RatingService/CreateRating", want: ScopeRatingsWrite},
+		{method: "/movieinfo.rating.RatingService/GetRating", want: ScopeReadOnly},
+		{method: "/movieinfo.user.UserService/Login", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			if got := policy.Scope(tt.method); got != tt.want {
+				t.Fatalf("Scope(%s) = %q, want %q", tt.method, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		scope  string
+		want   bool
+	}{
+		{name: "read-only method with read-only key", scopes: []string{ScopeReadOnly}, scope: ScopeReadOnly, want: true},
+		{name: "read-only method with write key", scopes: []string{ScopeRatingsWrite}, scope: ScopeReadOnly, want: true},
+		{name: "read-only method with no scopes", scope: ScopeReadOnly, want: true},
+		{name: "write method with matching scope", scopes: []string{ScopeReadOnly, ScopeCatalogueWrite}, scope: ScopeCatalogueWrite, want: true},
+		{name: "write method with read-only key", scopes: []string{ScopeReadOnly}, scope: ScopeCatalogueWrite},
+		{name: "write method with other write scope", scopes: []string{ScopeRatingsWrite}, scope: ScopeCatalogueWrite},
+		{name: "method not allowed for keys", scopes: Scopes, scope: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allows(tt.scopes, tt.scope); got != tt.want {
+				t.Fatalf("Allows(%v, %q) = %v, want %v", tt.scopes, tt.scope, got, tt.want)
+			}
+		})
+	}
+}
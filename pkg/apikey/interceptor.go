@@ -0,0 +1,105 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// Interceptor API密钥认证拦截器
+type Interceptor struct {
+	authenticator Authenticator
+	policy        Policy
+}
+
+// NewInterceptor 创建API密钥认证拦截器，policy 为各方法所需的权限范围
+func NewInterceptor(authenticator Authenticator, policy Policy) *Interceptor {
+	return &Interceptor{authenticator: authenticator, policy: policy}
+}
+
+// UnaryServerInterceptor 返回一元调用认证拦截器
+// 携带密钥的请求校验密钥和权限范围后将用户ID和角色写入上下文，未携带密钥的请求原样交给后续的令牌认证
+// 需要放在令牌认证拦截器之前，同一请求不能同时携带密钥和访问令牌
+func (i *Interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor 返回流式调用认证拦截器，规则与一元调用相同
+func (i *Interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate 校验请求中的密钥，返回带有用户ID、角色和密钥ID的上下文
+func (i *Interceptor) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	key := keyFromContext(ctx)
+	if key == "" {
+		return ctx, nil
+	}
+	if auth.BearerToken(ctx) != "" {
+		return nil, apperror.New(apperror.InvalidArgument, "pass either an api key or an access token, not both")
+	}
+
+	identity, err := i.authenticator.Authenticate(ctx, key)
+	if errors.Is(err, ErrInvalidKey) {
+		return nil, apperror.New(apperror.Unauthenticated, "invalid or expired api key")
+	}
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Errorf("failed to authenticate api key: %v", err)
+		return nil, apperror.New(apperror.InternalError, "failed to authenticate api key")
+	}
+
+	scope := i.policy.Scope(fullMethod)
+	if !Allows(identity.Scopes, scope) {
+		logger.Warnf("Permission denied: api key %d of user %d with scopes %v called %s", identity.KeyID, identity.UserID, identity.Scopes, fullMethod)
+		if scope == "" {
+			return nil, apperror.Newf(apperror.PermissionDenied, "%s cannot be called with an api key", fullMethod)
+		}
+		return nil, apperror.Newf(apperror.PermissionDenied, "api key scope %s required", scope)
+	}
+
+	ctx = WithKeyID(auth.WithUserID(ctx, identity.UserID), identity.KeyID)
+	return auth.WithRoles(ctx, identity.Roles), nil
+}
+
+// keyFromContext 从请求元数据中读取密钥，未携带时返回空字符串
+func keyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(Header); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// authenticatedStream 替换流的上下文
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,137 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+)
+
+const (
+	testKey     = "mik_0123456789ab_secret"
+	readMethod  = "/movieinfo.movie.MovieService/GetMovie"
+	writeMethod = "/movieinfo.movie.MovieService/CreateMovie"
+	otherMethod = "/movieinfo.user.UserService/ChangePassword"
+)
+
+// fakeAuthenticator 只接受 testKey，返回拥有 scopes 的身份
+type fakeAuthenticator struct {
+	scopes []string
+}
+
+func (a *fakeAuthenticator) Authenticate(ctx context.Context, key string) (*Identity, error) {
+	if key != testKey {
+		return nil, ErrInvalidKey
+	}
+	return &Identity{KeyID: 7, UserID: 2, Roles: []string{"editor"}, Scopes: a.scopes}, nil
+}
+
+func TestInterceptor(t *testing.T) {
+	policy := Policy{readMethod: ScopeReadOnly, writeMethod: ScopeCatalogueWrite}
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		scopes []string
+		method string
+		want   string
+		// wantKey 期望调用以密钥身份进入处理函数
+		wantKey bool
+	}{
+		{
+			name:   "no key passes through",
+			md:     metadata.Pairs(auth.AuthorizationHeader, "Bearer token"),
+			method: writeMethod,
+		},
+		{
+			name:    "read-only key calls read method",
+			md:      metadata.Pairs(Header, testKey),
+			scopes:  []string{ScopeReadOnly},
+			method:  readMethod,
+			wantKey: true,
+		},
+		{
+			name:   "read-only key calls write method",
+			md:     metadata.Pairs(Header, testKey),
+			scopes: []string{ScopeReadOnly},
+			method: writeMethod,
+			want:   apperror.PermissionDenied.String(),
+		},
+		{
+			name:    "write key calls write method",
+			md:      metadata.Pairs(Header, testKey),
+			scopes:  []string{ScopeCatalogueWrite},
+			method:  writeMethod,
+			wantKey: true,
+		},
+		{
+			name:   "method not allowed for keys",
+			md:     metadata.Pairs(Header, testKey),
+			scopes: Scopes,
+			method: otherMethod,
+			want:   apperror.PermissionDenied.String(),
+		},
+		{
+			name:   "invalid key",
+			md:     metadata.Pairs(Header, "mik_0123456789ab_wrong"),
+			scopes: Scopes,
+			method: readMethod,
+			want:   apperror.Unauthenticated.String(),
+		},
+		{
+			name:   "key and access token together",
+			md:     metadata.Pairs(Header, testKey, auth.AuthorizationHeader, "Bearer token"),
+			scopes: Scopes,
+			method: readMethod,
+			want:   apperror.InvalidArgument.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewInterceptor(&fakeAuthenticator{scopes: tt.scopes}, policy).UnaryServerInterceptor()
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			var handlerCtx context.Context
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerCtx = ctx
+				return nil, nil
+			}
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			got := ""
+			if err != nil {
+				appErr, ok := apperror.As(err)
+				if !ok {
+					t.Fatalf("interceptor error = %v, want an application error", err)
+				}
+				got = appErr.Code.String()
+			}
+			if got != tt.want {
+				t.Fatalf("interceptor error = %v, want code %q", err, tt.want)
+			}
+			if tt.want != "" {
+				if handlerCtx != nil {
+					t.Fatal("handler called for a rejected request")
+				}
+				return
+			}
+
+			keyID, ok := KeyIDFromContext(handlerCtx)
+			if ok != tt.wantKey {
+				t.Fatalf("KeyIDFromContext() ok = %v, want %v", ok, tt.wantKey)
+			}
+			if !tt.wantKey {
+				return
+			}
+			userID, _ := auth.UserIDFromContext(handlerCtx)
+			roles := auth.RolesFromContext(handlerCtx)
+			if keyID != 7 || userID != 2 || len(roles) != 1 || roles[0] != "editor" {
+				t.Fatalf("handler context key %d, user %d, roles %v", keyID, userID, roles)
+			}
+		})
+	}
+}
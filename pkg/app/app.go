@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/health"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// Config 应用生命周期配置
+type Config struct {
+	DrainTimeout time.Duration `yaml:"drain_timeout"` // 关闭时每个组件等待进行中的请求完成的最长时间，超时后强制停止
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		DrainTimeout: 30 * time.Second,
+	}
+}
+
+// Component 由应用统一启动和关闭的组件，如日志、数据库、缓存、gRPC服务、HTTP服务
+// 各函数均可为空：Start 完成初始化后返回；Run 为阻塞的服务循环，返回错误时应用开始关闭；Stop 释放资源
+type Component struct {
+	Name         string
+	Start        func(ctx context.Context) error
+	Run          func() error
+	Stop         func(ctx context.Context) error
+	DrainTimeout time.Duration // Stop 的等待时间，为0时使用应用的 DrainTimeout
+}
+
+// App 按依赖顺序启动组件，收到 SIGINT/SIGTERM 后按相反顺序关闭
+type App struct {
+	name       string
+	config     *Config
+	health     *health.Server
+	components []Component
+}
+
+// New 创建应用，配置需在此之前加载完成
+func New(name string, config *Config) *App {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if config.DrainTimeout <= 0 {
+		config.DrainTimeout = DefaultConfig().DrainTimeout
+	}
+
+	return &App{
+		name:   name,
+		config: config,
+		health: health.NewServer(),
+	}
+}
+
+// Health 返回应用的 grpc.health.v1 服务，关闭开始时所有服务被标记为 NOT_SERVING
+func (a *App) Health() *health.Server {
+	return a.health
+}
+
+// Add 追加组件，组件按追加顺序启动
+func (a *App) Add(components ...Component) {
+	a.components = append(a.components, components...)
+}
+
+// Run 启动所有组件并阻塞，直到收到退出信号、ctx 被取消或某个组件的服务循环出错
+// 关闭时先将健康状态标记为 NOT_SERVING，再按相反顺序停止组件，每个组件各自最多等待 DrainTimeout
+func (a *App) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	started := 0
+	var startErr error
+	for _, c := range a.components {
+		if c.Start != nil {
+			if err := c.Start(ctx); err != nil {
+				startErr = fmt.Errorf("failed to start %s: %w", c.Name, err)
+				break
+			}
+		}
+		started++
+	}
+	if startErr != nil {
+		return errors.Join(startErr, a.shutdown(started))
+	}
+	logger.Infof("%s started", a.name)
+
+	runErr := make(chan error, len(a.components))
+	var wg sync.WaitGroup
+	for _, c := range a.components {
+		if c.Run == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(c Component) {
+			defer wg.Done()
+			if err := c.Run(); err != nil {
+				runErr <- fmt.Errorf("%s stopped unexpectedly: %w", c.Name, err)
+			}
+		}(c)
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+		logger.Infof("%s shutting down", a.name)
+	case err = <-runErr:
+		logger.Errorf("%s shutting down: %v", a.name, err)
+	}
+
+	shutdownErr := a.shutdown(started)
+	wg.Wait()
+	return errors.Join(err, shutdownErr)
+}
+
+// shutdown 停止前 n 个已启动的组件，日志组件通常最先启动，因此最后停止，确保关闭过程中的日志被写出
+func (a *App) shutdown(n int) error {
+	a.health.Shutdown()
+
+	var errs []error
+	for i := n - 1; i >= 0; i-- {
+		c := a.components[i]
+		if c.Stop == nil {
+			continue
+		}
+		if err := a.stop(c); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", c.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stop 停止组件，每个组件有各自的等待时间，前面的组件超时不会占用后面组件的时间
+func (a *App) stop(c Component) error {
+	timeout := c.DrainTimeout
+	if timeout <= 0 {
+		timeout = a.config.DrainTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := c.Stop(ctx)
+	if ctx.Err() != nil {
+		logger.Warnf("%s did not stop within %s, forced to stop", c.Name, timeout)
+	}
+	return err
+}
@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// recorder 记录组件的启动和停止顺序
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) component(name string, startErr error) Component {
+	return Component{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			r.record("start " + name)
+			return startErr
+		},
+		Stop: func(ctx context.Context) error {
+			r.record("stop " + name)
+			return nil
+		},
+	}
+}
+
+func TestRunStopsInReverseOrder(t *testing.T) {
+	r := &recorder{}
+	a := New("test", nil)
+	a.Add(r.component("logger", nil), r.component("database", nil), r.component("grpc", nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := a.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{"start logger", "start database", "start grpc", "stop grpc", "stop database", "stop logger"}
+	if !reflect.DeepEqual(r.events, want) {
+		t.Fatalf("events = %v, want %v", r.events, want)
+	}
+	resp, err := a.Health().Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("health = %v, want NOT_SERVING", resp.Status)
+	}
+}
+
+func TestRunStartFailureStopsStartedComponents(t *testing.T) {
+	r := &recorder{}
+	a := New("test", nil)
+	a.Add(r.component("logger", nil), r.component("database", errors.New("connection refused")), r.component("grpc", nil))
+
+	err := a.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to start database") {
+		t.Fatalf("Run() error = %v, want start failure of database", err)
+	}
+
+	want := []string{"start logger", "start database", "stop logger"}
+	if !reflect.DeepEqual(r.events, want) {
+		t.Fatalf("events = %v, want %v", r.events, want)
+	}
+}
+
+func TestRunShutsDownWhenRunFails(t *testing.T) {
+	r := &recorder{}
+	release := make(chan struct{})
+	server := r.component("http", nil)
+	server.Run = func() error {
+		<-release
+		return nil
+	}
+	server.Stop = func(ctx context.Context) error {
+		r.record("stop http")
+		close(release)
+		return nil
+	}
+	broken := Component{
+		Name: "grpc",
+		Run:  func() error { return errors.New("listener closed") },
+	}
+
+	a := New("test", &Config{DrainTimeout: time.Second})
+	a.Add(server, broken)
+
+	err := a.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "grpc stopped unexpectedly") {
+		t.Fatalf("Run() error = %v, want run failure of grpc", err)
+	}
+	want := []string{"start http", "stop http"}
+	if !reflect.DeepEqual(r.events, want) {
+		t.Fatalf("events = %v, want %v", r.events, want)
+	}
+}
+
+func TestShutdownDrainBudgetPerComponent(t *testing.T) {
+	r := &recorder{}
+	// web 在等待时间内没有停止，不影响之后停止的 grpc
+	web := Component{
+		Name: "web",
+		Stop: func(ctx context.Context) error {
+			<-ctx.Done()
+			r.record("stop web")
+			return ctx.Err()
+		},
+	}
+	grpc := Component{
+		Name: "grpc",
+		Stop: func(ctx context.Context) error {
+			deadline, _ := ctx.Deadline()
+			if left := time.Until(deadline); left < 50*time.Millisecond {
+				r.record("stop grpc with " + left.String())
+				return nil
+			}
+			r.record("stop grpc")
+			return nil
+		},
+	}
+	quick := Component{
+		Name:         "metrics",
+		DrainTimeout: 10 * time.Millisecond,
+		Stop: func(ctx context.Context) error {
+			<-ctx.Done()
+			r.record("stop metrics")
+			return ctx.Err()
+		},
+	}
+
+	a := New("test", &Config{DrainTimeout: 100 * time.Millisecond})
+	a.Add(grpc, web, quick)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	err := a.Run(ctx)
+	if err == nil || !strings.Contains(err.Error(), "failed to stop web") || !strings.Contains(err.Error(), "failed to stop metrics") {
+		t.Fatalf("Run() error = %v, want stop failures of web and metrics", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run() error = %v, want context.DeadlineExceeded", err)
+	}
+	want := []string{"stop metrics", "stop web", "stop grpc"}
+	if !reflect.DeepEqual(r.events, want) {
+		t.Fatalf("events = %v, want %v", r.events, want)
+	}
+	// metrics 使用自己的等待时间，web 使用应用的等待时间
+	if elapsed := time.Since(start); elapsed < 110*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("shutdown took %s, want about 110ms", elapsed)
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// Logger 全局日志组件，关闭时写出异步缓冲区中的日志
+func Logger(config *logger.Config) Component {
+	return Component{
+		Name: "logger",
+		Start: func(ctx context.Context) error {
+			return logger.InitGlobalLogger(config)
+		},
+		Stop: func(ctx context.Context) error {
+			return logger.Close()
+		},
+	}
+}
+
+// GRPCServer gRPC服务组件，同时在 server 上注册应用的健康检查服务
+// 关闭时等待进行中的RPC完成，超过 DrainTimeout 后强制断开连接
+func (a *App) GRPCServer(name string, server *grpc.Server, lis net.Listener) Component {
+	healthpb.RegisterHealthServer(server, a.health)
+
+	return Component{
+		Name: name,
+		Run: func() error {
+			logger.Infof("%s listening on %s", name, lis.Addr())
+			return server.Serve(lis)
+		},
+		Stop: func(ctx context.Context) error {
+			done := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				server.Stop()
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+// HTTPServer HTTP服务组件，关闭时等待进行中的请求完成，超过 DrainTimeout 后强制关闭连接
+func HTTPServer(name string, server *http.Server, lis net.Listener) Component {
+	return Component{
+		Name: name,
+		Run: func() error {
+			logger.Infof("%s listening on %s", name, lis.Addr())
+			if err := server.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			if err := server.Shutdown(ctx); err != nil {
+				server.Close()
+				return err
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,162 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorCode 错误代码，与 proto/common/error.proto 中的 ErrorCode 保持一致
+type ErrorCode int32
+
+const (
+	// 通用错误
+	UnknownError      ErrorCode = 0
+	InvalidArgument   ErrorCode = 1
+	NotFound          ErrorCode = 2
+	AlreadyExists     ErrorCode = 3
+	InternalError     ErrorCode = 4
+	ResourceExhausted ErrorCode = 5 // 请求过于频繁，客户端应等待后重试
+
+	// 认证相关错误
+	Unauthenticated  ErrorCode = 100
+	PermissionDenied ErrorCode = 101
+	TooManyAttempts  ErrorCode = 102
+
+	// 业务逻辑错误
+	BusinessError ErrorCode = 200
+)
+
+// String 返回错误代码的字符串表示
+func (c ErrorCode) String() string {
+	switch c {
+	case InvalidArgument:
+		return "INVALID_ARGUMENT"
+	case NotFound:
+		return "NOT_FOUND"
+	case AlreadyExists:
+		return "ALREADY_EXISTS"
+	case InternalError:
+		return "INTERNAL_ERROR"
+	case ResourceExhausted:
+		return "RESOURCE_EXHAUSTED"
+	case Unauthenticated:
+		return "UNAUTHENTICATED"
+	case PermissionDenied:
+		return "PERMISSION_DENIED"
+	case TooManyAttempts:
+		return "TOO_MANY_ATTEMPTS"
+	case BusinessError:
+		return "BUSINESS_ERROR"
+	default:
+		return "UNKNOWN_ERROR"
+	}
+}
+
+// GRPCCode 返回错误代码对应的gRPC状态码
+func (c ErrorCode) GRPCCode() codes.Code {
+	switch c {
+	case InvalidArgument:
+		return codes.InvalidArgument
+	case NotFound:
+		return codes.NotFound
+	case AlreadyExists:
+		return codes.AlreadyExists
+	case InternalError:
+		return codes.Internal
+	case ResourceExhausted:
+		return codes.ResourceExhausted
+	case Unauthenticated:
+		return codes.Unauthenticated
+	case PermissionDenied:
+		return codes.PermissionDenied
+	case TooManyAttempts:
+		return codes.ResourceExhausted
+	case BusinessError:
+		return codes.FailedPrecondition
+	default:
+		return codes.Unknown
+	}
+}
+
+// ErrorDetail 错误详情，对应 movieinfo.common.ErrorDetail
+type ErrorDetail struct {
+	Code    ErrorCode
+	Message string
+	Field   string
+}
+
+// Error 业务错误，可直接作为gRPC处理器的返回值
+type Error struct {
+	Code    ErrorCode
+	Message string
+	Details []ErrorDetail
+}
+
+// New 创建业务错误
+func New(code ErrorCode, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Newf 创建带格式化消息的业务错误
+func Newf(code ErrorCode, format string, args ...interface{}) *Error {
+	return New(code, fmt.Sprintf(format, args...))
+}
+
+// WithDetail 追加错误详情
+func (e *Error) WithDetail(detail ErrorDetail) *Error {
+	e.Details = append(e.Details, detail)
+	return e
+}
+
+// WithField 追加与字段相关的错误详情
+func (e *Error) WithField(field, message string) *Error {
+	return e.WithDetail(ErrorDetail{
+		Code:    e.Code,
+		Message: message,
+		Field:   field,
+	})
+}
+
+// Error 实现error接口
+func (e *Error) Error() string {
+	if len(e.Details) == 0 {
+		return e.Message
+	}
+
+	fields := make([]string, 0, len(e.Details))
+	for _, d := range e.Details {
+		if d.Field != "" {
+			fields = append(fields, fmt.Sprintf("%s: %s", d.Field, d.Message))
+		} else {
+			fields = append(fields, d.Message)
+		}
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, strings.Join(fields, "; "))
+}
+
+// GRPCStatus 转换为gRPC状态，status.FromError 会自动调用此方法
+func (e *Error) GRPCStatus() *status.Status {
+	return newStatus(e.Code.GRPCCode(), e.Message, e.Details)
+}
+
+// As 从错误链中提取业务错误
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// Is 判断错误链中是否包含指定代码的业务错误
+func Is(err error, code ErrorCode) bool {
+	appErr, ok := As(err)
+	return ok && appErr.Code == code
+}
@@ -0,0 +1,112 @@
+package apperror
+
+import (
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// errorDetailTypeURL movieinfo.common.ErrorDetail 的类型URL
+const errorDetailTypeURL = "type.googleapis.com/movieinfo.common.ErrorDetail"
+
+// newStatus 构建携带 ErrorDetail 的gRPC状态
+func newStatus(code codes.Code, message string, details []ErrorDetail) *status.Status {
+	p := &spb.Status{
+		Code:    int32(code),
+		Message: message,
+	}
+	for _, d := range details {
+		p.Details = append(p.Details, &anypb.Any{
+			TypeUrl: errorDetailTypeURL,
+			Value:   d.marshal(),
+		})
+	}
+	return status.FromProto(p)
+}
+
+// Details 从gRPC错误中解析 ErrorDetail 列表
+func Details(err error) []ErrorDetail {
+	if appErr, ok := As(err); ok {
+		return appErr.Details
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+
+	var details []ErrorDetail
+	for _, a := range st.Proto().GetDetails() {
+		if a.GetTypeUrl() != errorDetailTypeURL {
+			continue
+		}
+		if d, ok := unmarshalErrorDetail(a.GetValue()); ok {
+			details = append(details, d)
+		}
+	}
+	return details
+}
+
+// marshal 按 common/error.proto 的字段编号编码 ErrorDetail
+// code = 1, message = 2, field = 3
+func (d ErrorDetail) marshal() []byte {
+	var b []byte
+	if d.Code != 0 {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(d.Code))
+	}
+	if d.Message != "" {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendString(b, d.Message)
+	}
+	if d.Field != "" {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendString(b, d.Field)
+	}
+	return b
+}
+
+// unmarshalErrorDetail 解码 ErrorDetail，未知字段会被忽略
+func unmarshalErrorDetail(b []byte) (ErrorDetail, bool) {
+	var d ErrorDetail
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return d, false
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return d, false
+			}
+			d.Code = ErrorCode(v)
+			b = b[n:]
+		case num == 2 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return d, false
+			}
+			d.Message = v
+			b = b[n:]
+		case num == 3 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return d, false
+			}
+			d.Field = v
+			b = b[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return d, false
+			}
+			b = b[n:]
+		}
+	}
+	return d, true
+}
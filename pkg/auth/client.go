@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientIP 返回发起请求的客户端IP
+// 经HTTP网关转发的请求取 x-forwarded-for 的最后一项（网关看到的对端地址），否则取gRPC对端地址
+func ClientIP(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			hops := strings.Split(values[len(values)-1], ",")
+			if ip := strings.TrimSpace(hops[len(hops)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr := p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			return host
+		}
+		return addr
+	}
+	return ""
+}
+
+// UserAgent 返回客户端的 User-Agent，经HTTP网关转发的请求取浏览器的 User-Agent
+func UserAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// DeviceName 根据 User-Agent 识别设备名称，如 "Chrome on Windows"、"movieinfoctl"，无法识别时返回空字符串
+// 浏览器取浏览器和操作系统，其他客户端取第一个产品名称
+func DeviceName(userAgent string) string {
+	userAgent = strings.TrimSpace(userAgent)
+	if userAgent == "" {
+		return ""
+	}
+	if !strings.HasPrefix(userAgent, "Mozilla/") {
+		product, _, _ := strings.Cut(userAgent, " ")
+		product, _, _ = strings.Cut(product, "/")
+		return product
+	}
+
+	browser := "Browser"
+	for _, b := range []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	} {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+	for _, os := range []struct{ token, name string }{
+		{"Windows", "Windows"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	} {
+		if strings.Contains(userAgent, os.token) {
+			return browser + " on " + os.name
+		}
+	}
+	return browser
+}
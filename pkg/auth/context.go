@@ -0,0 +1,42 @@
+package auth
+
+import "context"
+
+type (
+	userIDKey    struct{}
+	rolesKey     struct{}
+	sessionIDKey struct{}
+)
+
+// WithUserID 将已认证的用户ID写入上下文
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext 从上下文中读取已认证的用户ID
+func UserIDFromContext(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(int64)
+	return userID, ok && userID > 0
+}
+
+// WithRoles 将已认证用户的角色写入上下文
+func WithRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, rolesKey{}, roles)
+}
+
+// RolesFromContext 从上下文中读取已认证用户的角色，匿名请求返回 nil
+func RolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey{}).([]string)
+	return roles
+}
+
+// WithSessionID 将访问令牌所在的会话ID写入上下文
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionIDKey{}, sessionID)
+}
+
+// SessionIDFromContext 从上下文中读取当前请求所在的会话ID，匿名请求返回空字符串
+func SessionIDFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionIDKey{}).(string)
+	return sessionID
+}
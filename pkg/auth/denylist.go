@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DenyList 已注销的访问令牌，按 jti 记录到令牌过期为止
+type DenyList interface {
+	// Deny 注销令牌，expiresAt 之后记录自动删除
+	Deny(ctx context.Context, jti string, expiresAt time.Time) error
+	// IsDenied 判断令牌是否已注销
+	IsDenied(ctx context.Context, jti string) (bool, error)
+}
+
+// memoryDenyList 进程内实现，多实例部署时各实例互不可见
+type memoryDenyList struct {
+	mu     sync.Mutex
+	denied map[string]time.Time
+}
+
+// NewMemoryDenyList 创建进程内的令牌注销列表
+func NewMemoryDenyList() DenyList {
+	return &memoryDenyList{denied: make(map[string]time.Time)}
+}
+
+func (l *memoryDenyList) Deny(ctx context.Context, jti string, expiresAt time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for id, exp := range l.denied {
+		if !exp.After(now) {
+			delete(l.denied, id)
+		}
+	}
+	if expiresAt.After(now) {
+		l.denied[jti] = expiresAt
+	}
+	return nil
+}
+
+func (l *memoryDenyList) IsDenied(ctx context.Context, jti string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	exp, ok := l.denied[jti]
+	return ok && exp.After(time.Now()), nil
+}
+
+// redisDenyList 基于Redis的实现，注销在集群所有实例间生效
+type redisDenyList struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisDenyList 创建基于Redis的令牌注销列表
+func NewRedisDenyList(client *redis.Client, prefix string) DenyList {
+	return &redisDenyList{client: client, prefix: prefix}
+}
+
+func (l *redisDenyList) Deny(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := l.client.Set(ctx, l.prefix+jti, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to deny token: %w", err)
+	}
+	return nil
+}
+
+func (l *redisDenyList) IsDenied(ctx context.Context, jti string) (bool, error) {
+	n, err := l.client.Exists(ctx, l.prefix+jti).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check denied token: %w", err)
+	}
+	return n > 0, nil
+}
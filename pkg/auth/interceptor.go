@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// AuthorizationHeader 携带访问令牌的元数据键，值为 "Bearer <token>"
+const AuthorizationHeader = "authorization"
+
+// UnaryServerInterceptor 返回一元调用认证拦截器
+// 携带访问令牌的请求校验令牌后将用户ID、会话ID和角色写入上下文，令牌无效时拒绝请求；
+// 未携带令牌的请求作为匿名请求继续处理，由处理器决定是否要求登录
+// 需要放在限流、幂等等按用户区分调用方的拦截器之前
+func (s *Sessions) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := s.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor 返回流式调用认证拦截器，规则与一元调用相同
+func (s *Sessions) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := s.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate 校验请求中的访问令牌，返回带有用户ID、会话ID和角色的上下文
+func (s *Sessions) authenticate(ctx context.Context) (context.Context, error) {
+	token := BearerToken(ctx)
+	if token == "" {
+		return ctx, nil
+	}
+
+	claims, err := s.Authenticate(ctx, token)
+	if errors.Is(err, ErrInvalidToken) {
+		return nil, apperror.New(apperror.Unauthenticated, "invalid or expired access token")
+	}
+	if err != nil {
+		logger.Errorf("failed to authenticate access token: %v", err)
+		return nil, apperror.New(apperror.InternalError, "failed to authenticate access token")
+	}
+	ctx = WithSessionID(WithUserID(ctx, claims.UserID), claims.SessionID)
+	return WithRoles(ctx, claims.Roles), nil
+}
+
+// BearerToken 从请求元数据中读取访问令牌，未携带时返回空字符串
+func BearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(AuthorizationHeader)
+	if len(values) == 0 {
+		return ""
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// authenticatedStream 替换流的上下文
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
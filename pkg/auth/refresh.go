@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrTokenReused 刷新令牌已经使用过，说明令牌可能已泄露
+var ErrTokenReused = errors.New("refresh token reused")
+
+// RefreshRecord 刷新令牌记录
+type RefreshRecord struct {
+	UserID    int64
+	SessionID string
+	IssuedAt  time.Time // 会话创建（登录）时间，精确到毫秒
+	ExpiresAt time.Time // 会话过期时间，会话中轮换出的刷新令牌都在此时过期
+}
+
+// RefreshStore 刷新令牌存储，只保存令牌的哈希值
+type RefreshStore interface {
+	// Save 保存刷新令牌，过期后自动删除
+	Save(ctx context.Context, hash string, record *RefreshRecord) error
+	// Use 将刷新令牌标记为已使用并返回记录，令牌不存在或已过期时返回 ErrInvalidToken
+	// 已使用过的令牌同时返回记录和 ErrTokenReused，已使用的令牌保留到过期以便发现重放
+	Use(ctx context.Context, hash string) (*RefreshRecord, error)
+	// RevokeSession 注销会话，会话中签发的刷新令牌和访问令牌全部失效
+	RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error
+	// SessionRevoked 判断会话是否已注销
+	SessionRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// memoryRefreshEntry 进程内的刷新令牌记录
+type memoryRefreshEntry struct {
+	record RefreshRecord
+	used   bool
+}
+
+// memoryRefreshStore 进程内实现，多实例部署时各实例互不可见
+type memoryRefreshStore struct {
+	mu       sync.Mutex
+	tokens   map[string]*memoryRefreshEntry
+	sessions map[string]time.Time // 已注销的会话及其过期时间
+}
+
+// NewMemoryRefreshStore 创建进程内的刷新令牌存储
+func NewMemoryRefreshStore() RefreshStore {
+	return &memoryRefreshStore{
+		tokens:   make(map[string]*memoryRefreshEntry),
+		sessions: make(map[string]time.Time),
+	}
+}
+
+func (s *memoryRefreshStore) Save(ctx context.Context, hash string, record *RefreshRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purge(time.Now())
+	s.tokens[hash] = &memoryRefreshEntry{record: *record}
+	return nil
+}
+
+func (s *memoryRefreshStore) Use(ctx context.Context, hash string) (*RefreshRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.tokens[hash]
+	if !ok || !entry.record.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidToken
+	}
+	record := entry.record
+	if entry.used {
+		return &record, ErrTokenReused
+	}
+	entry.used = true
+	return &record, nil
+}
+
+func (s *memoryRefreshStore) RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[sessionID] = expiresAt
+	return nil
+}
+
+func (s *memoryRefreshStore) SessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.sessions[sessionID]
+	return ok, nil
+}
+
+// purge 清理已过期的令牌和会话注销记录
+func (s *memoryRefreshStore) purge(now time.Time) {
+	for hash, entry := range s.tokens {
+		if !entry.record.ExpiresAt.After(now) {
+			delete(s.tokens, hash)
+		}
+	}
+	for id, exp := range s.sessions {
+		if !exp.After(now) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// useScript 原子地将刷新令牌标记为已使用
+// 令牌不存在时返回 nil，否则返回 {是否首次使用, user_id, session_id, issued_at, expires_at}
+var useScript = redis.NewScript(`
+local entry = redis.call('HMGET', KEYS[1], 'user_id', 'session_id', 'issued_at', 'expires_at')
+if not entry[1] then
+  return false
+end
+local first = redis.call('HSETNX', KEYS[1], 'used', '1')
+return {first, entry[1], entry[2], entry[3], entry[4]}
+`)
+
+// redisRefreshStore 基于Redis的实现，刷新令牌在集群所有实例间共享
+type redisRefreshStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisRefreshStore 创建基于Redis的刷新令牌存储
+func NewRedisRefreshStore(client *redis.Client, prefix string) RefreshStore {
+	return &redisRefreshStore{client: client, prefix: prefix}
+}
+
+func (s *redisRefreshStore) Save(ctx context.Context, hash string, record *RefreshRecord) error {
+	key := s.prefix + "refresh:" + hash
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			"user_id", record.UserID,
+			"session_id", record.SessionID,
+			"issued_at", record.IssuedAt.UnixMilli(),
+			"expires_at", record.ExpiresAt.Unix())
+		pipe.ExpireAt(ctx, key, record.ExpiresAt)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save refresh token: %w", err)
+	}
+	return nil
+}
+
+func (s *redisRefreshStore) Use(ctx context.Context, hash string) (*RefreshRecord, error) {
+	result, err := useScript.Run(ctx, s.client, []string{s.prefix + "refresh:" + hash}).Slice()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to use refresh token: %w", err)
+	}
+	if len(result) != 5 {
+		return nil, fmt.Errorf("unexpected refresh token script result: %v", result)
+	}
+
+	record := &RefreshRecord{SessionID: fmt.Sprint(result[2])}
+	record.UserID, _ = strconv.ParseInt(fmt.Sprint(result[1]), 10, 64)
+	issuedAt, _ := strconv.ParseInt(fmt.Sprint(result[3]), 10, 64)
+	expiresAt, _ := strconv.ParseInt(fmt.Sprint(result[4]), 10, 64)
+	record.IssuedAt = time.UnixMilli(issuedAt)
+	record.ExpiresAt = time.Unix(expiresAt, 0)
+
+	if first, _ := result[0].(int64); first == 0 {
+		return record, ErrTokenReused
+	}
+	return record, nil
+}
+
+func (s *redisRefreshStore) RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	key := s.prefix + "session:revoked:" + sessionID
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := s.client.Set(ctx, key, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+func (s *redisRefreshStore) SessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	n, err := s.client.Exists(ctx, s.prefix+"session:revoked:"+sessionID).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check revoked session: %w", err)
+	}
+	return n > 0, nil
+}
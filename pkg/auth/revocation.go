@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Revocations 记录注销用户全部会话的时间，签发时间不晚于该时间的令牌视为已注销
+// 用于重置密码、账号被盗等需要让所有设备重新登录的场景
+type Revocations interface {
+	// RevokeAll 注销用户当前的全部会话
+	RevokeAll(ctx context.Context, userID int64) error
+	// RevokedAt 返回用户最近一次注销全部会话的时间，未注销过时返回零值
+	RevokedAt(ctx context.Context, userID int64) (time.Time, error)
+}
+
+// IsRevoked 判断 issuedAt 时签发的令牌是否已被注销
+// 签发和注销时间都精确到毫秒，注销之后立即重新登录签发的令牌不受影响；与注销发生在同一毫秒内签发的令牌视为已注销
+func IsRevoked(ctx context.Context, r Revocations, userID int64, issuedAt time.Time) (bool, error) {
+	revokedAt, err := r.RevokedAt(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return !revokedAt.IsZero() && issuedAt.UnixMilli() <= revokedAt.UnixMilli(), nil
+}
+
+// memoryRevocations 进程内实现，多实例部署时各实例互不可见
+type memoryRevocations struct {
+	mu        sync.RWMutex
+	revokedAt map[int64]time.Time
+}
+
+// NewMemoryRevocations 创建进程内的会话注销记录
+func NewMemoryRevocations() Revocations {
+	return &memoryRevocations{revokedAt: make(map[int64]time.Time)}
+}
+
+func (r *memoryRevocations) RevokeAll(ctx context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.revokedAt[userID] = time.Now()
+	return nil
+}
+
+func (r *memoryRevocations) RevokedAt(ctx context.Context, userID int64) (time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.revokedAt[userID], nil
+}
+
+// redisRevocations 基于Redis的实现，注销记录在集群所有实例间共享
+type redisRevocations struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisRevocations 创建基于Redis的会话注销记录
+// ttl 为记录的保留时间，应不短于令牌的最长有效期，过期后旧令牌本身也已失效
+func NewRedisRevocations(client *redis.Client, prefix string, ttl time.Duration) Revocations {
+	return &redisRevocations{client: client, prefix: prefix, ttl: ttl}
+}
+
+func (r *redisRevocations) RevokeAll(ctx context.Context, userID int64) error {
+	key := r.prefix + strconv.FormatInt(userID, 10)
+	if err := r.client.Set(ctx, key, time.Now().UnixMilli(), r.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+func (r *redisRevocations) RevokedAt(ctx context.Context, userID int64) (time.Time, error) {
+	key := r.prefix + strconv.FormatInt(userID, 10)
+	ms, err := r.client.Get(ctx, key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get session revocation: %w", err)
+	}
+	return time.UnixMilli(ms), nil
+}
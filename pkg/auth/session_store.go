@@ -0,0 +1,345 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// ErrSessionNotFound 会话记录不存在或已过期
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionInfo 会话记录，用于向用户展示已登录的设备，ID 与刷新令牌记录中的会话ID相同
+type SessionInfo struct {
+	ID         string
+	UserID     int64
+	Device     string // 根据 User-Agent 识别的设备名称，无法识别时为空
+	IP         string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time // 登录或最近一次刷新令牌的时间
+	ExpiresAt  time.Time
+}
+
+// SessionStore 会话记录存储，会话过期后自动删除
+// 记录只用于展示和按会话注销，令牌是否有效由刷新令牌记录和注销记录决定
+type SessionStore interface {
+	// Create 保存新的会话记录
+	Create(ctx context.Context, session *SessionInfo) error
+	// Get 获取会话记录，不存在或已过期时返回 ErrSessionNotFound
+	Get(ctx context.Context, sessionID string) (*SessionInfo, error)
+	// List 返回用户未过期的会话记录，按最近活动时间倒序
+	List(ctx context.Context, userID int64) ([]*SessionInfo, error)
+	// Touch 更新会话的最近活动时间和IP，会话不存在时返回 ErrSessionNotFound
+	Touch(ctx context.Context, sessionID string, seenAt time.Time, ip string) error
+	// Delete 删除会话记录，不存在时不返回错误
+	Delete(ctx context.Context, userID int64, sessionID string) error
+}
+
+// sortSessions 按最近活动时间倒序排列
+func sortSessions(sessions []*SessionInfo) {
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+}
+
+// memorySessionStore 进程内实现，多实例部署时各实例互不可见
+type memorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]SessionInfo
+}
+
+// NewMemorySessionStore 创建进程内的会话记录存储
+func NewMemorySessionStore() SessionStore {
+	return &memorySessionStore{sessions: make(map[string]SessionInfo)}
+}
+
+func (s *memorySessionStore) Create(ctx context.Context, session *SessionInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, existing := range s.sessions {
+		if !existing.ExpiresAt.After(now) {
+			delete(s.sessions, id)
+		}
+	}
+	s.sessions[session.ID] = *session
+	return nil
+}
+
+func (s *memorySessionStore) Get(ctx context.Context, sessionID string) (*SessionInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || !session.ExpiresAt.After(time.Now()) {
+		return nil, ErrSessionNotFound
+	}
+	return &session, nil
+}
+
+func (s *memorySessionStore) List(ctx context.Context, userID int64) ([]*SessionInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var sessions []*SessionInfo
+	for _, session := range s.sessions {
+		if session.UserID == userID && session.ExpiresAt.After(now) {
+			session := session
+			sessions = append(sessions, &session)
+		}
+	}
+	sortSessions(sessions)
+	return sessions, nil
+}
+
+func (s *memorySessionStore) Touch(ctx context.Context, sessionID string, seenAt time.Time, ip string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || !session.ExpiresAt.After(time.Now()) {
+		return ErrSessionNotFound
+	}
+	session.LastSeenAt = seenAt
+	session.IP = ip
+	s.sessions[sessionID] = session
+	return nil
+}
+
+func (s *memorySessionStore) Delete(ctx context.Context, userID int64, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, sessionID)
+	return nil
+}
+
+// touchScript 会话记录存在时更新最近活动时间和IP，返回是否存在
+var touchScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return 0
+end
+redis.call('HSET', KEYS[1], 'last_seen_at', ARGV[1], 'ip', ARGV[2])
+return 1
+`)
+
+// redisSessionStore 基于Redis的实现
+// 每个会话保存为一个哈希，另用有序集合按用户索引会话ID，分数为会话的过期时间
+type redisSessionStore struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisSessionStore 创建基于Redis的会话记录存储，ttl 为会话的最长有效期，用于设置用户索引的过期时间
+func NewRedisSessionStore(client *redis.Client, prefix string, ttl time.Duration) SessionStore {
+	return &redisSessionStore{client: client, prefix: prefix, ttl: ttl}
+}
+
+func (s *redisSessionStore) sessionKey(sessionID string) string {
+	return s.prefix + "session:info:" + sessionID
+}
+
+func (s *redisSessionStore) userKey(userID int64) string {
+	return s.prefix + "user:sessions:" + strconv.FormatInt(userID, 10)
+}
+
+func (s *redisSessionStore) Create(ctx context.Context, session *SessionInfo) error {
+	key := s.sessionKey(session.ID)
+	userKey := s.userKey(session.UserID)
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			"user_id", session.UserID,
+			"device", session.Device,
+			"ip", session.IP,
+			"user_agent", session.UserAgent,
+			"created_at", session.CreatedAt.Unix(),
+			"last_seen_at", session.LastSeenAt.Unix(),
+			"expires_at", session.ExpiresAt.Unix())
+		pipe.ExpireAt(ctx, key, session.ExpiresAt)
+		pipe.ZAdd(ctx, userKey, redis.Z{Score: float64(session.ExpiresAt.Unix()), Member: session.ID})
+		pipe.ZRemRangeByScore(ctx, userKey, "-inf", strconv.FormatInt(time.Now().Unix(), 10))
+		pipe.Expire(ctx, userKey, s.ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+func (s *redisSessionStore) Get(ctx context.Context, sessionID string) (*SessionInfo, error) {
+	fields, err := s.client.HGetAll(ctx, s.sessionKey(sessionID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, ErrSessionNotFound
+	}
+	return parseSession(sessionID, fields), nil
+}
+
+func (s *redisSessionStore) List(ctx context.Context, userID int64) ([]*SessionInfo, error) {
+	userKey := s.userKey(userID)
+	ids, err := s.client.ZRangeByScore(ctx, userKey, &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(time.Now().Unix(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	cmds := make([]*redis.MapStringStringCmd, len(ids))
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			cmds[i] = pipe.HGetAll(ctx, s.sessionKey(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*SessionInfo, 0, len(ids))
+	var missing []interface{}
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			missing = append(missing, ids[i])
+			continue
+		}
+		sessions = append(sessions, parseSession(ids[i], fields))
+	}
+	// 会话记录已被删除但索引仍在，顺便清理索引
+	if len(missing) > 0 {
+		if err := s.client.ZRem(ctx, userKey, missing...).Err(); err != nil {
+			logger.Warnf("failed to clean session index of user %d: %v", userID, err)
+		}
+	}
+	sortSessions(sessions)
+	return sessions, nil
+}
+
+func (s *redisSessionStore) Touch(ctx context.Context, sessionID string, seenAt time.Time, ip string) error {
+	found, err := touchScript.Run(ctx, s.client, []string{s.sessionKey(sessionID)}, seenAt.Unix(), ip).Int()
+	if err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	if found == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+func (s *redisSessionStore) Delete(ctx context.Context, userID int64, sessionID string) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, s.sessionKey(sessionID))
+		pipe.ZRem(ctx, s.userKey(userID), sessionID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// parseSession 解析会话哈希
+func parseSession(sessionID string, fields map[string]string) *SessionInfo {
+	unix := func(name string) time.Time {
+		v, _ := strconv.ParseInt(fields[name], 10, 64)
+		return time.Unix(v, 0)
+	}
+	userID, _ := strconv.ParseInt(fields["user_id"], 10, 64)
+	return &SessionInfo{
+		ID:         sessionID,
+		UserID:     userID,
+		Device:     fields["device"],
+		IP:         fields["ip"],
+		UserAgent:  fields["user_agent"],
+		CreatedAt:  unix("created_at"),
+		LastSeenAt: unix("last_seen_at"),
+		ExpiresAt:  unix("expires_at"),
+	}
+}
+
+// fallbackSessionStore 以缓存存储为主、数据库为备的会话记录存储
+// 写入同时写两边，数据库写入失败时返回错误；读取优先读缓存，缓存出错或缺失记录时读数据库
+type fallbackSessionStore struct {
+	primary  SessionStore
+	fallback SessionStore
+}
+
+// NewFallbackSessionStore 创建带持久化备份的会话记录存储
+func NewFallbackSessionStore(primary, fallback SessionStore) SessionStore {
+	return &fallbackSessionStore{primary: primary, fallback: fallback}
+}
+
+func (s *fallbackSessionStore) Create(ctx context.Context, session *SessionInfo) error {
+	if err := s.fallback.Create(ctx, session); err != nil {
+		return err
+	}
+	if err := s.primary.Create(ctx, session); err != nil {
+		logger.Warnf("failed to cache session %s: %v", session.ID, err)
+	}
+	return nil
+}
+
+func (s *fallbackSessionStore) Get(ctx context.Context, sessionID string) (*SessionInfo, error) {
+	session, err := s.primary.Get(ctx, sessionID)
+	if err == nil {
+		return session, nil
+	}
+	if !errors.Is(err, ErrSessionNotFound) {
+		logger.Warnf("failed to get cached session %s, reading from database: %v", sessionID, err)
+	}
+	return s.fallback.Get(ctx, sessionID)
+}
+
+func (s *fallbackSessionStore) List(ctx context.Context, userID int64) ([]*SessionInfo, error) {
+	sessions, err := s.primary.List(ctx, userID)
+	if err == nil {
+		return sessions, nil
+	}
+	logger.Warnf("failed to list cached sessions of user %d, reading from database: %v", userID, err)
+	return s.fallback.List(ctx, userID)
+}
+
+// Touch 两边都更新，缓存中缺失的记录从数据库补回
+func (s *fallbackSessionStore) Touch(ctx context.Context, sessionID string, seenAt time.Time, ip string) error {
+	if err := s.fallback.Touch(ctx, sessionID, seenAt, ip); err != nil {
+		return err
+	}
+
+	err := s.primary.Touch(ctx, sessionID, seenAt, ip)
+	if errors.Is(err, ErrSessionNotFound) {
+		var session *SessionInfo
+		if session, err = s.fallback.Get(ctx, sessionID); err == nil {
+			err = s.primary.Create(ctx, session)
+		}
+	}
+	if err != nil {
+		logger.Warnf("failed to touch cached session %s: %v", sessionID, err)
+	}
+	return nil
+}
+
+func (s *fallbackSessionStore) Delete(ctx context.Context, userID int64, sessionID string) error {
+	if err := s.primary.Delete(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	return s.fallback.Delete(ctx, userID, sessionID)
+}
@@ -0,0 +1,340 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// refreshTokenBytes 刷新令牌的随机字节数
+const refreshTokenBytes = 32
+
+// TokenPair 登录或刷新时签发的一对令牌
+type TokenPair struct {
+	UserID           int64
+	SessionID        string
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// RoleSource 查询用户当前的角色，签发访问令牌时写入 roles 声明
+type RoleSource interface {
+	GetUserRoles(ctx context.Context, userID int64) ([]string, error)
+}
+
+// Sessions 管理登录会话：签发短期访问令牌和可轮换的刷新令牌、校验访问令牌、登出
+// 每次刷新都会签发新的刷新令牌并使旧令牌失效，旧令牌再次使用时注销整个会话
+type Sessions struct {
+	config      *Config
+	tokens      *Tokens
+	refresh     RefreshStore
+	deny        DenyList
+	revocations Revocations
+	records     SessionStore
+	roles       RoleSource
+}
+
+// NewSessions 根据配置创建会话管理器，store 为 redis 时需要传入Redis客户端
+// roles 为 nil 时签发的访问令牌不携带角色；persistent 不为 nil 时会话记录同时写入该存储，缓存不可用时从中读取
+func NewSessions(config *Config, client *redis.Client, roles RoleSource, persistent SessionStore) (*Sessions, error) {
+	tokens, err := NewTokens(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.RefreshExpireTime <= 0 {
+		return nil, fmt.Errorf("jwt refresh expire time must be positive")
+	}
+
+	s := &Sessions{config: config, tokens: tokens, roles: roles}
+	switch config.Store {
+	case "", "memory":
+		s.refresh = NewMemoryRefreshStore()
+		s.deny = NewMemoryDenyList()
+		s.revocations = NewMemoryRevocations()
+		s.records = NewMemorySessionStore()
+	case "redis":
+		if client == nil {
+			return nil, fmt.Errorf("redis client is required for redis session store")
+		}
+		s.refresh = NewRedisRefreshStore(client, config.KeyPrefix)
+		s.deny = NewRedisDenyList(client, config.KeyPrefix+"deny:")
+		s.revocations = NewRedisRevocations(client, config.KeyPrefix+"user:revoked:", config.RefreshExpireTime)
+		s.records = NewRedisSessionStore(client, config.KeyPrefix, config.RefreshExpireTime)
+	default:
+		return nil, fmt.Errorf("unsupported session store: %s", config.Store)
+	}
+	if persistent != nil {
+		s.records = NewFallbackSessionStore(s.records, persistent)
+	}
+	return s, nil
+}
+
+// Revocations 返回用户级的会话注销记录，用于重置密码等需要注销全部会话的场景
+func (s *Sessions) Revocations() Revocations {
+	return s.revocations
+}
+
+// Create 为登录成功的用户创建会话，并按请求的客户端IP和 User-Agent 保存会话记录
+func (s *Sessions) Create(ctx context.Context, userID int64) (*TokenPair, error) {
+	now := time.Now()
+	record := &RefreshRecord{
+		UserID:    userID,
+		SessionID: randomID(),
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.config.RefreshExpireTime),
+	}
+	if err := s.records.Create(ctx, newSessionInfo(ctx, record, now)); err != nil {
+		return nil, err
+	}
+	return s.issue(ctx, record)
+}
+
+// Refresh 使用刷新令牌换取新的令牌对，旧的刷新令牌随之失效
+// 新的访问令牌按用户当前的角色签发，授予的角色在刷新后生效
+// 已使用过的刷新令牌再次出现时注销整个会话并返回 ErrTokenReused
+func (s *Sessions) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	record, err := s.refresh.Use(ctx, hashToken(refreshToken))
+	if errors.Is(err, ErrTokenReused) {
+		// 登出时刷新令牌同样被标记为已使用，会话已注销时不视为重放
+		revoked, err := s.refresh.SessionRevoked(ctx, record.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrInvalidToken
+		}
+
+		logger.Warnf("refresh token reuse detected for user %d, revoking session %s", record.UserID, record.SessionID)
+		if err := s.revokeSession(ctx, record.UserID, record.SessionID, record.ExpiresAt); err != nil {
+			return nil, err
+		}
+		return nil, ErrTokenReused
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSession(ctx, record.UserID, record.SessionID, record.IssuedAt); err != nil {
+		return nil, err
+	}
+	s.touch(ctx, record)
+	return s.issue(ctx, record)
+}
+
+// Authenticate 校验访问令牌，已过期、已登出或所在会话已注销的令牌返回 ErrInvalidToken
+func (s *Sessions) Authenticate(ctx context.Context, accessToken string) (*Claims, error) {
+	claims, err := s.tokens.Parse(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	denied, err := s.deny.IsDenied(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if denied {
+		return nil, ErrInvalidToken
+	}
+	if err := s.checkSession(ctx, claims.UserID, claims.SessionID, claims.IssuedAt); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Logout 登出：注销访问令牌并注销其所在会话，同时使刷新令牌失效
+// 两个令牌都可以为空，已过期的访问令牌同样可以用于登出
+func (s *Sessions) Logout(ctx context.Context, accessToken, refreshToken string) error {
+	if accessToken != "" {
+		claims, err := s.tokens.ParseExpired(accessToken)
+		if err != nil {
+			return err
+		}
+		if err := s.deny.Deny(ctx, claims.ID, claims.ExpiresAt); err != nil {
+			return err
+		}
+		// 访问令牌中没有会话的过期时间，按会话最长有效期保留注销记录
+		if err := s.revokeSession(ctx, claims.UserID, claims.SessionID, claims.IssuedAt.Add(s.config.RefreshExpireTime)); err != nil {
+			return err
+		}
+	}
+
+	if refreshToken != "" {
+		record, err := s.refresh.Use(ctx, hashToken(refreshToken))
+		if errors.Is(err, ErrInvalidToken) {
+			return nil
+		}
+		if err != nil && !errors.Is(err, ErrTokenReused) {
+			return err
+		}
+		if err := s.revokeSession(ctx, record.UserID, record.SessionID, record.ExpiresAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List 返回用户当前有效的会话，已注销的会话记录随之清理
+func (s *Sessions) List(ctx context.Context, userID int64) ([]*SessionInfo, error) {
+	sessions, err := s.records.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := sessions[:0]
+	for _, session := range sessions {
+		err := s.checkSession(ctx, userID, session.ID, session.CreatedAt)
+		if errors.Is(err, ErrInvalidToken) {
+			if err := s.records.Delete(ctx, userID, session.ID); err != nil {
+				logger.Warnf("failed to delete revoked session %s: %v", session.ID, err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		active = append(active, session)
+	}
+	return active, nil
+}
+
+// Revoke 注销用户的指定会话，会话不存在或不属于该用户时返回 ErrSessionNotFound
+func (s *Sessions) Revoke(ctx context.Context, userID int64, sessionID string) error {
+	session, err := s.records.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	return s.revokeSession(ctx, userID, sessionID, session.ExpiresAt)
+}
+
+// RevokeOthers 注销用户除 currentSessionID 以外的全部会话，返回注销的会话数量
+func (s *Sessions) RevokeOthers(ctx context.Context, userID int64, currentSessionID string) (int, error) {
+	sessions, err := s.List(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if session.ID == currentSessionID {
+			continue
+		}
+		if err := s.revokeSession(ctx, userID, session.ID, session.ExpiresAt); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// revokeSession 注销会话并删除会话记录，记录删除失败不影响注销
+func (s *Sessions) revokeSession(ctx context.Context, userID int64, sessionID string, expiresAt time.Time) error {
+	if err := s.refresh.RevokeSession(ctx, sessionID, expiresAt); err != nil {
+		return err
+	}
+	if err := s.records.Delete(ctx, userID, sessionID); err != nil {
+		logger.Warnf("failed to delete session %s of user %d: %v", sessionID, userID, err)
+	}
+	return nil
+}
+
+// touch 刷新令牌时更新会话的最近活动时间，更新失败不影响刷新
+// 会话记录不存在时（如记录功能上线前登录的会话）按刷新令牌记录补建
+func (s *Sessions) touch(ctx context.Context, record *RefreshRecord) {
+	now := time.Now()
+	err := s.records.Touch(ctx, record.SessionID, now, ClientIP(ctx))
+	if errors.Is(err, ErrSessionNotFound) {
+		err = s.records.Create(ctx, newSessionInfo(ctx, record, now))
+	}
+	if err != nil {
+		logger.Warnf("failed to update last seen time of session %s: %v", record.SessionID, err)
+	}
+}
+
+// issue 在会话中签发新的访问令牌和刷新令牌
+func (s *Sessions) issue(ctx context.Context, record *RefreshRecord) (*TokenPair, error) {
+	var roles []string
+	if s.roles != nil {
+		var err error
+		if roles, err = s.roles.GetUserRoles(ctx, record.UserID); err != nil {
+			return nil, fmt.Errorf("failed to get roles of user %d: %w", record.UserID, err)
+		}
+	}
+
+	accessToken, claims, err := s.tokens.Issue(record.UserID, record.SessionID, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(b)
+	if err := s.refresh.Save(ctx, hashToken(refreshToken), record); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		UserID:           record.UserID,
+		SessionID:        record.SessionID,
+		AccessToken:      accessToken,
+		AccessExpiresAt:  claims.ExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: record.ExpiresAt,
+	}, nil
+}
+
+// checkSession 会话已注销或用户在 issuedAt 之后注销了全部会话时返回 ErrInvalidToken
+func (s *Sessions) checkSession(ctx context.Context, userID int64, sessionID string, issuedAt time.Time) error {
+	revoked, err := s.refresh.SessionRevoked(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return ErrInvalidToken
+	}
+
+	revoked, err = IsRevoked(ctx, s.revocations, userID, issuedAt)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// newSessionInfo 根据请求的客户端信息创建会话记录
+func newSessionInfo(ctx context.Context, record *RefreshRecord, now time.Time) *SessionInfo {
+	userAgent := UserAgent(ctx)
+	return &SessionInfo{
+		ID:         record.SessionID,
+		UserID:     record.UserID,
+		Device:     DeviceName(userAgent),
+		IP:         ClientIP(ctx),
+		UserAgent:  userAgent,
+		CreatedAt:  record.IssuedAt,
+		LastSeenAt: now,
+		ExpiresAt:  record.ExpiresAt,
+	}
+}
+
+// hashToken 计算刷新令牌的哈希，存储中只保存哈希值
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,396 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeRoles 内存中的用户角色
+type fakeRoles map[int64][]string
+
+func (r fakeRoles) GetUserRoles(ctx context.Context, userID int64) ([]string, error) {
+	return r[userID], nil
+}
+
+// newTestSessions 创建使用内存存储的会话管理器
+func newTestSessions(t *testing.T) *Sessions {
+	t.Helper()
+	return newTestSessionsWithRoles(t, nil)
+}
+
+// newTestSessionsWithRoles 创建签发令牌时从 roles 读取角色的会话管理器
+func newTestSessionsWithRoles(t *testing.T, roles RoleSource) *Sessions {
+	t.Helper()
+	sessions, err := NewSessions(&Config{
+		Secret:            "test-secret",
+		ExpireTime:        time.Minute,
+		Issuer:            "movieinfo",
+		RefreshExpireTime: time.Hour,
+		Store:             "memory",
+	}, nil, roles, nil)
+	if err != nil {
+		t.Fatalf("NewSessions() error = %v", err)
+	}
+	return sessions
+}
+
+func TestRefreshRotation(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSessions(t)
+
+	first, err := s.Create(ctx, 2)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	second, err := s.Refresh(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if second.SessionID != first.SessionID || second.RefreshToken == first.RefreshToken {
+		t.Fatalf("Refresh() = session %s token %s, want a new token in session %s",
+			second.SessionID, second.RefreshToken, first.SessionID)
+	}
+	if second.RefreshExpiresAt != first.RefreshExpiresAt {
+		t.Fatalf("Refresh() expires at %v, want the session expiry %v", second.RefreshExpiresAt, first.RefreshExpiresAt)
+	}
+	if _, err := s.Authenticate(ctx, second.AccessToken); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	// 旧令牌重放时注销整个会话，轮换出的令牌全部失效
+	if _, err := s.Refresh(ctx, first.RefreshToken); !errors.Is(err, ErrTokenReused) {
+		t.Fatalf("Refresh() with a used token error = %v, want ErrTokenReused", err)
+	}
+	if _, err := s.Refresh(ctx, second.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Refresh() after reuse error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := s.Authenticate(ctx, second.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Authenticate() after reuse error = %v, want ErrInvalidToken", err)
+	}
+
+	// 其他会话不受影响
+	other, err := s.Create(ctx, 2)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := s.Refresh(ctx, other.RefreshToken); err != nil {
+		t.Fatalf("Refresh() in another session error = %v", err)
+	}
+}
+
+func TestRefreshUpdatesRoles(t *testing.T) {
+	ctx := context.Background()
+	roles := fakeRoles{2: {"editor"}}
+	s := newTestSessionsWithRoles(t, roles)
+
+	pair, err := s.Create(ctx, 2)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	claims, err := s.Authenticate(ctx, pair.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if !reflect.DeepEqual(claims.Roles, []string{"editor"}) {
+		t.Fatalf("roles = %v, want [editor]", claims.Roles)
+	}
+
+	// 授予的角色在刷新令牌后生效
+	roles[2] = []string{"editor", "admin"}
+	pair, err = s.Refresh(ctx, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	claims, err = s.Authenticate(ctx, pair.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if !reflect.DeepEqual(claims.Roles, []string{"editor", "admin"}) {
+		t.Fatalf("roles after refresh = %v, want [editor admin]", claims.Roles)
+	}
+}
+
+func TestRefreshUnknownToken(t *testing.T) {
+	s := newTestSessions(t)
+	if _, err := s.Refresh(context.Background(), "unknown"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Refresh() error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	tests := []struct {
+		name         string
+		accessToken  bool
+		refreshToken bool
+	}{
+		{name: "access token", accessToken: true},
+		{name: "refresh token", refreshToken: true},
+		{name: "both tokens", accessToken: true, refreshToken: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newTestSessions(t)
+			pair, err := s.Create(ctx, 2)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			var access, refresh string
+			if tt.accessToken {
+				access = pair.AccessToken
+			}
+			if tt.refreshToken {
+				refresh = pair.RefreshToken
+			}
+			if err := s.Logout(ctx, access, refresh); err != nil {
+				t.Fatalf("Logout() error = %v", err)
+			}
+
+			// 任一令牌登出都会注销整个会话
+			if _, err := s.Authenticate(ctx, pair.AccessToken); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Authenticate() after logout error = %v, want ErrInvalidToken", err)
+			}
+			// 登出后使用刷新令牌不视为重放
+			if _, err := s.Refresh(ctx, pair.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Refresh() after logout error = %v, want ErrInvalidToken", err)
+			}
+			// 重复登出同样成功
+			if err := s.Logout(ctx, access, refresh); err != nil {
+				t.Fatalf("second Logout() error = %v", err)
+			}
+		})
+	}
+}
+
+// sessionIDs 返回用户当前有效会话的编号
+func sessionIDs(t *testing.T, s *Sessions, userID int64) []string {
+	t.Helper()
+	sessions, err := s.List(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	return ids
+}
+
+func TestRevokeSession(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSessions(t)
+	first, err := s.Create(ctx, 2)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	second, err := s.Create(ctx, 2)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// 其他用户不能注销不属于自己的会话
+	if err := s.Revoke(ctx, 3, first.SessionID); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("Revoke() by other user error = %v, want ErrSessionNotFound", err)
+	}
+	if err := s.Revoke(ctx, 2, first.SessionID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	if _, err := s.Authenticate(ctx, first.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Authenticate() revoked session error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := s.Refresh(ctx, first.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Refresh() revoked session error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := s.Authenticate(ctx, second.AccessToken); err != nil {
+		t.Fatalf("Authenticate() other session error = %v", err)
+	}
+	if ids, want := sessionIDs(t, s, 2), []string{second.SessionID}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("List() = %v, want %v", ids, want)
+	}
+	if err := s.Revoke(ctx, 2, first.SessionID); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("second Revoke() error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestRevokeOthers(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSessions(t)
+	var pairs []*TokenPair
+	for i := 0; i < 3; i++ {
+		pair, err := s.Create(ctx, 2)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		pairs = append(pairs, pair)
+	}
+	other, err := s.Create(ctx, 3)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	current := pairs[1]
+	revoked, err := s.RevokeOthers(ctx, 2, current.SessionID)
+	if err != nil {
+		t.Fatalf("RevokeOthers() error = %v", err)
+	}
+	if revoked != 2 {
+		t.Fatalf("RevokeOthers() = %d, want 2", revoked)
+	}
+
+	for _, pair := range pairs {
+		_, err := s.Authenticate(ctx, pair.AccessToken)
+		if pair == current && err != nil {
+			t.Fatalf("Authenticate() current session error = %v", err)
+		}
+		if pair != current && !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("Authenticate() revoked session error = %v, want ErrInvalidToken", err)
+		}
+	}
+	if ids, want := sessionIDs(t, s, 2), []string{current.SessionID}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("List() = %v, want %v", ids, want)
+	}
+	// 其他用户的会话不受影响
+	if _, err := s.Authenticate(ctx, other.AccessToken); err != nil {
+		t.Fatalf("Authenticate() other user error = %v", err)
+	}
+}
+
+func TestRevokeAllThenLogin(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSessions(t)
+	before, err := s.Create(ctx, 2)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := s.Revocations().RevokeAll(ctx, 2); err != nil {
+		t.Fatalf("RevokeAll() error = %v", err)
+	}
+	// 注销后立即重新登录，与注销通常在同一秒内
+	time.Sleep(2 * time.Millisecond)
+	after, err := s.Create(ctx, 2)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := s.Authenticate(ctx, before.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Authenticate() token issued before RevokeAll error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := s.Refresh(ctx, before.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Refresh() session created before RevokeAll error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := s.Authenticate(ctx, after.AccessToken); err != nil {
+		t.Fatalf("Authenticate() token issued after RevokeAll error = %v", err)
+	}
+	if _, err := s.Refresh(ctx, after.RefreshToken); err != nil {
+		t.Fatalf("Refresh() session created after RevokeAll error = %v", err)
+	}
+}
+
+func TestMemoryDenyList(t *testing.T) {
+	ctx := context.Background()
+	l := NewMemoryDenyList()
+
+	if err := l.Deny(ctx, "live", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("Deny() error = %v", err)
+	}
+	if err := l.Deny(ctx, "expired", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("Deny() error = %v", err)
+	}
+
+	tests := []struct {
+		jti  string
+		want bool
+	}{
+		{"live", true},
+		{"expired", false},
+		{"unknown", false},
+	}
+	for _, tt := range tests {
+		got, err := l.IsDenied(ctx, tt.jti)
+		if err != nil {
+			t.Fatalf("IsDenied(%q) error = %v", tt.jti, err)
+		}
+		if got != tt.want {
+			t.Fatalf("IsDenied(%q) = %v, want %v", tt.jti, got, tt.want)
+		}
+	}
+}
+
+func TestTokensParse(t *testing.T) {
+	config := &Config{Secret: "test-secret", ExpireTime: time.Minute, Issuer: "movieinfo"}
+	tokens, err := NewTokens(config)
+	if err != nil {
+		t.Fatalf("NewTokens() error = %v", err)
+	}
+	token, issued, err := tokens.Issue(2, "session", []string{"admin"})
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	claims, err := tokens.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !reflect.DeepEqual(claims, issued) {
+		t.Fatalf("Parse() = %+v, want %+v", *claims, *issued)
+	}
+
+	expired, _ := NewTokens(&Config{Secret: "test-secret", ExpireTime: time.Nanosecond, Issuer: "movieinfo"})
+	expiredToken, _, err := expired.Issue(2, "session", nil)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	otherSecret, _ := NewTokens(&Config{Secret: "other-secret", ExpireTime: time.Minute, Issuer: "movieinfo"})
+	otherIssuer, _ := NewTokens(&Config{Secret: "test-secret", ExpireTime: time.Minute, Issuer: "other"})
+
+	tests := []struct {
+		name   string
+		tokens *Tokens
+		token  string
+	}{
+		{name: "expired", tokens: tokens, token: expiredToken},
+		{name: "wrong secret", tokens: otherSecret, token: token},
+		{name: "wrong issuer", tokens: otherIssuer, token: token},
+		{name: "malformed", tokens: tokens, token: "not-a-jwt"},
+	}
+	for _, tt := range tests {
+		if _, err := tt.tokens.Parse(tt.token); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("Parse() %s error = %v, want ErrInvalidToken", tt.name, err)
+		}
+	}
+
+	// 登出时接受已过期的令牌
+	if _, err := tokens.ParseExpired(expiredToken); err != nil {
+		t.Fatalf("ParseExpired() error = %v", err)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"Bearer abc", "abc"},
+		{"bearer  abc ", "abc"},
+		{"Basic abc", ""},
+		{"abc", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationHeader, tt.header))
+		if got := BearerToken(ctx); got != tt.want {
+			t.Fatalf("BearerToken(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+	if got := BearerToken(context.Background()); got != "" {
+		t.Fatalf("BearerToken() without metadata = %q, want empty", got)
+	}
+}
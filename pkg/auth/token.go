@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken 令牌无效、已过期、已注销或签名不匹配
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Config JWT与会话配置
+type Config struct {
+	Secret            string        `yaml:"secret"`
+	ExpireTime        time.Duration `yaml:"expire_time"` // 访问令牌有效期
+	Issuer            string        `yaml:"issuer"`
+	RefreshExpireTime time.Duration `yaml:"refresh_expire_time"` // 刷新令牌有效期，从登录时起计算，刷新不会延长
+	Store             string        `yaml:"store"`               // 刷新令牌、会话记录和注销记录的存储：memory（单实例）| redis
+	KeyPrefix         string        `yaml:"key_prefix"`
+}
+
+// Claims 访问令牌中的声明
+type Claims struct {
+	ID        string    // 令牌ID，对应 jti，用于注销单个令牌
+	UserID    int64     // 用户ID，对应 sub
+	SessionID string    // 会话ID，对应 sid，同一次登录中轮换出的令牌共用
+	Roles     []string  // 签发时用户拥有的角色，对应 roles
+	IssuedAt  time.Time // 签发时间，精确到毫秒，对应 iat_ms
+	ExpiresAt time.Time // 过期时间，对应 exp
+}
+
+// accessClaims 访问令牌的JWT声明
+// 标准的 iat 只精确到秒，另外携带毫秒时间戳 iat_ms，与同一秒内注销全部会话的时间比较时不会误判
+type accessClaims struct {
+	SessionID  string   `json:"sid"`
+	Roles      []string `json:"roles,omitempty"`
+	IssuedAtMs int64    `json:"iat_ms,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Tokens 签发和解析HS256签名的访问令牌
+type Tokens struct {
+	config *Config
+}
+
+// NewTokens 创建令牌管理器
+func NewTokens(config *Config) (*Tokens, error) {
+	if config.Secret == "" {
+		return nil, fmt.Errorf("jwt secret is required")
+	}
+	if config.ExpireTime <= 0 {
+		return nil, fmt.Errorf("jwt expire time must be positive")
+	}
+	return &Tokens{config: config}, nil
+}
+
+// ExpireTime 返回访问令牌有效期
+func (t *Tokens) ExpireTime() time.Duration {
+	return t.config.ExpireTime
+}
+
+// Issue 为用户在会话中签发访问令牌，roles 为用户当前的角色
+func (t *Tokens) Issue(userID int64, sessionID string, roles []string) (string, *Claims, error) {
+	now := time.Now()
+	claims := &Claims{
+		ID:        randomID(),
+		UserID:    userID,
+		SessionID: sessionID,
+		Roles:     roles,
+		IssuedAt:  time.UnixMilli(now.UnixMilli()),
+		ExpiresAt: now.Add(t.config.ExpireTime).Truncate(time.Second),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims{
+		SessionID:  sessionID,
+		Roles:      roles,
+		IssuedAtMs: claims.IssuedAt.UnixMilli(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        claims.ID,
+			Issuer:    t.config.Issuer,
+			Subject:   strconv.FormatInt(userID, 10),
+			IssuedAt:  jwt.NewNumericDate(claims.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+		},
+	}).SignedString([]byte(t.config.Secret))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, claims, nil
+}
+
+// Parse 校验令牌签名、签发者和有效期并返回声明，无效时返回 ErrInvalidToken
+func (t *Tokens) Parse(token string) (*Claims, error) {
+	return t.parse(token, jwt.WithExpirationRequired())
+}
+
+// ParseExpired 只校验签名和签发者，已过期的令牌同样返回声明，用于登出
+func (t *Tokens) ParseExpired(token string) (*Claims, error) {
+	return t.parse(token, jwt.WithoutClaimsValidation())
+}
+
+func (t *Tokens) parse(token string, opts ...jwt.ParserOption) (*Claims, error) {
+	var claims accessClaims
+	opts = append(opts, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(t.config.Secret), nil
+	}, opts...)
+	if err != nil || claims.Issuer != t.config.Issuer {
+		return nil, ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userID <= 0 || claims.ID == "" || claims.IssuedAt == nil || claims.ExpiresAt == nil {
+		return nil, ErrInvalidToken
+	}
+	issuedAt := claims.IssuedAt.Time
+	if claims.IssuedAtMs > 0 {
+		issuedAt = time.UnixMilli(claims.IssuedAtMs)
+	}
+	return &Claims{
+		ID:        claims.ID,
+		UserID:    userID,
+		SessionID: claims.SessionID,
+		Roles:     claims.Roles,
+		IssuedAt:  issuedAt,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// randomID 生成128位随机ID
+func randomID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to read random bytes: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
@@ -32,8 +33,11 @@ func LoadConfig(configPath string) (*Config, error) {
 	bindEnvVars(v)
 
 	// 解析配置
+	// 结构体字段使用 yaml 标签描述配置键（如 max_open_conns）
 	var config Config
-	if err := v.Unmarshal(&config); err != nil {
+	if err := v.Unmarshal(&config, func(dc *mapstructure.DecoderConfig) {
+		dc.TagName = "yaml"
+	}); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
@@ -53,6 +57,7 @@ func bindEnvVars(v *viper.Viper) {
 	v.BindEnv("database.password", "MOVIEINFO_DATABASE_PASSWORD")
 	v.BindEnv("redis.password", "MOVIEINFO_REDIS_PASSWORD")
 	v.BindEnv("jwt.secret", "MOVIEINFO_JWT_SECRET")
+	v.BindEnv("mail.smtp.password", "MOVIEINFO_MAIL_SMTP_PASSWORD")
 }
 
 // applyDefaults 应用默认值
@@ -91,14 +96,202 @@ func applyDefaults(config *Config) {
 	if config.Log.File.MaxAge == 0 {
 		config.Log.File.MaxAge = 30
 	}
+	if config.Log.Async.BufferSize == 0 {
+		config.Log.Async.BufferSize = 4096
+	}
 
 	// JWT默认值
 	if config.JWT.ExpireTime == 0 {
-		config.JWT.ExpireTime = 24 * time.Hour
+		config.JWT.ExpireTime = 15 * time.Minute
 	}
 	if config.JWT.Issuer == "" {
 		config.JWT.Issuer = "movieinfo"
 	}
+	if config.JWT.RefreshExpireTime == 0 {
+		config.JWT.RefreshExpireTime = 30 * 24 * time.Hour
+	}
+	if config.JWT.Store == "" {
+		config.JWT.Store = "redis"
+	}
+	if config.JWT.KeyPrefix == "" {
+		config.JWT.KeyPrefix = "movieinfo:auth:"
+	}
+
+	// 事件总线默认值
+	if config.EventBus.Driver == "" {
+		config.EventBus.Driver = "memory"
+	}
+	if config.EventBus.BufferSize == 0 {
+		config.EventBus.BufferSize = 64
+	}
+
+	// 指标默认值
+	if config.Metrics.Addr == "" {
+		config.Metrics.Addr = ":9100"
+	}
+	if config.Metrics.Path == "" {
+		config.Metrics.Path = "/metrics"
+	}
+
+	// 链路追踪默认值，未配置 tracing 时不导出span
+	if config.Tracing.Exporter == "" {
+		config.Tracing.Exporter = "none"
+		config.Tracing.SampleRatio = 1
+	}
+	if config.Tracing.Endpoint == "" {
+		config.Tracing.Endpoint = "localhost:4317"
+	}
+
+	// 优雅关闭默认值
+	if config.Shutdown.DrainTimeout == 0 {
+		config.Shutdown.DrainTimeout = 30 * time.Second
+	}
+
+	// 邮件默认值
+	if config.Mail.Driver == "" {
+		config.Mail.Driver = "log"
+	}
+	if config.Mail.From == "" {
+		config.Mail.From = "MovieInfo <noreply@movieinfo.local>"
+	}
+	if config.Mail.SMTP.Port == 0 {
+		config.Mail.SMTP.Port = 587
+	}
+	if config.Mail.SMTP.Timeout == 0 {
+		config.Mail.SMTP.Timeout = 10 * time.Second
+	}
+	if config.Mail.Dir == "" {
+		config.Mail.Dir = "tmp/mail"
+	}
+
+	// 找回密码重置码默认值
+	if config.PasswordReset.Store == "" {
+		config.PasswordReset.Store = "redis"
+	}
+	if config.PasswordReset.KeyPrefix == "" {
+		config.PasswordReset.KeyPrefix = "movieinfo:password_reset:"
+	}
+	if config.PasswordReset.TTL == 0 {
+		config.PasswordReset.TTL = 15 * time.Minute
+	}
+	if config.PasswordReset.MaxAttempts == 0 {
+		config.PasswordReset.MaxAttempts = 5
+	}
+	if config.PasswordReset.Length == 0 {
+		config.PasswordReset.Length = 6
+	}
+	if config.PasswordReset.ResendInterval == 0 {
+		config.PasswordReset.ResendInterval = time.Minute
+	}
+	if config.PasswordReset.ResendBurst == 0 {
+		config.PasswordReset.ResendBurst = 3
+	}
+
+	// 邮箱验证默认值
+	if config.EmailVerification.Store == "" {
+		config.EmailVerification.Store = "redis"
+	}
+	if config.EmailVerification.KeyPrefix == "" {
+		config.EmailVerification.KeyPrefix = "movieinfo:email_verification:"
+	}
+	if config.EmailVerification.TTL == 0 {
+		config.EmailVerification.TTL = 24 * time.Hour
+	}
+	if config.EmailVerification.VerifyURL == "" {
+		config.EmailVerification.VerifyURL = fmt.Sprintf("http://localhost:%d/verify-email", config.App.Port)
+	}
+	if config.EmailVerification.ResendInterval == 0 {
+		config.EmailVerification.ResendInterval = time.Minute
+	}
+	if config.EmailVerification.ResendBurst == 0 {
+		config.EmailVerification.ResendBurst = 3
+	}
+
+	// 登录失败保护默认值
+	if config.LoginProtection.Store == "" {
+		config.LoginProtection.Store = "redis"
+	}
+	if config.LoginProtection.KeyPrefix == "" {
+		config.LoginProtection.KeyPrefix = "movieinfo:login:"
+	}
+	if config.LoginProtection.Window == 0 {
+		config.LoginProtection.Window = 30 * time.Minute
+	}
+	if config.LoginProtection.MaxAccountFailures == 0 {
+		config.LoginProtection.MaxAccountFailures = 5
+	}
+	if config.LoginProtection.LockoutDuration == 0 {
+		config.LoginProtection.LockoutDuration = 15 * time.Minute
+	}
+	if config.LoginProtection.BaseDelay == 0 {
+		config.LoginProtection.BaseDelay = time.Second
+	}
+	if config.LoginProtection.MaxDelay == 0 {
+		config.LoginProtection.MaxDelay = 30 * time.Second
+	}
+
+	if config.Password.Hash.Algorithm == "" {
+		config.Password.Hash.Algorithm = "argon2id"
+	}
+	if config.Password.Hash.Memory == 0 {
+		config.Password.Hash.Memory = 64 * 1024
+	}
+	if config.Password.Hash.Iterations == 0 {
+		config.Password.Hash.Iterations = 3
+	}
+	if config.Password.Hash.Parallelism == 0 {
+		config.Password.Hash.Parallelism = 2
+	}
+	if config.Password.Hash.SaltLength == 0 {
+		config.Password.Hash.SaltLength = 16
+	}
+	if config.Password.Hash.KeyLength == 0 {
+		config.Password.Hash.KeyLength = 32
+	}
+	if config.Password.Hash.BcryptCost == 0 {
+		config.Password.Hash.BcryptCost = 12
+	}
+	if config.Password.Policy.MinLength == 0 {
+		config.Password.Policy.MinLength = 8
+	}
+	if config.Password.Policy.MaxLength == 0 {
+		config.Password.Policy.MaxLength = 72
+	}
+
+	if config.TwoFactor.Store == "" {
+		config.TwoFactor.Store = "redis"
+	}
+	if config.TwoFactor.KeyPrefix == "" {
+		config.TwoFactor.KeyPrefix = "movieinfo:2fa:"
+	}
+	if config.TwoFactor.Issuer == "" {
+		config.TwoFactor.Issuer = "MovieInfo"
+	}
+	if config.TwoFactor.Digits == 0 {
+		config.TwoFactor.Digits = 6
+	}
+	if config.TwoFactor.Period == 0 {
+		config.TwoFactor.Period = 30 * time.Second
+	}
+	if config.TwoFactor.ChallengeTTL == 0 {
+		config.TwoFactor.ChallengeTTL = 5 * time.Minute
+	}
+	if config.TwoFactor.MaxChallengeAttempts == 0 {
+		config.TwoFactor.MaxChallengeAttempts = 5
+	}
+	if config.TwoFactor.RecoveryCodes == 0 {
+		config.TwoFactor.RecoveryCodes = 10
+	}
+
+	if config.OIDC.Store == "" {
+		config.OIDC.Store = "redis"
+	}
+	if config.OIDC.KeyPrefix == "" {
+		config.OIDC.KeyPrefix = "movieinfo:oidc:"
+	}
+	if config.OIDC.StateTTL == 0 {
+		config.OIDC.StateTTL = 10 * time.Minute
+	}
 }
 
 // validateConfig 验证配置
@@ -109,7 +302,7 @@ func validateConfig(config *Config) error {
 	}
 
 	// 额外的业务逻辑验证
-	if config.Database.Password == "" && !strings.Contains(config.App.Environment, "test") {
+	if config.Database.Driver == "mysql" && config.Database.Password == "" && !strings.Contains(config.App.Environment, "test") {
 		return fmt.Errorf("database password is required for non-test environments")
 	}
 
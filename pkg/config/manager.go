@@ -3,8 +3,21 @@ package config
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 )
 
+var (
+	// reloadSuccesses 配置重新加载成功的次数
+	reloadSuccesses atomic.Uint64
+	// reloadFailures 配置重新加载失败的次数
+	reloadFailures atomic.Uint64
+)
+
+// ReloadCounts 返回进程启动以来配置重新加载成功与失败的次数
+func ReloadCounts() (successes, failures uint64) {
+	return reloadSuccesses.Load(), reloadFailures.Load()
+}
+
 // Manager 配置管理器
 type Manager struct {
 	config     *Config
@@ -36,8 +49,10 @@ func (m *Manager) Get() *Config {
 func (m *Manager) Reload() error {
 	newConfig, err := LoadConfig(m.configPath)
 	if err != nil {
+		reloadFailures.Add(1)
 		return fmt.Errorf("failed to reload config: %w", err)
 	}
+	reloadSuccesses.Add(1)
 
 	m.mu.Lock()
 	m.config = newConfig
@@ -4,11 +4,22 @@ import "time"
 
 // Config 应用配置结构
 type Config struct {
-	App      AppConfig      `yaml:"app" validate:"required"`
-	Database DatabaseConfig `yaml:"database" validate:"required"`
-	Redis    RedisConfig    `yaml:"redis"`
-	Log      LogConfig      `yaml:"log" validate:"required"`
-	JWT      JWTConfig      `yaml:"jwt" validate:"required"`
+	App               AppConfig               `yaml:"app" validate:"required"`
+	Database          DatabaseConfig          `yaml:"database" validate:"required"`
+	Redis             RedisConfig             `yaml:"redis"`
+	Log               LogConfig               `yaml:"log" validate:"required"`
+	JWT               JWTConfig               `yaml:"jwt" validate:"required"`
+	EventBus          EventBusConfig          `yaml:"event_bus"`
+	Metrics           MetricsConfig           `yaml:"metrics"`
+	Tracing           TracingConfig           `yaml:"tracing"`
+	Shutdown          ShutdownConfig          `yaml:"shutdown"`
+	Mail              MailConfig              `yaml:"mail"`
+	PasswordReset     PasswordResetConfig     `yaml:"password_reset"` // 找回密码重置码
+	EmailVerification EmailVerificationConfig `yaml:"email_verification"`
+	LoginProtection   LoginProtectionConfig   `yaml:"login_protection"`
+	Password          PasswordConfig          `yaml:"password"`
+	TwoFactor         TwoFactorConfig         `yaml:"two_factor"`
+	OIDC              OIDCConfig              `yaml:"oidc"`
 }
 
 // AppConfig 应用基础配置
@@ -22,7 +33,7 @@ type AppConfig struct {
 
 // DatabaseConfig 数据库配置
 type DatabaseConfig struct {
-	Driver       string `yaml:"driver" validate:"required"`
+	Driver       string `yaml:"driver" validate:"required,oneof=mysql sqlite"`
 	Host         string `yaml:"host" validate:"required"`
 	Port         int    `yaml:"port" validate:"required,min=1,max=65535"`
 	Username     string `yaml:"username" validate:"required"`
@@ -47,6 +58,13 @@ type LogConfig struct {
 	Format string     `yaml:"format" validate:"required,oneof=json text"`
 	Output string     `yaml:"output" validate:"required,oneof=stdout stderr file"`
 	File   FileConfig `yaml:"file"`
+	Async  LogAsync   `yaml:"async"`
+}
+
+// LogAsync 日志异步输出配置
+type LogAsync struct {
+	Enabled    bool `yaml:"enabled"`
+	BufferSize int  `yaml:"buffer_size" validate:"min=0"` // 缓冲的日志条数，缓冲区满时丢弃
 }
 
 // FileConfig 文件输出配置
@@ -60,7 +78,145 @@ type FileConfig struct {
 
 // JWTConfig JWT配置
 type JWTConfig struct {
-	Secret     string        `yaml:"secret"`
-	ExpireTime time.Duration `yaml:"expire_time" validate:"required"`
-	Issuer     string        `yaml:"issuer" validate:"required"`
+	Secret            string        `yaml:"secret"`
+	ExpireTime        time.Duration `yaml:"expire_time" validate:"required"` // 访问令牌有效期
+	Issuer            string        `yaml:"issuer" validate:"required"`
+	RefreshExpireTime time.Duration `yaml:"refresh_expire_time"`                           // 刷新令牌有效期，从登录时起计算
+	Store             string        `yaml:"store" validate:"omitempty,oneof=memory redis"` // 刷新令牌和注销记录的存储
+	KeyPrefix         string        `yaml:"key_prefix"`
+}
+
+// EventBusConfig 事件总线配置
+type EventBusConfig struct {
+	Driver     string `yaml:"driver" validate:"oneof=memory redis"` // memory: 进程内, redis: Redis发布订阅
+	BufferSize int    `yaml:"buffer_size" validate:"min=1"`         // 每个订阅者的缓冲消息数
+}
+
+// MetricsConfig Prometheus指标配置
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Addr    string `yaml:"addr"` // 指标服务监听地址，如 :9100
+	Path    string `yaml:"path"` // 指标路径，默认 /metrics
+}
+
+// TracingConfig 链路追踪配置
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" validate:"omitempty,oneof=otlp stdout none"`
+	Endpoint    string  `yaml:"endpoint"`                            // OTLP gRPC 接收地址，如 localhost:4317
+	Insecure    bool    `yaml:"insecure"`                            // 不使用TLS连接采集器
+	SampleRatio float64 `yaml:"sample_ratio" validate:"min=0,max=1"` // 新链路的采样比例，已有父span时跟随父span
+}
+
+// ShutdownConfig 优雅关闭配置
+type ShutdownConfig struct {
+	DrainTimeout time.Duration `yaml:"drain_timeout"` // 每个组件等待进行中的请求完成的最长时间
+}
+
+// MailConfig 邮件发送配置
+type MailConfig struct {
+	Driver string   `yaml:"driver" validate:"omitempty,oneof=smtp file log"` // smtp: 真实发送, file: 写入目录, log: 写入日志（开发环境）
+	From   string   `yaml:"from"`                                            // 发件人，如 MovieInfo <noreply@movieinfo.com>
+	SMTP   MailSMTP `yaml:"smtp"`
+	Dir    string   `yaml:"dir"` // file 驱动的输出目录
+}
+
+// MailSMTP SMTP服务器配置
+type MailSMTP struct {
+	Host     string        `yaml:"host"`
+	Port     int           `yaml:"port"`
+	Username string        `yaml:"username"`
+	Password string        `yaml:"password"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// PasswordResetConfig 找回密码配置
+type PasswordResetConfig struct {
+	Store          string        `yaml:"store" validate:"omitempty,oneof=memory redis"` // 重置码和重发限流的存储，memory（单实例）| redis
+	KeyPrefix      string        `yaml:"key_prefix"`
+	TTL            time.Duration `yaml:"ttl"`                            // 有效期
+	MaxAttempts    int           `yaml:"max_attempts" validate:"min=0"`  // 最多允许输错的次数
+	Length         int           `yaml:"length" validate:"min=0,max=10"` // 数字位数
+	ResendInterval time.Duration `yaml:"resend_interval"`                // 重发重置码的平均间隔
+	ResendBurst    int           `yaml:"resend_burst" validate:"min=0"`  // 允许连续重发的次数
+}
+
+// EmailVerificationConfig 邮箱验证配置
+type EmailVerificationConfig struct {
+	Store              string        `yaml:"store" validate:"omitempty,oneof=memory redis"` // 验证令牌和重发限流的存储
+	KeyPrefix          string        `yaml:"key_prefix"`
+	TTL                time.Duration `yaml:"ttl"`                                                     // 验证链接有效期
+	VerifyURL          string        `yaml:"verify_url" validate:"omitempty,url"`                     // 邮件中验证页面的地址
+	ResendInterval     time.Duration `yaml:"resend_interval"`                                         // 重发邮件的平均间隔
+	ResendBurst        int           `yaml:"resend_burst" validate:"min=0"`                           // 允许连续重发的次数
+	RequireVerifiedFor []string      `yaml:"require_verified_for" validate:"dive,oneof=login rating"` // 邮箱验证前禁止的操作
+}
+
+// LoginProtectionConfig 登录失败保护配置
+type LoginProtectionConfig struct {
+	Enabled            bool          `yaml:"enabled"`
+	Store              string        `yaml:"store" validate:"omitempty,oneof=memory redis"` // memory（单实例）| redis
+	KeyPrefix          string        `yaml:"key_prefix"`
+	Window             time.Duration `yaml:"window"`                                // 失败次数的统计窗口，从最后一次失败起计算
+	MaxAccountFailures int           `yaml:"max_account_failures" validate:"min=0"` // 同一账号连续失败多少次后锁定
+	MaxIPFailures      int           `yaml:"max_ip_failures" validate:"min=0"`      // 同一IP失败多少次后锁定，0 表示不按IP限制
+	LockoutDuration    time.Duration `yaml:"lockout_duration"`                      // 锁定时长
+	BaseDelay          time.Duration `yaml:"base_delay"`                            // 账号首次失败后需要等待的时间，之后每次失败翻倍
+	MaxDelay           time.Duration `yaml:"max_delay"`                             // 等待时间上限
+}
+
+// PasswordConfig 密码哈希与密码策略配置
+type PasswordConfig struct {
+	Hash   PasswordHashConfig   `yaml:"hash"`
+	Policy PasswordPolicyConfig `yaml:"policy"`
+}
+
+// PasswordHashConfig 密码哈希配置，修改参数后已有的哈希在用户下次登录时自动升级
+type PasswordHashConfig struct {
+	Algorithm   string `yaml:"algorithm" validate:"omitempty,oneof=argon2id bcrypt"` // 新哈希使用的算法
+	Memory      uint32 `yaml:"memory"`                                               // argon2id 内存开销（KiB）
+	Iterations  uint32 `yaml:"iterations"`                                           // argon2id 迭代次数
+	Parallelism uint8  `yaml:"parallelism"`                                          // argon2id 并行度
+	SaltLength  uint32 `yaml:"salt_length"`                                          // argon2id 盐长度（字节）
+	KeyLength   uint32 `yaml:"key_length"`                                           // argon2id 输出长度（字节）
+	BcryptCost  int    `yaml:"bcrypt_cost" validate:"omitempty,min=4,max=31"`        // algorithm 为 bcrypt 时的开销
+}
+
+// PasswordPolicyConfig 密码策略配置，注册、修改密码和找回密码时检查
+type PasswordPolicyConfig struct {
+	MinLength           int    `yaml:"min_length" validate:"min=0"`                  // 最小长度（字符数）
+	MaxLength           int    `yaml:"max_length" validate:"min=0"`                  // 最大长度（字符数），0 表示不限制
+	MinCharacterClasses int    `yaml:"min_character_classes" validate:"min=0,max=4"` // 至少包含几类字符：小写字母、大写字母、数字、符号
+	BreachedList        string `yaml:"breached_list"`                                // 已泄露密码列表文件，每行一个，为空表示不检查
+}
+
+// TwoFactorConfig 两步验证配置
+type TwoFactorConfig struct {
+	Store                string        `yaml:"store" validate:"omitempty,oneof=memory redis"` // 登录挑战令牌的存储
+	KeyPrefix            string        `yaml:"key_prefix"`
+	Issuer               string        `yaml:"issuer"`                                  // 验证器应用中显示的发行方
+	Digits               int           `yaml:"digits" validate:"omitempty,oneof=6 8"`   // 动态码位数
+	Period               time.Duration `yaml:"period"`                                  // 动态码有效周期
+	Skew                 int           `yaml:"skew" validate:"min=0,max=2"`             // 允许前后偏差的周期数
+	ChallengeTTL         time.Duration `yaml:"challenge_ttl"`                           // 登录挑战令牌有效期
+	MaxChallengeAttempts int           `yaml:"max_challenge_attempts" validate:"min=0"` // 每个登录挑战最多允许输错动态码的次数
+	RecoveryCodes        int           `yaml:"recovery_codes" validate:"min=1,max=20"`  // 每次生成的恢复码数量
+	RequiredUsers        []string      `yaml:"required_users"`                          // 必须启用两步验证的用户名
+}
+
+// OIDCConfig 第三方身份登录配置
+type OIDCConfig struct {
+	Store     string                        `yaml:"store" validate:"omitempty,oneof=memory redis"` // 登录状态的存储
+	KeyPrefix string                        `yaml:"key_prefix"`
+	StateTTL  time.Duration                 `yaml:"state_ttl"`                 // 跳转到身份提供方后完成登录的时限
+	Providers map[string]OIDCProviderConfig `yaml:"providers" validate:"dive"` // 键为身份提供方名称
+}
+
+// OIDCProviderConfig 身份提供方配置
+type OIDCProviderConfig struct {
+	DisplayName  string   `yaml:"display_name"`
+	Issuer       string   `yaml:"issuer" validate:"required,url"`
+	ClientID     string   `yaml:"client_id" validate:"required"`
+	ClientSecret string   `yaml:"client_secret"`
+	RedirectURL  string   `yaml:"redirect_url" validate:"required,url"`
+	Scopes       []string `yaml:"scopes"`
 }
@@ -139,6 +139,8 @@ func PrintConfig(config *Config) {
 	fmt.Println("=== JWT ===")
 	fmt.Printf("Secret: %s\n", maskPassword(config.JWT.Secret))
 	fmt.Printf("Expire Time: %s\n", config.JWT.ExpireTime)
+	fmt.Printf("Refresh Expire Time: %s\n", config.JWT.RefreshExpireTime)
+	fmt.Printf("Store: %s\n", config.JWT.Store)
 	fmt.Printf("Issuer: %s\n", config.JWT.Issuer)
 	fmt.Println()
 }
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/XSAM/otelsql"
+	"github.com/go-sql-driver/mysql"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	_ "modernc.org/sqlite" // 注册 sqlite 驱动
+
+	"github.com/3inchtime/movieinfo/pkg/metrics"
+)
+
+// SQLiteMemory 使用进程内存的SQLite数据库，进程退出后数据丢失
+const SQLiteMemory = ":memory:"
+
+// Config 数据库连接配置
+// Driver 为 sqlite 时 Database 为数据库文件路径，连接参数中的主机、端口和账号不生效
+type Config struct {
+	Driver       string `yaml:"driver" validate:"required,oneof=mysql sqlite"`
+	Host         string `yaml:"host" validate:"required"`
+	Port         int    `yaml:"port" validate:"required,min=1,max=65535"`
+	Username     string `yaml:"username" validate:"required"`
+	Password     string `yaml:"password"`
+	Database     string `yaml:"database" validate:"required"`
+	Charset      string `yaml:"charset"`
+	MaxOpenConns int    `yaml:"max_open_conns" validate:"min=1"`
+	MaxIdleConns int    `yaml:"max_idle_conns" validate:"min=1"`
+}
+
+// DSN 返回连接字符串
+func (c *Config) DSN() string {
+	if c.Driver == "sqlite" {
+		return sqliteDSN(c.Database)
+	}
+	return c.mysqlDSN()
+}
+
+// mysqlDSN 返回MySQL连接字符串，时间字段按UTC解析为 time.Time
+func (c *Config) mysqlDSN() string {
+	dsn := mysql.NewConfig()
+	dsn.User = c.Username
+	dsn.Passwd = c.Password
+	dsn.Net = "tcp"
+	dsn.Addr = c.Host + ":" + strconv.Itoa(c.Port)
+	dsn.DBName = c.Database
+	dsn.ParseTime = true
+	dsn.Loc = time.UTC
+	if c.Charset != "" {
+		dsn.Params = map[string]string{"charset": c.Charset}
+	}
+	return dsn.FormatDSN()
+}
+
+// sqliteDSN 返回SQLite连接字符串，启用外键约束并在数据库被锁定时等待
+func sqliteDSN(path string) string {
+	pragmas := "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	if path == SQLiteMemory {
+		return "file::memory:?" + pragmas
+	}
+	return "file:" + path + "?_pragma=journal_mode(WAL)&" + pragmas
+}
+
+// Open 打开数据库连接并检查连通性
+// 每条SQL语句都会创建追踪span，连接池状态注册为Prometheus指标
+func Open(config *Config) (*sql.DB, error) {
+	var system attribute.KeyValue
+	switch config.Driver {
+	case "mysql":
+		system = semconv.DBSystemMySQL
+	case "sqlite":
+		system = semconv.DBSystemSqlite
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", config.Driver)
+	}
+
+	db, err := otelsql.Open(config.Driver, config.DSN(),
+		otelsql.WithAttributes(system, semconv.DBName(config.Database)),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{DisableErrSkip: true, OmitConnResetSession: true}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	if config.Driver == "sqlite" && config.Database == SQLiteMemory {
+		// 每个内存数据库连接都是独立的数据库，只保留一个连接并且不关闭它
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		db.SetConnMaxLifetime(0)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	if err := metrics.RegisterDB(config.Database, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,143 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// Discovery 服务发现接口
+type Discovery interface {
+	// Watch 订阅服务的实例地址，每次推送完整的地址列表，首个列表在可用后立即推送
+	// ctx 结束后通道关闭
+	Watch(ctx context.Context, service string) (<-chan []string, error)
+}
+
+// Config 服务发现配置
+type Config struct {
+	Enabled bool `yaml:"enabled"`
+	// Backend 地址来源：static（配置中的地址列表）、file（监听地址文件）、dns（DNS SRV记录）
+	Backend string `yaml:"backend"`
+	// RefreshInterval file 和 dns 后端重新获取地址的间隔
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	// HealthCheck 是否启用客户端健康检查，未通过 grpc.health.v1 检查的实例不参与负载均衡
+	HealthCheck bool `yaml:"health_check"`
+	// Static 服务名到实例地址列表的映射
+	Static map[string][]string `yaml:"static"`
+	File   FileConfig          `yaml:"file"`
+	DNS    DNSConfig           `yaml:"dns"`
+}
+
+// FileConfig 地址文件配置
+type FileConfig struct {
+	// Path YAML文件路径，内容为服务名到地址列表的映射
+	Path string `yaml:"path"`
+}
+
+// DNSConfig DNS SRV配置
+type DNSConfig struct {
+	// Domain 查询 _grpc._tcp.<service>.<domain> 的SRV记录
+	Domain string `yaml:"domain"`
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:         false,
+		Backend:         "static",
+		RefreshInterval: 10 * time.Second,
+		HealthCheck:     true,
+	}
+}
+
+// New 根据配置创建服务发现
+func New(config *Config) (Discovery, error) {
+	interval := config.RefreshInterval
+	if interval <= 0 {
+		interval = DefaultConfig().RefreshInterval
+	}
+
+	switch config.Backend {
+	case "", "static":
+		return NewStatic(config.Static), nil
+	case "file":
+		if config.File.Path == "" {
+			return nil, fmt.Errorf("discovery file path is required")
+		}
+		return NewFile(config.File.Path, interval), nil
+	case "dns":
+		if config.DNS.Domain == "" {
+			return nil, fmt.Errorf("discovery dns domain is required")
+		}
+		return NewDNS(config.DNS.Domain, interval), nil
+	default:
+		return nil, fmt.Errorf("unsupported discovery backend: %s", config.Backend)
+	}
+}
+
+// poll 定期调用 fetch 获取地址列表，列表变化时推送
+// 获取失败时保留上一次的结果，避免注册中心短暂故障导致所有实例被摘除
+func poll(ctx context.Context, service string, interval time.Duration, fetch func(context.Context) ([]string, error)) <-chan []string {
+	updates := make(chan []string, 1)
+
+	go func() {
+		defer close(updates)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last []string
+		for {
+			addrs, err := fetch(ctx)
+			if err != nil {
+				logger.Warnf("failed to discover %s endpoints: %v", service, err)
+			} else if addrs = normalize(addrs); last == nil || !equal(addrs, last) {
+				last = addrs
+				select {
+				case updates <- addrs:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return updates
+}
+
+// normalize 去重并排序地址列表，便于比较是否变化
+func normalize(addrs []string) []string {
+	seen := make(map[string]bool, len(addrs))
+	result := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		result = append(result, addr)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// equal 比较两个已排序的地址列表
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
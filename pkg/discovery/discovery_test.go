@@ -0,0 +1,108 @@
+package discovery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// next 等待下一次推送的地址列表
+func next(t *testing.T, updates <-chan []string) []string {
+	t.Helper()
+	select {
+	case addrs, ok := <-updates:
+		if !ok {
+			t.Fatal("updates channel closed")
+		}
+		return addrs
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for endpoints")
+		return nil
+	}
+}
+
+func TestStatic(t *testing.T) {
+	d := NewStatic(map[string][]string{"movie": {"10.0.0.2:8082", "10.0.0.1:8082", "10.0.0.2:8082"}})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	updates, err := d.Watch(ctx, "movie")
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if got, want := next(t, updates), []string{"10.0.0.1:8082", "10.0.0.2:8082"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("endpoints = %v, want %v", got, want)
+	}
+	cancel()
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Fatal("unexpected update after ctx ended")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("updates channel not closed after ctx ended")
+	}
+
+	if _, err := d.Watch(context.Background(), "rating"); err == nil {
+		t.Fatal("Watch() of a service without endpoints succeeded")
+	}
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endpoints.yaml")
+	// write 先写临时文件再改名，避免读到写了一半的文件
+	write := func(content string) {
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("movie:\n  - 10.0.0.1:8082\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := NewFile(path, 5*time.Millisecond).Watch(ctx, "movie")
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if got := next(t, updates); !reflect.DeepEqual(got, []string{"10.0.0.1:8082"}) {
+		t.Fatalf("endpoints = %v", got)
+	}
+
+	// 文件损坏时保留上一次的结果，修复后推送新的列表
+	write("movie: [")
+	time.Sleep(20 * time.Millisecond)
+	write("movie:\n  - 10.0.0.3:8082\n  - 10.0.0.1:8082\n")
+	if got, want := next(t, updates), []string{"10.0.0.1:8082", "10.0.0.3:8082"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("endpoints after change = %v, want %v", got, want)
+	}
+
+	if _, err := NewFile(filepath.Join(t.TempDir(), "missing.yaml"), time.Second).Watch(ctx, "movie"); err == nil {
+		t.Fatal("Watch() of a missing file succeeded")
+	}
+}
+
+func TestPollSkipsUnchangedLists(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := [][]string{{"b", "a"}, {"a", "b", "a"}, {"a"}}
+	calls := 0
+	updates := poll(ctx, "movie", time.Millisecond, func(context.Context) ([]string, error) {
+		addrs := results[calls%len(results)]
+		calls++
+		return addrs, nil
+	})
+
+	if got := next(t, updates); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("first update = %v", got)
+	}
+	// 第二次结果与第一次相同，不会推送
+	if got := next(t, updates); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("second update = %v, want [a]", got)
+	}
+}
@@ -0,0 +1,49 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DNS 通过 DNS SRV 记录发现实例，查询 _grpc._tcp.<service>.<domain>
+type DNS struct {
+	domain   string
+	interval time.Duration
+	resolver *net.Resolver
+}
+
+// NewDNS 创建基于 DNS SRV 的服务发现
+func NewDNS(domain string, interval time.Duration) *DNS {
+	return &DNS{
+		domain:   strings.TrimSuffix(domain, "."),
+		interval: interval,
+		resolver: net.DefaultResolver,
+	}
+}
+
+// Watch 定期查询服务的SRV记录
+func (d *DNS) Watch(ctx context.Context, service string) (<-chan []string, error) {
+	return poll(ctx, service, d.interval, func(ctx context.Context) ([]string, error) {
+		return d.lookup(ctx, service)
+	}), nil
+}
+
+// lookup 查询SRV记录并转换为 host:port 地址
+func (d *DNS) lookup(ctx context.Context, service string) ([]string, error) {
+	name := service + "." + d.domain
+	_, records, err := d.resolver.LookupSRV(ctx, "grpc", "tcp", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup srv records of %s: %w", name, err)
+	}
+
+	addrs := make([]string, 0, len(records))
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(record.Port))))
+	}
+	return addrs, nil
+}
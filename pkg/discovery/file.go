@@ -0,0 +1,49 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File 从YAML文件读取地址列表，文件修改后在下一个刷新周期生效
+//
+//	movie:
+//	  - 10.0.0.1:8082
+//	  - 10.0.0.2:8082
+type File struct {
+	path     string
+	interval time.Duration
+}
+
+// NewFile 创建基于地址文件的服务发现
+func NewFile(path string, interval time.Duration) *File {
+	return &File{path: path, interval: interval}
+}
+
+// Watch 定期读取文件中服务的地址列表
+func (f *File) Watch(ctx context.Context, service string) (<-chan []string, error) {
+	if _, err := f.read(service); err != nil {
+		return nil, err
+	}
+	return poll(ctx, service, f.interval, func(context.Context) ([]string, error) {
+		return f.read(service)
+	}), nil
+}
+
+// read 读取服务的地址列表
+func (f *File) read(service string) ([]string, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read endpoints file: %w", err)
+	}
+
+	var endpoints map[string][]string
+	if err := yaml.Unmarshal(data, &endpoints); err != nil {
+		return nil, fmt.Errorf("failed to parse endpoints file %s: %w", f.path, err)
+	}
+	return endpoints[service], nil
+}
@@ -0,0 +1,80 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/resolver"
+)
+
+// Scheme gRPC目标地址的方案名，如 movieinfo:///movie
+const Scheme = "movieinfo"
+
+// Target 返回服务对应的gRPC目标地址
+func Target(service string) string {
+	return Scheme + ":///" + service
+}
+
+// NewResolverBuilder 创建gRPC解析器，将服务发现的地址列表推送给gRPC连接
+// 配合 grpc.WithResolvers 使用，不注册到全局
+func NewResolverBuilder(d Discovery) resolver.Builder {
+	return &resolverBuilder{discovery: d}
+}
+
+// resolverBuilder gRPC解析器构建器
+type resolverBuilder struct {
+	discovery Discovery
+}
+
+// Build 实现 resolver.Builder
+func (b *resolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	service := strings.TrimPrefix(target.URL.Path, "/")
+	if service == "" {
+		return nil, fmt.Errorf("missing service name in target %s", target.URL.String())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := b.discovery.Watch(ctx, service)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		for addrs := range updates {
+			if len(addrs) == 0 {
+				cc.ReportError(fmt.Errorf("no endpoints available for service %s", service))
+				continue
+			}
+
+			state := resolver.State{Addresses: make([]resolver.Address, 0, len(addrs))}
+			for _, addr := range addrs {
+				state.Addresses = append(state.Addresses, resolver.Address{Addr: addr})
+			}
+			if err := cc.UpdateState(state); err != nil {
+				cc.ReportError(err)
+			}
+		}
+	}()
+
+	return &discoveryResolver{cancel: cancel}, nil
+}
+
+// Scheme 实现 resolver.Builder
+func (b *resolverBuilder) Scheme() string {
+	return Scheme
+}
+
+// discoveryResolver 地址由服务发现主动推送，ResolveNow 无需处理
+type discoveryResolver struct {
+	cancel context.CancelFunc
+}
+
+// ResolveNow 实现 resolver.Resolver
+func (r *discoveryResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+// Close 实现 resolver.Resolver
+func (r *discoveryResolver) Close() {
+	r.cancel()
+}
@@ -0,0 +1,32 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+)
+
+// Static 固定地址列表
+type Static struct {
+	endpoints map[string][]string
+}
+
+// NewStatic 创建固定地址列表的服务发现
+func NewStatic(endpoints map[string][]string) *Static {
+	return &Static{endpoints: endpoints}
+}
+
+// Watch 推送一次配置中的地址列表，ctx 结束后关闭通道
+func (s *Static) Watch(ctx context.Context, service string) (<-chan []string, error) {
+	addrs := normalize(s.endpoints[service])
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no static endpoints configured for service %q", service)
+	}
+
+	updates := make(chan []string, 1)
+	updates <- addrs
+	go func() {
+		<-ctx.Done()
+		close(updates)
+	}()
+	return updates, nil
+}
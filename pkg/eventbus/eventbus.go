@@ -0,0 +1,59 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrClosed 事件总线已关闭
+var ErrClosed = errors.New("event bus closed")
+
+// Bus 事件总线接口，消息以字节形式传递，便于在进程内实现与Redis实现之间切换
+type Bus interface {
+	// Publish 向主题发布消息，没有订阅者时消息会被丢弃
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe 订阅主题，ctx 结束或调用 Subscription.Close 后停止接收
+	Subscribe(ctx context.Context, topic string) (Subscription, error)
+	// Close 关闭事件总线及所有订阅
+	Close() error
+}
+
+// Subscription 主题订阅
+type Subscription interface {
+	// Messages 返回消息通道，订阅结束后通道会被关闭
+	Messages() <-chan []byte
+	// Close 取消订阅
+	Close() error
+}
+
+// Config 事件总线配置
+type Config struct {
+	Driver     string `yaml:"driver" validate:"oneof=memory redis"`
+	BufferSize int    `yaml:"buffer_size" validate:"min=1"` // 每个订阅者的缓冲消息数
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Driver:     "memory",
+		BufferSize: 64,
+	}
+}
+
+// New 根据配置创建事件总线，driver 为 redis 时需要传入Redis客户端
+func New(config *Config, client *redis.Client) (Bus, error) {
+	switch config.Driver {
+	case "", "memory":
+		return NewMemoryBus(config.BufferSize), nil
+	case "redis":
+		if client == nil {
+			return nil, fmt.Errorf("redis client is required for redis event bus")
+		}
+		return NewRedisBus(client, config.BufferSize), nil
+	default:
+		return nil, fmt.Errorf("unsupported event bus driver: %s", config.Driver)
+	}
+}
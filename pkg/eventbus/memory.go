@@ -0,0 +1,139 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// MemoryBus 进程内事件总线
+// 订阅者处理过慢导致缓冲区写满时，新消息会被丢弃而不是阻塞发布者
+type MemoryBus struct {
+	bufferSize int
+
+	mu     sync.RWMutex
+	subs   map[string]map[*memorySubscription]struct{}
+	closed bool
+}
+
+// NewMemoryBus 创建进程内事件总线
+func NewMemoryBus(bufferSize int) *MemoryBus {
+	if bufferSize <= 0 {
+		bufferSize = DefaultConfig().BufferSize
+	}
+	return &MemoryBus{
+		bufferSize: bufferSize,
+		subs:       make(map[string]map[*memorySubscription]struct{}),
+	}
+}
+
+// Publish 向主题发布消息
+func (b *MemoryBus) Publish(ctx context.Context, topic string, payload []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	for sub := range b.subs[topic] {
+		select {
+		case sub.ch <- payload:
+		default:
+			logger.Warnf("event bus subscriber on %s is full, message dropped", topic)
+		}
+	}
+	return nil
+}
+
+// Subscribe 订阅主题
+func (b *MemoryBus) Subscribe(ctx context.Context, topic string) (Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrClosed
+	}
+
+	sub := &memorySubscription{
+		bus:   b,
+		topic: topic,
+		ch:    make(chan []byte, b.bufferSize),
+		done:  make(chan struct{}),
+	}
+	if b.subs[topic] == nil {
+		b.subs[topic] = make(map[*memorySubscription]struct{})
+	}
+	b.subs[topic][sub] = struct{}{}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Close()
+		case <-sub.done:
+		}
+	}()
+
+	return sub, nil
+}
+
+// Close 关闭事件总线及所有订阅
+func (b *MemoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	for _, subs := range b.subs {
+		for sub := range subs {
+			sub.closeOnce.Do(sub.close)
+		}
+	}
+	b.subs = nil
+	return nil
+}
+
+// remove 移除订阅，调用方需持有写锁
+func (b *MemoryBus) remove(sub *memorySubscription) {
+	subs := b.subs[sub.topic]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subs, sub.topic)
+	}
+}
+
+// memorySubscription 进程内订阅
+type memorySubscription struct {
+	bus       *MemoryBus
+	topic     string
+	ch        chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Messages 返回消息通道
+func (s *memorySubscription) Messages() <-chan []byte {
+	return s.ch
+}
+
+// Close 取消订阅
+func (s *memorySubscription) Close() error {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.closeOnce.Do(func() {
+		s.bus.remove(s)
+		s.close()
+	})
+	return nil
+}
+
+// close 关闭消息通道并通知监听 ctx 的协程退出
+func (s *memorySubscription) close() {
+	close(s.ch)
+	close(s.done)
+}
@@ -0,0 +1,99 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// receive 等待订阅收到一条消息，通道关闭时 ok 为 false
+func receive(t *testing.T, sub Subscription) (payload string, ok bool) {
+	t.Helper()
+	select {
+	case msg, ok := <-sub.Messages():
+		return string(msg), ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a message")
+		return "", false
+	}
+}
+
+func TestMemoryBusPublish(t *testing.T) {
+	bus := NewMemoryBus(4)
+	defer bus.Close()
+	ctx := context.Background()
+
+	first, err := bus.Subscribe(ctx, "ratings.movie.1")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	second, _ := bus.Subscribe(ctx, "ratings.movie.1")
+	other, _ := bus.Subscribe(ctx, "ratings.movie.2")
+
+	if err := bus.Publish(ctx, "ratings.movie.1", []byte("created")); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	for _, sub := range []Subscription{first, second} {
+		if got, _ := receive(t, sub); got != "created" {
+			t.Fatalf("message = %q, want created", got)
+		}
+	}
+	select {
+	case msg := <-other.Messages():
+		t.Fatalf("subscriber of another topic received %q", msg)
+	default:
+	}
+}
+
+func TestMemoryBusFullSubscriberDropsMessages(t *testing.T) {
+	bus := NewMemoryBus(1)
+	defer bus.Close()
+	ctx := context.Background()
+	sub, _ := bus.Subscribe(ctx, "topic")
+
+	// 缓冲区写满后发布者不会被阻塞
+	for _, msg := range []string{"1", "2", "3"} {
+		if err := bus.Publish(ctx, "topic", []byte(msg)); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	if got, _ := receive(t, sub); got != "1" {
+		t.Fatalf("message = %q, want 1", got)
+	}
+	select {
+	case msg := <-sub.Messages():
+		t.Fatalf("dropped message %q delivered", msg)
+	default:
+	}
+}
+
+func TestMemoryBusSubscriptionEnds(t *testing.T) {
+	bus := NewMemoryBus(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	byContext, _ := bus.Subscribe(ctx, "topic")
+	byClose, _ := bus.Subscribe(context.Background(), "topic")
+	byBus, _ := bus.Subscribe(context.Background(), "topic")
+
+	cancel()
+	if _, ok := receive(t, byContext); ok {
+		t.Fatal("subscription open after ctx ended")
+	}
+	byClose.Close()
+	if _, ok := receive(t, byClose); ok {
+		t.Fatal("subscription open after Close")
+	}
+	bus.Close()
+	if _, ok := receive(t, byBus); ok {
+		t.Fatal("subscription open after the bus closed")
+	}
+	// 重复关闭是安全的
+	byBus.Close()
+
+	if err := bus.Publish(context.Background(), "topic", nil); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Publish() after Close error = %v, want %v", err, ErrClosed)
+	}
+	if _, err := bus.Subscribe(context.Background(), "topic"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Subscribe() after Close error = %v, want %v", err, ErrClosed)
+	}
+}
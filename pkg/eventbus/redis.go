@@ -0,0 +1,104 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBus 基于Redis发布订阅的事件总线，用于多实例部署
+type RedisBus struct {
+	client     *redis.Client
+	bufferSize int
+}
+
+// NewRedisBus 创建Redis事件总线
+func NewRedisBus(client *redis.Client, bufferSize int) *RedisBus {
+	if bufferSize <= 0 {
+		bufferSize = DefaultConfig().BufferSize
+	}
+	return &RedisBus{
+		client:     client,
+		bufferSize: bufferSize,
+	}
+}
+
+// Publish 向主题发布消息
+func (b *RedisBus) Publish(ctx context.Context, topic string, payload []byte) error {
+	if err := b.client.Publish(ctx, topic, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// Subscribe 订阅主题
+func (b *RedisBus) Subscribe(ctx context.Context, topic string) (Subscription, error) {
+	pubsub := b.client.Subscribe(ctx, topic)
+	// 等待订阅确认，保证返回后发布的消息不会丢失
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+	}
+
+	sub := &redisSubscription{
+		pubsub: pubsub,
+		ch:     make(chan []byte, b.bufferSize),
+		done:   make(chan struct{}),
+	}
+	go sub.run(ctx, pubsub.Channel(redis.WithChannelSize(b.bufferSize)))
+
+	return sub, nil
+}
+
+// Close Redis客户端由调用方管理，这里不做处理
+func (b *RedisBus) Close() error {
+	return nil
+}
+
+// redisSubscription Redis订阅
+type redisSubscription struct {
+	pubsub    *redis.PubSub
+	ch        chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// run 将Redis消息转发到订阅通道
+func (s *redisSubscription) run(ctx context.Context, messages <-chan *redis.Message) {
+	defer close(s.ch)
+	defer s.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.done:
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			select {
+			case s.ch <- []byte(msg.Payload):
+			default:
+			}
+		}
+	}
+}
+
+// Messages 返回消息通道
+func (s *redisSubscription) Messages() <-chan []byte {
+	return s.ch
+}
+
+// Close 取消订阅
+func (s *redisSubscription) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+		err = s.pubsub.Close()
+	})
+	return err
+}
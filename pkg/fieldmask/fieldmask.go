@@ -0,0 +1,59 @@
+package fieldmask
+
+import (
+	"strings"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+)
+
+// Policy 字段掩码策略，描述一个更新接口允许修改哪些字段
+type Policy struct {
+	// Columns 可更新的字段路径到数据库列的映射
+	Columns map[string]string
+	// Immutable 资源中存在但不允许修改的字段路径
+	Immutable []string
+}
+
+// Resolve 校验字段掩码路径并返回需要更新的数据库列
+// 返回的列按路径在掩码中出现的顺序排列，重复路径只保留一次
+// 未知路径和不可变路径会一次性全部报告，每个路径对应一条 ErrorDetail
+func (p *Policy) Resolve(paths []string) ([]string, error) {
+	appErr := apperror.New(apperror.InvalidArgument, "invalid update mask")
+
+	seen := make(map[string]bool, len(paths))
+	columns := make([]string, 0, len(paths))
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		if p.isImmutable(path) {
+			appErr.WithField(path, "field is immutable")
+			continue
+		}
+
+		column, ok := p.Columns[path]
+		if !ok {
+			appErr.WithField(path, "unknown field path")
+			continue
+		}
+		columns = append(columns, column)
+	}
+
+	if len(appErr.Details) > 0 {
+		return nil, appErr
+	}
+	return columns, nil
+}
+
+// isImmutable 判断路径是否为不可变字段
+func (p *Policy) isImmutable(path string) bool {
+	for _, immutable := range p.Immutable {
+		if path == immutable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package fieldmask
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+)
+
+func TestResolve(t *testing.T) {
+	policy := &Policy{
+		Columns:   map[string]string{"title": "title", "directors": "director"},
+		Immutable: []string{"id", "created_at"},
+	}
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+		// wantFields 期望报告的错误字段，为空时期望成功
+		wantFields []string
+	}{
+		{name: "mapped to columns", paths: []string{"directors", "title"}, want: []string{"director", "title"}},
+		{name: "duplicates removed", paths: []string{"title", " title ", "title"}, want: []string{"title"}},
+		{name: "empty mask", paths: nil, want: []string{}},
+		{name: "unknown path", paths: []string{"title", "rating"}, wantFields: []string{"rating"}},
+		{name: "immutable path", paths: []string{"id"}, wantFields: []string{"id"}},
+		{
+			name:       "all invalid paths reported",
+			paths:      []string{"created_at", "title", "poster", "id"},
+			wantFields: []string{"created_at", "poster", "id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.Resolve(tt.paths)
+			if len(tt.wantFields) == 0 {
+				if err != nil || !reflect.DeepEqual(got, tt.want) {
+					t.Fatalf("Resolve(%v) = (%v, %v), want %v", tt.paths, got, err, tt.want)
+				}
+				return
+			}
+
+			appErr, ok := apperror.As(err)
+			if !ok || appErr.Code != apperror.InvalidArgument {
+				t.Fatalf("Resolve(%v) error = %v, want InvalidArgument", tt.paths, err)
+			}
+			var fields []string
+			for _, detail := range appErr.Details {
+				fields = append(fields, detail.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Fatalf("error fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
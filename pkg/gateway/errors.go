@@ -0,0 +1,143 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+	"github.com/3inchtime/movieinfo/pkg/ratelimit"
+)
+
+// errorDetailType 错误详情的类型标识，与gRPC状态中的 Any 类型URL一致
+const errorDetailType = "type.googleapis.com/movieinfo.common.ErrorDetail"
+
+// ErrorBody HTTP错误响应体
+//
+//	{
+//	  "code": "INVALID_ARGUMENT",
+//	  "message": "invalid update mask",
+//	  "details": [
+//	    {"@type": "type.googleapis.com/movieinfo.common.ErrorDetail", "code": "INVALID_ARGUMENT", "message": "unknown field path", "field": "foo"}
+//	  ]
+//	}
+type ErrorBody struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details []ErrorDetailBody `json:"details"`
+}
+
+// ErrorDetailBody 错误详情，对应 movieinfo.common.ErrorDetail
+type ErrorDetailBody struct {
+	Type    string `json:"@type"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+}
+
+// NewErrorBody 根据gRPC错误构建HTTP错误响应体
+func NewErrorBody(err error) *ErrorBody {
+	st := status.Convert(err)
+	body := &ErrorBody{
+		Code:    codeName(st.Code()),
+		Message: st.Message(),
+		Details: []ErrorDetailBody{},
+	}
+	for _, d := range apperror.Details(err) {
+		body.Details = append(body.Details, ErrorDetailBody{
+			Type:    errorDetailType,
+			Code:    d.Code.String(),
+			Message: d.Message,
+			Field:   d.Field,
+		})
+	}
+	return body
+}
+
+// errorHandler 一元调用的错误处理器
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	if st.Code() == codes.Internal || st.Code() == codes.Unknown {
+		logger.Errorf("gateway %s %s failed: %v", r.Method, r.URL.Path, err)
+	}
+
+	// 限流拒绝时携带的 retry-after 元数据转换为标准的 Retry-After 响应头
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		if v := md.HeaderMD.Get(ratelimit.RetryAfterKey); len(v) > 0 {
+			w.Header().Set("Retry-After", v[0])
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	if encodeErr := json.NewEncoder(w).Encode(NewErrorBody(err)); encodeErr != nil {
+		logger.Warnf("failed to write gateway error response: %v", encodeErr)
+	}
+}
+
+// routingErrorHandler 路由不存在或方法不允许时返回统一格式的错误
+func routingErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+	code := codes.Internal
+	switch httpStatus {
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusMethodNotAllowed:
+		code = codes.Unimplemented
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	json.NewEncoder(w).Encode(NewErrorBody(status.Error(code, http.StatusText(httpStatus))))
+}
+
+// streamErrorHandler 流式调用中途出错时，错误作为最后一条消息写出
+func streamErrorHandler(ctx context.Context, err error) *status.Status {
+	return status.Convert(err)
+}
+
+// codeName 返回gRPC状态码的大写名称，如 INVALID_ARGUMENT
+func codeName(code codes.Code) string {
+	switch code {
+	case codes.OK:
+		return "OK"
+	case codes.Canceled:
+		return "CANCELLED"
+	case codes.InvalidArgument:
+		return "INVALID_ARGUMENT"
+	case codes.DeadlineExceeded:
+		return "DEADLINE_EXCEEDED"
+	case codes.NotFound:
+		return "NOT_FOUND"
+	case codes.AlreadyExists:
+		return "ALREADY_EXISTS"
+	case codes.PermissionDenied:
+		return "PERMISSION_DENIED"
+	case codes.ResourceExhausted:
+		return "RESOURCE_EXHAUSTED"
+	case codes.FailedPrecondition:
+		return "FAILED_PRECONDITION"
+	case codes.Aborted:
+		return "ABORTED"
+	case codes.OutOfRange:
+		return "OUT_OF_RANGE"
+	case codes.Unimplemented:
+		return "UNIMPLEMENTED"
+	case codes.Internal:
+		return "INTERNAL"
+	case codes.Unavailable:
+		return "UNAVAILABLE"
+	case codes.DataLoss:
+		return "DATA_LOSS"
+	case codes.Unauthenticated:
+		return "UNAUTHENTICATED"
+	default:
+		return "UNKNOWN"
+	}
+}
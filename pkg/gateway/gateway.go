@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/3inchtime/movieinfo/pkg/apikey"
+	"github.com/3inchtime/movieinfo/pkg/tracing"
+)
+
+// RegisterFunc 生成代码中 Register<Service>HandlerFromEndpoint 的函数签名
+type RegisterFunc func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error
+
+// Service 网关代理的后端gRPC服务
+type Service struct {
+	Name     string       // 服务名称，如 user、movie、rating
+	Endpoint string       // gRPC服务地址，如 localhost:8081
+	Register RegisterFunc // 由 protoc-gen-grpc-gateway 生成的注册函数
+}
+
+// NewHandler 创建HTTP/JSON网关
+// 路由来自 proto 文件中的 google.api.http 注解，请求和响应使用 protojson 编码
+// HTTP请求和转发的gRPC调用位于同一条追踪链路中
+func NewHandler(ctx context.Context, services []Service, opts []grpc.DialOption) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.HTTPBodyMarshaler{
+			Marshaler: &runtime.JSONPb{
+				MarshalOptions: protojson.MarshalOptions{
+					UseProtoNames:   true,
+					EmitUnpopulated: true,
+				},
+				UnmarshalOptions: protojson.UnmarshalOptions{
+					DiscardUnknown: true,
+				},
+			},
+		}),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithRoutingErrorHandler(routingErrorHandler),
+		runtime.WithStreamErrorHandler(streamErrorHandler),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+	)
+
+	dialOpts := append([]grpc.DialOption{grpc.WithStatsHandler(otelgrpc.NewClientHandler())}, opts...)
+	for _, svc := range services {
+		if err := svc.Register(ctx, mux, svc.Endpoint, dialOpts); err != nil {
+			return nil, fmt.Errorf("failed to register %s service gateway: %w", svc.Name, err)
+		}
+	}
+
+	return tracing.HTTPMiddleware("gateway", mux), nil
+}
+
+// headerMatcher 将 X-Api-Key 请求头转发为API密钥元数据，其他请求头使用默认规则
+func headerMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, apikey.Header) {
+		return apikey.Header, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
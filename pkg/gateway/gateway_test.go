@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+)
+
+// registerFailingMovie 注册一个总是返回业务错误的路由，代替生成的注册函数
+func registerFailingMovie(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error {
+	return mux.HandlePath(http.MethodPatch, "/v1/movies/{id}", func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		_, marshaler := runtime.MarshalerForRequest(mux, r)
+		err := apperror.New(apperror.InvalidArgument, "invalid update mask").WithField("foo", "unknown field path")
+		runtime.HTTPError(r.Context(), mux, marshaler, w, r, err)
+	})
+}
+
+func TestHandlerErrors(t *testing.T) {
+	handler, err := NewHandler(context.Background(), []Service{{Name: "movie", Register: registerFailingMovie}}, nil)
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		want       ErrorBody
+	}{
+		{
+			name:   "application error",
+			method: http.MethodPatch, path: "/v1/movies/1",
+			wantStatus: http.StatusBadRequest,
+			want: ErrorBody{Code: "INVALID_ARGUMENT", Message: "invalid update mask", Details: []ErrorDetailBody{
+				{Type: errorDetailType, Code: "INVALID_ARGUMENT", Message: "unknown field path", Field: "foo"},
+			}},
+		},
+		{
+			name:   "unknown route",
+			method: http.MethodGet, path: "/v1/directors",
+			wantStatus: http.StatusNotFound,
+			want:       ErrorBody{Code: "NOT_FOUND", Message: "Not Found", Details: []ErrorDetailBody{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Fatalf("Content-Type = %q, want application/json", ct)
+			}
+			var body ErrorBody
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid error body %s: %v", rec.Body, err)
+			}
+			if body.Code != tt.want.Code || body.Message != tt.want.Message || len(body.Details) != len(tt.want.Details) {
+				t.Fatalf("body = %+v, want %+v", body, tt.want)
+			}
+			for i := range body.Details {
+				if body.Details[i] != tt.want.Details[i] {
+					t.Fatalf("detail = %+v, want %+v", body.Details[i], tt.want.Details[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewHandlerRegisterError(t *testing.T) {
+	failed := errors.New("dial failed")
+	register := func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error {
+		return failed
+	}
+	_, err := NewHandler(context.Background(), []Service{{Name: "user", Register: register}}, nil)
+	if !errors.Is(err, failed) {
+		t.Fatalf("NewHandler() error = %v, want %v", err, failed)
+	}
+}
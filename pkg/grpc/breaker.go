@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// ErrCircuitOpen 熔断器打开时返回的错误，状态码为 Unavailable，调用方可按服务不可用降级
+var ErrCircuitOpen = status.Error(codes.Unavailable, "circuit breaker is open")
+
+// breakerState 熔断器状态
+type breakerState int
+
+const (
+	stateClosed   breakerState = iota // 正常放行
+	stateOpen                         // 熔断，直接拒绝
+	stateHalfOpen                     // 放行少量探测请求
+)
+
+// String 返回状态名称
+func (s breakerState) String() string {
+	switch s {
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker 按连续失败次数熔断的熔断器
+// 只有 Unavailable 和 DeadlineExceeded 计为失败，业务错误说明下游仍在正常工作
+type circuitBreaker struct {
+	name   string
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// newCircuitBreaker 创建熔断器
+func newCircuitBreaker(name string, config CircuitBreakerConfig) *circuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = 30 * time.Second
+	}
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = 1
+	}
+	return &circuitBreaker{name: name, config: config, now: time.Now}
+}
+
+// allow 判断是否放行请求，熔断期间返回 ErrCircuitOpen
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == stateOpen {
+		if b.now().Sub(b.openedAt) < b.config.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		b.setState(stateHalfOpen)
+	}
+
+	if b.state == stateHalfOpen {
+		if b.probes >= b.config.HalfOpenRequests {
+			return ErrCircuitOpen
+		}
+		b.probes++
+	}
+	return nil
+}
+
+// record 记录请求结果
+func (b *circuitBreaker) record(err error) {
+	code := status.Code(err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == stateHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+
+	switch {
+	case code == codes.Canceled:
+		// 调用方主动取消，不能说明下游状态
+	case code == codes.Unavailable || code == codes.DeadlineExceeded:
+		b.failures++
+		if b.state == stateHalfOpen || b.failures >= b.config.FailureThreshold {
+			b.openedAt = b.now()
+			b.setState(stateOpen)
+		}
+	default:
+		b.failures = 0
+		if b.state == stateHalfOpen {
+			b.setState(stateClosed)
+		}
+	}
+}
+
+// setState 切换状态并记录日志
+func (b *circuitBreaker) setState(state breakerState) {
+	if b.state == state {
+		return
+	}
+	logger.Warnf("circuit breaker for %s changed from %s to %s", b.name, b.state, state)
+	b.state = state
+	b.probes = 0
+	if state == stateClosed {
+		b.failures = 0
+	}
+}
+
+// unaryInterceptor 一元调用的熔断拦截器
+func (b *circuitBreaker) unaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := b.allow(); err != nil {
+			return err
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(err)
+		return err
+	}
+}
+
+// streamInterceptor 流式调用的熔断拦截器，只统计建立流的结果
+func (b *circuitBreaker) streamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := b.allow(); err != nil {
+			return nil, err
+		}
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		b.record(err)
+		return cs, err
+	}
+}
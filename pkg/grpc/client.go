@@ -0,0 +1,225 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health" // 启用客户端健康检查
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/resolver"
+
+	"github.com/3inchtime/movieinfo/pkg/discovery"
+	"github.com/3inchtime/movieinfo/pkg/metrics"
+)
+
+// ClientFactory 按服务名创建并复用gRPC连接
+// 每个服务拥有独立的连接池和熔断器，一元调用统一应用默认超时和幂等方法重试：
+//
+//	factory, _ := grpc.NewClientFactory(&config.Client)
+//	conn, _ := factory.Conn("movie")
+//	client := moviepb.NewMovieServiceClient(conn)
+type ClientFactory struct {
+	config    *ClientConfig
+	policies  *policies
+	retryable map[codes.Code]bool
+	creds     credentials.TransportCredentials
+	resolver  resolver.Builder
+	opts      []grpc.DialOption
+
+	mu       sync.Mutex
+	pools    map[string]*connPool
+	breakers map[string]*circuitBreaker
+	closed   bool
+}
+
+// NewClientFactory 创建客户端工厂，opts 追加到每个连接的拨号选项之后
+func NewClientFactory(config *ClientConfig, opts ...grpc.DialOption) (*ClientFactory, error) {
+	retryable, err := parseCodes(config.Retry.RetryableCodes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry config: %w", err)
+	}
+
+	creds := insecure.NewCredentials()
+	if config.TLS.Enabled {
+		if creds, err = NewClientCredentials(config.TLS); err != nil {
+			return nil, fmt.Errorf("failed to create client tls credentials: %w", err)
+		}
+	}
+
+	f := &ClientFactory{
+		config:    config,
+		policies:  newPolicies(config),
+		retryable: retryable,
+		creds:     creds,
+		opts:      opts,
+		pools:     make(map[string]*connPool),
+		breakers:  make(map[string]*circuitBreaker),
+	}
+
+	if config.Discovery.Enabled {
+		d, err := discovery.New(discoveryConfig(config))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create service discovery: %w", err)
+		}
+		f.resolver = discovery.NewResolverBuilder(d)
+	}
+
+	return f, nil
+}
+
+// discoveryConfig 返回服务发现配置，static 后端未列出的服务使用 targets 中的地址
+func discoveryConfig(config *ClientConfig) *discovery.Config {
+	dc := config.Discovery
+	dc.Static = make(map[string][]string, len(config.Targets))
+	for service, target := range config.Targets {
+		dc.Static[service] = []string{target}
+	}
+	for service, addrs := range config.Discovery.Static {
+		dc.Static[service] = addrs
+	}
+	return &dc
+}
+
+// Conn 返回服务的连接，首次调用时建立连接
+// 连接以非阻塞方式建立，下游暂时不可用不会导致调用方启动失败，请求会返回 Unavailable
+func (f *ClientFactory) Conn(service string) (grpc.ClientConnInterface, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return nil, errors.New("client factory is closed")
+	}
+	if pool, ok := f.pools[service]; ok {
+		return pool, nil
+	}
+
+	target, err := f.target(service)
+	if err != nil {
+		return nil, err
+	}
+
+	size := f.config.PoolSize
+	if size <= 0 {
+		size = 1
+	}
+
+	opts := f.dialOptions(service)
+	pool := &connPool{conns: make([]*grpc.ClientConn, 0, size)}
+	for i := 0; i < size; i++ {
+		conn, err := grpc.Dial(target, opts...)
+		if err != nil {
+			pool.close()
+			return nil, fmt.Errorf("failed to connect to %s service at %s: %w", service, target, err)
+		}
+		pool.conns = append(pool.conns, conn)
+	}
+
+	f.pools[service] = pool
+	return pool, nil
+}
+
+// Close 关闭所有连接
+func (f *ClientFactory) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	var errs []error
+	for service, pool := range f.pools {
+		if err := pool.close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s service connections: %w", service, err))
+		}
+	}
+	f.pools = make(map[string]*connPool)
+	return errors.Join(errs...)
+}
+
+// target 返回服务的拨号地址，启用服务发现时由解析器提供实例列表
+func (f *ClientFactory) target(service string) (string, error) {
+	if f.resolver != nil {
+		return discovery.Target(service), nil
+	}
+	return f.config.Target(service)
+}
+
+// dialOptions 构建服务连接的拨号选项
+// 拦截器顺序：默认超时 -> 熔断 -> 重试，一次逻辑调用只计入熔断器一次
+func (f *ClientFactory) dialOptions(service string) []grpc.DialOption {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(f.creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+
+	var callOpts []grpc.CallOption
+	if f.config.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(f.config.MaxRecvMsgSize))
+	}
+	if f.config.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(f.config.MaxSendMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+
+	if f.config.DialTimeout > 0 {
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: f.config.DialTimeout,
+		}))
+	}
+	if f.config.Keepalive.Time > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                f.config.Keepalive.Time,
+			Timeout:             f.config.Keepalive.Timeout,
+			PermitWithoutStream: f.config.Keepalive.PermitWithoutStream,
+		}))
+	}
+
+	if f.resolver != nil {
+		opts = append(opts,
+			grpc.WithResolvers(f.resolver),
+			grpc.WithDefaultServiceConfig(serviceConfig(f.config.Discovery.HealthCheck)),
+		)
+	}
+
+	// 指标拦截器在最外层，耗时包含重试与熔断
+	unary := []grpc.UnaryClientInterceptor{metrics.UnaryClientInterceptor(), deadlineInterceptor(f.policies)}
+	stream := []grpc.StreamClientInterceptor{metrics.StreamClientInterceptor()}
+	if f.config.CircuitBreaker.Enabled {
+		breaker := f.breaker(service)
+		unary = append(unary, breaker.unaryInterceptor())
+		stream = append(stream, breaker.streamInterceptor())
+	}
+	unary = append(unary, retryInterceptor(f.config.Retry, f.retryable, f.policies))
+
+	opts = append(opts,
+		grpc.WithChainUnaryInterceptor(unary...),
+		grpc.WithChainStreamInterceptor(stream...),
+	)
+	return append(opts, f.opts...)
+}
+
+// breaker 返回服务的熔断器，调用方需持有 f.mu
+func (f *ClientFactory) breaker(service string) *circuitBreaker {
+	b, ok := f.breakers[service]
+	if !ok {
+		b = newCircuitBreaker(service, f.config.CircuitBreaker)
+		f.breakers[service] = b
+	}
+	return b
+}
+
+// serviceConfig 多实例连接的服务配置：轮询负载均衡，可选按 grpc.health.v1 摘除不健康的实例
+func serviceConfig(healthCheck bool) string {
+	if healthCheck {
+		return `{"loadBalancingConfig": [{"round_robin": {}}], "healthCheckConfig": {"serviceName": ""}}`
+	}
+	return `{"loadBalancingConfig": [{"round_robin": {}}]}`
+}
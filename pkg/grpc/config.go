@@ -0,0 +1,169 @@
+package grpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+
+	"github.com/3inchtime/movieinfo/pkg/discovery"
+	"github.com/3inchtime/movieinfo/pkg/idempotency"
+	"github.com/3inchtime/movieinfo/pkg/ratelimit"
+)
+
+// Config gRPC配置，对应 configs/grpc.yaml 中的 grpc 节点
+type Config struct {
+	Server ServerConfig `yaml:"server"`
+	Client ClientConfig `yaml:"client"`
+}
+
+// ServerConfig gRPC服务器配置
+type ServerConfig struct {
+	Host              string             `yaml:"host"`
+	Port              int                `yaml:"port"`
+	MaxRecvMsgSize    int                `yaml:"max_recv_msg_size"`
+	MaxSendMsgSize    int                `yaml:"max_send_msg_size"`
+	ConnectionTimeout time.Duration      `yaml:"connection_timeout"`
+	Keepalive         KeepaliveConfig    `yaml:"keepalive"`
+	TLS               TLSConfig          `yaml:"tls"`
+	RateLimit         ratelimit.Config   `yaml:"rate_limit"`
+	Idempotency       idempotency.Config `yaml:"idempotency"`
+}
+
+// ClientConfig gRPC客户端配置
+type ClientConfig struct {
+	// Targets 服务名称到地址的映射，如 movie: localhost:8082
+	Targets        map[string]string `yaml:"targets"`
+	MaxRecvMsgSize int               `yaml:"max_recv_msg_size"`
+	MaxSendMsgSize int               `yaml:"max_send_msg_size"`
+	DialTimeout    time.Duration     `yaml:"dial_timeout"`
+	// TLS 连接服务时使用的TLS配置，配置证书时作为客户端身份用于双向TLS
+	TLS TLSConfig `yaml:"tls"`
+	// RequestTimeout 未在 Methods 中单独配置的一元调用的默认超时
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// PoolSize 每个服务建立的连接数，请求在连接间轮询
+	PoolSize       int                  `yaml:"pool_size"`
+	Keepalive      KeepaliveConfig      `yaml:"keepalive"`
+	Retry          RetryConfig          `yaml:"retry"`
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker"`
+	// Methods 按方法覆盖超时和幂等性
+	Methods []MethodConfig `yaml:"methods"`
+	// Discovery 服务发现配置，启用后同一服务的多个实例间轮询负载均衡
+	Discovery discovery.Config `yaml:"discovery"`
+}
+
+// RetryConfig 重试配置，只对幂等方法生效
+type RetryConfig struct {
+	MaxAttempts    int           `yaml:"max_attempts"` // 包含首次调用在内的最大尝试次数，1 表示不重试
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+	Multiplier     float64       `yaml:"multiplier"`
+	// RetryableCodes 可重试的状态码，如 UNAVAILABLE
+	RetryableCodes []string `yaml:"retryable_codes"`
+}
+
+// CircuitBreakerConfig 熔断配置，每个服务一个熔断器
+type CircuitBreakerConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// FailureThreshold 连续失败多少次后熔断
+	FailureThreshold int `yaml:"failure_threshold"`
+	// OpenTimeout 熔断持续时间，之后进入半开状态放行探测请求
+	OpenTimeout time.Duration `yaml:"open_timeout"`
+	// HalfOpenRequests 半开状态下允许同时进行的探测请求数
+	HalfOpenRequests int `yaml:"half_open_requests"`
+}
+
+// MethodConfig 单个方法或服务的调用配置
+type MethodConfig struct {
+	// Name 完整方法名（/movieinfo.movie.MovieService/GetMovie）或服务名（movieinfo.movie.MovieService）
+	Name    string        `yaml:"name"`
+	Timeout time.Duration `yaml:"timeout"`
+	// Idempotent 是否可以安全重试，未设置时按 proto 中方法的 idempotency_level 判断
+	Idempotent *bool `yaml:"idempotent"`
+}
+
+// KeepaliveConfig 连接保活配置
+type KeepaliveConfig struct {
+	Time                time.Duration `yaml:"time"`
+	Timeout             time.Duration `yaml:"timeout"`
+	PermitWithoutStream bool          `yaml:"permit_without_stream"`
+}
+
+// DefaultClientConfig 返回默认客户端配置
+func DefaultClientConfig() *ClientConfig {
+	return &ClientConfig{
+		Targets: map[string]string{
+			"user":   "localhost:8081",
+			"movie":  "localhost:8082",
+			"rating": "localhost:8083",
+		},
+		MaxRecvMsgSize: 4 * 1024 * 1024,
+		MaxSendMsgSize: 4 * 1024 * 1024,
+		DialTimeout:    5 * time.Second,
+		RequestTimeout: 10 * time.Second,
+		PoolSize:       1,
+		Keepalive: KeepaliveConfig{
+			Time:                30 * time.Second,
+			Timeout:             5 * time.Second,
+			PermitWithoutStream: true,
+		},
+		Retry: RetryConfig{
+			MaxAttempts:    3,
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     time.Second,
+			Multiplier:     2,
+			RetryableCodes: []string{"UNAVAILABLE"},
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			Enabled:          true,
+			FailureThreshold: 5,
+			OpenTimeout:      30 * time.Second,
+			HalfOpenRequests: 1,
+		},
+		Discovery: *discovery.DefaultConfig(),
+	}
+}
+
+// LoadConfig 从 configs/grpc.yaml 加载gRPC配置，未设置的客户端配置使用默认值
+func LoadConfig(path string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read grpc config file: %w", err)
+	}
+
+	config := Config{
+		Server: ServerConfig{
+			RateLimit:   *ratelimit.DefaultConfig(),
+			Idempotency: *idempotency.DefaultConfig(),
+		},
+		Client: *DefaultClientConfig(),
+	}
+	if err := v.UnmarshalKey("grpc", &config, func(dc *mapstructure.DecoderConfig) {
+		dc.TagName = "yaml"
+	}); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal grpc config: %w", err)
+	}
+
+	return &config, nil
+}
+
+// ForService 返回替换了证书路径中 {service} 占位符的配置副本
+// 各服务共用 configs/grpc.yaml，通过占位符使用各自的证书，如 certs/{service}.pem
+func (c *Config) ForService(service string) *Config {
+	config := *c
+	config.Server.TLS = c.Server.TLS.forService(service)
+	config.Client.TLS = c.Client.TLS.forService(service)
+	return &config
+}
+
+// Target 返回服务的地址
+func (c *ClientConfig) Target(service string) (string, error) {
+	target, ok := c.Targets[service]
+	if !ok || target == "" {
+		return "", fmt.Errorf("no target configured for service %q", service)
+	}
+	return target, nil
+}
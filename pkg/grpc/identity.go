@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// identityURIPrefix 服务身份URI的前缀，devcerts 生成的证书使用 spiffe://movieinfo/<service>
+const identityURIPrefix = "spiffe://movieinfo/"
+
+// Identity 从已校验的客户端证书中得到的调用方身份
+type Identity struct {
+	// Service 调用方服务名，取自身份URI，没有时使用证书的 CommonName
+	Service    string
+	CommonName string
+	URIs       []string
+	DNSNames   []string
+}
+
+// PeerIdentity 返回调用方的服务身份，只有客户端出示了通过校验的证书时才返回 true
+// PeerInterceptor 据此限制只允许特定服务调用
+func PeerIdentity(ctx context.Context) (*Identity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+
+	cert := info.State.VerifiedChains[0][0]
+	identity := &Identity{
+		Service:    cert.Subject.CommonName,
+		CommonName: cert.Subject.CommonName,
+		DNSNames:   cert.DNSNames,
+	}
+	for _, uri := range cert.URIs {
+		s := uri.String()
+		identity.URIs = append(identity.URIs, s)
+		if strings.HasPrefix(s, identityURIPrefix) {
+			identity.Service = strings.TrimPrefix(s, identityURIPrefix)
+		}
+	}
+	return identity, true
+}
+
+// PeerInterceptor 按客户端证书中的服务身份限制调用方
+type PeerInterceptor struct {
+	allowed map[string]bool
+}
+
+// NewPeerInterceptor 创建调用方身份拦截器，只允许 services 中的服务调用
+func NewPeerInterceptor(services []string) *PeerInterceptor {
+	allowed := make(map[string]bool, len(services))
+	for _, service := range services {
+		allowed[service] = true
+	}
+	return &PeerInterceptor{allowed: allowed}
+}
+
+// UnaryServerInterceptor 一元调用的身份拦截器
+func (i *PeerInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.check(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor 流式调用的身份拦截器
+func (i *PeerInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.check(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// check 未出示证书时返回 Unauthenticated，服务不在允许列表中时返回 PermissionDenied
+func (i *PeerInterceptor) check(ctx context.Context) error {
+	identity, ok := PeerIdentity(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "client certificate required")
+	}
+	if !i.allowed[identity.Service] {
+		return status.Errorf(codes.PermissionDenied, "service %q is not allowed to call this server", identity.Service)
+	}
+	return nil
+}
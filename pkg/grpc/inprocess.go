@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// inProcessBufferSize 每个内存连接的缓冲区大小
+const inProcessBufferSize = 1 << 20
+
+// InProcess 进程内的gRPC网络，服务之间通过内存连接(bufconn)调用，不占用端口
+// 用于单进程开发模式和集成测试：
+//
+//	network := grpc.NewInProcess()
+//	go server.Serve(network.Listen("movie"))
+//	config.Targets["movie"] = network.Target("movie")
+//	factory, _ := grpc.NewClientFactory(config, network.DialOption())
+type InProcess struct {
+	mu        sync.Mutex
+	listeners map[string]*bufconn.Listener
+}
+
+// NewInProcess 创建进程内网络
+func NewInProcess() *InProcess {
+	return &InProcess{listeners: make(map[string]*bufconn.Listener)}
+}
+
+// Listen 返回服务的监听器，同一服务多次调用返回同一个监听器
+func (n *InProcess) Listen(service string) net.Listener {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	lis, ok := n.listeners[service]
+	if !ok {
+		lis = bufconn.Listen(inProcessBufferSize)
+		n.listeners[service] = lis
+	}
+	return lis
+}
+
+// Target 返回服务的拨号地址，需配合 DialOption 使用
+func (n *InProcess) Target(service string) string {
+	return "passthrough:///" + service
+}
+
+// DialOption 返回通过内存连接拨号的选项，地址为 Target 返回的服务名
+func (n *InProcess) DialOption() grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, service string) (net.Conn, error) {
+		n.mu.Lock()
+		lis, ok := n.listeners[service]
+		n.mu.Unlock()
+
+		if !ok {
+			return nil, fmt.Errorf("no in-process listener for service %q", service)
+		}
+		return lis.DialContext(ctx)
+	})
+}
+
+// Close 关闭所有监听器
+func (n *InProcess) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var errs []error
+	for _, lis := range n.listeners {
+		if err := lis.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
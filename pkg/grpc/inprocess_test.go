@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestInProcess(t *testing.T) {
+	network := NewInProcess()
+	defer network.Close()
+
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("movie", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(network.Listen("movie"))
+	defer server.Stop()
+
+	conn, err := grpc.Dial(network.Target("movie"), network.DialOption(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: "movie"})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Check() = %v, want SERVING", resp.Status)
+	}
+
+	// 未监听的服务拨号失败
+	other, err := grpc.Dial(network.Target("rating"), network.DialOption(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer other.Close()
+	if _, err := healthpb.NewHealthClient(other).Check(context.Background(), &healthpb.HealthCheckRequest{}); err == nil {
+		t.Fatal("Check() on a service without listener succeeded")
+	}
+}
@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// methodPolicy 单个方法生效的调用策略
+type methodPolicy struct {
+	timeout    time.Duration
+	idempotent bool
+}
+
+// policies 按方法解析调用策略，完整方法名的配置优先于服务级配置
+type policies struct {
+	defaultTimeout time.Duration
+	methods        map[string]MethodConfig
+}
+
+// newPolicies 根据客户端配置创建策略表
+func newPolicies(config *ClientConfig) *policies {
+	p := &policies{
+		defaultTimeout: config.RequestTimeout,
+		methods:        make(map[string]MethodConfig, len(config.Methods)),
+	}
+	for _, m := range config.Methods {
+		p.methods[strings.TrimPrefix(m.Name, "/")] = m
+	}
+	return p
+}
+
+// lookup 返回方法的调用策略，fullMethod 形如 /movieinfo.movie.MovieService/GetMovie
+func (p *policies) lookup(fullMethod string) methodPolicy {
+	service, method := splitMethod(fullMethod)
+	policy := methodPolicy{
+		timeout:    p.defaultTimeout,
+		idempotent: declaredIdempotent(service, method),
+	}
+
+	// 先应用服务级配置，再用方法级配置覆盖
+	for _, key := range []string{service, service + "/" + method} {
+		m, ok := p.methods[key]
+		if !ok {
+			continue
+		}
+		if m.Timeout > 0 {
+			policy.timeout = m.Timeout
+		}
+		if m.Idempotent != nil {
+			policy.idempotent = *m.Idempotent
+		}
+	}
+	return policy
+}
+
+// splitMethod 将 /pkg.Service/Method 拆分为服务名和方法名
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "", fullMethod
+}
+
+// declaredIdempotent 判断方法在 proto 中是否声明了 idempotency_level（NO_SIDE_EFFECTS 或 IDEMPOTENT）
+// 方法描述符由生成代码注册到 protoregistry.GlobalFiles，未注册或未声明的方法视为不可重试
+func declaredIdempotent(service, method string) bool {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service + "." + method))
+	if err != nil {
+		return false
+	}
+	md, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return false
+	}
+	options, ok := md.Options().(*descriptorpb.MethodOptions)
+	return ok && options.GetIdempotencyLevel() != descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN
+}
+
+// parseCodes 解析状态码名称，如 UNAVAILABLE、DEADLINE_EXCEEDED
+func parseCodes(names []string) (map[codes.Code]bool, error) {
+	set := make(map[codes.Code]bool, len(names))
+	for _, name := range names {
+		var code codes.Code
+		if err := code.UnmarshalJSON([]byte(`"` + strings.ToUpper(strings.TrimSpace(name)) + `"`)); err != nil {
+			return nil, fmt.Errorf("invalid status code %q: %w", name, err)
+		}
+		set[code] = true
+	}
+	return set, nil
+}
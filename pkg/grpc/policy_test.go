@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	// 注册方法描述符，幂等性按 proto 中声明的 idempotency_level 判断
+	_ "github.com/3inchtime/movieinfo/proto/gen/movie"
+	_ "github.com/3inchtime/movieinfo/proto/gen/rating"
+	_ "github.com/3inchtime/movieinfo/proto/gen/user"
+)
+
+const (
+	getMovie    = "/movieinfo.movie.MovieService/GetMovie"
+	createMovie = "/movieinfo.movie.MovieService/CreateMovie"
+	login       = "/movieinfo.user.UserService/Login"
+)
+
+func TestPoliciesLookup(t *testing.T) {
+	yes, no := true, false
+	p := newPolicies(&ClientConfig{
+		RequestTimeout: 5 * time.Second,
+		Methods: []MethodConfig{
+			{Name: "movieinfo.movie.MovieService", Timeout: 2 * time.Second},
+			{Name: createMovie, Timeout: 10 * time.Second, Idempotent: &yes},
+			{Name: "/movieinfo.movie.MovieService/ListMovies", Idempotent: &no},
+		},
+	})
+	tests := []struct {
+		method string
+		want   methodPolicy
+	}{
+		{method: getMovie, want: methodPolicy{timeout: 2 * time.Second, idempotent: true}},
+		{method: createMovie, want: methodPolicy{timeout: 10 * time.Second, idempotent: true}},
+		{method: "/movieinfo.movie.MovieService/ListMovies", want: methodPolicy{timeout: 2 * time.Second}},
+		{method: "/movieinfo.rating.RatingService/BatchGetUserRatings", want: methodPolicy{timeout: 5 * time.Second, idempotent: true}},
+		{method: login, want: methodPolicy{timeout: 5 * time.Second}},
+		{method: "/movieinfo.user.UserService/ListSessions", want: methodPolicy{timeout: 5 * time.Second, idempotent: true}},
+		{method: "/movieinfo.user.UserService/VerifyResetCode", want: methodPolicy{timeout: 5 * time.Second}},
+		{method: "/other.Service/GetThing", want: methodPolicy{timeout: 5 * time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			if got := p.lookup(tt.method); got != tt.want {
+				t.Fatalf("lookup(%s) = %+v, want %+v", tt.method, got, tt.want)
+			}
+		})
+	}
+}
+
+// failingInvoker 前 failures 次调用返回 code，之后成功，并记录调用次数
+func failingInvoker(failures int, code codes.Code, calls *int) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= failures {
+			return status.Error(code, "failed")
+		}
+		return nil
+	}
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	config := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, Multiplier: 2}
+	retryable := map[codes.Code]bool{codes.Unavailable: true}
+	interceptor := retryInterceptor(config, retryable, newPolicies(&ClientConfig{}))
+	tests := []struct {
+		name      string
+		method    string
+		failures  int
+		code      codes.Code
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{name: "idempotent method retried", method: getMovie, failures: 2, code: codes.Unavailable, wantCalls: 3},
+		{name: "attempts limited", method: getMovie, failures: 5, code: codes.Unavailable, wantCalls: 3, wantCode: codes.Unavailable},
+		{name: "non-retryable code", method: getMovie, failures: 1, code: codes.NotFound, wantCalls: 1, wantCode: codes.NotFound},
+		{name: "non-idempotent method", method: createMovie, failures: 1, code: codes.Unavailable, wantCalls: 1, wantCode: codes.Unavailable},
+		{name: "login never retried", method: login, failures: 1, code: codes.Unavailable, wantCalls: 1, wantCode: codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := interceptor(context.Background(), tt.method, nil, nil, nil, failingInvoker(tt.failures, tt.code, &calls))
+			if status.Code(err) != tt.wantCode || calls != tt.wantCalls {
+				t.Fatalf("got (%v, %d calls), want (%v, %d calls)", err, calls, tt.wantCode, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryInterceptorDeadline(t *testing.T) {
+	config := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Second, Multiplier: 2}
+	interceptor := retryInterceptor(config, map[codes.Code]bool{codes.Unavailable: true}, newPolicies(&ClientConfig{}))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// 剩余时间不足一次退避时不再等待
+	calls := 0
+	start := time.Now()
+	err := interceptor(ctx, getMovie, nil, nil, nil, failingInvoker(5, codes.Unavailable, &calls))
+	if status.Code(err) != codes.Unavailable || calls != 1 || time.Since(start) > 50*time.Millisecond {
+		t.Fatalf("got (%v, %d calls) after %v, want one call without waiting", err, calls, time.Since(start))
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker("movie", CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute, HalfOpenRequests: 1})
+	b.now = func() time.Time { return now }
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	steps := []struct {
+		name      string
+		advance   time.Duration
+		result    error // 放行时请求的结果
+		wantAllow bool
+		wantState breakerState
+	}{
+		{name: "business errors not counted", result: status.Error(codes.NotFound, "missing"), wantAllow: true, wantState: stateClosed},
+		{name: "first failure", result: unavailable, wantAllow: true, wantState: stateClosed},
+		{name: "threshold reached", result: status.Error(codes.DeadlineExceeded, "slow"), wantAllow: true, wantState: stateOpen},
+		{name: "rejected while open", advance: 30 * time.Second, wantState: stateOpen},
+		{name: "probe after open timeout fails", advance: 31 * time.Second, result: unavailable, wantAllow: true, wantState: stateOpen},
+		{name: "rejected again", wantState: stateOpen},
+		{name: "probe succeeds", advance: time.Minute, result: nil, wantAllow: true, wantState: stateClosed},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		err := b.allow()
+		if allowed := err == nil; allowed != step.wantAllow {
+			t.Fatalf("%s: allow() = %v, want allowed %v", step.name, err, step.wantAllow)
+		}
+		if err == nil {
+			b.record(step.result)
+		}
+		if b.state != step.wantState {
+			t.Fatalf("%s: state = %s, want %s", step.name, b.state, step.wantState)
+		}
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbes(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker("movie", CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second, HalfOpenRequests: 1})
+	b.now = func() time.Time { return now }
+	b.allow()
+	b.record(status.Error(codes.Unavailable, "down"))
+
+	now = now.Add(time.Second)
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+	// 探测请求未完成时其余请求仍被拒绝
+	if err := b.allow(); err != ErrCircuitOpen {
+		t.Fatalf("second request during probe = %v, want %v", err, ErrCircuitOpen)
+	}
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+)
+
+// connPool 同一服务的多个连接，请求在连接间轮询分配
+// 实现 grpc.ClientConnInterface，可直接传给生成的 NewXxxServiceClient
+type connPool struct {
+	conns []*grpc.ClientConn
+	next  uint32
+}
+
+// Invoke 实现 grpc.ClientConnInterface
+func (p *connPool) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	return p.pick().Invoke(ctx, method, args, reply, opts...)
+}
+
+// NewStream 实现 grpc.ClientConnInterface
+func (p *connPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return p.pick().NewStream(ctx, desc, method, opts...)
+}
+
+// pick 轮询选择一个连接
+func (p *connPool) pick() *grpc.ClientConn {
+	n := atomic.AddUint32(&p.next, 1)
+	return p.conns[n%uint32(len(p.conns))]
+}
+
+// close 关闭所有连接
+func (p *connPool) close() error {
+	var errs []error
+	for _, conn := range p.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+package grpc
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/3inchtime/movieinfo/pkg/idempotency"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// deadlineInterceptor 为没有截止时间的一元调用设置方法的默认超时
+func deadlineInterceptor(p *policies) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			if timeout := p.lookup(method).timeout; timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// retryInterceptor 幂等方法或带有幂等键的调用遇到可重试的状态码时按指数退避重试
+// 所有尝试共享调用的截止时间，剩余时间不足一次退避时直接返回最后一次的错误
+func retryInterceptor(config RetryConfig, retryable map[codes.Code]bool, p *policies) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if config.MaxAttempts <= 1 || !(p.lookup(method).idempotent || idempotency.HasKey(ctx)) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		backoff := config.InitialBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= config.MaxAttempts || !retryable[status.Code(err)] {
+				return err
+			}
+
+			wait := jitter(backoff)
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				return err
+			}
+			logger.Warnf("retrying %s in %v (attempt %d/%d): %v", method, wait, attempt+1, config.MaxAttempts, err)
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+
+			backoff = nextBackoff(backoff, config)
+		}
+	}
+}
+
+// nextBackoff 计算下一次退避时间，不超过 MaxBackoff
+func nextBackoff(current time.Duration, config RetryConfig) time.Duration {
+	multiplier := config.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	next := time.Duration(float64(current) * multiplier)
+	if config.MaxBackoff > 0 && next > config.MaxBackoff {
+		next = config.MaxBackoff
+	}
+	return next
+}
+
+// jitter 在 [d/2, d) 范围内随机化退避时间，避免多个客户端同时重试
+func jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)))
+}
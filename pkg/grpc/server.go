@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/3inchtime/movieinfo/pkg/metrics"
+)
+
+// ServerOptions 根据服务器配置构建 grpc.NewServer 的选项，opts 追加在配置生成的选项之后
+// 指标拦截器位于拦截器链的最外层，其次是调用方身份拦截器，opts 中的 ChainUnaryInterceptor 等依次追加在其后
+// 追踪通过 stats handler 实现，从请求元数据中提取W3C trace-context
+func ServerOptions(config *ServerConfig, opts ...grpc.ServerOption) ([]grpc.ServerOption, error) {
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor()),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	}
+
+	if config.TLS.Enabled {
+		creds, err := NewServerCredentials(config.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create server tls credentials: %w", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+		if len(config.TLS.AllowedPeers) > 0 {
+			peers := NewPeerInterceptor(config.TLS.AllowedPeers)
+			serverOpts = append(serverOpts,
+				grpc.ChainUnaryInterceptor(peers.UnaryServerInterceptor()),
+				grpc.ChainStreamInterceptor(peers.StreamServerInterceptor()))
+		}
+	}
+	if config.MaxRecvMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(config.MaxRecvMsgSize))
+	}
+	if config.MaxSendMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxSendMsgSize(config.MaxSendMsgSize))
+	}
+	if config.ConnectionTimeout > 0 {
+		serverOpts = append(serverOpts, grpc.ConnectionTimeout(config.ConnectionTimeout))
+	}
+	if config.Keepalive.Time > 0 {
+		serverOpts = append(serverOpts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    config.Keepalive.Time,
+			Timeout: config.Keepalive.Timeout,
+		}))
+	}
+
+	return append(serverOpts, opts...), nil
+}
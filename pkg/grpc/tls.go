@@ -0,0 +1,256 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// serviceToken 证书路径中的服务名占位符，由 Config.ForService 替换
+const serviceToken = "{service}"
+
+// TLSConfig TLS配置，证书文件更新后在下一次握手时自动重新加载
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	CertFile string `yaml:"cert_file"` // 本端证书，可包含 {service} 占位符
+	KeyFile  string `yaml:"key_file"`  // 本端私钥
+	CAFile   string `yaml:"ca_file"`   // 用于校验对端证书的CA
+	// ClientAuth 服务端对客户端证书的要求：none、request（提供时校验）、require（双向TLS）
+	ClientAuth string `yaml:"client_auth"`
+	// AllowedPeers 允许调用服务端的服务名，取自客户端证书的服务身份，为空时不限制
+	AllowedPeers []string `yaml:"allowed_peers"`
+	// ServerName 客户端校验服务端证书时使用的名称，为空时使用连接目标的主机名
+	ServerName string `yaml:"server_name"`
+	// ReloadInterval 检查证书文件是否变化的最小间隔
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+}
+
+// forService 替换证书路径中的服务名占位符
+func (c TLSConfig) forService(service string) TLSConfig {
+	c.CertFile = strings.ReplaceAll(c.CertFile, serviceToken, service)
+	c.KeyFile = strings.ReplaceAll(c.KeyFile, serviceToken, service)
+	c.CAFile = strings.ReplaceAll(c.CAFile, serviceToken, service)
+	return c
+}
+
+// clientAuthType 解析客户端证书要求
+func (c TLSConfig) clientAuthType() (tls.ClientAuthType, error) {
+	switch strings.ToLower(c.ClientAuth) {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "request":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require":
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("invalid client_auth %q, expected none, request or require", c.ClientAuth)
+	}
+}
+
+// NewServerCredentials 创建服务端TLS凭证
+// 证书和客户端CA在每次握手时按需重新加载，证书轮换无需重启服务
+func NewServerCredentials(config TLSConfig) (credentials.TransportCredentials, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("tls cert_file and key_file are required for the server")
+	}
+	clientAuth, err := config.clientAuthType()
+	if err != nil {
+		return nil, err
+	}
+	if clientAuth != tls.NoClientCert && config.CAFile == "" {
+		return nil, errors.New("tls ca_file is required to verify client certificates")
+	}
+	if clientAuth == tls.NoClientCert && len(config.AllowedPeers) > 0 {
+		return nil, errors.New("tls allowed_peers requires client_auth request or require")
+	}
+
+	reloader, err := newCertReloader(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			state := reloader.current()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*state.cert},
+				ClientCAs:    state.roots,
+				ClientAuth:   clientAuth,
+			}, nil
+		},
+	}), nil
+}
+
+// NewClientCredentials 创建客户端TLS凭证，配置了证书时向服务端出示客户端证书（双向TLS）
+func NewClientCredentials(config TLSConfig) (credentials.TransportCredentials, error) {
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, errors.New("tls cert_file and key_file must be set together")
+	}
+
+	reloader, err := newCertReloader(config)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.ServerName,
+	}
+	if config.CertFile != "" {
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return reloader.current().cert, nil
+		}
+	}
+	if config.CAFile != "" {
+		// RootCAs 无法在握手间替换，这里关闭内置校验，改为在 VerifyConnection 中用最新的CA完成同等校验
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyServer(cs, reloader.current().roots, config.ServerName)
+		}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// verifyServer 校验服务端证书链和主机名
+func verifyServer(cs tls.ConnectionState, roots *x509.CertPool, serverName string) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("server did not present a certificate")
+	}
+	if serverName == "" {
+		serverName = cs.ServerName
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       serverName,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := cs.PeerCertificates[0].Verify(opts); err != nil {
+		return fmt.Errorf("failed to verify server certificate: %w", err)
+	}
+	return nil
+}
+
+// certState 当前生效的证书和CA
+type certState struct {
+	cert  *tls.Certificate
+	roots *x509.CertPool
+}
+
+// certReloader 证书文件加载器，文件修改时间变化后重新加载
+// 加载失败时继续使用上一次成功加载的证书，避免轮换过程中的中间状态导致握手失败
+type certReloader struct {
+	config TLSConfig
+
+	mu        sync.Mutex
+	state     certState
+	modTimes  map[string]time.Time
+	checkedAt time.Time
+}
+
+// newCertReloader 创建加载器并立即加载一次，首次加载失败返回错误
+func newCertReloader(config TLSConfig) (*certReloader, error) {
+	if config.ReloadInterval <= 0 {
+		config.ReloadInterval = 30 * time.Second
+	}
+
+	r := &certReloader{config: config}
+	state, modTimes, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.state = state
+	r.modTimes = modTimes
+	r.checkedAt = time.Now()
+	return r, nil
+}
+
+// current 返回当前证书，距上次检查超过 ReloadInterval 且文件有变化时重新加载
+func (r *certReloader) current() certState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) < r.config.ReloadInterval {
+		return r.state
+	}
+	r.checkedAt = time.Now()
+
+	if !r.changed() {
+		return r.state
+	}
+
+	state, modTimes, err := r.load()
+	if err != nil {
+		logger.Errorf("failed to reload tls certificates, keeping the previous ones: %v", err)
+		return r.state
+	}
+	logger.Infof("reloaded tls certificates from %s", r.config.CertFile)
+	r.state = state
+	r.modTimes = modTimes
+	return r.state
+}
+
+// changed 判断证书文件的修改时间是否变化
+func (r *certReloader) changed() bool {
+	for path, modTime := range r.modTimes {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// load 读取证书、私钥和CA文件
+func (r *certReloader) load() (certState, map[string]time.Time, error) {
+	var state certState
+	modTimes := make(map[string]time.Time)
+
+	for _, path := range []string{r.config.CertFile, r.config.KeyFile, r.config.CAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return state, nil, fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		modTimes[path] = info.ModTime()
+	}
+
+	if r.config.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+		if err != nil {
+			return state, nil, fmt.Errorf("failed to load tls key pair: %w", err)
+		}
+		state.cert = &cert
+	}
+
+	if r.config.CAFile != "" {
+		pem, err := os.ReadFile(r.config.CAFile)
+		if err != nil {
+			return state, nil, fmt.Errorf("failed to read ca file: %w", err)
+		}
+		state.roots = x509.NewCertPool()
+		if !state.roots.AppendCertsFromPEM(pem) {
+			return state, nil, fmt.Errorf("no certificates found in %s", r.config.CAFile)
+		}
+	}
+
+	return state, modTimes, nil
+}
@@ -0,0 +1,282 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// testCA 测试用的证书颁发机构，证书写入 dir
+type testCA struct {
+	dir  string
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	// serial 下一张证书的序列号
+	serial int64
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	ca := &testCA{dir: t.TempDir(), serial: 1}
+	ca.key = newKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(ca.serial),
+		Subject:               pkix.Name{CommonName: "movieinfo test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &ca.key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create ca: %v", err)
+	}
+	ca.cert, _ = x509.ParseCertificate(der)
+	writePEM(t, ca.path("ca.pem"), "CERTIFICATE", der)
+	return ca
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+func (ca *testCA) path(name string) string {
+	return filepath.Join(ca.dir, name)
+}
+
+// issue 为服务签发证书，写入 <service>.pem 和 <service>-key.pem，返回证书序列号
+func (ca *testCA) issue(t *testing.T, service string) int64 {
+	t.Helper()
+	ca.serial++
+	key := newKey(t)
+	identity, _ := url.Parse(identityURIPrefix + service)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(ca.serial),
+		Subject:      pkix.Name{CommonName: service},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		URIs:         []*url.URL{identity},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to issue certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	writePEM(t, ca.path(service+".pem"), "CERTIFICATE", der)
+	writePEM(t, ca.path(service+"-key.pem"), "EC PRIVATE KEY", keyDER)
+	return ca.serial
+}
+
+// config 返回使用 service 证书的TLS配置
+func (ca *testCA) config(service string) TLSConfig {
+	return TLSConfig{
+		Enabled:  true,
+		CertFile: ca.path(service + ".pem"),
+		KeyFile:  ca.path(service + "-key.pem"),
+		CAFile:   ca.path("ca.pem"),
+	}
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+// serial 返回加载的证书的序列号
+func serial(t *testing.T, state certState) int64 {
+	t.Helper()
+	cert, err := x509.ParseCertificate(state.cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("failed to parse loaded certificate: %v", err)
+	}
+	return cert.SerialNumber.Int64()
+}
+
+// touch 将文件的修改时间推后，保证重新加载能够发现变化
+func touch(t *testing.T, paths ...string) {
+	t.Helper()
+	later := time.Now().Add(time.Minute)
+	for _, path := range paths {
+		if err := os.Chtimes(path, later, later); err != nil {
+			t.Fatalf("failed to touch %s: %v", path, err)
+		}
+	}
+}
+
+func TestCertReload(t *testing.T) {
+	ca := newTestCA(t)
+	first := ca.issue(t, "movie")
+	config := ca.config("movie")
+	config.ReloadInterval = time.Millisecond
+	reloader, err := newCertReloader(config)
+	if err != nil {
+		t.Fatalf("newCertReloader() error = %v", err)
+	}
+	if got := serial(t, reloader.current()); got != first {
+		t.Fatalf("loaded certificate %d, want %d", got, first)
+	}
+
+	rotated := ca.issue(t, "movie")
+	touch(t, config.CertFile, config.KeyFile)
+	time.Sleep(2 * time.Millisecond)
+	if got := serial(t, reloader.current()); got != rotated {
+		t.Fatalf("certificate after rotation = %d, want %d", got, rotated)
+	}
+
+	// 写入一半的证书无法加载时继续使用上一次的证书
+	if err := os.WriteFile(config.CertFile, []byte("-----BEGIN CERTIFICATE-----"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, config.CertFile)
+	time.Sleep(2 * time.Millisecond)
+	if got := serial(t, reloader.current()); got != rotated {
+		t.Fatalf("certificate after a broken rotation = %d, want %d", got, rotated)
+	}
+}
+
+func TestNewServerCredentialsConfig(t *testing.T) {
+	ca := newTestCA(t)
+	ca.issue(t, "movie")
+	tests := []struct {
+		name    string
+		modify  func(c *TLSConfig)
+		wantErr bool
+	}{
+		{name: "mutual tls", modify: func(c *TLSConfig) { c.ClientAuth = "require"; c.AllowedPeers = []string{"web"} }},
+		{name: "server only", modify: func(c *TLSConfig) { c.CAFile = "" }},
+		{name: "missing key", modify: func(c *TLSConfig) { c.KeyFile = "" }, wantErr: true},
+		{name: "client auth without ca", modify: func(c *TLSConfig) { c.ClientAuth = "require"; c.CAFile = "" }, wantErr: true},
+		{name: "invalid client auth", modify: func(c *TLSConfig) { c.ClientAuth = "always" }, wantErr: true},
+		{name: "allowed peers without client auth", modify: func(c *TLSConfig) { c.AllowedPeers = []string{"web"} }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ca.config("movie")
+			tt.modify(&config)
+			if _, err := NewServerCredentials(config); (err != nil) != tt.wantErr {
+				t.Fatalf("NewServerCredentials() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPeerInterceptor(t *testing.T) {
+	ca := newTestCA(t)
+	ca.issue(t, "web")
+	webCert, _ := tls.LoadX509KeyPair(ca.path("web.pem"), ca.path("web-key.pem"))
+	web, _ := x509.ParseCertificate(webCert.Certificate[0])
+
+	verified := func(cert *x509.Certificate) context.Context {
+		info := credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert, ca.cert}}}}
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+	}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		allowed []string
+		want    codes.Code
+	}{
+		{name: "allowed service", ctx: verified(web), allowed: []string{"user", "web"}, want: codes.OK},
+		{name: "other service", ctx: verified(web), allowed: []string{"user"}, want: codes.PermissionDenied},
+		{name: "no client certificate", ctx: peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}}), allowed: []string{"web"}, want: codes.Unauthenticated},
+		{name: "no peer", ctx: context.Background(), allowed: []string{"web"}, want: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewPeerInterceptor(tt.allowed).UnaryServerInterceptor()
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: getMovie}, handler)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("interceptor = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestMutualTLSAllowedPeers 通过真实的双向TLS连接检查 allowed_peers
+func TestMutualTLSAllowedPeers(t *testing.T) {
+	ca := newTestCA(t)
+	for _, service := range []string{"movie", "web", "rating"} {
+		ca.issue(t, service)
+	}
+	serverTLS := ca.config("movie")
+	serverTLS.ClientAuth = "require"
+	serverTLS.AllowedPeers = []string{"web"}
+	opts, err := ServerOptions(&ServerConfig{TLS: serverTLS})
+	if err != nil {
+		t.Fatalf("ServerOptions() error = %v", err)
+	}
+	server := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go server.Serve(lis)
+	defer server.Stop()
+
+	tests := []struct {
+		name   string
+		client TLSConfig
+		want   codes.Code
+	}{
+		{name: "allowed service", client: ca.config("web"), want: codes.OK},
+		{name: "other service", client: ca.config("rating"), want: codes.PermissionDenied},
+		{name: "no client certificate", client: TLSConfig{Enabled: true, CAFile: ca.path("ca.pem")}, want: codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds, err := NewClientCredentials(tt.client)
+			if err != nil {
+				t.Fatalf("NewClientCredentials() error = %v", err)
+			}
+			conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(creds))
+			if err != nil {
+				t.Fatalf("Dial() error = %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("Check() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey 客户端传递幂等键的元数据键
+const MetadataKey = "idempotency-key"
+
+// ReplayedKey 响应来自已保存的结果时，服务端在响应头中设置该元数据
+const ReplayedKey = "idempotent-replayed"
+
+// maxKeyLength 幂等键的最大长度
+const maxKeyLength = 255
+
+// ErrNotFound 记录不存在或已过期
+var ErrNotFound = errors.New("idempotency record not found")
+
+// Record 幂等记录
+type Record struct {
+	Fingerprint string `json:"fingerprint"`        // 请求指纹，用于识别同一幂等键下的不同请求
+	Completed   bool   `json:"completed"`          // 为 false 时请求仍在处理中
+	Response    []byte `json:"response,omitempty"` // 序列化的 google.protobuf.Any 响应
+}
+
+// Store 幂等记录存储
+type Store interface {
+	// Reserve 为 key 创建处理中的记录，key 已存在时返回已有记录且 reserved 为 false
+	// 处理中的记录在 lockTimeout 后过期，避免服务异常退出后幂等键永久不可用
+	Reserve(ctx context.Context, key, fingerprint string, lockTimeout time.Duration) (record *Record, reserved bool, err error)
+	// Complete 保存处理结果，记录在 ttl 后过期
+	Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error
+	// Release 删除记录，处理失败后允许客户端使用同一个幂等键重试
+	Release(ctx context.Context, key string) error
+}
+
+// Config 幂等配置
+type Config struct {
+	Enabled     bool          `yaml:"enabled"`
+	Store       string        `yaml:"store" validate:"omitempty,oneof=memory redis mysql"`
+	KeyPrefix   string        `yaml:"key_prefix"`
+	TTL         time.Duration `yaml:"ttl"`          // 已完成请求的响应保存时间
+	LockTimeout time.Duration `yaml:"lock_timeout"` // 处理中记录的过期时间，应大于请求的最长处理时间
+	Methods     []string      `yaml:"methods"`      // 支持幂等键的完整方法名
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Store:       "memory",
+		KeyPrefix:   "movieinfo:idempotency:",
+		TTL:         24 * time.Hour,
+		LockTimeout: 30 * time.Second,
+		Methods: []string{
+			"/movieinfo.movie.MovieService/CreateMovie",
+			"/movieinfo.user.UserService/CreateUser",
+			"/movieinfo.rating.RatingService/CreateRating",
+		},
+	}
+}
+
+// NewStore 根据配置创建存储，store 为 redis 时需要传入Redis客户端，为 mysql 时需要传入数据库连接
+func NewStore(config *Config, client *redis.Client, db *sql.DB) (Store, error) {
+	switch config.Store {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "redis":
+		if client == nil {
+			return nil, fmt.Errorf("redis client is required for redis idempotency store")
+		}
+		return NewRedisStore(client, config.KeyPrefix), nil
+	case "mysql":
+		if db == nil {
+			return nil, fmt.Errorf("database is required for mysql idempotency store")
+		}
+		return NewMySQLStore(db), nil
+	default:
+		return nil, fmt.Errorf("unsupported idempotency store: %s", config.Store)
+	}
+}
+
+// NewKey 生成随机的幂等键
+func NewKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate idempotency key: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithKey 在调用的元数据中附加幂等键，同一个业务操作的重试应使用同一个键
+func WithKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, key)
+}
+
+// HasKey 判断调用的元数据中是否带有幂等键
+func HasKey(ctx context.Context) bool {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	return ok && len(md.Get(MetadataKey)) > 0
+}
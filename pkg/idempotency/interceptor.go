@@ -0,0 +1,168 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// storeTimeout 请求结束后写入或释放记录的超时时间，不受调用方取消的影响
+const storeTimeout = 5 * time.Second
+
+// Interceptor 为创建类方法提供幂等键支持
+// 首次请求的响应被保存，相同幂等键的重复请求直接返回保存的响应；请求内容不同时拒绝
+type Interceptor struct {
+	config  *Config
+	store   Store
+	methods map[string]bool
+}
+
+// NewInterceptor 创建幂等拦截器
+func NewInterceptor(config *Config, store Store) *Interceptor {
+	methods := make(map[string]bool, len(config.Methods))
+	for _, method := range config.Methods {
+		methods[method] = true
+	}
+	return &Interceptor{config: config, store: store, methods: methods}
+}
+
+// UnaryServerInterceptor 返回一元调用幂等拦截器，应放在认证拦截器之后，使幂等键按用户隔离
+func (i *Interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !i.config.Enabled || !i.methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		key := incomingKey(ctx)
+		msg, ok := req.(proto.Message)
+		if key == "" || !ok {
+			return handler(ctx, req)
+		}
+		if len(key) > maxKeyLength {
+			return nil, apperror.New(apperror.InvalidArgument, "invalid idempotency key").
+				WithField(MetadataKey, fmt.Sprintf("must be at most %d characters", maxKeyLength))
+		}
+
+		fingerprint, err := requestFingerprint(msg)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fingerprint request: %v", err)
+		}
+
+		storeKey := i.storeKey(ctx, info.FullMethod, key)
+		record, reserved, err := i.store.Reserve(ctx, storeKey, fingerprint, i.config.LockTimeout)
+		if err != nil {
+			logger.Errorf("Failed to reserve idempotency key for %s: %v", info.FullMethod, err)
+			return nil, status.Error(codes.Unavailable, "idempotency store unavailable, retry with the same key")
+		}
+		if !reserved {
+			return i.replay(ctx, record, fingerprint)
+		}
+
+		resp, err := handler(ctx, req)
+
+		// 调用方超时取消后仍需保存结果，否则客户端重试时会重复执行
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeTimeout)
+		defer cancel()
+
+		if err != nil {
+			if releaseErr := i.store.Release(storeCtx, storeKey); releaseErr != nil {
+				logger.Warnf("Failed to release idempotency key for %s: %v", info.FullMethod, releaseErr)
+			}
+			return nil, err
+		}
+
+		if err := i.complete(storeCtx, storeKey, fingerprint, resp); err != nil {
+			logger.Warnf("Failed to save idempotent response for %s: %v", info.FullMethod, err)
+		}
+		return resp, nil
+	}
+}
+
+// replay 返回已保存的响应，请求内容不一致或首次请求仍在处理时返回错误
+func (i *Interceptor) replay(ctx context.Context, record *Record, fingerprint string) (interface{}, error) {
+	if record.Fingerprint != fingerprint {
+		return nil, apperror.New(apperror.InvalidArgument, "idempotency key was already used with a different request").
+			WithField(MetadataKey, "reuse the key only to retry the same request")
+	}
+	if !record.Completed {
+		return nil, status.Error(codes.Aborted, "a request with the same idempotency key is still in progress")
+	}
+
+	var stored anypb.Any
+	if err := proto.Unmarshal(record.Response, &stored); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode stored response: %v", err)
+	}
+	resp, err := stored.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode stored response: %v", err)
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(ReplayedKey, strconv.FormatBool(true))); err != nil {
+		logger.Warnf("Failed to set %s header: %v", ReplayedKey, err)
+	}
+	return resp, nil
+}
+
+func (i *Interceptor) complete(ctx context.Context, key, fingerprint string, resp interface{}) error {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return fmt.Errorf("response %T is not a protobuf message", resp)
+	}
+
+	stored, err := anypb.New(msg)
+	if err != nil {
+		return fmt.Errorf("failed to wrap response: %w", err)
+	}
+	data, err := proto.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return i.store.Complete(ctx, key, &Record{Fingerprint: fingerprint, Completed: true, Response: data}, i.config.TTL)
+}
+
+// storeKey 组合方法、用户与客户端幂等键，不同用户使用相同的键互不影响
+func (i *Interceptor) storeKey(ctx context.Context, fullMethod, key string) string {
+	scope := ""
+	if userID, ok := auth.UserIDFromContext(ctx); ok {
+		scope = strconv.FormatInt(userID, 10)
+	}
+	sum := sha256.Sum256([]byte(fullMethod + "\n" + scope + "\n" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestFingerprint 计算请求内容的指纹
+func requestFingerprint(msg proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// incomingKey 读取请求元数据中的幂等键
+func incomingKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if keys := md.Get(MetadataKey); len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
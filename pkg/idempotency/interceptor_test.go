@@ -0,0 +1,176 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+)
+
+const createMethod = "/movieinfo.movie.MovieService/CreateMovie"
+
+// fakeTransportStream 记录拦截器设置的响应头
+type fakeTransportStream struct {
+	header metadata.MD
+}
+
+func (s *fakeTransportStream) Method() string { return createMethod }
+
+func (s *fakeTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *fakeTransportStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *fakeTransportStream) SetTrailer(md metadata.MD) error { return nil }
+
+// call 一次调用及其期望结果
+type call struct {
+	user    int64  // 调用方用户ID，为0时匿名调用
+	key     string // 幂等键，为空时不携带
+	method  string // 为空时使用 createMethod
+	request string
+	fail    bool // 处理函数返回错误
+
+	want         string // 期望的响应，call-N 表示第N次执行处理函数的结果
+	wantCode     string // 期望的错误代码
+	wantReplayed bool
+}
+
+func TestInterceptor(t *testing.T) {
+	invalid := apperror.InvalidArgument.String()
+	tests := []struct {
+		name  string
+		calls []call
+	}{
+		{
+			name: "same request replayed",
+			calls: []call{
+				{key: "k1", request: "alien", want: "call-1"},
+				{key: "k1", request: "alien", want: "call-1", wantReplayed: true},
+				{key: "k1", request: "alien", want: "call-1", wantReplayed: true},
+			},
+		},
+		{
+			name: "different request with the same key",
+			calls: []call{
+				{key: "k1", request: "alien", want: "call-1"},
+				{key: "k1", request: "aliens", wantCode: invalid},
+				{key: "k1", request: "alien", want: "call-1", wantReplayed: true},
+			},
+		},
+		{
+			name: "different keys executed separately",
+			calls: []call{
+				{key: "k1", request: "alien", want: "call-1"},
+				{key: "k2", request: "alien", want: "call-2"},
+			},
+		},
+		{
+			name: "keys scoped by user",
+			calls: []call{
+				{user: 1, key: "k1", request: "alien", want: "call-1"},
+				{user: 2, key: "k1", request: "aliens", want: "call-2"},
+				{user: 1, key: "k1", request: "alien", want: "call-1", wantReplayed: true},
+			},
+		},
+		{
+			name: "failed request releases the key",
+			calls: []call{
+				{key: "k1", request: "alien", fail: true, wantCode: codes.FailedPrecondition.String()},
+				{key: "k1", request: "alien", want: "call-2"},
+				{key: "k1", request: "alien", want: "call-2", wantReplayed: true},
+			},
+		},
+		{
+			name: "requests without a key always executed",
+			calls: []call{
+				{request: "alien", want: "call-1"},
+				{request: "alien", want: "call-2"},
+			},
+		},
+		{
+			name: "methods not configured always executed",
+			calls: []call{
+				{key: "k1", method: "/movieinfo.movie.MovieService/UpdateMovie", request: "alien", want: "call-1"},
+				{key: "k1", method: "/movieinfo.movie.MovieService/UpdateMovie", request: "alien", want: "call-2"},
+			},
+		},
+		{
+			name: "key too long",
+			calls: []call{
+				{key: strings.Repeat("k", maxKeyLength+1), request: "alien", wantCode: invalid},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.Enabled = true
+			interceptor := NewInterceptor(config, NewMemoryStore()).UnaryServerInterceptor()
+
+			// current 正在进行的调用，处理函数按它决定是否失败
+			var current call
+			executed := 0
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				executed++
+				if current.fail {
+					return nil, status.Error(codes.FailedPrecondition, "handler failed")
+				}
+				return wrapperspb.String(fmt.Sprintf("call-%d", executed)), nil
+			}
+
+			for i, c := range tt.calls {
+				current = c
+				stream := &fakeTransportStream{}
+				ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+				if c.user != 0 {
+					ctx = auth.WithUserID(ctx, c.user)
+				}
+				if c.key != "" {
+					ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(MetadataKey, c.key))
+				}
+				method := c.method
+				if method == "" {
+					method = createMethod
+				}
+
+				resp, err := interceptor(ctx, wrapperspb.String(c.request), &grpc.UnaryServerInfo{FullMethod: method}, handler)
+				if got := errorCode(err); got != c.wantCode {
+					t.Fatalf("call #%d: error = %v, want code %q", i+1, err, c.wantCode)
+				}
+				if c.wantCode != "" {
+					continue
+				}
+				if got := resp.(*wrapperspb.StringValue).GetValue(); got != c.want {
+					t.Fatalf("call #%d: response = %s, want %s", i+1, got, c.want)
+				}
+				if replayed := len(stream.header.Get(ReplayedKey)) > 0; replayed != c.wantReplayed {
+					t.Fatalf("call #%d: replayed = %v, want %v", i+1, replayed, c.wantReplayed)
+				}
+			}
+		})
+	}
+}
+
+// errorCode 返回业务错误或gRPC状态的代码，成功时返回空字符串
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	if appErr, ok := apperror.As(err); ok {
+		return appErr.Code.String()
+	}
+	return status.Code(err).String()
+}
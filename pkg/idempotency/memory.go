@@ -0,0 +1,70 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	record    Record
+	expiresAt time.Time
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+	now     func() time.Time
+}
+
+// NewMemoryStore 创建进程内存储，只在单实例内有效
+func NewMemoryStore() Store {
+	return &memoryStore{
+		entries: make(map[string]*memoryEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStore) Reserve(ctx context.Context, key, fingerprint string, lockTimeout time.Duration) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.purge(now)
+
+	if entry, ok := s.entries[key]; ok {
+		record := entry.record
+		return &record, false, nil
+	}
+
+	s.entries[key] = &memoryEntry{
+		record:    Record{Fingerprint: fingerprint},
+		expiresAt: now.Add(lockTimeout),
+	}
+	return nil, true, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = &memoryEntry{record: *record, expiresAt: s.now().Add(ttl)}
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// purge 删除过期的记录
+func (s *memoryStore) purge(now time.Time) {
+	for key, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
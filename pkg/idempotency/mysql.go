@@ -0,0 +1,111 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlErrDuplicateEntry MySQL唯一约束冲突的错误码
+const mysqlErrDuplicateEntry = 1062
+
+// purgeInterval 清理过期记录的最小间隔
+const purgeInterval = time.Minute
+
+type mysqlStore struct {
+	db  *sql.DB
+	now func() time.Time
+
+	mu         sync.Mutex
+	lastPurged time.Time
+}
+
+// NewMySQLStore 创建基于MySQL的存储，使用 idempotency_keys 表
+func NewMySQLStore(db *sql.DB) Store {
+	return &mysqlStore{db: db, now: time.Now}
+}
+
+func (s *mysqlStore) Reserve(ctx context.Context, key, fingerprint string, lockTimeout time.Duration) (*Record, bool, error) {
+	now := s.now().UTC()
+	s.purge(ctx, now)
+
+	// 过期的记录视为不存在
+	if _, err := s.db.ExecContext(ctx,
+		"DELETE FROM idempotency_keys WHERE idem_key = ? AND expires_at < ?", key, now); err != nil {
+		return nil, false, fmt.Errorf("failed to delete expired idempotency key: %w", err)
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		_, err := s.db.ExecContext(ctx,
+			"INSERT INTO idempotency_keys (idem_key, fingerprint, completed, expires_at) VALUES (?, ?, 0, ?)",
+			key, fingerprint, now.Add(lockTimeout))
+		if err == nil {
+			return nil, true, nil
+		}
+
+		var mysqlErr *mysql.MySQLError
+		if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlErrDuplicateEntry {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+
+		record, err := s.get(ctx, key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return record, false, nil
+	}
+	return nil, false, fmt.Errorf("failed to reserve idempotency key: key is changing concurrently")
+}
+
+func (s *mysqlStore) Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE idempotency_keys SET completed = 1, response = ?, expires_at = ? WHERE idem_key = ?",
+		record.Response, s.now().UTC().Add(ttl), key)
+	if err != nil {
+		return fmt.Errorf("failed to save idempotency record: %w", err)
+	}
+	return nil
+}
+
+func (s *mysqlStore) Release(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE idem_key = ?", key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (s *mysqlStore) get(ctx context.Context, key string) (*Record, error) {
+	var record Record
+	err := s.db.QueryRowContext(ctx,
+		"SELECT fingerprint, completed, response FROM idempotency_keys WHERE idem_key = ?", key).
+		Scan(&record.Fingerprint, &record.Completed, &record.Response)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency record: %w", err)
+	}
+	return &record, nil
+}
+
+// purge 定期批量删除过期记录，避免表无限增长
+func (s *mysqlStore) purge(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastPurged) < purgeInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPurged = now
+	s.mu.Unlock()
+
+	// 清理失败不影响当前请求，下一个间隔会再次尝试
+	s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at < ? LIMIT 1000", now)
+}
@@ -0,0 +1,83 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore 创建基于Redis的存储，记录在集群所有实例间共享
+func NewRedisStore(client *redis.Client, prefix string) Store {
+	return &redisStore{client: client, prefix: prefix}
+}
+
+func (s *redisStore) Reserve(ctx context.Context, key, fingerprint string, lockTimeout time.Duration) (*Record, bool, error) {
+	data, err := json.Marshal(&Record{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	// 记录可能在 SETNX 与 GET 之间过期，此时重新尝试预留
+	for attempt := 0; attempt < 2; attempt++ {
+		ok, err := s.client.SetNX(ctx, s.prefix+key, data, lockTimeout).Result()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		if ok {
+			return nil, true, nil
+		}
+
+		record, err := s.get(ctx, key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return record, false, nil
+	}
+	return nil, false, fmt.Errorf("failed to reserve idempotency key: key is changing concurrently")
+}
+
+func (s *redisStore) Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+	if err := s.client.Set(ctx, s.prefix+key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save idempotency record: %w", err)
+	}
+	return nil
+}
+
+func (s *redisStore) Release(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (s *redisStore) get(ctx context.Context, key string) (*Record, error) {
+	data, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency record: %w", err)
+	}
+
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+	}
+	return &record, nil
+}
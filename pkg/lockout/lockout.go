@@ -0,0 +1,195 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// Attempts 一个键在统计窗口内的失败记录
+type Attempts struct {
+	Failures    int
+	LastFailure time.Time
+}
+
+// Limit 失败次数对应的等待时间
+// 失败次数达到 Threshold 后等待 Lockout，之前等待 BaseDelay * 2^(失败次数-1)，不超过 MaxDelay
+type Limit struct {
+	Threshold int // 0 表示不锁定
+	Lockout   time.Duration
+	BaseDelay time.Duration
+	MaxDelay  time.Duration // 0 表示等待时间不翻倍
+}
+
+// Wait 返回距离允许下一次尝试还需等待的时间
+func (l Limit) Wait(attempts Attempts, now time.Time) time.Duration {
+	if attempts.Failures == 0 {
+		return 0
+	}
+
+	delay := l.Lockout
+	if l.Threshold <= 0 || attempts.Failures < l.Threshold {
+		delay = l.delay(attempts.Failures)
+	}
+	if wait := attempts.LastFailure.Add(delay).Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// delay 返回第 failures 次失败后的等待时间：BaseDelay * 2^(failures-1)，不超过 MaxDelay
+func (l Limit) delay(failures int) time.Duration {
+	delay := l.BaseDelay
+	for i := 1; i < failures && delay < l.MaxDelay; i++ {
+		delay *= 2
+	}
+	if l.MaxDelay > 0 && delay > l.MaxDelay {
+		delay = l.MaxDelay
+	}
+	return delay
+}
+
+// Store 失败次数存储
+// 尝试在校验密码之前占用，检查等待时间和计数在一次原子操作中完成，并发的尝试不会越过限制
+type Store interface {
+	// Reserve 按 limit 需要等待时不修改记录，返回剩余的等待时间；
+	// 否则失败次数加一并记录时间，返回0。最后一次尝试 window 之后记录自动删除
+	Reserve(ctx context.Context, key string, limit Limit, window time.Duration) (Attempts, time.Duration, error)
+	// Release 撤销一次占用的尝试，失败次数减为0时删除记录
+	Release(ctx context.Context, key string) error
+	// Reset 删除键的失败记录
+	Reset(ctx context.Context, key string) error
+}
+
+// Config 登录保护配置
+type Config struct {
+	Enabled            bool          `yaml:"enabled"`
+	Store              string        `yaml:"store" validate:"omitempty,oneof=memory redis"` // memory（单实例）| redis
+	KeyPrefix          string        `yaml:"key_prefix"`
+	Window             time.Duration `yaml:"window"`                                // 失败次数的统计窗口，从最后一次失败起计算
+	MaxAccountFailures int           `yaml:"max_account_failures" validate:"min=0"` // 同一账号连续失败多少次后锁定
+	MaxIPFailures      int           `yaml:"max_ip_failures" validate:"min=0"`      // 同一IP失败多少次后锁定，0 表示不按IP限制
+	LockoutDuration    time.Duration `yaml:"lockout_duration"`                      // 锁定时长
+	BaseDelay          time.Duration `yaml:"base_delay"`                            // 账号首次失败后需要等待的时间，之后每次失败翻倍
+	MaxDelay           time.Duration `yaml:"max_delay"`                             // 等待时间上限
+}
+
+// NewStore 根据配置创建存储，store 为 redis 时需要传入Redis客户端
+func NewStore(config *Config, client *redis.Client) (Store, error) {
+	switch config.Store {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "redis":
+		if client == nil {
+			return nil, fmt.Errorf("redis client is required for redis lockout store")
+		}
+		return NewRedisStore(client, config.KeyPrefix), nil
+	default:
+		return nil, fmt.Errorf("unsupported lockout store: %s", config.Store)
+	}
+}
+
+// Guard 按账号和IP统计登录尝试次数
+// 每次尝试在校验前先按失败计数，成功后撤销；账号每次失败后需要等待的时间逐次翻倍，
+// 账号或IP的失败次数达到上限后在 LockoutDuration 内拒绝登录
+type Guard struct {
+	config  *Config
+	store   Store
+	account Limit
+	ip      Limit
+}
+
+// New 创建登录保护
+func New(config *Config, store Store) *Guard {
+	return &Guard{
+		config: config,
+		store:  store,
+		account: Limit{
+			Threshold: config.MaxAccountFailures,
+			Lockout:   config.LockoutDuration,
+			BaseDelay: config.BaseDelay,
+			MaxDelay:  config.MaxDelay,
+		},
+		ip: Limit{
+			Threshold: config.MaxIPFailures,
+			Lockout:   config.LockoutDuration,
+		},
+	}
+}
+
+// Reserve 占用一次尝试，返回账号或IP需要等待的时间，0 表示允许尝试
+// 允许时尝试已按失败计数，校验通过后需要调用 Succeed；存储不可用时放行，避免登录保护成为单点故障
+func (g *Guard) Reserve(ctx context.Context, account, ip string) time.Duration {
+	if !g.config.Enabled {
+		return 0
+	}
+
+	limitIP := ip != "" && g.config.MaxIPFailures > 0
+	if limitIP {
+		attempts, wait, err := g.store.Reserve(ctx, ipKey(ip), g.ip, g.config.Window)
+		if err != nil {
+			logger.Warnf("Login guard unavailable for ip %s: %v", ip, err)
+			limitIP = false
+		} else if wait > 0 {
+			return wait
+		} else if attempts.Failures == g.config.MaxIPFailures {
+			logger.Warnf("IP %s locked for %s after %d failed logins", ip, g.config.LockoutDuration, attempts.Failures)
+		}
+	}
+
+	attempts, wait, err := g.store.Reserve(ctx, accountKey(account), g.account, g.config.Window)
+	if err != nil {
+		logger.Warnf("Login guard unavailable for account %s: %v", account, err)
+		return 0
+	}
+	if wait > 0 {
+		// 账号拒绝的尝试不计入IP的失败次数
+		if limitIP {
+			g.release(ctx, ip)
+		}
+		return wait
+	}
+	if attempts.Failures == g.config.MaxAccountFailures {
+		logger.Warnf("Account %s locked for %s after %d failed logins", account, g.config.LockoutDuration, attempts.Failures)
+	}
+	return 0
+}
+
+// Succeed 校验通过后清除账号的失败记录，并撤销本次尝试对IP的计数
+func (g *Guard) Succeed(ctx context.Context, account, ip string) error {
+	if !g.config.Enabled {
+		return nil
+	}
+	if ip != "" && g.config.MaxIPFailures > 0 {
+		g.release(ctx, ip)
+	}
+	return g.Reset(ctx, account)
+}
+
+// Reset 清除账号的失败记录，用于管理员解锁
+// IP的失败记录不清除，避免攻击者穿插登录自己的账号来绕过IP限制
+func (g *Guard) Reset(ctx context.Context, account string) error {
+	if err := g.store.Reset(ctx, accountKey(account)); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	return nil
+}
+
+// release 撤销IP的一次尝试
+func (g *Guard) release(ctx context.Context, ip string) {
+	if err := g.store.Release(ctx, ipKey(ip)); err != nil {
+		logger.Warnf("Failed to release login attempt for ip %s: %v", ip, err)
+	}
+}
+
+func accountKey(account string) string {
+	return "account:" + account
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
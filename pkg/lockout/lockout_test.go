@@ -0,0 +1,245 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testConfig 账号失败3次锁定，IP失败5次锁定，等待时间从1秒起翻倍，最多4秒
+func testConfig() *Config {
+	return &Config{
+		Enabled:            true,
+		Window:             time.Hour,
+		MaxAccountFailures: 3,
+		MaxIPFailures:      5,
+		LockoutDuration:    time.Minute,
+		BaseDelay:          time.Second,
+		MaxDelay:           4 * time.Second,
+	}
+}
+
+func TestLimitDelay(t *testing.T) {
+	guard := New(testConfig(), NewMemoryStore())
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 1, want: time.Second},
+		{failures: 2, want: 2 * time.Second},
+		{failures: 3, want: 4 * time.Second},
+		{failures: 4, want: 4 * time.Second},
+		{failures: 60, want: 4 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d failures", tt.failures), func(t *testing.T) {
+			if got := guard.account.delay(tt.failures); got != tt.want {
+				t.Fatalf("delay(%d) = %s, want %s", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitWait(t *testing.T) {
+	guard := New(testConfig(), NewMemoryStore())
+	now := time.Now()
+	tests := []struct {
+		name     string
+		attempts Attempts
+		want     time.Duration
+	}{
+		{name: "no failures", want: 0},
+		{name: "first failure", attempts: Attempts{Failures: 1, LastFailure: now}, want: time.Second},
+		{name: "below threshold", attempts: Attempts{Failures: 2, LastFailure: now}, want: 2 * time.Second},
+		{name: "at threshold", attempts: Attempts{Failures: 3, LastFailure: now}, want: time.Minute},
+		{name: "above threshold", attempts: Attempts{Failures: 4, LastFailure: now}, want: time.Minute},
+		{name: "delay elapsed", attempts: Attempts{Failures: 2, LastFailure: now.Add(-3 * time.Second)}, want: 0},
+		{name: "lockout partly elapsed", attempts: Attempts{Failures: 3, LastFailure: now.Add(-20 * time.Second)}, want: 40 * time.Second},
+		{name: "lockout elapsed", attempts: Attempts{Failures: 3, LastFailure: now.Add(-time.Minute)}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := guard.account.Wait(tt.attempts, now); got != tt.want {
+				t.Fatalf("Wait() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuardThresholds(t *testing.T) {
+	const ip = "192.0.2.1"
+
+	// failure 一次失败的登录，等待时间按已过去计算
+	type failure struct {
+		account string
+		ip      string
+	}
+	tests := []struct {
+		name     string
+		config   func(*Config)
+		failures []failure
+		reset    string // 检查前清除此账号的失败记录
+		account  string
+		ip       string
+		want     time.Duration
+	}{
+		{
+			name:    "no failures",
+			account: "alice", ip: ip,
+			want: 0,
+		},
+		{
+			name:     "progressive delay below account threshold",
+			failures: []failure{{"alice", ip}, {"alice", ip}},
+			account:  "alice", ip: ip,
+			want: 2 * time.Second,
+		},
+		{
+			name:     "account locked at threshold",
+			failures: []failure{{"alice", ip}, {"alice", ip}, {"alice", ip}},
+			account:  "alice", ip: ip,
+			want: time.Minute,
+		},
+		{
+			name:     "failures of other accounts do not delay the account",
+			failures: []failure{{"bob", ip}, {"bob", ip}, {"bob", ip}},
+			account:  "alice", ip: "198.51.100.1",
+			want: 0,
+		},
+		{
+			name:     "ip locked across accounts",
+			failures: []failure{{"a", ip}, {"b", ip}, {"c", ip}, {"d", ip}, {"e", ip}},
+			account:  "alice", ip: ip,
+			want: time.Minute,
+		},
+		{
+			name:     "ip below threshold does not lock other accounts",
+			failures: []failure{{"a", ip}, {"b", ip}, {"c", ip}, {"d", ip}},
+			account:  "alice", ip: ip,
+			want: 0,
+		},
+		{
+			name:     "ip limit disabled",
+			config:   func(c *Config) { c.MaxIPFailures = 0 },
+			failures: []failure{{"a", ip}, {"b", ip}, {"c", ip}, {"d", ip}, {"e", ip}},
+			account:  "alice", ip: ip,
+			want: 0,
+		},
+		{
+			name:     "reset clears the account",
+			failures: []failure{{"alice", ip}, {"alice", ip}, {"alice", ip}},
+			reset:    "alice",
+			account:  "alice", ip: "198.51.100.1",
+			want: 0,
+		},
+		{
+			name:     "reset keeps ip failures",
+			failures: []failure{{"alice", ip}, {"alice", ip}, {"alice", ip}, {"bob", ip}, {"bob", ip}},
+			reset:    "alice",
+			account:  "alice", ip: ip,
+			want: time.Minute,
+		},
+		{
+			name:     "disabled guard",
+			config:   func(c *Config) { c.Enabled = false },
+			failures: []failure{{"alice", ip}, {"alice", ip}, {"alice", ip}},
+			account:  "alice", ip: ip,
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			config := testConfig()
+			if tt.config != nil {
+				tt.config(config)
+			}
+			store := NewMemoryStore()
+			guard := New(config, store)
+			for _, f := range tt.failures {
+				// 不设限制地占用尝试，相当于每次失败都在等待结束之后发生
+				for _, key := range []string{accountKey(f.account), ipKey(f.ip)} {
+					if _, _, err := store.Reserve(ctx, key, Limit{}, config.Window); err != nil {
+						t.Fatalf("Reserve() error = %v", err)
+					}
+				}
+			}
+			if tt.reset != "" {
+				if err := guard.Reset(ctx, tt.reset); err != nil {
+					t.Fatalf("Reset() error = %v", err)
+				}
+			}
+
+			// 等待时间从最后一次失败起计算，允许执行测试本身花费的时间
+			got := guard.Reserve(ctx, tt.account, tt.ip)
+			if got > tt.want || got < tt.want-time.Second {
+				t.Fatalf("Reserve() = %s, want about %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuardReserve(t *testing.T) {
+	const ip = "192.0.2.1"
+	ctx := context.Background()
+	config := testConfig()
+	config.BaseDelay = 0
+	guard := New(config, NewMemoryStore())
+
+	// 成功的尝试不计入IP的失败次数
+	for i := 0; i < config.MaxIPFailures; i++ {
+		if wait := guard.Reserve(ctx, "alice", ip); wait != 0 {
+			t.Fatalf("Reserve() #%d = %s, want 0", i+1, wait)
+		}
+		if err := guard.Succeed(ctx, "alice", ip); err != nil {
+			t.Fatalf("Succeed() error = %v", err)
+		}
+	}
+
+	// 失败的尝试保持计数，达到账号上限后锁定
+	for i := 0; i < config.MaxAccountFailures; i++ {
+		if wait := guard.Reserve(ctx, "bob", ip); wait != 0 {
+			t.Fatalf("Reserve() #%d = %s, want 0", i+1, wait)
+		}
+	}
+	// 被账号拒绝的尝试不计入IP的失败次数
+	for i := 0; i < config.MaxIPFailures; i++ {
+		if wait := guard.Reserve(ctx, "bob", ip); wait <= 0 {
+			t.Fatalf("Reserve() after %d failures = %s, want lockout", config.MaxAccountFailures, wait)
+		}
+	}
+	if wait := guard.Reserve(ctx, "carol", ip); wait != 0 {
+		t.Fatalf("Reserve() = %s, want 0 after %d ip failures", wait, config.MaxAccountFailures)
+	}
+}
+
+func TestGuardReserveConcurrent(t *testing.T) {
+	ctx := context.Background()
+	config := testConfig()
+	config.BaseDelay = 0
+	config.MaxIPFailures = 0
+	guard := New(config, NewMemoryStore())
+
+	// 并发的错误尝试最多通过 MaxAccountFailures 次
+	const attempts = 50
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if guard.Reserve(ctx, "alice", "") == 0 {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := int(allowed.Load()); got != config.MaxAccountFailures {
+		t.Fatalf("%d concurrent attempts allowed, want %d", got, config.MaxAccountFailures)
+	}
+}
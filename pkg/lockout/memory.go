@@ -0,0 +1,76 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry 内存中的失败记录
+type memoryEntry struct {
+	attempts  Attempts
+	expiresAt time.Time
+}
+
+// memoryStore 进程内存储，多实例部署时各实例分别计数
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+// NewMemoryStore 创建进程内存储
+func NewMemoryStore() Store {
+	return &memoryStore{entries: make(map[string]*memoryEntry)}
+}
+
+func (s *memoryStore) Reserve(ctx context.Context, key string, limit Limit, window time.Duration) (Attempts, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.purge(now)
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &memoryEntry{}
+		s.entries[key] = entry
+	}
+	if wait := limit.Wait(entry.attempts, now); wait > 0 {
+		return entry.attempts, wait, nil
+	}
+	entry.attempts.Failures++
+	entry.attempts.LastFailure = now
+	entry.expiresAt = now.Add(window)
+	return entry.attempts, 0, nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil
+	}
+	entry.attempts.Failures--
+	if entry.attempts.Failures <= 0 {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+func (s *memoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// purge 清理已过期的记录
+func (s *memoryStore) purge(now time.Time) {
+	for key, entry := range s.entries {
+		if !entry.expiresAt.After(now) {
+			delete(s.entries, key)
+		}
+	}
+}
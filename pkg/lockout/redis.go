@@ -0,0 +1,100 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// reserveScript 在Redis中原子地检查等待时间并占用一次尝试，等待时间的计算与 Limit.Wait 一致
+// 使用Redis服务器时间避免实例间时钟偏差，返回 {失败次数, 最后一次失败的毫秒时间戳, 需要等待的毫秒数}
+var reserveScript = redis.NewScript(`
+local threshold = tonumber(ARGV[1])
+local lockout = tonumber(ARGV[2])
+local base = tonumber(ARGV[3])
+local max = tonumber(ARGV[4])
+local window = tonumber(ARGV[5])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local data = redis.call('HMGET', KEYS[1], 'failures', 'last_failure')
+local failures = tonumber(data[1]) or 0
+local last = tonumber(data[2]) or 0
+
+if failures > 0 then
+  local delay = lockout
+  if threshold <= 0 or failures < threshold then
+    delay = base
+    local i = 1
+    while i < failures and delay < max do
+      delay = delay * 2
+      i = i + 1
+    end
+    if max > 0 and delay > max then
+      delay = max
+    end
+  end
+  local wait = last + delay - now
+  if wait > 0 then
+    return {failures, last, wait}
+  end
+end
+
+failures = redis.call('HINCRBY', KEYS[1], 'failures', 1)
+redis.call('HSET', KEYS[1], 'last_failure', now)
+redis.call('PEXPIRE', KEYS[1], window)
+return {failures, now, 0}
+`)
+
+// releaseScript 失败次数减一，减为0时删除记录
+var releaseScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return 0
+end
+if redis.call('HINCRBY', KEYS[1], 'failures', -1) <= 0 then
+  redis.call('DEL', KEYS[1])
+end
+return 1
+`)
+
+// redisStore 基于Redis的存储，失败次数在集群所有实例间共享
+type redisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore 创建基于Redis的存储
+func NewRedisStore(client *redis.Client, prefix string) Store {
+	return &redisStore{client: client, prefix: prefix}
+}
+
+func (s *redisStore) Reserve(ctx context.Context, key string, limit Limit, window time.Duration) (Attempts, time.Duration, error) {
+	result, err := reserveScript.Run(ctx, s.client, []string{s.prefix + key},
+		limit.Threshold, limit.Lockout.Milliseconds(), limit.BaseDelay.Milliseconds(),
+		limit.MaxDelay.Milliseconds(), window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Attempts{}, 0, fmt.Errorf("failed to reserve login attempt: %w", err)
+	}
+	if len(result) != 3 {
+		return Attempts{}, 0, fmt.Errorf("unexpected login attempt script result: %v", result)
+	}
+
+	attempts := Attempts{Failures: int(result[0]), LastFailure: time.UnixMilli(result[1])}
+	return attempts, time.Duration(result[2]) * time.Millisecond, nil
+}
+
+func (s *redisStore) Release(ctx context.Context, key string) error {
+	if err := releaseScript.Run(ctx, s.client, []string{s.prefix + key}).Err(); err != nil {
+		return fmt.Errorf("failed to release login attempt: %w", err)
+	}
+	return nil
+}
+
+func (s *redisStore) Reset(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"io"
+	"sync"
+)
+
+// asyncWriter 异步写入器，缓冲区满时丢弃日志而不阻塞调用方
+type asyncWriter struct {
+	out     io.Writer
+	entries chan []byte
+	done    chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// newAsyncWriter 创建异步写入器，bufferSize 为最多缓冲的日志条数
+func newAsyncWriter(out io.Writer, bufferSize int) *asyncWriter {
+	w := &asyncWriter{
+		out:     out,
+		entries: make(chan []byte, bufferSize),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write slog 每条日志调用一次 Write，写入缓冲区失败时计入丢弃数
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	// 关闭后同步写入，避免关闭过程中的日志丢失
+	if w.closed {
+		return w.out.Write(p)
+	}
+
+	entry := make([]byte, len(p))
+	copy(entry, p)
+	select {
+	case w.entries <- entry:
+	default:
+		droppedEntries.Add(1)
+	}
+	return len(p), nil
+}
+
+// Close 写出缓冲区中剩余的日志后返回
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.entries)
+	w.mu.Unlock()
+
+	<-w.done
+	return nil
+}
+
+func (w *asyncWriter) run() {
+	defer close(w.done)
+	for entry := range w.entries {
+		w.out.Write(entry)
+	}
+}
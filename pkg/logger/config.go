@@ -9,6 +9,9 @@ type Config struct {
 
 	// 文件输出配置
 	File FileConfig `yaml:"file"`
+
+	// 异步输出配置
+	Async AsyncConfig `yaml:"async"`
 }
 
 // FileConfig 文件输出配置
@@ -20,6 +23,12 @@ type FileConfig struct {
 	Compress   bool   `yaml:"compress"`                     // 是否压缩
 }
 
+// AsyncConfig 异步输出配置，启用后日志先写入缓冲区，缓冲区满时丢弃
+type AsyncConfig struct {
+	Enabled    bool `yaml:"enabled"`
+	BufferSize int  `yaml:"buffer_size" validate:"min=0"` // 缓冲的日志条数
+}
+
 // DefaultConfig 返回默认配置
 func DefaultConfig() *Config {
 	return &Config{
@@ -33,5 +42,8 @@ func DefaultConfig() *Config {
 			MaxAge:     30,
 			Compress:   true,
 		},
+		Async: AsyncConfig{
+			BufferSize: 4096,
+		},
 	}
 }
@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"context"
+	"io"
 	"sync"
 )
 
@@ -17,9 +19,23 @@ func InitGlobalLogger(config *Config) error {
 	}
 
 	mu.Lock()
+	previous := globalLogger
 	globalLogger = logger
 	mu.Unlock()
 
+	// 重新初始化时写出旧日志器缓冲区中的日志
+	if c, ok := previous.(io.Closer); ok {
+		c.Close()
+	}
+
+	return nil
+}
+
+// Close 写出全局日志器异步缓冲区中的日志，应在进程退出前调用
+func Close() error {
+	if c, ok := GetGlobalLogger().(io.Closer); ok {
+		return c.Close()
+	}
 	return nil
 }
 
@@ -92,3 +108,10 @@ func WithError(err error) Logger {
 	}
 	return nil
 }
+
+func WithContext(ctx context.Context) Logger {
+	if globalLogger != nil {
+		return globalLogger.WithContext(ctx)
+	}
+	return nil
+}
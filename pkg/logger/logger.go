@@ -1,5 +1,7 @@
 package logger
 
+import "context"
+
 // Level 日志级别
 type Level int
 
@@ -59,4 +61,6 @@ type Logger interface {
 	// 带字段的日志方法
 	WithField(key string, value interface{}) Logger
 	WithError(err error) Logger
+	// WithContext 附加上下文中的 trace_id 和 span_id
+	WithContext(ctx context.Context) Logger
 }
@@ -1,11 +1,13 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
 
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -14,6 +16,7 @@ type SimpleLogger struct {
 	logger *slog.Logger
 	level  Level
 	fields map[string]interface{}
+	closer io.Closer // 异步写入器，未启用时为 nil
 }
 
 // NewLogger 创建新的日志器
@@ -37,6 +40,16 @@ func NewLogger(config *Config) (Logger, error) {
 		writer = os.Stdout
 	}
 
+	var closer io.Closer
+	if config.Async.Enabled {
+		bufferSize := config.Async.BufferSize
+		if bufferSize <= 0 {
+			bufferSize = DefaultConfig().Async.BufferSize
+		}
+		async := newAsyncWriter(writer, bufferSize)
+		writer, closer = async, async
+	}
+
 	// 设置处理器
 	var handler slog.Handler
 	if config.Format == "json" {
@@ -53,9 +66,18 @@ func NewLogger(config *Config) (Logger, error) {
 		logger: slog.New(handler),
 		level:  ParseLevel(config.Level),
 		fields: make(map[string]interface{}),
+		closer: closer,
 	}, nil
 }
 
+// Close 写出异步缓冲区中剩余的日志，未启用异步输出时直接返回
+func (l *SimpleLogger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
 // convertLevel 转换日志级别
 func convertLevel(level Level) slog.Level {
 	switch level {
@@ -110,6 +132,7 @@ func (l *SimpleLogger) WithField(key string, value interface{}) Logger {
 		logger: l.logger,
 		level:  l.level,
 		fields: make(map[string]interface{}),
+		closer: l.closer,
 	}
 	// 复制现有字段
 	for k, v := range l.fields {
@@ -124,11 +147,21 @@ func (l *SimpleLogger) WithError(err error) Logger {
 	return l.WithField("error", err.Error())
 }
 
+func (l *SimpleLogger) WithContext(ctx context.Context) Logger {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return l
+	}
+	return l.WithField("trace_id", spanCtx.TraceID().String()).
+		WithField("span_id", spanCtx.SpanID().String())
+}
+
 // log 内部日志方法
 func (l *SimpleLogger) log(level Level, msg string) {
 	if level < l.level {
 		return
 	}
+	entryCounts[level].Add(1)
 
 	// 构建属性
 	args := make([]interface{}, 0, len(l.fields)*2)
@@ -0,0 +1,23 @@
+package logger
+
+import "sync/atomic"
+
+var (
+	// entryCounts 各级别已输出的日志条数
+	entryCounts [ErrorLevel + 1]atomic.Uint64
+	// droppedEntries 异步写入时因缓冲区已满丢弃的日志条数
+	droppedEntries atomic.Uint64
+)
+
+// EntryCount 返回进程启动以来指定级别输出的日志条数，低于日志级别被过滤的日志不计入
+func EntryCount(level Level) uint64 {
+	if level < DebugLevel || level > ErrorLevel {
+		return 0
+	}
+	return entryCounts[level].Load()
+}
+
+// DroppedCount 返回进程启动以来异步写入丢弃的日志条数
+func DroppedCount() uint64 {
+	return droppedEntries.Load()
+}
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// unsafeFileChars 收件人地址中不能出现在文件名里的字符
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9@._-]`)
+
+// fileMailer 将邮件写入目录，每封邮件一个 .eml 文件，用于本地开发和测试
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer 创建写入目录的邮件发送器，目录不存在时自动创建
+func NewFileMailer(dir, from string) (Mailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &fileMailer{dir: dir, from: from}, nil
+}
+
+// Send 写入邮件文件，文件名包含时间和收件人
+func (m *fileMailer) Send(ctx context.Context, msg *Message) error {
+	name := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102T150405.000000000"), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+	if err := os.WriteFile(filepath.Join(m.dir, name), format(m.from, msg), 0o600); err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+	return nil
+}
+
+// logMailer 将邮件内容写入日志，用于本地开发
+type logMailer struct{}
+
+// NewLogMailer 创建写入日志的邮件发送器
+func NewLogMailer() Mailer {
+	return logMailer{}
+}
+
+// Send 以 Info 级别记录邮件
+func (logMailer) Send(ctx context.Context, msg *Message) error {
+	logger.Infof("mail to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,66 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Message 待发送的邮件，正文为纯文本
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer 邮件发送接口
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Config 邮件发送配置
+type Config struct {
+	Driver string     `yaml:"driver" validate:"omitempty,oneof=smtp file log"` // smtp: 真实发送, file: 写入目录, log: 写入日志（开发环境）
+	From   string     `yaml:"from"`                                            // 发件人，如 MovieInfo <noreply@movieinfo.com>
+	SMTP   SMTPConfig `yaml:"smtp"`
+	Dir    string     `yaml:"dir"` // file 驱动的输出目录
+}
+
+// SMTPConfig SMTP服务器配置
+type SMTPConfig struct {
+	Host     string        `yaml:"host"`
+	Port     int           `yaml:"port"`
+	Username string        `yaml:"username"`
+	Password string        `yaml:"password"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Driver: "log",
+		From:   "MovieInfo <noreply@movieinfo.local>",
+		SMTP: SMTPConfig{
+			Port:    587,
+			Timeout: 10 * time.Second,
+		},
+		Dir: "tmp/mail",
+	}
+}
+
+// New 根据配置创建邮件发送器
+func New(config *Config) (Mailer, error) {
+	switch config.Driver {
+	case "", "log":
+		return NewLogMailer(), nil
+	case "file":
+		return NewFileMailer(config.Dir, config.From)
+	case "smtp":
+		if config.SMTP.Host == "" {
+			return nil, fmt.Errorf("smtp host is required for smtp mailer")
+		}
+		return NewSMTPMailer(&config.SMTP, config.From), nil
+	default:
+		return nil, fmt.Errorf("unsupported mailer driver: %s", config.Driver)
+	}
+}
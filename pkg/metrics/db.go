@@ -0,0 +1,16 @@
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// RegisterDB 注册数据库连接池指标（go_sql_* 系列，按 db_name 区分），应在打开数据库后调用一次
+func RegisterDB(name string, db *sql.DB) error {
+	if err := Registry.Register(collectors.NewDBStatsCollector(db, name)); err != nil {
+		return fmt.Errorf("failed to register db metrics for %s: %w", name, err)
+	}
+	return nil
+}
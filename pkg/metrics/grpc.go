@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	serverStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_started_total",
+		Help: "Total number of RPCs started on the server.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+
+	serverHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+
+	serverHandling = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Histogram of response latency of RPCs handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+
+	clientHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "Total number of RPCs completed by the client, regardless of success or failure.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+
+	clientHandling = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "Histogram of response latency of RPCs made by the client, including retries.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+)
+
+func init() {
+	Registry.MustRegister(serverStarted, serverHandled, serverHandling, clientHandled, clientHandling)
+}
+
+// UnaryServerInterceptor 返回记录一元调用次数与耗时的服务端拦截器
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		service, method := splitMethod(info.FullMethod)
+		serverStarted.WithLabelValues("unary", service, method).Inc()
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeServer("unary", service, method, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor 返回记录流式调用次数与耗时的服务端拦截器，耗时为整个流的持续时间
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		service, method := splitMethod(info.FullMethod)
+		rpcType := streamType(info.IsClientStream, info.IsServerStream)
+		serverStarted.WithLabelValues(rpcType, service, method).Inc()
+
+		start := time.Now()
+		err := handler(srv, ss)
+		observeServer(rpcType, service, method, start, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor 返回记录一元调用次数与耗时的客户端拦截器
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, fullMethod string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service, method := splitMethod(fullMethod)
+
+		start := time.Now()
+		err := invoker(ctx, fullMethod, req, reply, cc, opts...)
+		clientHandled.WithLabelValues("unary", service, method, status.Code(err).String()).Inc()
+		clientHandling.WithLabelValues("unary", service, method).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// StreamClientInterceptor 返回记录流式调用建立结果的客户端拦截器
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, fullMethod string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		service, method := splitMethod(fullMethod)
+		rpcType := streamType(desc.ClientStreams, desc.ServerStreams)
+
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, fullMethod, opts...)
+		clientHandled.WithLabelValues(rpcType, service, method, status.Code(err).String()).Inc()
+		clientHandling.WithLabelValues(rpcType, service, method).Observe(time.Since(start).Seconds())
+		return stream, err
+	}
+}
+
+func observeServer(rpcType, service, method string, start time.Time, err error) {
+	serverHandled.WithLabelValues(rpcType, service, method, status.Code(err).String()).Inc()
+	serverHandling.WithLabelValues(rpcType, service, method).Observe(time.Since(start).Seconds())
+}
+
+// splitMethod 将 /package.Service/Method 拆分为服务名与方法名
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
+func streamType(clientStream, serverStream bool) string {
+	switch {
+	case clientStream && serverStream:
+		return "bidi_stream"
+	case clientStream:
+		return "client_stream"
+	case serverStream:
+		return "server_stream"
+	default:
+		return "unary"
+	}
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests by handler, method and status code.",
+	}, []string{"handler", "method", "code"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Histogram of HTTP request latency by handler and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"handler", "method"})
+)
+
+func init() {
+	Registry.MustRegister(httpRequests, httpDuration)
+}
+
+// HTTPMiddleware 记录HTTP请求次数与耗时，name 用于区分处理器，如 web、gateway
+func HTTPMiddleware(name string, next http.Handler) http.Handler {
+	labels := prometheus.Labels{"handler": name}
+	return promhttp.InstrumentHandlerCounter(httpRequests.MustCurryWith(labels),
+		promhttp.InstrumentHandlerDuration(httpDuration.MustCurryWith(labels), next))
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry 进程内所有指标的注册表
+var Registry = prometheus.NewRegistry()
+
+// Config 指标配置
+type Config struct {
+	Enabled bool   `yaml:"enabled"`
+	Addr    string `yaml:"addr"` // 指标服务监听地址，如 :9100
+	Path    string `yaml:"path"` // 指标路径，默认 /metrics
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Addr: ":9100",
+		Path: "/metrics",
+	}
+}
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		newLoggerCollector(),
+		newConfigCollector(),
+	)
+}
+
+// Handler 返回以Prometheus文本格式输出所有指标的HTTP处理器
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// NewServer 创建指标HTTP服务，由调用方负责启动与关闭
+func NewServer(config *Config) *http.Server {
+	path := config.Path
+	if path == "" {
+		path = DefaultConfig().Path
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, Handler())
+	return &http.Server{
+		Addr:              config.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scrape 以Prometheus文本格式抓取所有指标
+func scrape(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("scrape status = %d", rec.Code)
+	}
+	return rec.Body.String()
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/movieinfo.movie.MovieService/MetricsTestGet"}
+	handlers := []grpc.UnaryHandler{
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil },
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "missing")
+		},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "missing")
+		},
+	}
+	for _, handler := range handlers {
+		interceptor(context.Background(), nil, info, handler)
+	}
+
+	body := scrape(t)
+	const labels = `grpc_method="MetricsTestGet",grpc_service="movieinfo.movie.MovieService",grpc_type="unary"`
+	for _, want := range []string{
+		`grpc_server_started_total{` + labels + `} 3`,
+		`grpc_server_handled_total{grpc_code="OK",` + labels + `} 1`,
+		`grpc_server_handled_total{grpc_code="NotFound",` + labels + `} 2`,
+		`grpc_server_handling_seconds_count{` + labels + `} 3`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %s", want)
+		}
+	}
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	handler := HTTPMiddleware("metrics_test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+
+	body := scrape(t)
+	for _, want := range []string{
+		`http_requests_total{code="418",handler="metrics_test",method="post"} 1`,
+		`http_request_duration_seconds_count{handler="metrics_test",method="post"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %s", want)
+		}
+	}
+}
+
+func TestSplitMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod, service, method string
+	}{
+		{"/movieinfo.movie.MovieService/GetMovie", "movieinfo.movie.MovieService", "GetMovie"},
+		{"GetMovie", "unknown", "GetMovie"},
+	}
+	for _, tt := range tests {
+		if service, method := splitMethod(tt.fullMethod); service != tt.service || method != tt.method {
+			t.Errorf("splitMethod(%q) = (%q, %q), want (%q, %q)", tt.fullMethod, service, method, tt.service, tt.method)
+		}
+	}
+}
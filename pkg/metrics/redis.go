@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// cacheReadCommands 计入命中率的读取命令，返回 redis.Nil 视为未命中
+var cacheReadCommands = map[string]bool{
+	"get":    true,
+	"getex":  true,
+	"getdel": true,
+	"hget":   true,
+	"mget":   true,
+	"hmget":  true,
+}
+
+var (
+	redisCommands = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "redis_commands_total",
+		Help: "Total number of Redis commands by command and result (ok or error).",
+	}, []string{"command", "result"})
+
+	redisDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "redis_command_duration_seconds",
+		Help:    "Histogram of Redis command latency, pipelines are observed as a whole.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"command"})
+
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_requests_total",
+		Help: "Total number of Redis cache reads by result (hit or miss).",
+	}, []string{"command", "result"})
+)
+
+func init() {
+	Registry.MustRegister(redisCommands, redisDuration, cacheRequests)
+}
+
+// RedisHook 返回记录Redis命令次数、耗时与缓存命中率的钩子
+func RedisHook() redis.Hook {
+	return redisHook{}
+}
+
+type redisHook struct{}
+
+func (redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		redisDuration.WithLabelValues(cmd.Name()).Observe(time.Since(start).Seconds())
+		observeRedis(cmd)
+		return err
+	}
+}
+
+func (redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		redisDuration.WithLabelValues("pipeline").Observe(time.Since(start).Seconds())
+		for _, cmd := range cmds {
+			observeRedis(cmd)
+		}
+		return err
+	}
+}
+
+func observeRedis(cmd redis.Cmder) {
+	name := cmd.Name()
+	err := cmd.Err()
+
+	result := "ok"
+	if err != nil && !errors.Is(err, redis.Nil) {
+		result = "error"
+	}
+	redisCommands.WithLabelValues(name, result).Inc()
+
+	if !cacheReadCommands[name] || result == "error" {
+		return
+	}
+	if errors.Is(err, redis.Nil) || isEmptyMultiGet(cmd) {
+		cacheRequests.WithLabelValues(name, "miss").Inc()
+	} else {
+		cacheRequests.WithLabelValues(name, "hit").Inc()
+	}
+}
+
+// isEmptyMultiGet MGET/HMGET 不返回 redis.Nil，所有值都为空时视为未命中
+func isEmptyMultiGet(cmd redis.Cmder) bool {
+	sc, ok := cmd.(*redis.SliceCmd)
+	if !ok {
+		return false
+	}
+	for _, v := range sc.Val() {
+		if v != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/3inchtime/movieinfo/pkg/config"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// loggerCollector 在采集时读取 pkg/logger 的计数
+type loggerCollector struct {
+	entries *prometheus.Desc
+	dropped *prometheus.Desc
+}
+
+func newLoggerCollector() prometheus.Collector {
+	return &loggerCollector{
+		entries: prometheus.NewDesc("log_entries_total",
+			"Total number of log entries written by level.", []string{"level"}, nil),
+		dropped: prometheus.NewDesc("log_dropped_entries_total",
+			"Total number of log entries dropped because the async buffer was full.", nil, nil),
+	}
+}
+
+func (c *loggerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.entries
+	ch <- c.dropped
+}
+
+func (c *loggerCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, level := range []logger.Level{logger.DebugLevel, logger.InfoLevel, logger.WarnLevel, logger.ErrorLevel} {
+		ch <- prometheus.MustNewConstMetric(c.entries, prometheus.CounterValue,
+			float64(logger.EntryCount(level)), strings.ToLower(level.String()))
+	}
+	ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(logger.DroppedCount()))
+}
+
+// configCollector 在采集时读取 pkg/config 的重新加载计数
+type configCollector struct {
+	reloads *prometheus.Desc
+}
+
+func newConfigCollector() prometheus.Collector {
+	return &configCollector{
+		reloads: prometheus.NewDesc("config_reloads_total",
+			"Total number of config reloads by result (success or failure).", []string{"result"}, nil),
+	}
+}
+
+func (c *configCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.reloads
+}
+
+func (c *configCollector) Collect(ch chan<- prometheus.Metric) {
+	successes, failures := config.ReloadCounts()
+	ch <- prometheus.MustNewConstMetric(c.reloads, prometheus.CounterValue, float64(successes), "success")
+	ch <- prometheus.MustNewConstMetric(c.reloads, prometheus.CounterValue, float64(failures), "failure")
+}
@@ -0,0 +1,55 @@
+package oidc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry 内存中的登录状态
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryStore 进程内存储，多实例部署时回调必须落到发起登录的实例
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+// NewMemoryStore 创建进程内存储
+func NewMemoryStore() Store {
+	return &memoryStore{entries: make(map[string]*memoryEntry)}
+}
+
+func (s *memoryStore) Save(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.purge(now)
+	s.entries[key] = &memoryEntry{value: value, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (s *memoryStore) Take(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	delete(s.entries, key)
+	if !ok || !entry.expiresAt.After(time.Now()) {
+		return nil, ErrInvalidState
+	}
+	return entry.value, nil
+}
+
+// purge 清理已过期的登录状态
+func (s *memoryStore) purge(now time.Time) {
+	for key, entry := range s.entries {
+		if !entry.expiresAt.After(now) {
+			delete(s.entries, key)
+		}
+	}
+}
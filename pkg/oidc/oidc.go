@@ -0,0 +1,247 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/oauth2"
+)
+
+var (
+	// ErrUnknownProvider 身份提供方未配置
+	ErrUnknownProvider = errors.New("unknown identity provider")
+	// ErrRedirectURI 回调地址既不是配置的地址，也不是本机回环地址
+	ErrRedirectURI = errors.New("redirect uri not allowed")
+	// ErrInvalidState 登录状态不存在、已使用或已过期
+	ErrInvalidState = errors.New("invalid or expired login state")
+	// ErrAuthentication 授权码无效或ID令牌校验失败
+	ErrAuthentication = errors.New("identity provider authentication failed")
+)
+
+// Store 登录状态存储，保存跳转到身份提供方到回调之间需要的数据
+type Store interface {
+	// Save 保存登录状态，ttl 后自动删除
+	Save(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Take 取出并删除登录状态，不存在或已过期时返回 ErrInvalidState
+	Take(ctx context.Context, key string) ([]byte, error)
+}
+
+// Config OIDC登录配置
+type Config struct {
+	Store     string                    `yaml:"store" validate:"omitempty,oneof=memory redis"` // memory（单实例）| redis
+	KeyPrefix string                    `yaml:"key_prefix"`
+	StateTTL  time.Duration             `yaml:"state_ttl"` // 跳转到身份提供方后完成登录的时限
+	Providers map[string]ProviderConfig `yaml:"providers"` // 键为身份提供方名称
+}
+
+// ProviderConfig 身份提供方配置
+type ProviderConfig struct {
+	DisplayName  string   `yaml:"display_name"`
+	Issuer       string   `yaml:"issuer"` // 发现文档位于 {issuer}/.well-known/openid-configuration
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"` // 公开客户端留空，只依靠PKCE
+	RedirectURL  string   `yaml:"redirect_url"`  // 在身份提供方登记的回调地址
+	Scopes       []string `yaml:"scopes"`
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Store:     "redis",
+		KeyPrefix: "movieinfo:oidc:",
+		StateTTL:  10 * time.Minute,
+	}
+}
+
+// defaultScopes 未配置 scopes 时请求的范围
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// NewStore 根据配置创建存储，store 为 redis 时需要传入Redis客户端
+func NewStore(config *Config, client *redis.Client) (Store, error) {
+	switch config.Store {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "redis":
+		if client == nil {
+			return nil, fmt.Errorf("redis client is required for redis oidc store")
+		}
+		return NewRedisStore(client, config.KeyPrefix), nil
+	default:
+		return nil, fmt.Errorf("unsupported oidc store: %s", config.Store)
+	}
+}
+
+// Provider 可供登录的身份提供方
+type Provider struct {
+	Name        string
+	DisplayName string
+}
+
+// AuthRequest 跳转到身份提供方的授权请求
+type AuthRequest struct {
+	URL       string        // 授权地址
+	State     string        // 回调时原样带回，客户端应校验与回调中的 state 一致
+	ExpiresIn time.Duration // 需要在此时间内完成登录
+}
+
+// Identity 身份提供方校验通过的用户身份，取自ID令牌
+type Identity struct {
+	Provider          string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	Picture           string
+}
+
+// pending 保存在存储中的登录状态
+type pending struct {
+	Provider    string `json:"provider"`
+	RedirectURI string `json:"redirect_uri"`
+	Nonce       string `json:"nonce"`
+	Verifier    string `json:"verifier"` // PKCE code_verifier，只保存在服务端
+}
+
+// RelyingParty OIDC依赖方，使用授权码流程和PKCE登录，身份提供方的端点通过发现文档获取
+type RelyingParty struct {
+	config    *Config
+	store     Store
+	providers map[string]*provider
+}
+
+// New 创建OIDC依赖方，未设置的配置项使用默认值，身份提供方在首次使用时读取发现文档
+func New(config *Config, store Store) *RelyingParty {
+	c := *config
+	if c.StateTTL <= 0 {
+		c.StateTTL = DefaultConfig().StateTTL
+	}
+	providers := make(map[string]*provider, len(c.Providers))
+	for name, pc := range c.Providers {
+		providers[name] = newProvider(name, pc)
+	}
+	return &RelyingParty{config: &c, store: store, providers: providers}
+}
+
+// Providers 返回已配置的身份提供方，按名称排序
+func (rp *RelyingParty) Providers() []Provider {
+	result := make([]Provider, 0, len(rp.providers))
+	for name, p := range rp.providers {
+		displayName := p.config.DisplayName
+		if displayName == "" {
+			displayName = name
+		}
+		result = append(result, Provider{Name: name, DisplayName: displayName})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// AuthCodeURL 生成授权地址，登录状态、nonce 和 PKCE code_verifier 保存在服务端
+// redirectURI 为空时使用配置的回调地址，本机回环地址用于命令行等原生客户端（RFC 8252）
+func (rp *RelyingParty) AuthCodeURL(ctx context.Context, name, redirectURI string) (*AuthRequest, error) {
+	p, ok := rp.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	redirectURI, err := allowedRedirect(p.config.RedirectURL, redirectURI)
+	if err != nil {
+		return nil, err
+	}
+	oauth, _, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+	data, err := json.Marshal(&pending{Provider: name, RedirectURI: redirectURI, Nonce: nonce, Verifier: verifier})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode login state: %w", err)
+	}
+	if err := rp.store.Save(ctx, stateKey(state), data, rp.config.StateTTL); err != nil {
+		return nil, err
+	}
+
+	oauth.RedirectURL = redirectURI
+	return &AuthRequest{
+		URL: oauth.AuthCodeURL(state,
+			oauth2.S256ChallengeOption(verifier),
+			oauth2.SetAuthURLParam("nonce", nonce)),
+		State:     state,
+		ExpiresIn: rp.config.StateTTL,
+	}, nil
+}
+
+// Exchange 使用回调中的 state 和授权码换取ID令牌并校验签名、签发方、受众、有效期和 nonce
+// 登录状态只能使用一次，无论成功与否都会被删除
+func (rp *RelyingParty) Exchange(ctx context.Context, state, code string) (*Identity, error) {
+	data, err := rp.store.Take(ctx, stateKey(state))
+	if err != nil {
+		return nil, err
+	}
+	var saved pending
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to decode login state: %w", err)
+	}
+	p, ok := rp.providers[saved.Provider]
+	if !ok {
+		return nil, ErrInvalidState
+	}
+	return p.exchange(ctx, &saved, code)
+}
+
+// allowedRedirect 校验客户端指定的回调地址
+func allowedRedirect(configured, requested string) (string, error) {
+	if requested == "" || requested == configured {
+		return configured, nil
+	}
+	u, err := url.Parse(requested)
+	if err != nil || u.Scheme != "http" || u.User != nil || u.Fragment != "" {
+		return "", ErrRedirectURI
+	}
+	host := u.Hostname()
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return "", ErrRedirectURI
+	}
+	return requested, nil
+}
+
+// stateKey 登录状态在存储中的键，只保存 state 的哈希
+func stateKey(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return "state:" + hex.EncodeToString(sum[:])
+}
+
+// randomString 生成用作 state 和 nonce 的随机串
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random string: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// equal 以固定时间比较字符串
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
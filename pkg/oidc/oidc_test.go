@@ -0,0 +1,233 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/3inchtime/movieinfo/pkg/oidc/oidctest"
+)
+
+const (
+	testClientID    = "movieinfo"
+	testRedirectURL = "http://localhost:8080/oidc/callback"
+)
+
+// testIdentity 模拟身份提供方自动授权的身份
+var testIdentity = oidctest.Identity{
+	Subject:           "mock-alice",
+	Email:             "alice@example.com",
+	EmailVerified:     true,
+	Name:              "Alice",
+	PreferredUsername: "alice",
+}
+
+// newTestRelyingParty 启动自动授权的模拟身份提供方，返回配置了该提供方的依赖方
+func newTestRelyingParty(t *testing.T) *RelyingParty {
+	t.Helper()
+	var mock *oidctest.Provider
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mock.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	var err error
+	mock, err = oidctest.NewProvider(&oidctest.Config{
+		Issuer:      server.URL,
+		ClientID:    testClientID,
+		Identity:    testIdentity,
+		AutoApprove: true,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	return New(&Config{Providers: map[string]ProviderConfig{
+		"mock": {Issuer: server.URL, ClientID: testClientID, RedirectURL: testRedirectURL},
+	}}, NewMemoryStore())
+}
+
+// authorize 访问授权地址，返回身份提供方跳转回调时携带的授权码
+func authorize(t *testing.T, authURL string) string {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize request error = %v", err)
+	}
+	resp.Body.Close()
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || location.Query().Get("code") == "" {
+		t.Fatalf("authorize response %d has no code in Location %q", resp.StatusCode, resp.Header.Get("Location"))
+	}
+	return location.Query().Get("code")
+}
+
+// authRequest 向模拟身份提供方发起登录
+func authRequest(t *testing.T, rp *RelyingParty) *AuthRequest {
+	t.Helper()
+	req, err := rp.AuthCodeURL(context.Background(), "mock", "")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	return req
+}
+
+// withChallenge 替换授权地址中的PKCE code_challenge
+func withChallenge(authURL, challenge string) string {
+	u, _ := url.Parse(authURL)
+	query := u.Query()
+	query.Set("code_challenge", challenge)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func TestExchange(t *testing.T) {
+	tests := []struct {
+		name string
+		// login 发起登录并返回回调中的 state 和授权码
+		login func(t *testing.T, rp *RelyingParty) (state, code string)
+		want  error
+	}{
+		{
+			name: "valid state and code",
+			login: func(t *testing.T, rp *RelyingParty) (string, string) {
+				req := authRequest(t, rp)
+				return req.State, authorize(t, req.URL)
+			},
+		},
+		{
+			name: "unknown state",
+			login: func(t *testing.T, rp *RelyingParty) (string, string) {
+				req := authRequest(t, rp)
+				return "forged-state", authorize(t, req.URL)
+			},
+			want: ErrInvalidState,
+		},
+		{
+			name: "state used twice",
+			login: func(t *testing.T, rp *RelyingParty) (string, string) {
+				req := authRequest(t, rp)
+				code := authorize(t, req.URL)
+				if _, err := rp.Exchange(context.Background(), req.State, code); err != nil {
+					t.Fatalf("first Exchange() error = %v", err)
+				}
+				return req.State, authorize(t, req.URL)
+			},
+			want: ErrInvalidState,
+		},
+		{
+			name: "code issued for a different PKCE challenge",
+			login: func(t *testing.T, rp *RelyingParty) (string, string) {
+				req := authRequest(t, rp)
+				return req.State, authorize(t, withChallenge(req.URL, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"))
+			},
+			want: ErrAuthentication,
+		},
+		{
+			name: "code from another login",
+			login: func(t *testing.T, rp *RelyingParty) (string, string) {
+				first := authRequest(t, rp)
+				second := authRequest(t, rp)
+				return first.State, authorize(t, second.URL)
+			},
+			want: ErrAuthentication,
+		},
+		{
+			name: "invalid code",
+			login: func(t *testing.T, rp *RelyingParty) (string, string) {
+				return authRequest(t, rp).State, "not-a-code"
+			},
+			want: ErrAuthentication,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := newTestRelyingParty(t)
+			state, code := tt.login(t, rp)
+			identity, err := rp.Exchange(context.Background(), state, code)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Exchange() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				return
+			}
+			if identity.Provider != "mock" || identity.Subject != testIdentity.Subject ||
+				identity.Email != testIdentity.Email || !identity.EmailVerified {
+				t.Fatalf("Exchange() identity = %+v", identity)
+			}
+		})
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	rp := newTestRelyingParty(t)
+	req := authRequest(t, rp)
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		t.Fatalf("invalid auth url %q: %v", req.URL, err)
+	}
+	query := u.Query()
+
+	tests := []struct {
+		param string
+		want  string
+	}{
+		{param: "state", want: req.State},
+		{param: "client_id", want: testClientID},
+		{param: "redirect_uri", want: testRedirectURL},
+		{param: "response_type", want: "code"},
+		{param: "code_challenge_method", want: "S256"},
+	}
+	for _, tt := range tests {
+		if got := query.Get(tt.param); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.param, got, tt.want)
+		}
+	}
+	for _, param := range []string{"code_challenge", "nonce"} {
+		if query.Get(param) == "" {
+			t.Errorf("%s is missing", param)
+		}
+	}
+	if query.Get("code_verifier") != "" {
+		t.Error("code_verifier must not leave the server")
+	}
+
+	if _, err := rp.AuthCodeURL(context.Background(), "unknown", ""); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("AuthCodeURL(unknown) error = %v, want %v", err, ErrUnknownProvider)
+	}
+}
+
+func TestAllowedRedirect(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		want      string
+		wantErr   error
+	}{
+		{name: "default", requested: "", want: testRedirectURL},
+		{name: "configured", requested: testRedirectURL, want: testRedirectURL},
+		{name: "loopback ip", requested: "http://127.0.0.1:53682/callback", want: "http://127.0.0.1:53682/callback"},
+		{name: "ipv6 loopback", requested: "http://[::1]:53682/callback", want: "http://[::1]:53682/callback"},
+		{name: "localhost", requested: "http://localhost:53682/callback", want: "http://localhost:53682/callback"},
+		{name: "other host", requested: "http://evil.example.com/callback", wantErr: ErrRedirectURI},
+		{name: "https loopback", requested: "https://127.0.0.1/callback", wantErr: ErrRedirectURI},
+		{name: "userinfo", requested: "http://evil.example.com@127.0.0.1/callback", wantErr: ErrRedirectURI},
+		{name: "fragment", requested: "http://127.0.0.1/callback#token", wantErr: ErrRedirectURI},
+		{name: "localhost suffix", requested: "http://localhost.evil.example.com/callback", wantErr: ErrRedirectURI},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := allowedRedirect(testRedirectURL, tt.requested)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Fatalf("allowedRedirect(%q) = (%q, %v), want (%q, %v)", tt.requested, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
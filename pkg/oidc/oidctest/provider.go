@@ -0,0 +1,344 @@
+// Package oidctest 本地模拟的OIDC身份提供方，用于在开发和测试中走通授权码登录流程
+//
+// 支持发现文档、授权码流程（要求PKCE S256）和RS256签名的ID令牌，授权页面上可以填写任意身份，
+// 不校验用户，不能用于生产环境
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Identity 模拟登录的用户身份
+type Identity struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// Config 模拟身份提供方配置
+type Config struct {
+	Issuer       string // 对外访问的地址，如 http://localhost:9400，必须与依赖方配置的 issuer 一致
+	ClientID     string
+	ClientSecret string // 为空时不校验客户端密钥
+	Identity     Identity
+	AutoApprove  bool          // 不展示授权页面，直接以 Identity 登录，便于脚本测试
+	CodeTTL      time.Duration // 授权码有效期，默认1分钟
+	TokenTTL     time.Duration // ID令牌有效期，默认1小时
+}
+
+// grant 已签发的授权码
+type grant struct {
+	identity      Identity
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+// Provider 模拟身份提供方，实现 http.Handler
+type Provider struct {
+	config *Config
+	key    *rsa.PrivateKey
+	keyID  string
+	mux    *http.ServeMux
+
+	mu     sync.Mutex
+	grants map[string]*grant
+}
+
+// NewProvider 创建模拟身份提供方，每次创建生成新的签名密钥
+func NewProvider(config *Config) (*Provider, error) {
+	c := *config
+	c.Issuer = strings.TrimSuffix(c.Issuer, "/")
+	if c.CodeTTL <= 0 {
+		c.CodeTTL = time.Minute
+	}
+	if c.TokenTTL <= 0 {
+		c.TokenTTL = time.Hour
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	keyID, err := randomString(8)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{config: &c, key: key, keyID: keyID, mux: http.NewServeMux(), grants: make(map[string]*grant)}
+	p.mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	p.mux.HandleFunc("/authorize", p.authorize)
+	p.mux.HandleFunc("/token", p.token)
+	p.mux.HandleFunc("/keys", p.keys)
+	return p, nil
+}
+
+// ServeHTTP 处理身份提供方的请求
+func (p *Provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mux.ServeHTTP(w, r)
+}
+
+// discovery 发现文档
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.config.Issuer,
+		"authorization_endpoint":                p.config.Issuer + "/authorize",
+		"token_endpoint":                        p.config.Issuer + "/token",
+		"jwks_uri":                              p.config.Issuer + "/keys",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// authorizeTemplate 授权页面，填写要模拟登录的身份
+var authorizeTemplate = template.Must(template.New("authorize").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head><meta charset="utf-8"><title>模拟身份提供方</title></head>
+<body>
+<h1>模拟身份提供方</h1>
+<p>应用 {{.ClientID}} 请求登录，填写要使用的身份：</p>
+<form method="post" action="{{.Action}}">
+<p><label>用户标识（sub） <input name="sub" value="{{.Identity.Subject}}" required></label></p>
+<p><label>邮箱 <input type="email" name="email" value="{{.Identity.Email}}"></label></p>
+<p><label><input type="checkbox" name="email_verified" value="true"{{if .Identity.EmailVerified}} checked{{end}}> 邮箱已验证</label></p>
+<p><label>姓名 <input name="name" value="{{.Identity.Name}}"></label></p>
+<p><label>用户名 <input name="preferred_username" value="{{.Identity.PreferredUsername}}"></label></p>
+<button type="submit">授权</button>
+</form>
+</body>
+</html>
+`))
+
+// authorize 授权端点，GET 展示授权页面，POST 或自动授权时签发授权码并跳转回依赖方
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI := query.Get("redirect_uri")
+	redirect, err := url.Parse(redirectURI)
+	if redirectURI == "" || err != nil || !redirect.IsAbs() {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if query.Get("client_id") != p.config.ClientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	switch {
+	case query.Get("response_type") != "code":
+		redirectError(w, r, redirect, query.Get("state"), "unsupported_response_type")
+		return
+	case query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256":
+		redirectError(w, r, redirect, query.Get("state"), "invalid_request")
+		return
+	}
+
+	identity := p.config.Identity
+	switch {
+	case r.Method == http.MethodPost:
+		identity = Identity{
+			Subject:           r.PostFormValue("sub"),
+			Email:             r.PostFormValue("email"),
+			EmailVerified:     r.PostFormValue("email_verified") == "true",
+			Name:              r.PostFormValue("name"),
+			PreferredUsername: r.PostFormValue("preferred_username"),
+		}
+		if identity.Subject == "" {
+			http.Error(w, "sub is required", http.StatusBadRequest)
+			return
+		}
+	case !p.config.AutoApprove:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		authorizeTemplate.Execute(w, map[string]interface{}{
+			"ClientID": p.config.ClientID,
+			"Action":   template.URL("/authorize?" + r.URL.RawQuery),
+			"Identity": identity,
+		})
+		return
+	}
+
+	code, err := randomString(32)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	p.mu.Lock()
+	p.grants[code] = &grant{
+		identity:      identity,
+		clientID:      p.config.ClientID,
+		redirectURI:   redirectURI,
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		expiresAt:     time.Now().Add(p.config.CodeTTL),
+	}
+	p.mu.Unlock()
+
+	params := redirect.Query()
+	params.Set("code", code)
+	if state := query.Get("state"); state != "" {
+		params.Set("state", state)
+	}
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// token 令牌端点，校验客户端、授权码、回调地址和 code_verifier 后签发ID令牌
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	if clientID != p.config.ClientID || (p.config.ClientSecret != "" && !equal(clientSecret, p.config.ClientSecret)) {
+		tokenError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+	if r.PostFormValue("grant_type") != "authorization_code" {
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	// 授权码只能使用一次
+	p.mu.Lock()
+	g, ok := p.grants[r.PostFormValue("code")]
+	delete(p.grants, r.PostFormValue("code"))
+	p.mu.Unlock()
+	if !ok || time.Now().After(g.expiresAt) || g.clientID != clientID || g.redirectURI != r.PostFormValue("redirect_uri") ||
+		!equal(s256(r.PostFormValue("code_verifier")), g.codeChallenge) {
+		tokenError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	idToken, err := p.signIDToken(g)
+	if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+	accessToken, err := randomString(32)
+	if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int64(p.config.TokenTTL / time.Second),
+		"id_token":     idToken,
+	})
+}
+
+// signIDToken 签发ID令牌
+func (p *Provider) signIDToken(g *grant) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.config.Issuer,
+		"sub":            g.identity.Subject,
+		"aud":            g.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(p.config.TokenTTL).Unix(),
+		"email":          g.identity.Email,
+		"email_verified": g.identity.EmailVerified,
+	}
+	if g.nonce != "" {
+		claims["nonce"] = g.nonce
+	}
+	if g.identity.Name != "" {
+		claims["name"] = g.identity.Name
+	}
+	if g.identity.PreferredUsername != "" {
+		claims["preferred_username"] = g.identity.PreferredUsername
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = p.keyID
+	return token.SignedString(p.key)
+}
+
+// keys 签名公钥（JWKS）
+func (p *Provider) keys(w http.ResponseWriter, r *http.Request) {
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": p.keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// redirectError 按OAuth2规范把错误带回依赖方的回调地址
+func redirectError(w http.ResponseWriter, r *http.Request, redirect *url.URL, state, code string) {
+	params := redirect.Query()
+	params.Set("error", code)
+	if state != "" {
+		params.Set("state", state)
+	}
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// tokenError 令牌端点的错误响应
+func tokenError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+// writeJSON 输出JSON响应
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// s256 计算PKCE S256 code_challenge
+func s256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomString 生成随机串
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random string: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// equal 以固定时间比较字符串
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,123 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// httpTimeout 请求身份提供方的超时时间
+const httpTimeout = 10 * time.Second
+
+// provider 一个身份提供方，发现文档读取成功后缓存，失败时下次使用再重试
+type provider struct {
+	name   string
+	config ProviderConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// newProvider 创建身份提供方
+func newProvider(name string, config ProviderConfig) *provider {
+	return &provider{name: name, config: config, client: &http.Client{Timeout: httpTimeout}}
+}
+
+// discover 读取发现文档，返回OAuth2配置的副本和ID令牌校验器
+func (p *provider) discover(ctx context.Context) (oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth == nil {
+		discovered, err := gooidc.NewProvider(gooidc.ClientContext(ctx, p.client), p.config.Issuer)
+		if err != nil {
+			return oauth2.Config{}, nil, fmt.Errorf("failed to discover identity provider %s: %w", p.name, err)
+		}
+		scopes := p.config.Scopes
+		if len(scopes) == 0 {
+			scopes = defaultScopes
+		}
+		p.oauth = &oauth2.Config{
+			ClientID:     p.config.ClientID,
+			ClientSecret: p.config.ClientSecret,
+			Endpoint:     discovered.Endpoint(),
+			Scopes:       scopes,
+		}
+		p.verifier = discovered.Verifier(&gooidc.Config{ClientID: p.config.ClientID})
+	}
+	return *p.oauth, p.verifier, nil
+}
+
+// exchange 用授权码换取令牌并校验ID令牌
+func (p *provider) exchange(ctx context.Context, saved *pending, code string) (*Identity, error) {
+	oauth, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = gooidc.ClientContext(ctx, p.client)
+
+	oauth.RedirectURL = saved.RedirectURI
+	token, err := oauth.Exchange(ctx, code, oauth2.VerifierOption(saved.Verifier))
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return nil, fmt.Errorf("%w: %v", ErrAuthentication, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code with identity provider %s: %w", p.name, err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id_token", ErrAuthentication)
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAuthentication, err)
+	}
+	if !equal(idToken.Nonce, saved.Nonce) {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrAuthentication)
+	}
+
+	var claims struct {
+		Email             string    `json:"email"`
+		EmailVerified     claimBool `json:"email_verified"`
+		Name              string    `json:"name"`
+		PreferredUsername string    `json:"preferred_username"`
+		Picture           string    `json:"picture"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAuthentication, err)
+	}
+	return &Identity{
+		Provider:          p.name,
+		Subject:           idToken.Subject,
+		Email:             claims.Email,
+		EmailVerified:     bool(claims.EmailVerified),
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+		Picture:           claims.Picture,
+	}, nil
+}
+
+// claimBool 布尔类型的声明，部分身份提供方以字符串 "true" 返回 email_verified
+type claimBool bool
+
+func (b *claimBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "true", `"true"`:
+		*b = true
+	case "false", `"false"`, "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean claim: %s", data)
+	}
+	return nil
+}
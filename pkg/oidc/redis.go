@@ -0,0 +1,40 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisStore 基于Redis的存储，登录状态在集群所有实例间共享
+type redisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore 创建基于Redis的存储
+func NewRedisStore(client *redis.Client, prefix string) Store {
+	return &redisStore{client: client, prefix: prefix}
+}
+
+func (s *redisStore) Save(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := s.client.Set(ctx, s.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save login state: %w", err)
+	}
+	return nil
+}
+
+// Take 使用 GETDEL 原子地取出并删除，同一 state 并发回调时只有一个成功
+func (s *redisStore) Take(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.GetDel(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidState
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to take login state: %w", err)
+	}
+	return value, nil
+}
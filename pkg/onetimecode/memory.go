@@ -0,0 +1,96 @@
+package onetimecode
+
+import (
+	"context"
+	"crypto/subtle"
+	"sync"
+	"time"
+)
+
+// memoryEntry 内存中的验证码记录
+type memoryEntry struct {
+	hash      string
+	attempts  int
+	expiresAt time.Time
+}
+
+// memoryStore 进程内存储，多实例部署时各实例的验证码互不可见
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+// NewMemoryStore 创建进程内存储
+func NewMemoryStore() Store {
+	return &memoryStore{entries: make(map[string]*memoryEntry)}
+}
+
+func (s *memoryStore) Save(ctx context.Context, key, hash string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.purge(now)
+	s.entries[key] = &memoryEntry{hash: hash, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (s *memoryStore) Check(ctx context.Context, key, hash string, maxAttempts int, consume bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return ErrInvalid
+	}
+	if entry.attempts >= maxAttempts {
+		return ErrTooManyAttempts
+	}
+
+	if subtle.ConstantTimeCompare([]byte(entry.hash), []byte(hash)) == 1 {
+		if consume {
+			delete(s.entries, key)
+		}
+		return nil
+	}
+
+	entry.attempts++
+	if entry.attempts >= maxAttempts {
+		return ErrTooManyAttempts
+	}
+	return ErrInvalid
+}
+
+func (s *memoryStore) Fail(ctx context.Context, key string, maxAttempts int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return ErrInvalid
+	}
+	entry.attempts++
+	if entry.attempts >= maxAttempts {
+		return ErrTooManyAttempts
+	}
+	return nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// purge 删除已过期的验证码
+func (s *memoryStore) purge(now time.Time) {
+	for key, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package onetimecode
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrInvalid 验证码错误、已过期或不存在
+	ErrInvalid = errors.New("invalid or expired code")
+	// ErrTooManyAttempts 错误次数达到上限，验证码已失效，需要重新获取
+	ErrTooManyAttempts = errors.New("too many attempts")
+)
+
+// Store 验证码存储，只保存验证码的哈希值
+type Store interface {
+	// Save 保存验证码哈希，覆盖同一键上的旧验证码并清零错误次数
+	Save(ctx context.Context, key, hash string, ttl time.Duration) error
+	// Check 校验验证码哈希，不匹配时错误次数加一，达到 maxAttempts 后验证码失效
+	// consume 为 true 时校验成功后删除验证码
+	Check(ctx context.Context, key, hash string, maxAttempts int, consume bool) error
+	// Fail 错误次数加一，用于验证码之外的校验失败，达到 maxAttempts 后验证码失效
+	Fail(ctx context.Context, key string, maxAttempts int) error
+	// Delete 删除验证码
+	Delete(ctx context.Context, key string) error
+}
+
+// Config 验证码配置
+type Config struct {
+	Store       string        `yaml:"store" validate:"omitempty,oneof=memory redis"` // memory（单实例）| redis
+	KeyPrefix   string        `yaml:"key_prefix"`
+	TTL         time.Duration `yaml:"ttl"`                            // 有效期
+	MaxAttempts int           `yaml:"max_attempts" validate:"min=0"`  // 最多允许输错的次数
+	Length      int           `yaml:"length" validate:"min=0,max=10"` // 数字位数
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Store:       "redis",
+		KeyPrefix:   "movieinfo:code:",
+		TTL:         15 * time.Minute,
+		MaxAttempts: 5,
+		Length:      6,
+	}
+}
+
+// NewStore 根据配置创建存储，store 为 redis 时需要传入Redis客户端
+func NewStore(config *Config, client *redis.Client) (Store, error) {
+	switch config.Store {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "redis":
+		if client == nil {
+			return nil, fmt.Errorf("redis client is required for redis code store")
+		}
+		return NewRedisStore(client, config.KeyPrefix), nil
+	default:
+		return nil, fmt.Errorf("unsupported code store: %s", config.Store)
+	}
+}
+
+// tokenBytes 链接令牌的随机字节数
+const tokenBytes = 32
+
+// Codes 按用途签发和校验验证码，如找回密码的数字验证码、邮箱验证链接中的令牌
+type Codes struct {
+	config *Config
+	store  Store
+}
+
+// New 创建验证码管理器，未设置的配置项使用默认值
+func New(config *Config, store Store) *Codes {
+	c := *config
+	defaults := DefaultConfig()
+	if c.TTL <= 0 {
+		c.TTL = defaults.TTL
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaults.MaxAttempts
+	}
+	if c.Length <= 0 {
+		c.Length = defaults.Length
+	}
+	return &Codes{config: &c, store: store}
+}
+
+// TTL 返回验证码有效期
+func (c *Codes) TTL() time.Duration {
+	return c.config.TTL
+}
+
+// Issue 为 subject 签发新的验证码，同一用途下旧的验证码随之失效
+func (c *Codes) Issue(ctx context.Context, purpose, subject string) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(c.config.Length)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate code: %w", err)
+	}
+	code := fmt.Sprintf("%0*d", c.config.Length, n)
+
+	if err := c.store.Save(ctx, key(purpose, subject), hash(purpose, subject, code), c.config.TTL); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// IssueToken 为 subject 签发放在链接中的随机令牌，与 Issue 共用存储和校验方式
+// 令牌足够长，不需要依赖错误次数限制防止猜测
+func (c *Codes) IssueToken(ctx context.Context, purpose, subject string) (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	if err := c.store.Save(ctx, key(purpose, subject), hash(purpose, subject, token), c.config.TTL); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Verify 校验验证码，成功后验证码仍然有效
+func (c *Codes) Verify(ctx context.Context, purpose, subject, code string) error {
+	return c.store.Check(ctx, key(purpose, subject), hash(purpose, subject, code), c.config.MaxAttempts, false)
+}
+
+// Consume 校验验证码，成功后验证码失效
+func (c *Codes) Consume(ctx context.Context, purpose, subject, code string) error {
+	return c.store.Check(ctx, key(purpose, subject), hash(purpose, subject, code), c.config.MaxAttempts, true)
+}
+
+// Fail 记录一次与验证码一起提交的其他凭据错误，如挑战令牌正确但动态码错误
+// 错误次数与 Verify 共用上限，达到上限时返回 ErrTooManyAttempts，验证码已失效
+func (c *Codes) Fail(ctx context.Context, purpose, subject string) error {
+	return c.store.Fail(ctx, key(purpose, subject), c.config.MaxAttempts)
+}
+
+// Revoke 使 subject 当前的验证码失效
+func (c *Codes) Revoke(ctx context.Context, purpose, subject string) error {
+	return c.store.Delete(ctx, key(purpose, subject))
+}
+
+// key 返回验证码在存储中的键
+func key(purpose, subject string) string {
+	return purpose + ":" + subject
+}
+
+// hash 计算验证码的哈希，加入用途和对象，同一验证码在不同场景下的哈希不同
+func hash(purpose, subject, code string) string {
+	sum := sha256.Sum256([]byte(purpose + "\x00" + subject + "\x00" + code))
+	return hex.EncodeToString(sum[:])
+}
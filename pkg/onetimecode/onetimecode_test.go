@@ -0,0 +1,227 @@
+package onetimecode
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testPurpose = "password_reset"
+	testSubject = "alice@example.com"
+)
+
+// attempt 一次校验操作及其期望结果
+type attempt struct {
+	right   bool // 使用正确的验证码
+	consume bool
+	fail    bool // 不校验验证码，记录一次其他凭据的错误
+	want    error
+}
+
+func TestCodesAttempts(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		attempts    []attempt
+	}{
+		{
+			name:        "correct code verifies repeatedly",
+			maxAttempts: 3,
+			attempts: []attempt{
+				{right: true},
+				{right: true},
+			},
+		},
+		{
+			name:        "consumed code cannot be reused",
+			maxAttempts: 3,
+			attempts: []attempt{
+				{right: true, consume: true},
+				{right: true, want: ErrInvalid},
+			},
+		},
+		{
+			name:        "wrong codes below the limit",
+			maxAttempts: 3,
+			attempts: []attempt{
+				{want: ErrInvalid},
+				{want: ErrInvalid},
+				{right: true},
+			},
+		},
+		{
+			name:        "reaching the limit invalidates the code",
+			maxAttempts: 3,
+			attempts: []attempt{
+				{want: ErrInvalid},
+				{want: ErrInvalid},
+				{want: ErrTooManyAttempts},
+				{right: true, want: ErrTooManyAttempts},
+			},
+		},
+		{
+			name:        "successful checks do not reset the counter",
+			maxAttempts: 2,
+			attempts: []attempt{
+				{want: ErrInvalid},
+				{right: true},
+				{want: ErrTooManyAttempts},
+				{right: true, consume: true, want: ErrTooManyAttempts},
+			},
+		},
+		{
+			name:        "other failures share the limit",
+			maxAttempts: 3,
+			attempts: []attempt{
+				{fail: true},
+				{want: ErrInvalid},
+				{right: true},
+				{fail: true, want: ErrTooManyAttempts},
+				{right: true, want: ErrTooManyAttempts},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			codes := New(&Config{MaxAttempts: tt.maxAttempts, Length: 6}, NewMemoryStore())
+			code, err := codes.Issue(ctx, testPurpose, testSubject)
+			if err != nil {
+				t.Fatalf("Issue() error = %v", err)
+			}
+
+			for i, a := range tt.attempts {
+				input := wrongCode(code)
+				if a.right {
+					input = code
+				}
+				check := codes.Verify
+				if a.fail {
+					check = func(ctx context.Context, purpose, subject, _ string) error {
+						return codes.Fail(ctx, purpose, subject)
+					}
+				}
+				if a.consume {
+					check = codes.Consume
+				}
+				if err := check(ctx, testPurpose, testSubject, input); !errors.Is(err, a.want) {
+					t.Fatalf("attempt %d: error = %v, want %v", i+1, err, a.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCodesIssueReplacesPreviousCode(t *testing.T) {
+	ctx := context.Background()
+	codes := New(&Config{MaxAttempts: 2}, NewMemoryStore())
+
+	first, err := codes.Issue(ctx, testPurpose, testSubject)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if err := codes.Verify(ctx, testPurpose, testSubject, wrongCode(first)); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Verify(wrong) error = %v, want %v", err, ErrInvalid)
+	}
+	second, err := codes.Issue(ctx, testPurpose, testSubject)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	if first != second {
+		if err := codes.Verify(ctx, testPurpose, testSubject, first); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("Verify(old code) error = %v, want %v", err, ErrInvalid)
+		}
+	}
+	// 新验证码的错误次数从零开始，上面的错误尝试不计入
+	if err := codes.Verify(ctx, testPurpose, testSubject, second); err != nil {
+		t.Fatalf("Verify(new code) error = %v", err)
+	}
+}
+
+func TestCodesScopedByPurposeAndSubject(t *testing.T) {
+	ctx := context.Background()
+	codes := New(&Config{}, NewMemoryStore())
+	code, err := codes.Issue(ctx, testPurpose, testSubject)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		purpose string
+		subject string
+		want    error
+	}{
+		{name: "same purpose and subject", purpose: testPurpose, subject: testSubject},
+		{name: "other subject", purpose: testPurpose, subject: "bob@example.com", want: ErrInvalid},
+		{name: "other purpose", purpose: "email_verification", subject: testSubject, want: ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := codes.Verify(ctx, tt.purpose, tt.subject, code); !errors.Is(err, tt.want) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodesExpiry(t *testing.T) {
+	ctx := context.Background()
+	codes := New(&Config{TTL: time.Millisecond}, NewMemoryStore())
+	code, err := codes.Issue(ctx, testPurpose, testSubject)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if err := codes.Verify(ctx, testPurpose, testSubject, code); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Verify() error = %v, want %v", err, ErrInvalid)
+	}
+}
+
+func TestCodesConsumeOnce(t *testing.T) {
+	ctx := context.Background()
+	codes := New(&Config{MaxAttempts: 100}, NewMemoryStore())
+	code, err := codes.Issue(ctx, testPurpose, testSubject)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	const callers = 20
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if codes.Consume(ctx, testPurpose, testSubject, code) == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Fatalf("%d concurrent Consume calls succeeded, want 1", succeeded)
+	}
+}
+
+// wrongCode 返回与 code 不同的同长度验证码
+func wrongCode(code string) string {
+	b := []byte(code)
+	if b[0] == '9' {
+		b[0] = '0'
+	} else {
+		b[0]++
+	}
+	return string(b)
+}
@@ -0,0 +1,117 @@
+package onetimecode
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// checkScript 原子地校验验证码并累加错误次数
+// 返回 1 表示匹配，0 表示不匹配或不存在，-1 表示错误次数已达上限
+var checkScript = redis.NewScript(`
+local entry = redis.call('HMGET', KEYS[1], 'hash', 'attempts')
+if not entry[1] then
+  return 0
+end
+
+local max = tonumber(ARGV[2])
+local attempts = tonumber(entry[2]) or 0
+if attempts >= max then
+  return -1
+end
+
+if entry[1] == ARGV[1] then
+  if ARGV[3] == '1' then
+    redis.call('DEL', KEYS[1])
+  end
+  return 1
+end
+
+attempts = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+if attempts >= max then
+  return -1
+end
+return 0
+`)
+
+// failScript 原子地累加错误次数
+// 返回 1 表示已记录，0 表示验证码不存在，-1 表示错误次数已达上限
+var failScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return 0
+end
+if redis.call('HINCRBY', KEYS[1], 'attempts', 1) >= tonumber(ARGV[1]) then
+  return -1
+end
+return 1
+`)
+
+// redisStore 基于Redis的存储，验证码在集群所有实例间共享
+type redisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore 创建基于Redis的存储
+func NewRedisStore(client *redis.Client, prefix string) Store {
+	return &redisStore{client: client, prefix: prefix}
+}
+
+func (s *redisStore) Save(ctx context.Context, key, hash string, ttl time.Duration) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, s.prefix+key)
+		pipe.HSet(ctx, s.prefix+key, "hash", hash, "attempts", 0)
+		pipe.PExpire(ctx, s.prefix+key, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save code: %w", err)
+	}
+	return nil
+}
+
+func (s *redisStore) Check(ctx context.Context, key, hash string, maxAttempts int, consume bool) error {
+	consumeArg := "0"
+	if consume {
+		consumeArg = "1"
+	}
+
+	result, err := checkScript.Run(ctx, s.client, []string{s.prefix + key}, hash, maxAttempts, consumeArg).Int()
+	if err != nil {
+		return fmt.Errorf("failed to check code: %w", err)
+	}
+
+	switch result {
+	case 1:
+		return nil
+	case -1:
+		return ErrTooManyAttempts
+	default:
+		return ErrInvalid
+	}
+}
+
+func (s *redisStore) Fail(ctx context.Context, key string, maxAttempts int) error {
+	result, err := failScript.Run(ctx, s.client, []string{s.prefix + key}, maxAttempts).Int()
+	if err != nil {
+		return fmt.Errorf("failed to record code failure: %w", err)
+	}
+
+	switch result {
+	case 1:
+		return nil
+	case -1:
+		return ErrTooManyAttempts
+	default:
+		return ErrInvalid
+	}
+}
+
+func (s *redisStore) Delete(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to delete code: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2Params 从 argon2id 哈希中解析出的参数
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	saltLength  uint32
+	keyLength   uint32
+}
+
+// matches 判断参数是否与配置一致
+func (p *argon2Params) matches(config HashConfig) bool {
+	return p.memory == config.Memory &&
+		p.iterations == config.Iterations &&
+		p.parallelism == config.Parallelism &&
+		p.saltLength == config.SaltLength &&
+		p.keyLength == config.KeyLength
+}
+
+// hashArgon2id 生成PHC格式的 argon2id 哈希：$argon2id$v=19$m=65536,t=3,p=2$<盐>$<哈希>
+func hashArgon2id(password string, config HashConfig) (string, error) {
+	salt := make([]byte, config.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, config.Iterations, config.Memory, config.Parallelism, config.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version,
+		config.Memory, config.Iterations, config.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// verifyArgon2id 校验 argon2id 哈希，匹配时返回哈希的参数，不匹配时返回 nil
+func verifyArgon2id(password, encoded string) (*argon2Params, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return nil, ErrUnsupportedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrUnsupportedHash
+	}
+	var params argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return nil, ErrUnsupportedHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, ErrUnsupportedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, ErrUnsupportedHash
+	}
+	params.saltLength = uint32(len(salt))
+	params.keyLength = uint32(len(key))
+
+	actual := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, params.keyLength)
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return nil, nil
+	}
+	return &params, nil
+}
@@ -0,0 +1,136 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 支持的哈希算法
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// ErrUnsupportedHash 哈希格式无法识别
+var ErrUnsupportedHash = errors.New("unsupported password hash")
+
+// Config 密码哈希与密码策略配置
+type Config struct {
+	Hash   HashConfig
+	Policy PolicyConfig
+}
+
+// HashConfig 密码哈希配置，修改参数后已有的哈希在用户下次登录时自动升级
+type HashConfig struct {
+	Algorithm   string `yaml:"algorithm" validate:"omitempty,oneof=argon2id bcrypt"` // 新哈希使用的算法
+	Memory      uint32 `yaml:"memory"`                                               // argon2id 内存开销（KiB）
+	Iterations  uint32 `yaml:"iterations"`                                           // argon2id 迭代次数
+	Parallelism uint8  `yaml:"parallelism"`                                          // argon2id 并行度
+	SaltLength  uint32 `yaml:"salt_length"`                                          // argon2id 盐长度（字节）
+	KeyLength   uint32 `yaml:"key_length"`                                           // argon2id 输出长度（字节）
+	BcryptCost  int    `yaml:"bcrypt_cost" validate:"omitempty,min=4,max=31"`        // algorithm 为 bcrypt 时的开销
+}
+
+// DefaultHashConfig 返回默认的哈希配置，argon2id 参数取 RFC 9106 推荐的低内存配置
+func DefaultHashConfig() HashConfig {
+	return HashConfig{
+		Algorithm:   AlgorithmArgon2id,
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+		BcryptCost:  12,
+	}
+}
+
+// Hasher 按配置生成密码哈希，并能校验 argon2id 和 bcrypt 两种格式的已有哈希
+type Hasher struct {
+	config HashConfig
+}
+
+// NewHasher 创建密码哈希器，未设置的参数使用默认值
+func NewHasher(hashConfig *HashConfig) (*Hasher, error) {
+	config := *hashConfig
+	defaults := DefaultHashConfig()
+	if config.Algorithm == "" {
+		config.Algorithm = defaults.Algorithm
+	}
+	if config.Memory == 0 {
+		config.Memory = defaults.Memory
+	}
+	if config.Iterations == 0 {
+		config.Iterations = defaults.Iterations
+	}
+	if config.Parallelism == 0 {
+		config.Parallelism = defaults.Parallelism
+	}
+	if config.SaltLength == 0 {
+		config.SaltLength = defaults.SaltLength
+	}
+	if config.KeyLength == 0 {
+		config.KeyLength = defaults.KeyLength
+	}
+	if config.BcryptCost == 0 {
+		config.BcryptCost = defaults.BcryptCost
+	}
+
+	switch config.Algorithm {
+	case AlgorithmArgon2id, AlgorithmBcrypt:
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm: %s", config.Algorithm)
+	}
+	if config.BcryptCost < bcrypt.MinCost || config.BcryptCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	return &Hasher{config: config}, nil
+}
+
+// Hash 使用当前配置的算法和参数生成密码哈希
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.config.Algorithm == AlgorithmBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.config.BcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash password: %w", err)
+		}
+		return string(hash), nil
+	}
+	return hashArgon2id(password, h.config)
+}
+
+// Verify 校验密码是否与哈希匹配
+// 匹配且哈希的算法或参数与当前配置不一致时 needsRehash 为 true，调用方应使用 Hash 重新生成并保存
+func (h *Hasher) Verify(password, encoded string) (ok, needsRehash bool, err error) {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		params, err := verifyArgon2id(password, encoded)
+		if err != nil || params == nil {
+			return false, false, err
+		}
+		return true, h.config.Algorithm != AlgorithmArgon2id || !params.matches(h.config), nil
+
+	case isBcrypt(encoded):
+		if err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, false, nil
+			}
+			return false, false, fmt.Errorf("failed to verify bcrypt hash: %w", err)
+		}
+		if h.config.Algorithm != AlgorithmBcrypt {
+			return true, true, nil
+		}
+		cost, err := bcrypt.Cost([]byte(encoded))
+		return true, err != nil || cost != h.config.BcryptCost, nil
+
+	default:
+		return false, false, ErrUnsupportedHash
+	}
+}
+
+// isBcrypt 判断是否为 bcrypt 哈希（$2a$、$2b$、$2y$）
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
@@ -0,0 +1,98 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testHashConfig 使用最低开销的 argon2id 参数
+func testHashConfig() HashConfig {
+	return HashConfig{Memory: 1024, Iterations: 1, Parallelism: 1}
+}
+
+func newTestHasher(t *testing.T, config HashConfig) *Hasher {
+	t.Helper()
+	hasher, err := NewHasher(&config)
+	if err != nil {
+		t.Fatalf("NewHasher() error = %v", err)
+	}
+	return hasher
+}
+
+func TestHasherVerify(t *testing.T) {
+	const secret = "correct horse battery"
+
+	current := newTestHasher(t, testHashConfig())
+	argon2Hash, err := current.Hash(secret)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(argon2Hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Fatalf("Hash() = %q, want an argon2id hash with the configured parameters", argon2Hash)
+	}
+
+	stronger := testHashConfig()
+	stronger.Iterations = 2
+	oldArgon2Hash, err := newTestHasher(t, stronger).Hash(secret)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+
+	bcryptConfig := testHashConfig()
+	bcryptConfig.Algorithm = AlgorithmBcrypt
+	bcryptConfig.BcryptCost = bcrypt.MinCost
+	bcryptHasher := newTestHasher(t, bcryptConfig)
+
+	tests := []struct {
+		name        string
+		hasher      *Hasher
+		password    string
+		hash        string
+		ok          bool
+		needsRehash bool
+		err         error
+	}{
+		{name: "argon2id current parameters", hasher: current, password: secret, hash: argon2Hash, ok: true},
+		{name: "argon2id wrong password", hasher: current, password: "wrong", hash: argon2Hash},
+		{name: "argon2id old parameters", hasher: current, password: secret, hash: oldArgon2Hash, ok: true, needsRehash: true},
+		{name: "bcrypt upgraded to argon2id", hasher: current, password: secret, hash: string(bcryptHash), ok: true, needsRehash: true},
+		{name: "bcrypt wrong password", hasher: current, password: "wrong", hash: string(bcryptHash)},
+		{name: "bcrypt with configured cost", hasher: bcryptHasher, password: secret, hash: string(bcryptHash), ok: true},
+		{name: "argon2id downgraded to bcrypt", hasher: bcryptHasher, password: secret, hash: argon2Hash, ok: true, needsRehash: true},
+		{name: "unsupported hash", hasher: current, password: secret, hash: "plain", err: ErrUnsupportedHash},
+		{name: "malformed argon2id", hasher: current, password: secret, hash: "$argon2id$v=19$m=1024$x$y", err: ErrUnsupportedHash},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, needsRehash, err := tt.hasher.Verify(tt.password, tt.hash)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.err)
+			}
+			if ok != tt.ok || needsRehash != tt.needsRehash {
+				t.Fatalf("Verify() = %v, %v, want %v, %v", ok, needsRehash, tt.ok, tt.needsRehash)
+			}
+		})
+	}
+}
+
+func TestNewHasherInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config HashConfig
+	}{
+		{name: "unknown algorithm", config: HashConfig{Algorithm: "md5"}},
+		{name: "bcrypt cost too low", config: HashConfig{Algorithm: AlgorithmBcrypt, BcryptCost: 2}},
+	}
+	for _, tt := range tests {
+		if _, err := NewHasher(&tt.config); err == nil {
+			t.Fatalf("NewHasher() with %s succeeded", tt.name)
+		}
+	}
+}
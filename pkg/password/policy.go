@@ -0,0 +1,128 @@
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PolicyConfig 密码策略配置
+type PolicyConfig struct {
+	MinLength           int    `yaml:"min_length" validate:"min=0"`                  // 最小长度（字符数）
+	MaxLength           int    `yaml:"max_length" validate:"min=0"`                  // 最大长度（字符数），0 表示不限制
+	MinCharacterClasses int    `yaml:"min_character_classes" validate:"min=0,max=4"` // 至少包含几类字符：小写字母、大写字母、数字、符号
+	BreachedList        string `yaml:"breached_list"`                                // 已泄露密码列表文件，每行一个，为空表示不检查
+}
+
+// PolicyError 密码不满足策略，Reasons 为全部不满足的规则
+type PolicyError struct {
+	Reasons []string
+}
+
+func (e *PolicyError) Error() string {
+	return "password does not meet policy: " + strings.Join(e.Reasons, "; ")
+}
+
+// Policy 密码策略
+type Policy struct {
+	config   PolicyConfig
+	breached map[string]struct{}
+}
+
+// NewPolicy 创建密码策略，配置了已泄露密码列表时从文件加载
+func NewPolicy(policyConfig *PolicyConfig) (*Policy, error) {
+	config := *policyConfig
+	if config.MaxLength > 0 && config.MaxLength < config.MinLength {
+		return nil, fmt.Errorf("password max length %d is less than min length %d", config.MaxLength, config.MinLength)
+	}
+
+	p := &Policy{config: config}
+	if config.BreachedList != "" {
+		breached, err := loadBreachedList(config.BreachedList)
+		if err != nil {
+			return nil, err
+		}
+		p.breached = breached
+	}
+	return p, nil
+}
+
+// Validate 检查密码是否满足策略，不满足时返回 *PolicyError
+func (p *Policy) Validate(password string) error {
+	var reasons []string
+
+	length := utf8.RuneCountInString(password)
+	if length < p.config.MinLength {
+		reasons = append(reasons, fmt.Sprintf("must be at least %d characters", p.config.MinLength))
+	}
+	if p.config.MaxLength > 0 && length > p.config.MaxLength {
+		reasons = append(reasons, fmt.Sprintf("must be at most %d characters", p.config.MaxLength))
+	}
+	if classes := characterClasses(password); classes < p.config.MinCharacterClasses {
+		reasons = append(reasons, fmt.Sprintf("must contain at least %d of: lowercase letters, uppercase letters, digits, symbols", p.config.MinCharacterClasses))
+	}
+	if _, ok := p.breached[strings.ToLower(password)]; ok {
+		reasons = append(reasons, "appears in a list of breached passwords")
+	}
+
+	if len(reasons) > 0 {
+		return &PolicyError{Reasons: reasons}
+	}
+	return nil
+}
+
+// BreachedCount 返回已加载的泄露密码数量
+func (p *Policy) BreachedCount() int {
+	return len(p.breached)
+}
+
+// characterClasses 统计密码包含的字符类别数
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, has := range []bool{lower, upper, digit, symbol} {
+		if has {
+			count++
+		}
+	}
+	return count
+}
+
+// loadBreachedList 加载已泄露密码列表，忽略空行和 # 开头的注释行，比较时不区分大小写
+func loadBreachedList(path string) (map[string]struct{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password list: %w", err)
+	}
+	defer file.Close()
+
+	breached := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		breached[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breached password list: %w", err)
+	}
+	return breached, nil
+}
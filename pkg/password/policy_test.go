@@ -0,0 +1,62 @@
+package password
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	list := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(list, []byte("# common passwords\n\nPassword123!\nletmein\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	policy, err := NewPolicy(&PolicyConfig{MinLength: 8, MaxLength: 16, MinCharacterClasses: 3, BreachedList: list})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	if got := policy.BreachedCount(); got != 2 {
+		t.Fatalf("BreachedCount() = %d, want 2", got)
+	}
+
+	tests := []struct {
+		password string
+		want     []string
+	}{
+		{password: "Tr0ub4dor", want: nil},
+		{password: "密码Pass12", want: nil},
+		{password: "Ab1!", want: []string{"must be at least 8 characters"}},
+		{password: "Abcdefgh1234567890", want: []string{"must be at most 16 characters"}},
+		{password: "abcdefgh", want: []string{"must contain at least 3 of: lowercase letters, uppercase letters, digits, symbols"}},
+		{password: "PASSWORD123!", want: []string{"appears in a list of breached passwords"}},
+		{password: "letmein", want: []string{
+			"must be at least 8 characters",
+			"must contain at least 3 of: lowercase letters, uppercase letters, digits, symbols",
+			"appears in a list of breached passwords",
+		}},
+	}
+	for _, tt := range tests {
+		err := policy.Validate(tt.password)
+		var got []string
+		var policyErr *PolicyError
+		if errors.As(err, &policyErr) {
+			got = policyErr.Reasons
+		} else if err != nil {
+			t.Fatalf("Validate(%q) error = %v", tt.password, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("Validate(%q) = %q, want %q", tt.password, got, tt.want)
+		}
+	}
+}
+
+func TestNewPolicyInvalidConfig(t *testing.T) {
+	if _, err := NewPolicy(&PolicyConfig{MinLength: 12, MaxLength: 8}); err == nil {
+		t.Fatal("NewPolicy() with max length below min length succeeded")
+	}
+	if _, err := NewPolicy(&PolicyConfig{BreachedList: filepath.Join(t.TempDir(), "missing.txt")}); err == nil {
+		t.Fatal("NewPolicy() with a missing breached list succeeded")
+	}
+}
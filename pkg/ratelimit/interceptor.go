@@ -0,0 +1,157 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/3inchtime/movieinfo/pkg/apikey"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// RetryAfterKey 被限流时返回的元数据键，值为建议等待的秒数
+const RetryAfterKey = "retry-after"
+
+// APIKeyHeader 调用方传递API Key的元数据键
+const APIKeyHeader = apikey.Header
+
+// Interceptor 按方法与调用方进行令牌桶限流，并按方法限制并发数
+type Interceptor struct {
+	config  *Config
+	limiter Limiter
+
+	mu        sync.Mutex
+	bulkheads map[string]chan struct{}
+}
+
+// NewInterceptor 创建限流拦截器
+func NewInterceptor(config *Config, limiter Limiter) *Interceptor {
+	return &Interceptor{
+		config:    config,
+		limiter:   limiter,
+		bulkheads: make(map[string]chan struct{}),
+	}
+}
+
+// UnaryServerInterceptor 返回一元调用限流拦截器，应放在认证拦截器之后以便识别用户
+func (i *Interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		release, err := i.acquire(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor 返回流式调用限流拦截器，每个流只计一次
+func (i *Interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		release, err := i.acquire(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return handler(srv, ss)
+	}
+}
+
+// acquire 依次检查令牌桶与并发隔离舱，成功时返回释放函数
+func (i *Interceptor) acquire(ctx context.Context, fullMethod string) (func(), error) {
+	if !i.config.Enabled {
+		return func() {}, nil
+	}
+
+	rule := i.config.lookup(fullMethod)
+
+	if rule.Rate > 0 {
+		key := fullMethod
+		if rule.Scope == ScopeCaller {
+			key += ":" + CallerKey(ctx)
+		}
+
+		allowed, wait, err := i.limiter.Allow(ctx, key, rule.Rate, rule.Burst)
+		if err != nil {
+			// 限流存储不可用时放行，避免限流组件成为单点故障
+			logger.Warnf("Rate limiter unavailable for %s: %v", fullMethod, err)
+		} else if !allowed {
+			return nil, i.reject(ctx, fullMethod, "rate limit exceeded", wait)
+		}
+	}
+
+	if rule.MaxConcurrent <= 0 {
+		return func() {}, nil
+	}
+
+	sem := i.bulkhead(fullMethod, rule.MaxConcurrent)
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	default:
+		return nil, i.reject(ctx, fullMethod, "too many concurrent requests", time.Second)
+	}
+}
+
+func (i *Interceptor) bulkhead(fullMethod string, size int) chan struct{} {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	sem, ok := i.bulkheads[fullMethod]
+	if !ok {
+		sem = make(chan struct{}, size)
+		i.bulkheads[fullMethod] = sem
+	}
+	return sem
+}
+
+// reject 设置 retry-after 元数据并返回 ResourceExhausted 错误
+func (i *Interceptor) reject(ctx context.Context, fullMethod, reason string, wait time.Duration) error {
+	seconds := int64((wait + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RetryAfterKey, strconv.FormatInt(seconds, 10))); err != nil {
+		logger.Warnf("Failed to set retry-after header for %s: %v", fullMethod, err)
+	}
+
+	return status.Errorf(codes.ResourceExhausted, "%s for %s, retry after %ds", reason, fullMethod, seconds)
+}
+
+// CallerKey 识别调用方，优先使用已认证的用户ID，其次是API Key，最后是对端IP
+func CallerKey(ctx context.Context) string {
+	if userID, ok := auth.UserIDFromContext(ctx); ok {
+		return "user:" + strconv.FormatInt(userID, 10)
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(APIKeyHeader); len(keys) > 0 && keys[0] != "" {
+			sum := sha256.Sum256([]byte(keys[0]))
+			return "key:" + hex.EncodeToString(sum[:8])
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr := p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+		return "ip:" + addr
+	}
+
+	return "anonymous"
+}
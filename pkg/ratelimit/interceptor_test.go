@@ -0,0 +1,166 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/3inchtime/movieinfo/pkg/auth"
+)
+
+const (
+	login    = "/movieinfo.user.UserService/Login"
+	getMovie = "/movieinfo.movie.MovieService/GetMovie"
+)
+
+// fakeTransportStream 记录拦截器设置的响应头
+type fakeTransportStream struct {
+	header metadata.MD
+}
+
+func (s *fakeTransportStream) Method() string { return login }
+
+func (s *fakeTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *fakeTransportStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *fakeTransportStream) SetTrailer(md metadata.MD) error { return nil }
+
+// call 以 ctx 调用一次拦截器，返回响应头中的 retry-after 和错误
+func call(ctx context.Context, interceptor grpc.UnaryServerInterceptor, method string, handler grpc.UnaryHandler) (string, error) {
+	stream := &fakeTransportStream{}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	retryAfter := ""
+	if values := stream.header.Get(RetryAfterKey); len(values) > 0 {
+		retryAfter = values[0]
+	}
+	return retryAfter, err
+}
+
+func ok(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+
+func TestInterceptorRateLimit(t *testing.T) {
+	config := &Config{
+		Enabled: true,
+		Methods: []MethodRule{
+			{Name: login, Rule: Rule{Rate: 0.1, Burst: 1}},
+			{Name: getMovie, Rule: Rule{Rate: 0.5, Burst: 1, Scope: ScopeGlobal}},
+		},
+	}
+	interceptor := NewInterceptor(config, NewMemoryLimiter()).UnaryServerInterceptor()
+	alice := auth.WithUserID(context.Background(), 1)
+	bob := auth.WithUserID(context.Background(), 2)
+
+	steps := []struct {
+		name           string
+		ctx            context.Context
+		method         string
+		want           codes.Code
+		wantRetryAfter string
+	}{
+		{name: "first call", ctx: alice, method: login, want: codes.OK},
+		{name: "limited with retry-after", ctx: alice, method: login, want: codes.ResourceExhausted, wantRetryAfter: "10"},
+		{name: "other caller has its own bucket", ctx: bob, method: login, want: codes.OK},
+		{name: "global first call", ctx: alice, method: getMovie, want: codes.OK},
+		{name: "global bucket shared by callers", ctx: bob, method: getMovie, want: codes.ResourceExhausted, wantRetryAfter: "2"},
+		{name: "unconfigured method", ctx: alice, method: "/movieinfo.movie.MovieService/ListMovies", want: codes.OK},
+	}
+	for _, step := range steps {
+		retryAfter, err := call(step.ctx, interceptor, step.method, ok)
+		if status.Code(err) != step.want || retryAfter != step.wantRetryAfter {
+			t.Fatalf("%s: got (%v, retry-after %q), want (%v, %q)", step.name, err, retryAfter, step.want, step.wantRetryAfter)
+		}
+	}
+}
+
+func TestInterceptorDisabled(t *testing.T) {
+	config := &Config{Methods: []MethodRule{{Name: login, Rule: Rule{Rate: 0.1, Burst: 1, MaxConcurrent: 1}}}}
+	interceptor := NewInterceptor(config, NewMemoryLimiter()).UnaryServerInterceptor()
+	for i := 0; i < 3; i++ {
+		if _, err := call(context.Background(), interceptor, login, ok); err != nil {
+			t.Fatalf("call #%d: %v", i+1, err)
+		}
+	}
+}
+
+func TestInterceptorBulkhead(t *testing.T) {
+	config := &Config{Enabled: true, Methods: []MethodRule{{Name: getMovie, Rule: Rule{MaxConcurrent: 2}}}}
+	interceptor := NewInterceptor(config, NewMemoryLimiter()).UnaryServerInterceptor()
+
+	// 两个请求占满隔离舱，直到 release 关闭
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	blocking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		entered <- struct{}{}
+		<-release
+		return nil, nil
+	}
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := call(context.Background(), interceptor, getMovie, blocking)
+			done <- err
+		}()
+		<-entered
+	}
+
+	retryAfter, err := call(context.Background(), interceptor, getMovie, ok)
+	if status.Code(err) != codes.ResourceExhausted || retryAfter != "1" {
+		t.Fatalf("call over the limit = (%v, retry-after %q), want ResourceExhausted with retry-after 1", err, retryAfter)
+	}
+	// 其他方法不受影响
+	if _, err := call(context.Background(), interceptor, login, ok); err != nil {
+		t.Fatalf("other method: %v", err)
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Fatalf("blocked call: %v", err)
+		}
+	}
+	if _, err := call(context.Background(), interceptor, getMovie, ok); err != nil {
+		t.Fatalf("call after release: %v", err)
+	}
+}
+
+func TestCallerKey(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 50000}
+	withPeer := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	withKey := metadata.NewIncomingContext(withPeer, metadata.Pairs(APIKeyHeader, "mik_0123456789ab_secret"))
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "user", ctx: auth.WithUserID(withKey, 2), want: "user:2"},
+		{name: "api key", ctx: withKey, want: "key:"},
+		{name: "peer address without port", ctx: withPeer, want: "ip:10.0.0.1"},
+		{name: "anonymous", ctx: context.Background(), want: "anonymous"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CallerKey(tt.ctx)
+			if tt.want == "key:" {
+				// 只使用密钥摘要，不在限流键中保存密钥
+				if len(got) != len("key:")+16 || got[:4] != "key:" {
+					t.Fatalf("CallerKey() = %q, want key digest", got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Fatalf("CallerKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
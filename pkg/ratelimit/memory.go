@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	sweepInterval = time.Minute
+	idleTTL       = 10 * time.Minute
+)
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+type memoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryLimiter 创建进程内令牌桶限流器，计数仅在当前实例内有效
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (l *memoryLimiter) Allow(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	if rate <= 0 {
+		return true, 0, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(burst), b.tokens+elapsed*rate)
+	}
+	b.lastSeen = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+
+	wait := time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	return false, wait, nil
+}
+
+// sweep 定期清理长时间未使用的令牌桶，避免调用方数量增长导致内存泄漏
+func (l *memoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 限流范围
+const (
+	ScopeCaller = "caller" // 每个调用方独立计数
+	ScopeGlobal = "global" // 方法内所有调用方共享计数
+)
+
+// Limiter 令牌桶限流器
+type Limiter interface {
+	// Allow 从 key 对应的令牌桶中取出一个令牌，被拒绝时返回建议的重试等待时间
+	Allow(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error)
+}
+
+// Rule 限流规则
+type Rule struct {
+	Rate          float64 `yaml:"rate" validate:"min=0"`  // 每秒补充的令牌数，0 表示不限流
+	Burst         int     `yaml:"burst" validate:"min=0"` // 令牌桶容量，默认与 rate 相同
+	Scope         string  `yaml:"scope" validate:"omitempty,oneof=caller global"`
+	MaxConcurrent int     `yaml:"max_concurrent" validate:"min=0"` // 单实例最大并发数，0 表示不限制
+}
+
+// MethodRule 方法级限流规则，Name 为完整方法名或服务名
+type MethodRule struct {
+	Name string `yaml:"name" validate:"required"`
+	Rule `yaml:",squash"`
+}
+
+// Config 限流配置
+type Config struct {
+	Enabled   bool         `yaml:"enabled"`
+	Store     string       `yaml:"store" validate:"omitempty,oneof=memory redis"`
+	KeyPrefix string       `yaml:"key_prefix"`
+	Default   Rule         `yaml:"default"`
+	Methods   []MethodRule `yaml:"methods"`
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Store:     "memory",
+		KeyPrefix: "movieinfo:ratelimit:",
+		Default:   Rule{Scope: ScopeCaller},
+	}
+}
+
+// NewLimiter 根据配置创建令牌桶限流器，store 为 redis 时需要传入Redis客户端
+func NewLimiter(config *Config, client *redis.Client) (Limiter, error) {
+	switch config.Store {
+	case "", "memory":
+		return NewMemoryLimiter(), nil
+	case "redis":
+		if client == nil {
+			return nil, fmt.Errorf("redis client is required for redis rate limiter")
+		}
+		return NewRedisLimiter(client, config.KeyPrefix), nil
+	default:
+		return nil, fmt.Errorf("unsupported rate limit store: %s", config.Store)
+	}
+}
+
+// lookup 查找方法对应的规则，方法级配置优先于服务级配置，均未配置时使用默认规则
+func (c *Config) lookup(fullMethod string) Rule {
+	method := strings.TrimPrefix(fullMethod, "/")
+	service := method
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		service = method[:i]
+	}
+
+	rule := MethodRule{Rule: c.Default}
+	matched := false
+	for _, m := range c.Methods {
+		name := strings.TrimPrefix(m.Name, "/")
+		if name == method {
+			return m.normalize()
+		}
+		if !matched && name == service {
+			rule = m
+			matched = true
+		}
+	}
+	return rule.normalize()
+}
+
+func (m MethodRule) normalize() Rule {
+	rule := m.Rule
+	if rule.Burst <= 0 && rule.Rate > 0 {
+		rule.Burst = int(rule.Rate)
+		if rule.Burst < 1 {
+			rule.Burst = 1
+		}
+	}
+	if rule.Scope == "" {
+		rule.Scope = ScopeCaller
+	}
+	return rule
+}
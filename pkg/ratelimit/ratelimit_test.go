@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiter(t *testing.T) {
+	now := time.Now()
+	l := NewMemoryLimiter().(*memoryLimiter)
+	l.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	steps := []struct {
+		name     string
+		advance  time.Duration
+		key      string
+		want     bool
+		wantWait time.Duration
+	}{
+		{name: "burst 1", key: "a", want: true},
+		{name: "burst 2", key: "a", want: true},
+		{name: "burst exhausted", key: "a", wantWait: 500 * time.Millisecond},
+		{name: "other key has its own bucket", key: "b", want: true},
+		{name: "partly refilled", advance: 250 * time.Millisecond, key: "a", wantWait: 250 * time.Millisecond},
+		{name: "refilled", advance: 250 * time.Millisecond, key: "a", want: true},
+		{name: "refill capped at burst", advance: time.Hour, key: "a", want: true},
+		{name: "second token after idle", key: "a", want: true},
+		{name: "exhausted again", key: "a", wantWait: 500 * time.Millisecond},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		allowed, wait, err := l.Allow(ctx, step.key, 2, 2)
+		if err != nil || allowed != step.want || wait != step.wantWait {
+			t.Fatalf("%s: Allow() = (%v, %v, %v), want (%v, %v)", step.name, allowed, wait, err, step.want, step.wantWait)
+		}
+	}
+}
+
+func TestMemoryLimiterUnlimited(t *testing.T) {
+	l := NewMemoryLimiter()
+	for i := 0; i < 100; i++ {
+		if allowed, _, _ := l.Allow(context.Background(), "a", 0, 0); !allowed {
+			t.Fatal("rate 0 must not limit")
+		}
+	}
+}
+
+func TestConfigLookup(t *testing.T) {
+	config := &Config{
+		Default: Rule{Rate: 100},
+		Methods: []MethodRule{
+			{Name: "movieinfo.user.UserService", Rule: Rule{Rate: 10, Burst: 20, MaxConcurrent: 50}},
+			{Name: "/movieinfo.user.UserService/Login", Rule: Rule{Rate: 0.5, Scope: ScopeGlobal}},
+		},
+	}
+	tests := []struct {
+		method string
+		want   Rule
+	}{
+		{method: "/movieinfo.user.UserService/Login", want: Rule{Rate: 0.5, Burst: 1, Scope: ScopeGlobal}},
+		{method: "/movieinfo.user.UserService/GetUser", want: Rule{Rate: 10, Burst: 20, Scope: ScopeCaller, MaxConcurrent: 50}},
+		{method: "/movieinfo.movie.MovieService/GetMovie", want: Rule{Rate: 100, Burst: 100, Scope: ScopeCaller}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			if got := config.lookup(tt.method); got != tt.want {
+				t.Fatalf("lookup(%s) = %+v, want %+v", tt.method, got, tt.want)
+			}
+		})
+	}
+}
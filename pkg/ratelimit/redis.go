@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript 在Redis中原子地补充并扣减令牌，使用Redis服务器时间避免实例间时钟偏差
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local data = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(data[1])
+local ts = tonumber(data[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, wait}
+`)
+
+type redisLimiter struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisLimiter 创建基于Redis的令牌桶限流器，计数在集群所有实例间共享
+func NewRedisLimiter(client *redis.Client, prefix string) Limiter {
+	return &redisLimiter{client: client, prefix: prefix}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	if rate <= 0 {
+		return true, 0, nil
+	}
+
+	result, err := tokenBucketScript.Run(ctx, l.client, []string{l.prefix + key},
+		strconv.FormatFloat(rate, 'f', -1, 64), burst).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to run rate limit script: %w", err)
+	}
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,58 @@
+package rbac
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// UnaryServerInterceptor 返回一元调用授权拦截器
+// 需要放在认证拦截器之后，调用策略中列出的方法要求登录且角色拥有对应权限，每次拒绝都会记录日志
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor 返回流式调用授权拦截器，规则与一元调用相同
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authorize 检查调用方是否拥有方法所需的权限
+func (a *Authorizer) authorize(ctx context.Context, fullMethod string) error {
+	permission := a.policy.Permission(fullMethod)
+	if permission == "" {
+		return nil
+	}
+
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		logger.Warnf("Permission denied: anonymous caller of %s requires %s", fullMethod, permission)
+		return apperror.New(apperror.Unauthenticated, "login required")
+	}
+
+	roles := auth.RolesFromContext(ctx)
+	allowed, err := a.HasPermission(ctx, roles, permission)
+	if err != nil {
+		logger.Errorf("Failed to load role permissions for %s: %v", fullMethod, err)
+		return apperror.New(apperror.InternalError, "failed to check permission")
+	}
+	if !allowed {
+		logger.Warnf("Permission denied: user %d with roles %v called %s without %s", userID, roles, fullMethod, permission)
+		return apperror.Newf(apperror.PermissionDenied, "permission %s required", permission)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package rbac
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/3inchtime/movieinfo/pkg/logger"
+)
+
+// Policy 方法到所需权限的映射
+// 键为完整方法名（/movieinfo.movie.MovieService/DeleteMovie）或服务名（movieinfo.movie.MovieService），
+// 方法级配置优先，未列出的方法不要求权限
+type Policy map[string]string
+
+// Permission 返回调用方法所需的权限，不要求权限时返回空字符串
+func (p Policy) Permission(fullMethod string) string {
+	if permission, ok := p[fullMethod]; ok {
+		return permission
+	}
+	service := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(service, "/"); i >= 0 {
+		service = service[:i]
+	}
+	return p[service]
+}
+
+// PermissionStore 角色权限存储
+type PermissionStore interface {
+	// RolePermissions 返回全部角色及其拥有的权限
+	RolePermissions(ctx context.Context) (map[string][]string, error)
+}
+
+// Authorizer 根据角色判断调用方是否拥有权限，角色权限表缓存 ttl 后重新加载
+type Authorizer struct {
+	policy Policy
+	store  PermissionStore
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	grants   map[string]map[string]bool
+	loadedAt time.Time
+}
+
+// NewAuthorizer 创建授权器
+func NewAuthorizer(policy Policy, store PermissionStore, ttl time.Duration) *Authorizer {
+	return &Authorizer{policy: policy, store: store, ttl: ttl}
+}
+
+// HasPermission 判断任一角色是否拥有权限
+func (a *Authorizer) HasPermission(ctx context.Context, roles []string, permission string) (bool, error) {
+	grants, err := a.load(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		if grants[role][permission] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// load 返回缓存的角色权限表，过期时重新加载
+// 重新加载失败时继续使用旧的权限表，从未加载成功时返回错误
+func (a *Authorizer) load(ctx context.Context) (map[string]map[string]bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.grants != nil && time.Since(a.loadedAt) < a.ttl {
+		return a.grants, nil
+	}
+
+	permissions, err := a.store.RolePermissions(ctx)
+	if err != nil {
+		if a.grants != nil {
+			logger.Warnf("Failed to reload role permissions, using cached permissions: %v", err)
+			return a.grants, nil
+		}
+		return nil, err
+	}
+
+	grants := make(map[string]map[string]bool, len(permissions))
+	for role, names := range permissions {
+		grants[role] = make(map[string]bool, len(names))
+		for _, name := range names {
+			grants[role][name] = true
+		}
+	}
+	a.grants = grants
+	a.loadedAt = time.Now()
+	return grants, nil
+}
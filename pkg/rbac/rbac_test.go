@@ -0,0 +1,132 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+	"github.com/3inchtime/movieinfo/pkg/auth"
+)
+
+const (
+	deleteMovie = "/movieinfo.movie.MovieService/DeleteMovie"
+	getMovie    = "/movieinfo.movie.MovieService/GetMovie"
+	grantRole   = "/movieinfo.user.UserService/GrantRole"
+	login       = "/movieinfo.user.UserService/Login"
+)
+
+var testPolicy = Policy{
+	"movieinfo.movie.MovieService": "movies:write",
+	getMovie:                       "",
+	grantRole:                      "roles:manage",
+}
+
+// fakeStore 返回固定的角色权限表，err 不为空时加载失败
+type fakeStore struct {
+	permissions map[string][]string
+	err         error
+	loads       int
+}
+
+func (s *fakeStore) RolePermissions(ctx context.Context) (map[string][]string, error) {
+	s.loads++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.permissions, nil
+}
+
+func TestPolicyPermission(t *testing.T) {
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{deleteMovie, "movies:write"},
+		{getMovie, ""},
+		{grantRole, "roles:manage"},
+		{login, ""},
+	}
+	for _, tt := range tests {
+		if got := testPolicy.Permission(tt.method); got != tt.want {
+			t.Fatalf("Permission(%q) = %q, want %q", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	store := &fakeStore{permissions: map[string][]string{
+		"admin":  {"movies:write", "roles:manage"},
+		"editor": {"movies:write"},
+	}}
+	interceptor := NewAuthorizer(testPolicy, store, time.Minute).UnaryServerInterceptor()
+	caller := func(userID int64, roles ...string) context.Context {
+		return auth.WithRoles(auth.WithUserID(context.Background(), userID), roles)
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   apperror.ErrorCode
+	}{
+		{name: "anonymous public method", ctx: context.Background(), method: login},
+		{name: "anonymous protected method", ctx: context.Background(), method: deleteMovie, want: apperror.Unauthenticated},
+		{name: "method overrides service", ctx: caller(2), method: getMovie},
+		{name: "role with permission", ctx: caller(2, "editor"), method: deleteMovie},
+		{name: "role without permission", ctx: caller(2, "editor"), method: grantRole, want: apperror.PermissionDenied},
+		{name: "any role grants", ctx: caller(1, "editor", "admin"), method: grantRole},
+		{name: "unknown role", ctx: caller(2, "ghost"), method: deleteMovie, want: apperror.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					called = true
+					return nil, nil
+				})
+
+			var got apperror.ErrorCode
+			if appErr, ok := apperror.As(err); ok {
+				got = appErr.Code
+			} else if err != nil {
+				t.Fatalf("interceptor() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("interceptor() = %v, want %v", got, tt.want)
+			}
+			if called != (tt.want == 0) {
+				t.Fatalf("handler called = %v, want %v", called, tt.want == 0)
+			}
+		})
+	}
+	if store.loads != 1 {
+		t.Fatalf("permissions loaded %d times, want 1 within ttl", store.loads)
+	}
+}
+
+func TestHasPermissionReload(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeStore{err: errors.New("database unavailable")}
+	a := NewAuthorizer(testPolicy, store, 0)
+
+	if _, err := a.HasPermission(ctx, []string{"admin"}, "movies:write"); err == nil {
+		t.Fatal("HasPermission() without loaded permissions succeeded")
+	}
+
+	store.err = nil
+	store.permissions = map[string][]string{"admin": {"movies:write"}}
+	if ok, err := a.HasPermission(ctx, []string{"admin"}, "movies:write"); err != nil || !ok {
+		t.Fatalf("HasPermission() = %v, %v, want true", ok, err)
+	}
+
+	// 重新加载失败时继续使用已加载的权限
+	store.err = errors.New("database unavailable")
+	if ok, err := a.HasPermission(ctx, []string{"admin"}, "movies:write"); err != nil || !ok {
+		t.Fatalf("HasPermission() with failed reload = %v, %v, want cached true", ok, err)
+	}
+}
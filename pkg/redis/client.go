@@ -0,0 +1,44 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/3inchtime/movieinfo/pkg/metrics"
+)
+
+// Config Redis连接配置
+type Config struct {
+	Host     string `yaml:"host" validate:"required"`
+	Port     int    `yaml:"port" validate:"required,min=1,max=65535"`
+	Password string `yaml:"password"`
+	Database int    `yaml:"database" validate:"min=0"`
+}
+
+// NewClient 创建Redis客户端并检查连接
+func NewClient(config *Config) (*goredis.Client, error) {
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     fmt.Sprintf("%s:%d", config.Host, config.Port),
+		Password: config.Password,
+		DB:       config.Database,
+	})
+	client.AddHook(metrics.RedisHook())
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to instrument redis tracing: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	return client, nil
+}
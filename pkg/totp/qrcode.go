@@ -0,0 +1,19 @@
+package totp
+
+import (
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// QRCodeSize 二维码图片的边长（像素）
+const QRCodeSize = 256
+
+// QRCode 将 otpauth URI 编码为 PNG 格式的二维码图片
+func QRCode(uri string) ([]byte, error) {
+	png, err := qrcode.Encode(uri, qrcode.Medium, QRCodeSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode qr code: %w", err)
+	}
+	return png, nil
+}
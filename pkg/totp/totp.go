@@ -0,0 +1,153 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// secretBytes 密钥的随机字节数，与 HMAC-SHA1 的输出长度一致（RFC 4226 推荐值）
+const secretBytes = 20
+
+// encoding 密钥使用不带填充的 Base32 编码，与主流验证器应用兼容
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Config TOTP 配置
+type Config struct {
+	Issuer string        // 验证器应用中显示的发行方
+	Digits int           // 动态码位数
+	Period time.Duration // 动态码有效周期
+	Skew   int           // 允许前后偏差的周期数，用于容忍客户端时钟误差
+}
+
+// DefaultConfig 返回默认配置，与主流验证器应用的默认值一致
+func DefaultConfig() *Config {
+	return &Config{
+		Issuer: "MovieInfo",
+		Digits: 6,
+		Period: 30 * time.Second,
+		Skew:   1,
+	}
+}
+
+// TOTP 基于时间的一次性动态码（RFC 6238，HMAC-SHA1）
+type TOTP struct {
+	config Config
+}
+
+// New 创建 TOTP，未设置的参数使用默认值
+func New(config *Config) *TOTP {
+	c := *config
+	defaults := DefaultConfig()
+	if c.Issuer == "" {
+		c.Issuer = defaults.Issuer
+	}
+	if c.Digits <= 0 {
+		c.Digits = defaults.Digits
+	}
+	if c.Period <= 0 {
+		c.Period = defaults.Period
+	}
+	if c.Skew < 0 {
+		c.Skew = 0
+	}
+	return &TOTP{config: c}
+}
+
+// GenerateSecret 生成 Base32 编码的随机密钥
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// Step 返回时间所在的周期序号
+func (t *TOTP) Step(at time.Time) int64 {
+	return at.Unix() / int64(t.config.Period/time.Second)
+}
+
+// Code 返回密钥在指定时间的动态码
+func (t *TOTP) Code(secret string, at time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return t.code(key, t.Step(at)), nil
+}
+
+// Validate 校验动态码，允许前后 Skew 个周期的偏差
+// 匹配时返回动态码所在的周期序号，调用方应记录已使用的周期，拒绝同一周期的动态码再次使用
+func (t *TOTP) Validate(secret, code string, at time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != t.config.Digits {
+		return 0, false
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := t.Step(at)
+	for i := -t.config.Skew; i <= t.config.Skew; i++ {
+		step := current + int64(i)
+		if subtle.ConstantTimeCompare([]byte(t.code(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// URI 返回验证器应用扫码添加账号使用的 otpauth URI
+func (t *TOTP) URI(account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", t.config.Issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(t.config.Digits))
+	query.Set("period", strconv.Itoa(int(t.config.Period/time.Second)))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + t.config.Issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// code 按 RFC 4226 计算周期序号对应的动态码
+func (t *TOTP) code(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < t.config.Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", t.config.Digits, value%mod)
+}
+
+// decodeSecret 解码 Base32 密钥，忽略大小写、空格和填充
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := encoding.DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid totp secret: %w", err)
+	}
+	return key, nil
+}
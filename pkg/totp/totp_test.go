@@ -0,0 +1,86 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret RFC 6238 附录B中 SHA1 测试向量的密钥 "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeRFC6238(t *testing.T) {
+	totp := New(&Config{Digits: 8, Period: 30 * time.Second})
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "94287082"},
+		{unix: 1111111109, want: "07081804"},
+		{unix: 1111111111, want: "14050471"},
+		{unix: 1234567890, want: "89005924"},
+		{unix: 2000000000, want: "69279037"},
+		{unix: 20000000000, want: "65353130"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := totp.Code(rfcSecret, time.Unix(tt.unix, 0))
+			if err != nil {
+				t.Fatalf("Code() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Code(%d) = %s, want %s", tt.unix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// 使用固定的密钥和时间，各周期的动态码互不相同
+	secret := rfcSecret
+	totp := New(&Config{Skew: 1})
+	now := time.Unix(1234567890, 0)
+	current := totp.Step(now)
+	codeAt := func(offset int) string {
+		code, err := totp.Code(secret, now.Add(time.Duration(offset)*30*time.Second))
+		if err != nil {
+			t.Fatalf("Code() error = %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", secret: secret, code: codeAt(0), wantStep: current, wantOK: true},
+		{name: "previous step within skew", secret: secret, code: codeAt(-1), wantStep: current - 1, wantOK: true},
+		{name: "next step within skew", secret: secret, code: codeAt(1), wantStep: current + 1, wantOK: true},
+		{name: "outside skew", secret: secret, code: codeAt(-2)},
+		{name: "surrounding spaces", secret: secret, code: " " + codeAt(0) + " ", wantStep: current, wantOK: true},
+		{name: "lowercase secret with spaces", secret: strings.ToLower(secret[:8] + " " + secret[8:]), code: codeAt(0), wantStep: current, wantOK: true},
+		{name: "wrong length", secret: secret, code: codeAt(0)[:5]},
+		{name: "invalid secret", secret: "not base32!", code: codeAt(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := totp.Validate(tt.secret, tt.code, now)
+			if ok != tt.wantOK || (ok && step != tt.wantStep) {
+				t.Fatalf("Validate() = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestURI(t *testing.T) {
+	totp := New(&Config{Issuer: "MovieInfo"})
+	got := totp.URI("alice", rfcSecret)
+	for _, want := range []string{"otpauth://totp/MovieInfo:alice?", "secret=" + rfcSecret, "issuer=MovieInfo", "digits=6", "period=30"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("URI() = %s, want it to contain %s", got, want)
+		}
+	}
+}
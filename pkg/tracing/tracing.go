@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName 项目内手动创建的span使用的追踪器名称
+const instrumentationName = "github.com/3inchtime/movieinfo"
+
+// Config 链路追踪配置
+type Config struct {
+	Exporter    string  `yaml:"exporter" validate:"omitempty,oneof=otlp stdout none"`
+	Endpoint    string  `yaml:"endpoint"`                            // OTLP gRPC 接收地址，如 localhost:4317
+	Insecure    bool    `yaml:"insecure"`                            // 不使用TLS连接采集器
+	SampleRatio float64 `yaml:"sample_ratio" validate:"min=0,max=1"` // 新链路的采样比例，已有父span时跟随父span
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Exporter:    "none",
+		Endpoint:    "localhost:4317",
+		Insecure:    true,
+		SampleRatio: 1,
+	}
+}
+
+// Init 初始化全局的 TracerProvider 与 W3C trace-context 传播器，返回的函数在退出前调用以导出剩余的span
+// exporter 为 none 时不记录span，但仍会透传上游的链路上下文
+func Init(ctx context.Context, config *Config, serviceName, version string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch config.Exporter {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "otlp":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter: %s", config.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", config.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer 返回项目内手动创建span使用的追踪器
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// HTTPMiddleware 为HTTP请求创建span并提取请求头中的链路上下文，name 为span的操作名，如 web、gateway
+func HTTPMiddleware(name string, next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, name)
+}
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHTTPMiddlewarePropagatesTraceContext(t *testing.T) {
+	shutdown, err := Init(context.Background(), &Config{Exporter: "none"}, "movieinfo-test", "test")
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	defer shutdown(context.Background())
+
+	var got trace.SpanContext
+	handler := HTTPMiddleware("web", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = trace.SpanContextFromContext(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/movies", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// 未导出span时仍透传上游的链路
+	if got.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("trace id = %s, want the upstream trace", got.TraceID())
+	}
+}
+
+func TestInitUnsupportedExporter(t *testing.T) {
+	if _, err := Init(context.Background(), &Config{Exporter: "zipkin"}, "movieinfo-test", "test"); err == nil {
+		t.Fatal("Init() with an unsupported exporter succeeded")
+	}
+}
@@ -0,0 +1,184 @@
+package validation
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+)
+
+// allValidator protoc-gen-validate 生成的 ValidateAll 方法，返回所有违反的规则
+type allValidator interface {
+	ValidateAll() error
+}
+
+// validator protoc-gen-validate 生成的 Validate 方法，遇到第一个违反的规则即返回
+type validator interface {
+	Validate() error
+}
+
+// fieldError protoc-gen-validate 生成的字段校验错误
+type fieldError interface {
+	error
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// multiError protoc-gen-validate 生成的多错误集合
+type multiError interface {
+	error
+	AllErrors() []error
+}
+
+// Validate 按 proto 文件中声明的 validate.rules 校验消息
+// 校验失败时返回 InvalidArgument 错误，每个违反规则的字段对应一条错误详情，字段路径使用 proto 字段名，如 page.page_size、genres[0]
+// 消息没有生成校验方法时直接通过
+func Validate(msg interface{}) error {
+	var err error
+	switch v := msg.(type) {
+	case allValidator:
+		err = v.ValidateAll()
+	case validator:
+		err = v.Validate()
+	default:
+		return nil
+	}
+	if err == nil {
+		return nil
+	}
+
+	var desc protoreflect.MessageDescriptor
+	if m, ok := msg.(proto.Message); ok {
+		desc = m.ProtoReflect().Descriptor()
+	}
+
+	appErr := apperror.New(apperror.InvalidArgument, "invalid request")
+	collect(appErr, err, "", desc)
+	return appErr
+}
+
+// collect 将校验错误展开为字段错误详情，嵌套消息的错误递归展开
+func collect(appErr *apperror.Error, err error, prefix string, desc protoreflect.MessageDescriptor) {
+	if multi, ok := err.(multiError); ok {
+		for _, e := range multi.AllErrors() {
+			collect(appErr, e, prefix, desc)
+		}
+		return
+	}
+
+	fe, ok := err.(fieldError)
+	if !ok {
+		appErr.WithField(prefix, err.Error())
+		return
+	}
+
+	name, index := splitIndex(fe.Field())
+	path, fd := resolveField(desc, name)
+	if prefix != "" {
+		path = prefix + "." + path
+	}
+	path += index
+
+	if cause := fe.Cause(); cause != nil && (isFieldError(cause) || isMultiError(cause)) {
+		var nested protoreflect.MessageDescriptor
+		if fd != nil {
+			nested = fd.Message()
+		}
+		collect(appErr, cause, path, nested)
+		return
+	}
+
+	appErr.WithField(path, fe.Reason())
+}
+
+func isFieldError(err error) bool {
+	_, ok := err.(fieldError)
+	return ok
+}
+
+func isMultiError(err error) bool {
+	_, ok := err.(multiError)
+	return ok
+}
+
+// splitIndex 拆分字段名与下标，如 Genres[0] 拆分为 Genres 与 [0]
+func splitIndex(field string) (string, string) {
+	if i := strings.IndexByte(field, '['); i >= 0 {
+		return field[:i], field[i:]
+	}
+	return field, ""
+}
+
+// resolveField 将生成代码中的Go字段名转换为 proto 字段名，找不到描述符时转换为下划线形式
+func resolveField(desc protoreflect.MessageDescriptor, goName string) (string, protoreflect.FieldDescriptor) {
+	if desc != nil {
+		fields := desc.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			if goCamelCase(string(fd.Name())) == goName {
+				return string(fd.Name()), fd
+			}
+		}
+		oneofs := desc.Oneofs()
+		for i := 0; i < oneofs.Len(); i++ {
+			od := oneofs.Get(i)
+			if goCamelCase(string(od.Name())) == goName {
+				return string(od.Name()), nil
+			}
+		}
+	}
+	return snakeCase(goName), nil
+}
+
+// goCamelCase 与 protoc-gen-go 生成Go字段名的规则一致，如 page_size 转换为 PageSize
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isASCIILower(s[i+1]):
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+// snakeCase 将Go字段名转换为下划线形式，如 PageSize 转换为 page_size
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
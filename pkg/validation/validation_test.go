@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/3inchtime/movieinfo/pkg/apperror"
+)
+
+// fieldErr 与 protoc-gen-validate 生成的字段错误结构相同
+type fieldErr struct {
+	field  string
+	reason string
+	cause  error
+}
+
+func (e fieldErr) Error() string  { return e.field + ": " + e.reason }
+func (e fieldErr) Field() string  { return e.field }
+func (e fieldErr) Reason() string { return e.reason }
+func (e fieldErr) Cause() error   { return e.cause }
+
+// multiErr 与 protoc-gen-validate 生成的多错误集合结构相同
+type multiErr []error
+
+func (m multiErr) Error() string      { return "multiple errors" }
+func (m multiErr) AllErrors() []error { return m }
+
+// fileRequest 带有生成的 ValidateAll 方法的请求，使用 FileDescriptorProto 提供嵌套的消息描述符
+type fileRequest struct {
+	*descriptorpb.FileDescriptorProto
+	err error
+}
+
+func (r *fileRequest) ValidateAll() error { return r.err }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		// want 期望的字段路径和原因，为空时期望通过
+		want map[string]string
+	}{
+		{name: "valid"},
+		{
+			name: "go field names mapped to proto names",
+			err:  multiErr{fieldErr{field: "Name", reason: "value is required"}, fieldErr{field: "Dependency[1]", reason: "value must be unique"}},
+			want: map[string]string{"name": "value is required", "dependency[1]": "value must be unique"},
+		},
+		{
+			name: "nested message errors expanded",
+			err: fieldErr{field: "MessageType[0]", reason: "embedded message failed validation",
+				cause: multiErr{fieldErr{field: "Field[1]", reason: "embedded message failed validation",
+					cause: fieldErr{field: "JsonName", reason: "value length must be at most 64 runes"}}}},
+			want: map[string]string{"message_type[0].field[1].json_name": "value length must be at most 64 runes"},
+		},
+		{
+			name: "cause that is not a field error kept on the field",
+			err:  fieldErr{field: "Package", reason: "value does not match regex", cause: errors.New("regex")},
+			want: map[string]string{"package": "value does not match regex"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(&fileRequest{FileDescriptorProto: &descriptorpb.FileDescriptorProto{}, err: tt.err})
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			appErr, ok := apperror.As(err)
+			if !ok || appErr.Code != apperror.InvalidArgument {
+				t.Fatalf("Validate() error = %v, want InvalidArgument", err)
+			}
+			got := make(map[string]string)
+			for _, detail := range appErr.Details {
+				got[detail.Field] = detail.Message
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("details = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWithoutRules(t *testing.T) {
+	if err := Validate(&descriptorpb.FileDescriptorProto{}); err != nil {
+		t.Fatalf("Validate() of a message without rules = %v", err)
+	}
+}
+
+func TestGoCamelCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"page_size":    "PageSize",
+		"release_date": "ReleaseDate",
+		"ids":          "Ids",
+		"oidc_2fa":     "Oidc_2Fa",
+		"_private":     "XPrivate",
+	} {
+		if got := goCamelCase(name); got != want {
+			t.Errorf("goCamelCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+	invalid := &fileRequest{FileDescriptorProto: &descriptorpb.FileDescriptorProto{}, err: fieldErr{field: "Name", reason: "value is required"}}
+
+	_, err := interceptor(context.Background(), invalid, &grpc.UnaryServerInfo{}, handler)
+	if !apperror.Is(err, apperror.InvalidArgument) || called {
+		t.Fatalf("invalid request: error = %v, handler called = %v", err, called)
+	}
+	if _, err := interceptor(context.Background(), &fileRequest{FileDescriptorProto: &descriptorpb.FileDescriptorProto{}}, &grpc.UnaryServerInfo{}, handler); err != nil || !called {
+		t.Fatalf("valid request: error = %v, handler called = %v", err, called)
+	}
+}
@@ -0,0 +1,607 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: common/common.proto
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on PageRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PageRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PageRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PageRequestMultiError, or
+// nil if none found.
+func (m *PageRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PageRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetPage() < 0 {
+		err := PageRequestValidationError{
+			field:  "Page",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetPageSize(); val < 0 || val > 100 {
+		err := PageRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return PageRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PageRequestMultiError is an error wrapping multiple validation errors
+// returned by PageRequest.ValidateAll() if the designated constraints aren't met.
+type PageRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PageRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PageRequestMultiError) AllErrors() []error { return m }
+
+// PageRequestValidationError is the validation error returned by
+// PageRequest.Validate if the designated constraints aren't met.
+type PageRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PageRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PageRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PageRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PageRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PageRequestValidationError) ErrorName() string { return "PageRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PageRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPageRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PageRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PageRequestValidationError{}
+
+// Validate checks the field values on PageResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PageResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PageResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PageResponseMultiError, or
+// nil if none found.
+func (m *PageResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PageResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Page
+
+	// no validation rules for PageSize
+
+	// no validation rules for Total
+
+	// no validation rules for TotalPages
+
+	if len(errors) > 0 {
+		return PageResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PageResponseMultiError is an error wrapping multiple validation errors
+// returned by PageResponse.ValidateAll() if the designated constraints aren't met.
+type PageResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PageResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PageResponseMultiError) AllErrors() []error { return m }
+
+// PageResponseValidationError is the validation error returned by
+// PageResponse.Validate if the designated constraints aren't met.
+type PageResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PageResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PageResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PageResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PageResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PageResponseValidationError) ErrorName() string { return "PageResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PageResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPageResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PageResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PageResponseValidationError{}
+
+// Validate checks the field values on CommonResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CommonResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CommonResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CommonResponseMultiError,
+// or nil if none found.
+func (m *CommonResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CommonResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	// no validation rules for Message
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CommonResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CommonResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CommonResponseValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CommonResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CommonResponseMultiError is an error wrapping multiple validation errors
+// returned by CommonResponse.ValidateAll() if the designated constraints
+// aren't met.
+type CommonResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CommonResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CommonResponseMultiError) AllErrors() []error { return m }
+
+// CommonResponseValidationError is the validation error returned by
+// CommonResponse.Validate if the designated constraints aren't met.
+type CommonResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CommonResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CommonResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CommonResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CommonResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CommonResponseValidationError) ErrorName() string { return "CommonResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CommonResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCommonResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CommonResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CommonResponseValidationError{}
+
+// Validate checks the field values on HealthCheckRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *HealthCheckRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HealthCheckRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// HealthCheckRequestMultiError, or nil if none found.
+func (m *HealthCheckRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HealthCheckRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Service
+
+	if len(errors) > 0 {
+		return HealthCheckRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// HealthCheckRequestMultiError is an error wrapping multiple validation errors
+// returned by HealthCheckRequest.ValidateAll() if the designated constraints
+// aren't met.
+type HealthCheckRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HealthCheckRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HealthCheckRequestMultiError) AllErrors() []error { return m }
+
+// HealthCheckRequestValidationError is the validation error returned by
+// HealthCheckRequest.Validate if the designated constraints aren't met.
+type HealthCheckRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HealthCheckRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HealthCheckRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HealthCheckRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HealthCheckRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HealthCheckRequestValidationError) ErrorName() string {
+	return "HealthCheckRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e HealthCheckRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHealthCheckRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HealthCheckRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HealthCheckRequestValidationError{}
+
+// Validate checks the field values on HealthCheckResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *HealthCheckResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HealthCheckResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// HealthCheckResponseMultiError, or nil if none found.
+func (m *HealthCheckResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HealthCheckResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Status
+
+	// no validation rules for Message
+
+	if len(errors) > 0 {
+		return HealthCheckResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// HealthCheckResponseMultiError is an error wrapping multiple validation
+// errors returned by HealthCheckResponse.ValidateAll() if the designated
+// constraints aren't met.
+type HealthCheckResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HealthCheckResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HealthCheckResponseMultiError) AllErrors() []error { return m }
+
+// HealthCheckResponseValidationError is the validation error returned by
+// HealthCheckResponse.Validate if the designated constraints aren't met.
+type HealthCheckResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HealthCheckResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HealthCheckResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HealthCheckResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HealthCheckResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HealthCheckResponseValidationError) ErrorName() string {
+	return "HealthCheckResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e HealthCheckResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHealthCheckResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HealthCheckResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HealthCheckResponseValidationError{}
@@ -0,0 +1,258 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.4
+// source: common/error.proto
+
+package common
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 简化的错误代码 - 只保留最常用的错误类型
+type ErrorCode int32
+
+const (
+	// 通用错误
+	ErrorCode_UNKNOWN_ERROR      ErrorCode = 0
+	ErrorCode_INVALID_ARGUMENT   ErrorCode = 1
+	ErrorCode_NOT_FOUND          ErrorCode = 2
+	ErrorCode_ALREADY_EXISTS     ErrorCode = 3
+	ErrorCode_INTERNAL_ERROR     ErrorCode = 4
+	ErrorCode_RESOURCE_EXHAUSTED ErrorCode = 5 // 请求过于频繁，稍后重试
+	// 认证相关错误
+	ErrorCode_UNAUTHENTICATED   ErrorCode = 100
+	ErrorCode_PERMISSION_DENIED ErrorCode = 101
+	ErrorCode_TOO_MANY_ATTEMPTS ErrorCode = 102 // 登录失败次数过多，暂时锁定
+	// 业务逻辑错误
+	ErrorCode_BUSINESS_ERROR ErrorCode = 200
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0:   "UNKNOWN_ERROR",
+		1:   "INVALID_ARGUMENT",
+		2:   "NOT_FOUND",
+		3:   "ALREADY_EXISTS",
+		4:   "INTERNAL_ERROR",
+		5:   "RESOURCE_EXHAUSTED",
+		100: "UNAUTHENTICATED",
+		101: "PERMISSION_DENIED",
+		102: "TOO_MANY_ATTEMPTS",
+		200: "BUSINESS_ERROR",
+	}
+	ErrorCode_value = map[string]int32{
+		"UNKNOWN_ERROR":      0,
+		"INVALID_ARGUMENT":   1,
+		"NOT_FOUND":          2,
+		"ALREADY_EXISTS":     3,
+		"INTERNAL_ERROR":     4,
+		"RESOURCE_EXHAUSTED": 5,
+		"UNAUTHENTICATED":    100,
+		"PERMISSION_DENIED":  101,
+		"TOO_MANY_ATTEMPTS":  102,
+		"BUSINESS_ERROR":     200,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_error_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_common_error_proto_enumTypes[0]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_common_error_proto_rawDescGZIP(), []int{0}
+}
+
+// 简化的错误详情
+type ErrorDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    ErrorCode `protobuf:"varint,1,opt,name=code,proto3,enum=movieinfo.common.ErrorCode" json:"code,omitempty"` // 错误代码
+	Message string    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                            // 错误消息
+	Field   string    `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`                                // 相关字段（可选）
+}
+
+func (x *ErrorDetail) Reset() {
+	*x = ErrorDetail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_error_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDetail) ProtoMessage() {}
+
+func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_common_error_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return file_common_error_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ErrorDetail) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *ErrorDetail) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+var File_common_error_proto protoreflect.FileDescriptor
+
+var file_common_error_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x22, 0x6e, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2a, 0xdb, 0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4e, 0x56, 0x41, 0x4c,
+	0x49, 0x44, 0x5f, 0x41, 0x52, 0x47, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x0d, 0x0a,
+	0x09, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e,
+	0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x03,
+	0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52,
+	0x4f, 0x52, 0x10, 0x04, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45,
+	0x5f, 0x45, 0x58, 0x48, 0x41, 0x55, 0x53, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x13, 0x0a, 0x0f,
+	0x55, 0x4e, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41, 0x54, 0x45, 0x44, 0x10,
+	0x64, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x45, 0x52, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f,
+	0x44, 0x45, 0x4e, 0x49, 0x45, 0x44, 0x10, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x4f, 0x4f, 0x5f,
+	0x4d, 0x41, 0x4e, 0x59, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x4d, 0x50, 0x54, 0x53, 0x10, 0x66, 0x12,
+	0x13, 0x0a, 0x0e, 0x42, 0x55, 0x53, 0x49, 0x4e, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0xc8, 0x01, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x33, 0x69, 0x6e, 0x63, 0x68, 0x74, 0x69, 0x6d, 0x65, 0x2f, 0x6d, 0x6f, 0x76,
+	0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e,
+	0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_common_error_proto_rawDescOnce sync.Once
+	file_common_error_proto_rawDescData = file_common_error_proto_rawDesc
+)
+
+func file_common_error_proto_rawDescGZIP() []byte {
+	file_common_error_proto_rawDescOnce.Do(func() {
+		file_common_error_proto_rawDescData = protoimpl.X.CompressGZIP(file_common_error_proto_rawDescData)
+	})
+	return file_common_error_proto_rawDescData
+}
+
+var file_common_error_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_common_error_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_common_error_proto_goTypes = []interface{}{
+	(ErrorCode)(0),      // 0: movieinfo.common.ErrorCode
+	(*ErrorDetail)(nil), // 1: movieinfo.common.ErrorDetail
+}
+var file_common_error_proto_depIdxs = []int32{
+	0, // 0: movieinfo.common.ErrorDetail.code:type_name -> movieinfo.common.ErrorCode
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_common_error_proto_init() }
+func file_common_error_proto_init() {
+	if File_common_error_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_common_error_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorDetail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_common_error_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_error_proto_goTypes,
+		DependencyIndexes: file_common_error_proto_depIdxs,
+		EnumInfos:         file_common_error_proto_enumTypes,
+		MessageInfos:      file_common_error_proto_msgTypes,
+	}.Build()
+	File_common_error_proto = out.File
+	file_common_error_proto_rawDesc = nil
+	file_common_error_proto_goTypes = nil
+	file_common_error_proto_depIdxs = nil
+}
@@ -0,0 +1,3122 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: movie/movie.proto
+
+package movie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on Movie with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Movie) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Movie with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in MovieMultiError, or nil if none found.
+func (m *Movie) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Movie) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Title
+
+	// no validation rules for Description
+
+	// no validation rules for PosterUrl
+
+	// no validation rules for Duration
+
+	if all {
+		switch v := interface{}(m.GetReleaseDate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MovieValidationError{
+					field:  "ReleaseDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MovieValidationError{
+					field:  "ReleaseDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReleaseDate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MovieValidationError{
+				field:  "ReleaseDate",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Language
+
+	// no validation rules for AverageRating
+
+	// no validation rules for RatingCount
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MovieValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MovieValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MovieValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MovieValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MovieValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MovieValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return MovieMultiError(errors)
+	}
+
+	return nil
+}
+
+// MovieMultiError is an error wrapping multiple validation errors returned by
+// Movie.ValidateAll() if the designated constraints aren't met.
+type MovieMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MovieMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MovieMultiError) AllErrors() []error { return m }
+
+// MovieValidationError is the validation error returned by Movie.Validate if
+// the designated constraints aren't met.
+type MovieValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MovieValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MovieValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MovieValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MovieValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MovieValidationError) ErrorName() string { return "MovieValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MovieValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMovie.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MovieValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MovieValidationError{}
+
+// Validate checks the field values on CreateMovieRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateMovieRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateMovieRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateMovieRequestMultiError, or nil if none found.
+func (m *CreateMovieRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateMovieRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetTitle()); l < 1 || l > 200 {
+		err := CreateMovieRequestValidationError{
+			field:  "Title",
+			reason: "value length must be between 1 and 200 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetDescription()) > 5000 {
+		err := CreateMovieRequestValidationError{
+			field:  "Description",
+			reason: "value length must be at most 5000 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPosterUrl() != "" {
+
+		if utf8.RuneCountInString(m.GetPosterUrl()) > 255 {
+			err := CreateMovieRequestValidationError{
+				field:  "PosterUrl",
+				reason: "value length must be at most 255 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if uri, err := url.Parse(m.GetPosterUrl()); err != nil {
+			err = CreateMovieRequestValidationError{
+				field:  "PosterUrl",
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := CreateMovieRequestValidationError{
+				field:  "PosterUrl",
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if val := m.GetDuration(); val < 0 || val > 1440 {
+		err := CreateMovieRequestValidationError{
+			field:  "Duration",
+			reason: "value must be inside range [0, 1440]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetReleaseDate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateMovieRequestValidationError{
+					field:  "ReleaseDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateMovieRequestValidationError{
+					field:  "ReleaseDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReleaseDate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateMovieRequestValidationError{
+				field:  "ReleaseDate",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetLanguage()) > 50 {
+		err := CreateMovieRequestValidationError{
+			field:  "Language",
+			reason: "value length must be at most 50 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetGenres()) > 20 {
+		err := CreateMovieRequestValidationError{
+			field:  "Genres",
+			reason: "value must contain no more than 20 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetGenres() {
+		_, _ = idx, item
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 50 {
+			err := CreateMovieRequestValidationError{
+				field:  fmt.Sprintf("Genres[%v]", idx),
+				reason: "value length must be between 1 and 50 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetDirectors()) > 50 {
+		err := CreateMovieRequestValidationError{
+			field:  "Directors",
+			reason: "value must contain no more than 50 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetDirectors() {
+		_, _ = idx, item
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 100 {
+			err := CreateMovieRequestValidationError{
+				field:  fmt.Sprintf("Directors[%v]", idx),
+				reason: "value length must be between 1 and 100 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetActors()) > 200 {
+		err := CreateMovieRequestValidationError{
+			field:  "Actors",
+			reason: "value must contain no more than 200 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetActors() {
+		_, _ = idx, item
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 100 {
+			err := CreateMovieRequestValidationError{
+				field:  fmt.Sprintf("Actors[%v]", idx),
+				reason: "value length must be between 1 and 100 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CreateMovieRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateMovieRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateMovieRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateMovieRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateMovieRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateMovieRequestMultiError) AllErrors() []error { return m }
+
+// CreateMovieRequestValidationError is the validation error returned by
+// CreateMovieRequest.Validate if the designated constraints aren't met.
+type CreateMovieRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateMovieRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateMovieRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateMovieRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateMovieRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateMovieRequestValidationError) ErrorName() string {
+	return "CreateMovieRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateMovieRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateMovieRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateMovieRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateMovieRequestValidationError{}
+
+// Validate checks the field values on CreateMovieResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateMovieResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateMovieResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateMovieResponseMultiError, or nil if none found.
+func (m *CreateMovieResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateMovieResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateMovieResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateMovieResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateMovieResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetMovie()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateMovieResponseValidationError{
+					field:  "Movie",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateMovieResponseValidationError{
+					field:  "Movie",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMovie()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateMovieResponseValidationError{
+				field:  "Movie",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateMovieResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateMovieResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateMovieResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CreateMovieResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateMovieResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateMovieResponseMultiError) AllErrors() []error { return m }
+
+// CreateMovieResponseValidationError is the validation error returned by
+// CreateMovieResponse.Validate if the designated constraints aren't met.
+type CreateMovieResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateMovieResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateMovieResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateMovieResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateMovieResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateMovieResponseValidationError) ErrorName() string {
+	return "CreateMovieResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateMovieResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateMovieResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateMovieResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateMovieResponseValidationError{}
+
+// Validate checks the field values on GetMovieRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetMovieRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetMovieRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetMovieRequestMultiError, or nil if none found.
+func (m *GetMovieRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetMovieRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := GetMovieRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetMovieRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetMovieRequestMultiError is an error wrapping multiple validation errors
+// returned by GetMovieRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetMovieRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetMovieRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetMovieRequestMultiError) AllErrors() []error { return m }
+
+// GetMovieRequestValidationError is the validation error returned by
+// GetMovieRequest.Validate if the designated constraints aren't met.
+type GetMovieRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetMovieRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetMovieRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetMovieRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetMovieRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetMovieRequestValidationError) ErrorName() string { return "GetMovieRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetMovieRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetMovieRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetMovieRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetMovieRequestValidationError{}
+
+// Validate checks the field values on GetMovieResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetMovieResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetMovieResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetMovieResponseMultiError, or nil if none found.
+func (m *GetMovieResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetMovieResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetMovieResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetMovieResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetMovieResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetMovie()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetMovieResponseValidationError{
+					field:  "Movie",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetMovieResponseValidationError{
+					field:  "Movie",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMovie()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetMovieResponseValidationError{
+				field:  "Movie",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetMovieResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetMovieResponseMultiError is an error wrapping multiple validation errors
+// returned by GetMovieResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetMovieResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetMovieResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetMovieResponseMultiError) AllErrors() []error { return m }
+
+// GetMovieResponseValidationError is the validation error returned by
+// GetMovieResponse.Validate if the designated constraints aren't met.
+type GetMovieResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetMovieResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetMovieResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetMovieResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetMovieResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetMovieResponseValidationError) ErrorName() string { return "GetMovieResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetMovieResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetMovieResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetMovieResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetMovieResponseValidationError{}
+
+// Validate checks the field values on UpdateMovieRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateMovieRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateMovieRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateMovieRequestMultiError, or nil if none found.
+func (m *UpdateMovieRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateMovieRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := UpdateMovieRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetTitle()) > 200 {
+		err := UpdateMovieRequestValidationError{
+			field:  "Title",
+			reason: "value length must be at most 200 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetDescription()) > 5000 {
+		err := UpdateMovieRequestValidationError{
+			field:  "Description",
+			reason: "value length must be at most 5000 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPosterUrl() != "" {
+
+		if utf8.RuneCountInString(m.GetPosterUrl()) > 255 {
+			err := UpdateMovieRequestValidationError{
+				field:  "PosterUrl",
+				reason: "value length must be at most 255 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if uri, err := url.Parse(m.GetPosterUrl()); err != nil {
+			err = UpdateMovieRequestValidationError{
+				field:  "PosterUrl",
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := UpdateMovieRequestValidationError{
+				field:  "PosterUrl",
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if val := m.GetDuration(); val < 0 || val > 1440 {
+		err := UpdateMovieRequestValidationError{
+			field:  "Duration",
+			reason: "value must be inside range [0, 1440]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetReleaseDate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateMovieRequestValidationError{
+					field:  "ReleaseDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateMovieRequestValidationError{
+					field:  "ReleaseDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReleaseDate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateMovieRequestValidationError{
+				field:  "ReleaseDate",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetLanguage()) > 50 {
+		err := UpdateMovieRequestValidationError{
+			field:  "Language",
+			reason: "value length must be at most 50 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetGenres()) > 20 {
+		err := UpdateMovieRequestValidationError{
+			field:  "Genres",
+			reason: "value must contain no more than 20 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetGenres() {
+		_, _ = idx, item
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 50 {
+			err := UpdateMovieRequestValidationError{
+				field:  fmt.Sprintf("Genres[%v]", idx),
+				reason: "value length must be between 1 and 50 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetDirectors()) > 50 {
+		err := UpdateMovieRequestValidationError{
+			field:  "Directors",
+			reason: "value must contain no more than 50 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetDirectors() {
+		_, _ = idx, item
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 100 {
+			err := UpdateMovieRequestValidationError{
+				field:  fmt.Sprintf("Directors[%v]", idx),
+				reason: "value length must be between 1 and 100 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetActors()) > 200 {
+		err := UpdateMovieRequestValidationError{
+			field:  "Actors",
+			reason: "value must contain no more than 200 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetActors() {
+		_, _ = idx, item
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 100 {
+			err := UpdateMovieRequestValidationError{
+				field:  fmt.Sprintf("Actors[%v]", idx),
+				reason: "value length must be between 1 and 100 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdateMask()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateMovieRequestValidationError{
+					field:  "UpdateMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateMovieRequestValidationError{
+					field:  "UpdateMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdateMask()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateMovieRequestValidationError{
+				field:  "UpdateMask",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateMovieRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateMovieRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateMovieRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateMovieRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateMovieRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateMovieRequestMultiError) AllErrors() []error { return m }
+
+// UpdateMovieRequestValidationError is the validation error returned by
+// UpdateMovieRequest.Validate if the designated constraints aren't met.
+type UpdateMovieRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateMovieRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateMovieRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateMovieRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateMovieRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateMovieRequestValidationError) ErrorName() string {
+	return "UpdateMovieRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateMovieRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateMovieRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateMovieRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateMovieRequestValidationError{}
+
+// Validate checks the field values on UpdateMovieResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateMovieResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateMovieResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateMovieResponseMultiError, or nil if none found.
+func (m *UpdateMovieResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateMovieResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateMovieResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateMovieResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateMovieResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetMovie()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateMovieResponseValidationError{
+					field:  "Movie",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateMovieResponseValidationError{
+					field:  "Movie",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMovie()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateMovieResponseValidationError{
+				field:  "Movie",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateMovieResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateMovieResponseMultiError is an error wrapping multiple validation
+// errors returned by UpdateMovieResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateMovieResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateMovieResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateMovieResponseMultiError) AllErrors() []error { return m }
+
+// UpdateMovieResponseValidationError is the validation error returned by
+// UpdateMovieResponse.Validate if the designated constraints aren't met.
+type UpdateMovieResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateMovieResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateMovieResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateMovieResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateMovieResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateMovieResponseValidationError) ErrorName() string {
+	return "UpdateMovieResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateMovieResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateMovieResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateMovieResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateMovieResponseValidationError{}
+
+// Validate checks the field values on DeleteMovieRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteMovieRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteMovieRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteMovieRequestMultiError, or nil if none found.
+func (m *DeleteMovieRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteMovieRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := DeleteMovieRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteMovieRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteMovieRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteMovieRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteMovieRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteMovieRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteMovieRequestMultiError) AllErrors() []error { return m }
+
+// DeleteMovieRequestValidationError is the validation error returned by
+// DeleteMovieRequest.Validate if the designated constraints aren't met.
+type DeleteMovieRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteMovieRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteMovieRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteMovieRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteMovieRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteMovieRequestValidationError) ErrorName() string {
+	return "DeleteMovieRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteMovieRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteMovieRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteMovieRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteMovieRequestValidationError{}
+
+// Validate checks the field values on DeleteMovieResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteMovieResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteMovieResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteMovieResponseMultiError, or nil if none found.
+func (m *DeleteMovieResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteMovieResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DeleteMovieResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DeleteMovieResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DeleteMovieResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DeleteMovieResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteMovieResponseMultiError is an error wrapping multiple validation
+// errors returned by DeleteMovieResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteMovieResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteMovieResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteMovieResponseMultiError) AllErrors() []error { return m }
+
+// DeleteMovieResponseValidationError is the validation error returned by
+// DeleteMovieResponse.Validate if the designated constraints aren't met.
+type DeleteMovieResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteMovieResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteMovieResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteMovieResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteMovieResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteMovieResponseValidationError) ErrorName() string {
+	return "DeleteMovieResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteMovieResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteMovieResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteMovieResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteMovieResponseValidationError{}
+
+// Validate checks the field values on ListMoviesRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListMoviesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListMoviesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListMoviesRequestMultiError, or nil if none found.
+func (m *ListMoviesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListMoviesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetPage()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListMoviesRequestValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListMoviesRequestValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPage()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListMoviesRequestValidationError{
+				field:  "Page",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetGenres()) > 10 {
+		err := ListMoviesRequestValidationError{
+			field:  "Genres",
+			reason: "value must contain no more than 10 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetGenres() {
+		_, _ = idx, item
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 50 {
+			err := ListMoviesRequestValidationError{
+				field:  fmt.Sprintf("Genres[%v]", idx),
+				reason: "value length must be between 1 and 50 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetSearch()) > 100 {
+		err := ListMoviesRequestValidationError{
+			field:  "Search",
+			reason: "value length must be at most 100 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetLanguage()) > 50 {
+		err := ListMoviesRequestValidationError{
+			field:  "Language",
+			reason: "value length must be at most 50 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListMoviesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListMoviesRequestMultiError is an error wrapping multiple validation errors
+// returned by ListMoviesRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListMoviesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListMoviesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListMoviesRequestMultiError) AllErrors() []error { return m }
+
+// ListMoviesRequestValidationError is the validation error returned by
+// ListMoviesRequest.Validate if the designated constraints aren't met.
+type ListMoviesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListMoviesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListMoviesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListMoviesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListMoviesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListMoviesRequestValidationError) ErrorName() string {
+	return "ListMoviesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListMoviesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListMoviesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListMoviesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListMoviesRequestValidationError{}
+
+// Validate checks the field values on ListMoviesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListMoviesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListMoviesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListMoviesResponseMultiError, or nil if none found.
+func (m *ListMoviesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListMoviesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListMoviesResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListMoviesResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListMoviesResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetMovies() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListMoviesResponseValidationError{
+						field:  fmt.Sprintf("Movies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListMoviesResponseValidationError{
+						field:  fmt.Sprintf("Movies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListMoviesResponseValidationError{
+					field:  fmt.Sprintf("Movies[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetPage()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListMoviesResponseValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListMoviesResponseValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPage()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListMoviesResponseValidationError{
+				field:  "Page",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ListMoviesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListMoviesResponseMultiError is an error wrapping multiple validation errors
+// returned by ListMoviesResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListMoviesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListMoviesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListMoviesResponseMultiError) AllErrors() []error { return m }
+
+// ListMoviesResponseValidationError is the validation error returned by
+// ListMoviesResponse.Validate if the designated constraints aren't met.
+type ListMoviesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListMoviesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListMoviesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListMoviesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListMoviesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListMoviesResponseValidationError) ErrorName() string {
+	return "ListMoviesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListMoviesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListMoviesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListMoviesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListMoviesResponseValidationError{}
+
+// Validate checks the field values on SearchMoviesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SearchMoviesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SearchMoviesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SearchMoviesRequestMultiError, or nil if none found.
+func (m *SearchMoviesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SearchMoviesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetQuery()); l < 1 || l > 100 {
+		err := SearchMoviesRequestValidationError{
+			field:  "Query",
+			reason: "value length must be between 1 and 100 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetPage()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SearchMoviesRequestValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SearchMoviesRequestValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPage()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SearchMoviesRequestValidationError{
+				field:  "Page",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SearchMoviesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SearchMoviesRequestMultiError is an error wrapping multiple validation
+// errors returned by SearchMoviesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SearchMoviesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SearchMoviesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SearchMoviesRequestMultiError) AllErrors() []error { return m }
+
+// SearchMoviesRequestValidationError is the validation error returned by
+// SearchMoviesRequest.Validate if the designated constraints aren't met.
+type SearchMoviesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SearchMoviesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SearchMoviesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SearchMoviesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SearchMoviesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SearchMoviesRequestValidationError) ErrorName() string {
+	return "SearchMoviesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SearchMoviesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSearchMoviesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SearchMoviesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SearchMoviesRequestValidationError{}
+
+// Validate checks the field values on SearchMoviesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SearchMoviesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SearchMoviesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SearchMoviesResponseMultiError, or nil if none found.
+func (m *SearchMoviesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SearchMoviesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SearchMoviesResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SearchMoviesResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SearchMoviesResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetMovies() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SearchMoviesResponseValidationError{
+						field:  fmt.Sprintf("Movies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SearchMoviesResponseValidationError{
+						field:  fmt.Sprintf("Movies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SearchMoviesResponseValidationError{
+					field:  fmt.Sprintf("Movies[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetPage()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SearchMoviesResponseValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SearchMoviesResponseValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPage()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SearchMoviesResponseValidationError{
+				field:  "Page",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SearchMoviesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SearchMoviesResponseMultiError is an error wrapping multiple validation
+// errors returned by SearchMoviesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SearchMoviesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SearchMoviesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SearchMoviesResponseMultiError) AllErrors() []error { return m }
+
+// SearchMoviesResponseValidationError is the validation error returned by
+// SearchMoviesResponse.Validate if the designated constraints aren't met.
+type SearchMoviesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SearchMoviesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SearchMoviesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SearchMoviesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SearchMoviesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SearchMoviesResponseValidationError) ErrorName() string {
+	return "SearchMoviesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SearchMoviesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSearchMoviesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SearchMoviesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SearchMoviesResponseValidationError{}
+
+// Validate checks the field values on BatchGetMoviesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetMoviesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetMoviesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetMoviesRequestMultiError, or nil if none found.
+func (m *BatchGetMoviesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetMoviesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetIds()); l < 1 || l > 100 {
+		err := BatchGetMoviesRequestValidationError{
+			field:  "Ids",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetIds() {
+		_, _ = idx, item
+
+		if item <= 0 {
+			err := BatchGetMoviesRequestValidationError{
+				field:  fmt.Sprintf("Ids[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchGetMoviesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetMoviesRequestMultiError is an error wrapping multiple validation
+// errors returned by BatchGetMoviesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type BatchGetMoviesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetMoviesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetMoviesRequestMultiError) AllErrors() []error { return m }
+
+// BatchGetMoviesRequestValidationError is the validation error returned by
+// BatchGetMoviesRequest.Validate if the designated constraints aren't met.
+type BatchGetMoviesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetMoviesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetMoviesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetMoviesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetMoviesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetMoviesRequestValidationError) ErrorName() string {
+	return "BatchGetMoviesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetMoviesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetMoviesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetMoviesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetMoviesRequestValidationError{}
+
+// Validate checks the field values on BatchGetMoviesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetMoviesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetMoviesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetMoviesResponseMultiError, or nil if none found.
+func (m *BatchGetMoviesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetMoviesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BatchGetMoviesResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BatchGetMoviesResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchGetMoviesResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetMovies() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchGetMoviesResponseValidationError{
+						field:  fmt.Sprintf("Movies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchGetMoviesResponseValidationError{
+						field:  fmt.Sprintf("Movies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchGetMoviesResponseValidationError{
+					field:  fmt.Sprintf("Movies[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchGetMoviesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetMoviesResponseMultiError is an error wrapping multiple validation
+// errors returned by BatchGetMoviesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type BatchGetMoviesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetMoviesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetMoviesResponseMultiError) AllErrors() []error { return m }
+
+// BatchGetMoviesResponseValidationError is the validation error returned by
+// BatchGetMoviesResponse.Validate if the designated constraints aren't met.
+type BatchGetMoviesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetMoviesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetMoviesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetMoviesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetMoviesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetMoviesResponseValidationError) ErrorName() string {
+	return "BatchGetMoviesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetMoviesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetMoviesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetMoviesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetMoviesResponseValidationError{}
+
+// Validate checks the field values on BulkCreateMoviesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BulkCreateMoviesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BulkCreateMoviesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BulkCreateMoviesRequestMultiError, or nil if none found.
+func (m *BulkCreateMoviesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BulkCreateMoviesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetMovie() == nil {
+		err := BulkCreateMoviesRequestValidationError{
+			field:  "Movie",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// skipping validation for movie
+
+	if len(errors) > 0 {
+		return BulkCreateMoviesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BulkCreateMoviesRequestMultiError is an error wrapping multiple validation
+// errors returned by BulkCreateMoviesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type BulkCreateMoviesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BulkCreateMoviesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BulkCreateMoviesRequestMultiError) AllErrors() []error { return m }
+
+// BulkCreateMoviesRequestValidationError is the validation error returned by
+// BulkCreateMoviesRequest.Validate if the designated constraints aren't met.
+type BulkCreateMoviesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BulkCreateMoviesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BulkCreateMoviesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BulkCreateMoviesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BulkCreateMoviesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BulkCreateMoviesRequestValidationError) ErrorName() string {
+	return "BulkCreateMoviesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BulkCreateMoviesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBulkCreateMoviesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BulkCreateMoviesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BulkCreateMoviesRequestValidationError{}
+
+// Validate checks the field values on BulkCreateMovieError with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BulkCreateMovieError) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BulkCreateMovieError with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BulkCreateMovieErrorMultiError, or nil if none found.
+func (m *BulkCreateMovieError) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BulkCreateMovieError) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Index
+
+	for idx, item := range m.GetErrors() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BulkCreateMovieErrorValidationError{
+						field:  fmt.Sprintf("Errors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BulkCreateMovieErrorValidationError{
+						field:  fmt.Sprintf("Errors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BulkCreateMovieErrorValidationError{
+					field:  fmt.Sprintf("Errors[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BulkCreateMovieErrorMultiError(errors)
+	}
+
+	return nil
+}
+
+// BulkCreateMovieErrorMultiError is an error wrapping multiple validation
+// errors returned by BulkCreateMovieError.ValidateAll() if the designated
+// constraints aren't met.
+type BulkCreateMovieErrorMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BulkCreateMovieErrorMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BulkCreateMovieErrorMultiError) AllErrors() []error { return m }
+
+// BulkCreateMovieErrorValidationError is the validation error returned by
+// BulkCreateMovieError.Validate if the designated constraints aren't met.
+type BulkCreateMovieErrorValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BulkCreateMovieErrorValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BulkCreateMovieErrorValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BulkCreateMovieErrorValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BulkCreateMovieErrorValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BulkCreateMovieErrorValidationError) ErrorName() string {
+	return "BulkCreateMovieErrorValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BulkCreateMovieErrorValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBulkCreateMovieError.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BulkCreateMovieErrorValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BulkCreateMovieErrorValidationError{}
+
+// Validate checks the field values on BulkCreateMoviesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BulkCreateMoviesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BulkCreateMoviesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BulkCreateMoviesResponseMultiError, or nil if none found.
+func (m *BulkCreateMoviesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BulkCreateMoviesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BulkCreateMoviesResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BulkCreateMoviesResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BulkCreateMoviesResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Received
+
+	// no validation rules for Created
+
+	// no validation rules for Failed
+
+	for idx, item := range m.GetErrors() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BulkCreateMoviesResponseValidationError{
+						field:  fmt.Sprintf("Errors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BulkCreateMoviesResponseValidationError{
+						field:  fmt.Sprintf("Errors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BulkCreateMoviesResponseValidationError{
+					field:  fmt.Sprintf("Errors[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BulkCreateMoviesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BulkCreateMoviesResponseMultiError is an error wrapping multiple validation
+// errors returned by BulkCreateMoviesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type BulkCreateMoviesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BulkCreateMoviesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BulkCreateMoviesResponseMultiError) AllErrors() []error { return m }
+
+// BulkCreateMoviesResponseValidationError is the validation error returned by
+// BulkCreateMoviesResponse.Validate if the designated constraints aren't met.
+type BulkCreateMoviesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BulkCreateMoviesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BulkCreateMoviesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BulkCreateMoviesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BulkCreateMoviesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BulkCreateMoviesResponseValidationError) ErrorName() string {
+	return "BulkCreateMoviesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BulkCreateMoviesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBulkCreateMoviesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BulkCreateMoviesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BulkCreateMoviesResponseValidationError{}
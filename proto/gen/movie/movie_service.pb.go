@@ -0,0 +1,180 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.4
+// source: movie/movie_service.proto
+
+package movie
+
+import (
+	common "github.com/3inchtime/movieinfo/proto/gen/common"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_movie_movie_service_proto protoreflect.FileDescriptor
+
+var file_movie_movie_service_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x6d, 0x6f, 0x76,
+	0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x1a, 0x11, 0x6d, 0x6f,
+	0x76, 0x69, 0x65, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x13, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x32, 0xed, 0x08, 0x0a, 0x0c, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x73, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x6f, 0x76,
+	0x69, 0x65, 0x12, 0x23, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69,
+	0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4d, 0x6f, 0x76, 0x69, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x19, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x13, 0x3a, 0x01, 0x2a, 0x22, 0x0e, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x6f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4d,
+	0x6f, 0x76, 0x69, 0x65, 0x12, 0x20, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f,
+	0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e,
+	0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x76, 0x69,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x15, 0x12, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x90, 0x02, 0x01, 0x12, 0x78, 0x0a, 0x0b, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x12, 0x23, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
+	0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x3a, 0x01, 0x2a, 0x32, 0x13,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x2f, 0x7b,
+	0x69, 0x64, 0x7d, 0x12, 0x75, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x6f, 0x76,
+	0x69, 0x65, 0x12, 0x23, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69,
+	0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x4d, 0x6f, 0x76, 0x69, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x15, 0x2a, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x70, 0x0a, 0x0a, 0x4c, 0x69,
+	0x73, 0x74, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d,
+	0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x90, 0x02, 0x01, 0x12, 0x85, 0x01, 0x0a,
+	0x0e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12,
+	0x26, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69,
+	0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47,
+	0x65, 0x74, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x3a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65,
+	0x74, 0x90, 0x02, 0x01, 0x12, 0x8f, 0x01, 0x0a, 0x10, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x28, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e,
+	0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x3a, 0x01, 0x2a, 0x22, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x3a, 0x62, 0x75, 0x6c, 0x6b, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x28, 0x01, 0x12, 0x7d, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e,
+	0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d,
+	0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x3a, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x90, 0x02, 0x01, 0x12, 0x7b, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x12, 0x24, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x6f, 0x76,
+	0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x90,
+	0x02, 0x01, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x33, 0x69, 0x6e, 0x63, 0x68, 0x74, 0x69, 0x6d, 0x65, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x69, 0x6e, 0x66, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_movie_movie_service_proto_goTypes = []interface{}{
+	(*CreateMovieRequest)(nil),         // 0: movieinfo.movie.CreateMovieRequest
+	(*GetMovieRequest)(nil),            // 1: movieinfo.movie.GetMovieRequest
+	(*UpdateMovieRequest)(nil),         // 2: movieinfo.movie.UpdateMovieRequest
+	(*DeleteMovieRequest)(nil),         // 3: movieinfo.movie.DeleteMovieRequest
+	(*ListMoviesRequest)(nil),          // 4: movieinfo.movie.ListMoviesRequest
+	(*BatchGetMoviesRequest)(nil),      // 5: movieinfo.movie.BatchGetMoviesRequest
+	(*BulkCreateMoviesRequest)(nil),    // 6: movieinfo.movie.BulkCreateMoviesRequest
+	(*SearchMoviesRequest)(nil),        // 7: movieinfo.movie.SearchMoviesRequest
+	(*common.HealthCheckRequest)(nil),  // 8: movieinfo.common.HealthCheckRequest
+	(*CreateMovieResponse)(nil),        // 9: movieinfo.movie.CreateMovieResponse
+	(*GetMovieResponse)(nil),           // 10: movieinfo.movie.GetMovieResponse
+	(*UpdateMovieResponse)(nil),        // 11: movieinfo.movie.UpdateMovieResponse
+	(*DeleteMovieResponse)(nil),        // 12: movieinfo.movie.DeleteMovieResponse
+	(*ListMoviesResponse)(nil),         // 13: movieinfo.movie.ListMoviesResponse
+	(*BatchGetMoviesResponse)(nil),     // 14: movieinfo.movie.BatchGetMoviesResponse
+	(*BulkCreateMoviesResponse)(nil),   // 15: movieinfo.movie.BulkCreateMoviesResponse
+	(*SearchMoviesResponse)(nil),       // 16: movieinfo.movie.SearchMoviesResponse
+	(*common.HealthCheckResponse)(nil), // 17: movieinfo.common.HealthCheckResponse
+}
+var file_movie_movie_service_proto_depIdxs = []int32{
+	0,  // 0: movieinfo.movie.MovieService.CreateMovie:input_type -> movieinfo.movie.CreateMovieRequest
+	1,  // 1: movieinfo.movie.MovieService.GetMovie:input_type -> movieinfo.movie.GetMovieRequest
+	2,  // 2: movieinfo.movie.MovieService.UpdateMovie:input_type -> movieinfo.movie.UpdateMovieRequest
+	3,  // 3: movieinfo.movie.MovieService.DeleteMovie:input_type -> movieinfo.movie.DeleteMovieRequest
+	4,  // 4: movieinfo.movie.MovieService.ListMovies:input_type -> movieinfo.movie.ListMoviesRequest
+	5,  // 5: movieinfo.movie.MovieService.BatchGetMovies:input_type -> movieinfo.movie.BatchGetMoviesRequest
+	6,  // 6: movieinfo.movie.MovieService.BulkCreateMovies:input_type -> movieinfo.movie.BulkCreateMoviesRequest
+	7,  // 7: movieinfo.movie.MovieService.SearchMovies:input_type -> movieinfo.movie.SearchMoviesRequest
+	8,  // 8: movieinfo.movie.MovieService.HealthCheck:input_type -> movieinfo.common.HealthCheckRequest
+	9,  // 9: movieinfo.movie.MovieService.CreateMovie:output_type -> movieinfo.movie.CreateMovieResponse
+	10, // 10: movieinfo.movie.MovieService.GetMovie:output_type -> movieinfo.movie.GetMovieResponse
+	11, // 11: movieinfo.movie.MovieService.UpdateMovie:output_type -> movieinfo.movie.UpdateMovieResponse
+	12, // 12: movieinfo.movie.MovieService.DeleteMovie:output_type -> movieinfo.movie.DeleteMovieResponse
+	13, // 13: movieinfo.movie.MovieService.ListMovies:output_type -> movieinfo.movie.ListMoviesResponse
+	14, // 14: movieinfo.movie.MovieService.BatchGetMovies:output_type -> movieinfo.movie.BatchGetMoviesResponse
+	15, // 15: movieinfo.movie.MovieService.BulkCreateMovies:output_type -> movieinfo.movie.BulkCreateMoviesResponse
+	16, // 16: movieinfo.movie.MovieService.SearchMovies:output_type -> movieinfo.movie.SearchMoviesResponse
+	17, // 17: movieinfo.movie.MovieService.HealthCheck:output_type -> movieinfo.common.HealthCheckResponse
+	9,  // [9:18] is the sub-list for method output_type
+	0,  // [0:9] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_movie_movie_service_proto_init() }
+func file_movie_movie_service_proto_init() {
+	if File_movie_movie_service_proto != nil {
+		return
+	}
+	file_movie_movie_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_movie_movie_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_movie_movie_service_proto_goTypes,
+		DependencyIndexes: file_movie_movie_service_proto_depIdxs,
+	}.Build()
+	File_movie_movie_service_proto = out.File
+	file_movie_movie_service_proto_rawDesc = nil
+	file_movie_movie_service_proto_goTypes = nil
+	file_movie_movie_service_proto_depIdxs = nil
+}
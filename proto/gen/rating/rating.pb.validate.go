@@ -0,0 +1,2707 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: rating/rating.proto
+
+package rating
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on Rating with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Rating) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Rating with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in RatingMultiError, or nil if none found.
+func (m *Rating) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Rating) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for UserId
+
+	// no validation rules for MovieId
+
+	// no validation rules for Score
+
+	// no validation rules for Comment
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RatingValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RatingValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RatingValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RatingValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RatingValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RatingValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RatingMultiError(errors)
+	}
+
+	return nil
+}
+
+// RatingMultiError is an error wrapping multiple validation errors returned by
+// Rating.ValidateAll() if the designated constraints aren't met.
+type RatingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RatingMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RatingMultiError) AllErrors() []error { return m }
+
+// RatingValidationError is the validation error returned by Rating.Validate if
+// the designated constraints aren't met.
+type RatingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RatingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RatingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RatingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RatingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RatingValidationError) ErrorName() string { return "RatingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RatingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRating.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RatingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RatingValidationError{}
+
+// Validate checks the field values on CreateRatingRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateRatingRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateRatingRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateRatingRequestMultiError, or nil if none found.
+func (m *CreateRatingRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateRatingRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUserId() <= 0 {
+		err := CreateRatingRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMovieId() <= 0 {
+		err := CreateRatingRequestValidationError{
+			field:  "MovieId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetScore(); val < 1 || val > 5 {
+		err := CreateRatingRequestValidationError{
+			field:  "Score",
+			reason: "value must be inside range [1, 5]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetComment()) > 1000 {
+		err := CreateRatingRequestValidationError{
+			field:  "Comment",
+			reason: "value length must be at most 1000 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CreateRatingRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateRatingRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateRatingRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CreateRatingRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateRatingRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateRatingRequestMultiError) AllErrors() []error { return m }
+
+// CreateRatingRequestValidationError is the validation error returned by
+// CreateRatingRequest.Validate if the designated constraints aren't met.
+type CreateRatingRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateRatingRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateRatingRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateRatingRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateRatingRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateRatingRequestValidationError) ErrorName() string {
+	return "CreateRatingRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateRatingRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateRatingRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateRatingRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateRatingRequestValidationError{}
+
+// Validate checks the field values on CreateRatingResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateRatingResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateRatingResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateRatingResponseMultiError, or nil if none found.
+func (m *CreateRatingResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateRatingResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateRatingResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateRatingResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateRatingResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRating()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateRatingResponseValidationError{
+					field:  "Rating",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateRatingResponseValidationError{
+					field:  "Rating",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRating()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateRatingResponseValidationError{
+				field:  "Rating",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateRatingResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateRatingResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateRatingResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CreateRatingResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateRatingResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateRatingResponseMultiError) AllErrors() []error { return m }
+
+// CreateRatingResponseValidationError is the validation error returned by
+// CreateRatingResponse.Validate if the designated constraints aren't met.
+type CreateRatingResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateRatingResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateRatingResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateRatingResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateRatingResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateRatingResponseValidationError) ErrorName() string {
+	return "CreateRatingResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateRatingResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateRatingResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateRatingResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateRatingResponseValidationError{}
+
+// Validate checks the field values on GetRatingRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetRatingRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRatingRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRatingRequestMultiError, or nil if none found.
+func (m *GetRatingRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRatingRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := GetRatingRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetRatingRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRatingRequestMultiError is an error wrapping multiple validation errors
+// returned by GetRatingRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetRatingRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRatingRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRatingRequestMultiError) AllErrors() []error { return m }
+
+// GetRatingRequestValidationError is the validation error returned by
+// GetRatingRequest.Validate if the designated constraints aren't met.
+type GetRatingRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRatingRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRatingRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRatingRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRatingRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRatingRequestValidationError) ErrorName() string { return "GetRatingRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetRatingRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRatingRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRatingRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRatingRequestValidationError{}
+
+// Validate checks the field values on GetRatingResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetRatingResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRatingResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRatingResponseMultiError, or nil if none found.
+func (m *GetRatingResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRatingResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRatingResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRatingResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRatingResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRating()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRatingResponseValidationError{
+					field:  "Rating",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRatingResponseValidationError{
+					field:  "Rating",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRating()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRatingResponseValidationError{
+				field:  "Rating",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetRatingResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRatingResponseMultiError is an error wrapping multiple validation errors
+// returned by GetRatingResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetRatingResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRatingResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRatingResponseMultiError) AllErrors() []error { return m }
+
+// GetRatingResponseValidationError is the validation error returned by
+// GetRatingResponse.Validate if the designated constraints aren't met.
+type GetRatingResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRatingResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRatingResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRatingResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRatingResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRatingResponseValidationError) ErrorName() string {
+	return "GetRatingResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetRatingResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRatingResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRatingResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRatingResponseValidationError{}
+
+// Validate checks the field values on UpdateRatingRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateRatingRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateRatingRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateRatingRequestMultiError, or nil if none found.
+func (m *UpdateRatingRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateRatingRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := UpdateRatingRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetScore(); val < 1 || val > 5 {
+		err := UpdateRatingRequestValidationError{
+			field:  "Score",
+			reason: "value must be inside range [1, 5]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetComment()) > 1000 {
+		err := UpdateRatingRequestValidationError{
+			field:  "Comment",
+			reason: "value length must be at most 1000 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UpdateRatingRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateRatingRequestMultiError is an error wrapping multiple validation
+// errors returned by UpdateRatingRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateRatingRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateRatingRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateRatingRequestMultiError) AllErrors() []error { return m }
+
+// UpdateRatingRequestValidationError is the validation error returned by
+// UpdateRatingRequest.Validate if the designated constraints aren't met.
+type UpdateRatingRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateRatingRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateRatingRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateRatingRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateRatingRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateRatingRequestValidationError) ErrorName() string {
+	return "UpdateRatingRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateRatingRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateRatingRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateRatingRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateRatingRequestValidationError{}
+
+// Validate checks the field values on UpdateRatingResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateRatingResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateRatingResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateRatingResponseMultiError, or nil if none found.
+func (m *UpdateRatingResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateRatingResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateRatingResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateRatingResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateRatingResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRating()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateRatingResponseValidationError{
+					field:  "Rating",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateRatingResponseValidationError{
+					field:  "Rating",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRating()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateRatingResponseValidationError{
+				field:  "Rating",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateRatingResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateRatingResponseMultiError is an error wrapping multiple validation
+// errors returned by UpdateRatingResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateRatingResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateRatingResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateRatingResponseMultiError) AllErrors() []error { return m }
+
+// UpdateRatingResponseValidationError is the validation error returned by
+// UpdateRatingResponse.Validate if the designated constraints aren't met.
+type UpdateRatingResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateRatingResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateRatingResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateRatingResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateRatingResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateRatingResponseValidationError) ErrorName() string {
+	return "UpdateRatingResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateRatingResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateRatingResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateRatingResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateRatingResponseValidationError{}
+
+// Validate checks the field values on DeleteRatingRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteRatingRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteRatingRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteRatingRequestMultiError, or nil if none found.
+func (m *DeleteRatingRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteRatingRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := DeleteRatingRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteRatingRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteRatingRequestMultiError is an error wrapping multiple validation
+// errors returned by DeleteRatingRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteRatingRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteRatingRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteRatingRequestMultiError) AllErrors() []error { return m }
+
+// DeleteRatingRequestValidationError is the validation error returned by
+// DeleteRatingRequest.Validate if the designated constraints aren't met.
+type DeleteRatingRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteRatingRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteRatingRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteRatingRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteRatingRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteRatingRequestValidationError) ErrorName() string {
+	return "DeleteRatingRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteRatingRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteRatingRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteRatingRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteRatingRequestValidationError{}
+
+// Validate checks the field values on DeleteRatingResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteRatingResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteRatingResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteRatingResponseMultiError, or nil if none found.
+func (m *DeleteRatingResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteRatingResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DeleteRatingResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DeleteRatingResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DeleteRatingResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DeleteRatingResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteRatingResponseMultiError is an error wrapping multiple validation
+// errors returned by DeleteRatingResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteRatingResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteRatingResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteRatingResponseMultiError) AllErrors() []error { return m }
+
+// DeleteRatingResponseValidationError is the validation error returned by
+// DeleteRatingResponse.Validate if the designated constraints aren't met.
+type DeleteRatingResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteRatingResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteRatingResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteRatingResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteRatingResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteRatingResponseValidationError) ErrorName() string {
+	return "DeleteRatingResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteRatingResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteRatingResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteRatingResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteRatingResponseValidationError{}
+
+// Validate checks the field values on ListRatingsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListRatingsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListRatingsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListRatingsRequestMultiError, or nil if none found.
+func (m *ListRatingsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListRatingsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetPage()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListRatingsRequestValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListRatingsRequestValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPage()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListRatingsRequestValidationError{
+				field:  "Page",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetUserId() < 0 {
+		err := ListRatingsRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMovieId() < 0 {
+		err := ListRatingsRequestValidationError{
+			field:  "MovieId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListRatingsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListRatingsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListRatingsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListRatingsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListRatingsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListRatingsRequestMultiError) AllErrors() []error { return m }
+
+// ListRatingsRequestValidationError is the validation error returned by
+// ListRatingsRequest.Validate if the designated constraints aren't met.
+type ListRatingsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListRatingsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListRatingsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListRatingsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListRatingsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListRatingsRequestValidationError) ErrorName() string {
+	return "ListRatingsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListRatingsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListRatingsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListRatingsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListRatingsRequestValidationError{}
+
+// Validate checks the field values on ListRatingsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListRatingsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListRatingsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListRatingsResponseMultiError, or nil if none found.
+func (m *ListRatingsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListRatingsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListRatingsResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListRatingsResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListRatingsResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetRatings() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListRatingsResponseValidationError{
+						field:  fmt.Sprintf("Ratings[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListRatingsResponseValidationError{
+						field:  fmt.Sprintf("Ratings[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListRatingsResponseValidationError{
+					field:  fmt.Sprintf("Ratings[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetPage()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListRatingsResponseValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListRatingsResponseValidationError{
+					field:  "Page",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPage()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListRatingsResponseValidationError{
+				field:  "Page",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ListRatingsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListRatingsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListRatingsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListRatingsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListRatingsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListRatingsResponseMultiError) AllErrors() []error { return m }
+
+// ListRatingsResponseValidationError is the validation error returned by
+// ListRatingsResponse.Validate if the designated constraints aren't met.
+type ListRatingsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListRatingsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListRatingsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListRatingsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListRatingsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListRatingsResponseValidationError) ErrorName() string {
+	return "ListRatingsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListRatingsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListRatingsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListRatingsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListRatingsResponseValidationError{}
+
+// Validate checks the field values on GetMovieAverageRatingRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetMovieAverageRatingRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetMovieAverageRatingRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetMovieAverageRatingRequestMultiError, or nil if none found.
+func (m *GetMovieAverageRatingRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetMovieAverageRatingRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetMovieId() <= 0 {
+		err := GetMovieAverageRatingRequestValidationError{
+			field:  "MovieId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetMovieAverageRatingRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetMovieAverageRatingRequestMultiError is an error wrapping multiple
+// validation errors returned by GetMovieAverageRatingRequest.ValidateAll() if
+// the designated constraints aren't met.
+type GetMovieAverageRatingRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetMovieAverageRatingRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetMovieAverageRatingRequestMultiError) AllErrors() []error { return m }
+
+// GetMovieAverageRatingRequestValidationError is the validation error returned
+// by GetMovieAverageRatingRequest.Validate if the designated constraints
+// aren't met.
+type GetMovieAverageRatingRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetMovieAverageRatingRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetMovieAverageRatingRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetMovieAverageRatingRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetMovieAverageRatingRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetMovieAverageRatingRequestValidationError) ErrorName() string {
+	return "GetMovieAverageRatingRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetMovieAverageRatingRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetMovieAverageRatingRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetMovieAverageRatingRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetMovieAverageRatingRequestValidationError{}
+
+// Validate checks the field values on GetMovieAverageRatingResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetMovieAverageRatingResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetMovieAverageRatingResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetMovieAverageRatingResponseMultiError, or nil if none found.
+func (m *GetMovieAverageRatingResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetMovieAverageRatingResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetMovieAverageRatingResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetMovieAverageRatingResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetMovieAverageRatingResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for AverageRating
+
+	// no validation rules for TotalRatings
+
+	if len(errors) > 0 {
+		return GetMovieAverageRatingResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetMovieAverageRatingResponseMultiError is an error wrapping multiple
+// validation errors returned by GetMovieAverageRatingResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetMovieAverageRatingResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetMovieAverageRatingResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetMovieAverageRatingResponseMultiError) AllErrors() []error { return m }
+
+// GetMovieAverageRatingResponseValidationError is the validation error
+// returned by GetMovieAverageRatingResponse.Validate if the designated
+// constraints aren't met.
+type GetMovieAverageRatingResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetMovieAverageRatingResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetMovieAverageRatingResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetMovieAverageRatingResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetMovieAverageRatingResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetMovieAverageRatingResponseValidationError) ErrorName() string {
+	return "GetMovieAverageRatingResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetMovieAverageRatingResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetMovieAverageRatingResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetMovieAverageRatingResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetMovieAverageRatingResponseValidationError{}
+
+// Validate checks the field values on BatchGetUserRatingsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetUserRatingsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetUserRatingsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetUserRatingsRequestMultiError, or nil if none found.
+func (m *BatchGetUserRatingsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetUserRatingsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUserId() <= 0 {
+		err := BatchGetUserRatingsRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := len(m.GetMovieIds()); l < 1 || l > 100 {
+		err := BatchGetUserRatingsRequestValidationError{
+			field:  "MovieIds",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetMovieIds() {
+		_, _ = idx, item
+
+		if item <= 0 {
+			err := BatchGetUserRatingsRequestValidationError{
+				field:  fmt.Sprintf("MovieIds[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchGetUserRatingsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetUserRatingsRequestMultiError is an error wrapping multiple
+// validation errors returned by BatchGetUserRatingsRequest.ValidateAll() if
+// the designated constraints aren't met.
+type BatchGetUserRatingsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetUserRatingsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetUserRatingsRequestMultiError) AllErrors() []error { return m }
+
+// BatchGetUserRatingsRequestValidationError is the validation error returned
+// by BatchGetUserRatingsRequest.Validate if the designated constraints aren't met.
+type BatchGetUserRatingsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetUserRatingsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetUserRatingsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetUserRatingsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetUserRatingsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetUserRatingsRequestValidationError) ErrorName() string {
+	return "BatchGetUserRatingsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetUserRatingsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetUserRatingsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetUserRatingsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetUserRatingsRequestValidationError{}
+
+// Validate checks the field values on BatchGetUserRatingsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetUserRatingsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetUserRatingsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetUserRatingsResponseMultiError, or nil if none found.
+func (m *BatchGetUserRatingsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetUserRatingsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCommon()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BatchGetUserRatingsResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BatchGetUserRatingsResponseValidationError{
+					field:  "Common",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommon()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchGetUserRatingsResponseValidationError{
+				field:  "Common",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetRatings() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchGetUserRatingsResponseValidationError{
+						field:  fmt.Sprintf("Ratings[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchGetUserRatingsResponseValidationError{
+						field:  fmt.Sprintf("Ratings[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchGetUserRatingsResponseValidationError{
+					field:  fmt.Sprintf("Ratings[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchGetUserRatingsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetUserRatingsResponseMultiError is an error wrapping multiple
+// validation errors returned by BatchGetUserRatingsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type BatchGetUserRatingsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetUserRatingsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetUserRatingsResponseMultiError) AllErrors() []error { return m }
+
+// BatchGetUserRatingsResponseValidationError is the validation error returned
+// by BatchGetUserRatingsResponse.Validate if the designated constraints
+// aren't met.
+type BatchGetUserRatingsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetUserRatingsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetUserRatingsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetUserRatingsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetUserRatingsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetUserRatingsResponseValidationError) ErrorName() string {
+	return "BatchGetUserRatingsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetUserRatingsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetUserRatingsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetUserRatingsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetUserRatingsResponseValidationError{}
+
+// Validate checks the field values on WatchMovieRatingsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *WatchMovieRatingsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WatchMovieRatingsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WatchMovieRatingsRequestMultiError, or nil if none found.
+func (m *WatchMovieRatingsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WatchMovieRatingsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetMovieId() <= 0 {
+		err := WatchMovieRatingsRequestValidationError{
+			field:  "MovieId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return WatchMovieRatingsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// WatchMovieRatingsRequestMultiError is an error wrapping multiple validation
+// errors returned by WatchMovieRatingsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type WatchMovieRatingsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WatchMovieRatingsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WatchMovieRatingsRequestMultiError) AllErrors() []error { return m }
+
+// WatchMovieRatingsRequestValidationError is the validation error returned by
+// WatchMovieRatingsRequest.Validate if the designated constraints aren't met.
+type WatchMovieRatingsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchMovieRatingsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchMovieRatingsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchMovieRatingsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchMovieRatingsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchMovieRatingsRequestValidationError) ErrorName() string {
+	return "WatchMovieRatingsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WatchMovieRatingsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchMovieRatingsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchMovieRatingsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchMovieRatingsRequestValidationError{}
+
+// Validate checks the field values on RatingEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RatingEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RatingEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RatingEventMultiError, or
+// nil if none found.
+func (m *RatingEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RatingEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Type
+
+	if all {
+		switch v := interface{}(m.GetRating()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RatingEventValidationError{
+					field:  "Rating",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RatingEventValidationError{
+					field:  "Rating",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRating()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RatingEventValidationError{
+				field:  "Rating",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for AverageRating
+
+	// no validation rules for TotalRatings
+
+	if all {
+		switch v := interface{}(m.GetOccurredAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RatingEventValidationError{
+					field:  "OccurredAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RatingEventValidationError{
+					field:  "OccurredAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOccurredAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RatingEventValidationError{
+				field:  "OccurredAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RatingEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// RatingEventMultiError is an error wrapping multiple validation errors
+// returned by RatingEvent.ValidateAll() if the designated constraints aren't met.
+type RatingEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RatingEventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RatingEventMultiError) AllErrors() []error { return m }
+
+// RatingEventValidationError is the validation error returned by
+// RatingEvent.Validate if the designated constraints aren't met.
+type RatingEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RatingEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RatingEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RatingEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RatingEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RatingEventValidationError) ErrorName() string { return "RatingEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RatingEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRatingEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RatingEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RatingEventValidationError{}
+
+// Validate checks the field values on HealthCheckRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *HealthCheckRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HealthCheckRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// HealthCheckRequestMultiError, or nil if none found.
+func (m *HealthCheckRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HealthCheckRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Service
+
+	if len(errors) > 0 {
+		return HealthCheckRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// HealthCheckRequestMultiError is an error wrapping multiple validation errors
+// returned by HealthCheckRequest.ValidateAll() if the designated constraints
+// aren't met.
+type HealthCheckRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HealthCheckRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HealthCheckRequestMultiError) AllErrors() []error { return m }
+
+// HealthCheckRequestValidationError is the validation error returned by
+// HealthCheckRequest.Validate if the designated constraints aren't met.
+type HealthCheckRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HealthCheckRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HealthCheckRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HealthCheckRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HealthCheckRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HealthCheckRequestValidationError) ErrorName() string {
+	return "HealthCheckRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e HealthCheckRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHealthCheckRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HealthCheckRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HealthCheckRequestValidationError{}
+
+// Validate checks the field values on HealthCheckResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *HealthCheckResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HealthCheckResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// HealthCheckResponseMultiError, or nil if none found.
+func (m *HealthCheckResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HealthCheckResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Status
+
+	// no validation rules for Message
+
+	if len(errors) > 0 {
+		return HealthCheckResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// HealthCheckResponseMultiError is an error wrapping multiple validation
+// errors returned by HealthCheckResponse.ValidateAll() if the designated
+// constraints aren't met.
+type HealthCheckResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HealthCheckResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HealthCheckResponseMultiError) AllErrors() []error { return m }
+
+// HealthCheckResponseValidationError is the validation error returned by
+// HealthCheckResponse.Validate if the designated constraints aren't met.
+type HealthCheckResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HealthCheckResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HealthCheckResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HealthCheckResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HealthCheckResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HealthCheckResponseValidationError) ErrorName() string {
+	return "HealthCheckResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e HealthCheckResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHealthCheckResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HealthCheckResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HealthCheckResponseValidationError{}
@@ -0,0 +1,188 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.4
+// source: rating/rating_service.proto
+
+package rating
+
+import (
+	common "github.com/3inchtime/movieinfo/proto/gen/common"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_rating_rating_service_proto protoreflect.FileDescriptor
+
+var file_rating_rating_service_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x1a,
+	0x13, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xe5, 0x09, 0x0a, 0x0d, 0x52, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x79, 0x0a, 0x0c, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x26, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14,
+	0x3a, 0x01, 0x2a, 0x22, 0x0f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x75, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x12, 0x22, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66,
+	0x6f, 0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x16, 0x12, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x90, 0x02, 0x01, 0x12, 0x7e, 0x0a, 0x0c, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x2e, 0x6d, 0x6f,
+	0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72,
+	0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x19, 0x3a, 0x01, 0x2a, 0x32, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x72,
+	0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x7b, 0x0a, 0x0c, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x2e, 0x6d, 0x6f,
+	0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72,
+	0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x16, 0x2a, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x76, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x24, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69,
+	0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e,
+	0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x12, 0x0f, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x90, 0x02, 0x01,
+	0x12, 0xa7, 0x01, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2c, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e,
+	0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x33, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2a, 0x12, 0x28, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x3a, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x90, 0x02, 0x01, 0x12, 0xad, 0x01, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f,
+	0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x76, 0x69, 0x65,
+	0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f,
+	0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x76, 0x69, 0x65,
+	0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x33, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2a, 0x12, 0x28, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x2f, 0x7b, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
+	0x2d, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x90, 0x02, 0x01, 0x12, 0x94, 0x01, 0x0a, 0x11, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x12, 0x2a, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x52, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x32, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x29, 0x12, 0x27, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x6f, 0x76,
+	0x69, 0x65, 0x73, 0x2f, 0x7b, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72,
+	0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x3a, 0x77, 0x61, 0x74, 0x63, 0x68, 0x90, 0x02, 0x01, 0x30,
+	0x01, 0x12, 0x7c, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x12, 0x24, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e,
+	0x66, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x68,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x90, 0x02, 0x01, 0x42,
+	0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x33, 0x69,
+	0x6e, 0x63, 0x68, 0x74, 0x69, 0x6d, 0x65, 0x2f, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x69, 0x6e, 0x66,
+	0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x72, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_rating_rating_service_proto_goTypes = []interface{}{
+	(*CreateRatingRequest)(nil),           // 0: movieinfo.rating.CreateRatingRequest
+	(*GetRatingRequest)(nil),              // 1: movieinfo.rating.GetRatingRequest
+	(*UpdateRatingRequest)(nil),           // 2: movieinfo.rating.UpdateRatingRequest
+	(*DeleteRatingRequest)(nil),           // 3: movieinfo.rating.DeleteRatingRequest
+	(*ListRatingsRequest)(nil),            // 4: movieinfo.rating.ListRatingsRequest
+	(*BatchGetUserRatingsRequest)(nil),    // 5: movieinfo.rating.BatchGetUserRatingsRequest
+	(*GetMovieAverageRatingRequest)(nil),  // 6: movieinfo.rating.GetMovieAverageRatingRequest
+	(*WatchMovieRatingsRequest)(nil),      // 7: movieinfo.rating.WatchMovieRatingsRequest
+	(*common.HealthCheckRequest)(nil),     // 8: movieinfo.common.HealthCheckRequest
+	(*CreateRatingResponse)(nil),          // 9: movieinfo.rating.CreateRatingResponse
+	(*GetRatingResponse)(nil),             // 10: movieinfo.rating.GetRatingResponse
+	(*UpdateRatingResponse)(nil),          // 11: movieinfo.rating.UpdateRatingResponse
+	(*DeleteRatingResponse)(nil),          // 12: movieinfo.rating.DeleteRatingResponse
+	(*ListRatingsResponse)(nil),           // 13: movieinfo.rating.ListRatingsResponse
+	(*BatchGetUserRatingsResponse)(nil),   // 14: movieinfo.rating.BatchGetUserRatingsResponse
+	(*GetMovieAverageRatingResponse)(nil), // 15: movieinfo.rating.GetMovieAverageRatingResponse
+	(*RatingEvent)(nil),                   // 16: movieinfo.rating.RatingEvent
+	(*common.HealthCheckResponse)(nil),    // 17: movieinfo.common.HealthCheckResponse
+}
+var file_rating_rating_service_proto_depIdxs = []int32{
+	0,  // 0: movieinfo.rating.RatingService.CreateRating:input_type -> movieinfo.rating.CreateRatingRequest
+	1,  // 1: movieinfo.rating.RatingService.GetRating:input_type -> movieinfo.rating.GetRatingRequest
+	2,  // 2: movieinfo.rating.RatingService.UpdateRating:input_type -> movieinfo.rating.UpdateRatingRequest
+	3,  // 3: movieinfo.rating.RatingService.DeleteRating:input_type -> movieinfo.rating.DeleteRatingRequest
+	4,  // 4: movieinfo.rating.RatingService.ListRatings:input_type -> movieinfo.rating.ListRatingsRequest
+	5,  // 5: movieinfo.rating.RatingService.BatchGetUserRatings:input_type -> movieinfo.rating.BatchGetUserRatingsRequest
+	6,  // 6: movieinfo.rating.RatingService.GetMovieAverageRating:input_type -> movieinfo.rating.GetMovieAverageRatingRequest
+	7,  // 7: movieinfo.rating.RatingService.WatchMovieRatings:input_type -> movieinfo.rating.WatchMovieRatingsRequest
+	8,  // 8: movieinfo.rating.RatingService.HealthCheck:input_type -> movieinfo.common.HealthCheckRequest
+	9,  // 9: movieinfo.rating.RatingService.CreateRating:output_type -> movieinfo.rating.CreateRatingResponse
+	10, // 10: movieinfo.rating.RatingService.GetRating:output_type -> movieinfo.rating.GetRatingResponse
+	11, // 11: movieinfo.rating.RatingService.UpdateRating:output_type -> movieinfo.rating.UpdateRatingResponse
+	12, // 12: movieinfo.rating.RatingService.DeleteRating:output_type -> movieinfo.rating.DeleteRatingResponse
+	13, // 13: movieinfo.rating.RatingService.ListRatings:output_type -> movieinfo.rating.ListRatingsResponse
+	14, // 14: movieinfo.rating.RatingService.BatchGetUserRatings:output_type -> movieinfo.rating.BatchGetUserRatingsResponse
+	15, // 15: movieinfo.rating.RatingService.GetMovieAverageRating:output_type -> movieinfo.rating.GetMovieAverageRatingResponse
+	16, // 16: movieinfo.rating.RatingService.WatchMovieRatings:output_type -> movieinfo.rating.RatingEvent
+	17, // 17: movieinfo.rating.RatingService.HealthCheck:output_type -> movieinfo.common.HealthCheckResponse
+	9,  // [9:18] is the sub-list for method output_type
+	0,  // [0:9] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rating_rating_service_proto_init() }
+func file_rating_rating_service_proto_init() {
+	if File_rating_rating_service_proto != nil {
+		return
+	}
+	file_rating_rating_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rating_rating_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rating_rating_service_proto_goTypes,
+		DependencyIndexes: file_rating_rating_service_proto_depIdxs,
+	}.Build()
+	File_rating_rating_service_proto = out.File
+	file_rating_rating_service_proto_rawDesc = nil
+	file_rating_rating_service_proto_goTypes = nil
+	file_rating_rating_service_proto_depIdxs = nil
+}
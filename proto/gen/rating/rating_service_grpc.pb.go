@@ -0,0 +1,444 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: rating/rating_service.proto
+
+package rating
+
+import (
+	context "context"
+	common "github.com/3inchtime/movieinfo/proto/gen/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RatingService_CreateRating_FullMethodName          = "/movieinfo.rating.RatingService/CreateRating"
+	RatingService_GetRating_FullMethodName             = "/movieinfo.rating.RatingService/GetRating"
+	RatingService_UpdateRating_FullMethodName          = "/movieinfo.rating.RatingService/UpdateRating"
+	RatingService_DeleteRating_FullMethodName          = "/movieinfo.rating.RatingService/DeleteRating"
+	RatingService_ListRatings_FullMethodName           = "/movieinfo.rating.RatingService/ListRatings"
+	RatingService_BatchGetUserRatings_FullMethodName   = "/movieinfo.rating.RatingService/BatchGetUserRatings"
+	RatingService_GetMovieAverageRating_FullMethodName = "/movieinfo.rating.RatingService/GetMovieAverageRating"
+	RatingService_WatchMovieRatings_FullMethodName     = "/movieinfo.rating.RatingService/WatchMovieRatings"
+	RatingService_HealthCheck_FullMethodName           = "/movieinfo.rating.RatingService/HealthCheck"
+)
+
+// RatingServiceClient is the client API for RatingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RatingServiceClient interface {
+	// 基础评分管理
+	CreateRating(ctx context.Context, in *CreateRatingRequest, opts ...grpc.CallOption) (*CreateRatingResponse, error)
+	GetRating(ctx context.Context, in *GetRatingRequest, opts ...grpc.CallOption) (*GetRatingResponse, error)
+	UpdateRating(ctx context.Context, in *UpdateRatingRequest, opts ...grpc.CallOption) (*UpdateRatingResponse, error)
+	DeleteRating(ctx context.Context, in *DeleteRatingRequest, opts ...grpc.CallOption) (*DeleteRatingResponse, error)
+	ListRatings(ctx context.Context, in *ListRatingsRequest, opts ...grpc.CallOption) (*ListRatingsResponse, error)
+	// 批量操作
+	BatchGetUserRatings(ctx context.Context, in *BatchGetUserRatingsRequest, opts ...grpc.CallOption) (*BatchGetUserRatingsResponse, error)
+	// 统计功能
+	GetMovieAverageRating(ctx context.Context, in *GetMovieAverageRatingRequest, opts ...grpc.CallOption) (*GetMovieAverageRatingResponse, error)
+	// 实时推送电影评分变更（服务端流）
+	WatchMovieRatings(ctx context.Context, in *WatchMovieRatingsRequest, opts ...grpc.CallOption) (RatingService_WatchMovieRatingsClient, error)
+	// 健康检查
+	HealthCheck(ctx context.Context, in *common.HealthCheckRequest, opts ...grpc.CallOption) (*common.HealthCheckResponse, error)
+}
+
+type ratingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRatingServiceClient(cc grpc.ClientConnInterface) RatingServiceClient {
+	return &ratingServiceClient{cc}
+}
+
+func (c *ratingServiceClient) CreateRating(ctx context.Context, in *CreateRatingRequest, opts ...grpc.CallOption) (*CreateRatingResponse, error) {
+	out := new(CreateRatingResponse)
+	err := c.cc.Invoke(ctx, RatingService_CreateRating_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) GetRating(ctx context.Context, in *GetRatingRequest, opts ...grpc.CallOption) (*GetRatingResponse, error) {
+	out := new(GetRatingResponse)
+	err := c.cc.Invoke(ctx, RatingService_GetRating_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) UpdateRating(ctx context.Context, in *UpdateRatingRequest, opts ...grpc.CallOption) (*UpdateRatingResponse, error) {
+	out := new(UpdateRatingResponse)
+	err := c.cc.Invoke(ctx, RatingService_UpdateRating_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) DeleteRating(ctx context.Context, in *DeleteRatingRequest, opts ...grpc.CallOption) (*DeleteRatingResponse, error) {
+	out := new(DeleteRatingResponse)
+	err := c.cc.Invoke(ctx, RatingService_DeleteRating_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) ListRatings(ctx context.Context, in *ListRatingsRequest, opts ...grpc.CallOption) (*ListRatingsResponse, error) {
+	out := new(ListRatingsResponse)
+	err := c.cc.Invoke(ctx, RatingService_ListRatings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) BatchGetUserRatings(ctx context.Context, in *BatchGetUserRatingsRequest, opts ...grpc.CallOption) (*BatchGetUserRatingsResponse, error) {
+	out := new(BatchGetUserRatingsResponse)
+	err := c.cc.Invoke(ctx, RatingService_BatchGetUserRatings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) GetMovieAverageRating(ctx context.Context, in *GetMovieAverageRatingRequest, opts ...grpc.CallOption) (*GetMovieAverageRatingResponse, error) {
+	out := new(GetMovieAverageRatingResponse)
+	err := c.cc.Invoke(ctx, RatingService_GetMovieAverageRating_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) WatchMovieRatings(ctx context.Context, in *WatchMovieRatingsRequest, opts ...grpc.CallOption) (RatingService_WatchMovieRatingsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RatingService_ServiceDesc.Streams[0], RatingService_WatchMovieRatings_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ratingServiceWatchMovieRatingsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RatingService_WatchMovieRatingsClient interface {
+	Recv() (*RatingEvent, error)
+	grpc.ClientStream
+}
+
+type ratingServiceWatchMovieRatingsClient struct {
+	grpc.ClientStream
+}
+
+func (x *ratingServiceWatchMovieRatingsClient) Recv() (*RatingEvent, error) {
+	m := new(RatingEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ratingServiceClient) HealthCheck(ctx context.Context, in *common.HealthCheckRequest, opts ...grpc.CallOption) (*common.HealthCheckResponse, error) {
+	out := new(common.HealthCheckResponse)
+	err := c.cc.Invoke(ctx, RatingService_HealthCheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RatingServiceServer is the server API for RatingService service.
+// All implementations must embed UnimplementedRatingServiceServer
+// for forward compatibility
+type RatingServiceServer interface {
+	// 基础评分管理
+	CreateRating(context.Context, *CreateRatingRequest) (*CreateRatingResponse, error)
+	GetRating(context.Context, *GetRatingRequest) (*GetRatingResponse, error)
+	UpdateRating(context.Context, *UpdateRatingRequest) (*UpdateRatingResponse, error)
+	DeleteRating(context.Context, *DeleteRatingRequest) (*DeleteRatingResponse, error)
+	ListRatings(context.Context, *ListRatingsRequest) (*ListRatingsResponse, error)
+	// 批量操作
+	BatchGetUserRatings(context.Context, *BatchGetUserRatingsRequest) (*BatchGetUserRatingsResponse, error)
+	// 统计功能
+	GetMovieAverageRating(context.Context, *GetMovieAverageRatingRequest) (*GetMovieAverageRatingResponse, error)
+	// 实时推送电影评分变更（服务端流）
+	WatchMovieRatings(*WatchMovieRatingsRequest, RatingService_WatchMovieRatingsServer) error
+	// 健康检查
+	HealthCheck(context.Context, *common.HealthCheckRequest) (*common.HealthCheckResponse, error)
+	mustEmbedUnimplementedRatingServiceServer()
+}
+
+// UnimplementedRatingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRatingServiceServer struct {
+}
+
+func (UnimplementedRatingServiceServer) CreateRating(context.Context, *CreateRatingRequest) (*CreateRatingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRating not implemented")
+}
+func (UnimplementedRatingServiceServer) GetRating(context.Context, *GetRatingRequest) (*GetRatingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRating not implemented")
+}
+func (UnimplementedRatingServiceServer) UpdateRating(context.Context, *UpdateRatingRequest) (*UpdateRatingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRating not implemented")
+}
+func (UnimplementedRatingServiceServer) DeleteRating(context.Context, *DeleteRatingRequest) (*DeleteRatingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRating not implemented")
+}
+func (UnimplementedRatingServiceServer) ListRatings(context.Context, *ListRatingsRequest) (*ListRatingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRatings not implemented")
+}
+func (UnimplementedRatingServiceServer) BatchGetUserRatings(context.Context, *BatchGetUserRatingsRequest) (*BatchGetUserRatingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetUserRatings not implemented")
+}
+func (UnimplementedRatingServiceServer) GetMovieAverageRating(context.Context, *GetMovieAverageRatingRequest) (*GetMovieAverageRatingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMovieAverageRating not implemented")
+}
+func (UnimplementedRatingServiceServer) WatchMovieRatings(*WatchMovieRatingsRequest, RatingService_WatchMovieRatingsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMovieRatings not implemented")
+}
+func (UnimplementedRatingServiceServer) HealthCheck(context.Context, *common.HealthCheckRequest) (*common.HealthCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
+}
+func (UnimplementedRatingServiceServer) mustEmbedUnimplementedRatingServiceServer() {}
+
+// UnsafeRatingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RatingServiceServer will
+// result in compilation errors.
+type UnsafeRatingServiceServer interface {
+	mustEmbedUnimplementedRatingServiceServer()
+}
+
+func RegisterRatingServiceServer(s grpc.ServiceRegistrar, srv RatingServiceServer) {
+	s.RegisterService(&RatingService_ServiceDesc, srv)
+}
+
+func _RatingService_CreateRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).CreateRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_CreateRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).CreateRating(ctx, req.(*CreateRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_GetRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).GetRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_GetRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).GetRating(ctx, req.(*GetRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_UpdateRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).UpdateRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_UpdateRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).UpdateRating(ctx, req.(*UpdateRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_DeleteRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).DeleteRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_DeleteRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).DeleteRating(ctx, req.(*DeleteRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_ListRatings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRatingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).ListRatings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_ListRatings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).ListRatings(ctx, req.(*ListRatingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_BatchGetUserRatings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetUserRatingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).BatchGetUserRatings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_BatchGetUserRatings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).BatchGetUserRatings(ctx, req.(*BatchGetUserRatingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_GetMovieAverageRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMovieAverageRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).GetMovieAverageRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_GetMovieAverageRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).GetMovieAverageRating(ctx, req.(*GetMovieAverageRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_WatchMovieRatings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMovieRatingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RatingServiceServer).WatchMovieRatings(m, &ratingServiceWatchMovieRatingsServer{stream})
+}
+
+type RatingService_WatchMovieRatingsServer interface {
+	Send(*RatingEvent) error
+	grpc.ServerStream
+}
+
+type ratingServiceWatchMovieRatingsServer struct {
+	grpc.ServerStream
+}
+
+func (x *ratingServiceWatchMovieRatingsServer) Send(m *RatingEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RatingService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.HealthCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).HealthCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_HealthCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).HealthCheck(ctx, req.(*common.HealthCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RatingService_ServiceDesc is the grpc.ServiceDesc for RatingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RatingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "movieinfo.rating.RatingService",
+	HandlerType: (*RatingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRating",
+			Handler:    _RatingService_CreateRating_Handler,
+		},
+		{
+			MethodName: "GetRating",
+			Handler:    _RatingService_GetRating_Handler,
+		},
+		{
+			MethodName: "UpdateRating",
+			Handler:    _RatingService_UpdateRating_Handler,
+		},
+		{
+			MethodName: "DeleteRating",
+			Handler:    _RatingService_DeleteRating_Handler,
+		},
+		{
+			MethodName: "ListRatings",
+			Handler:    _RatingService_ListRatings_Handler,
+		},
+		{
+			MethodName: "BatchGetUserRatings",
+			Handler:    _RatingService_BatchGetUserRatings_Handler,
+		},
+		{
+			MethodName: "GetMovieAverageRating",
+			Handler:    _RatingService_GetMovieAverageRating_Handler,
+		},
+		{
+			MethodName: "HealthCheck",
+			Handler:    _RatingService_HealthCheck_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMovieRatings",
+			Handler:       _RatingService_WatchMovieRatings_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rating/rating_service.proto",
+}
@@ -103,3 +103,142 @@ CREATE TABLE user_ratings (
     CONSTRAINT fk_user_ratings_movie FOREIGN KEY (movie_id) REFERENCES movies (id) ON DELETE CASCADE,
     CONSTRAINT chk_rating_range CHECK (rating >= 1 AND rating <= 10)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户评分表';
+
+-- 创建幂等键表
+CREATE TABLE idempotency_keys (
+    idem_key CHAR(64) NOT NULL COMMENT '幂等键（方法、用户与客户端键组合后的SHA-256）',
+    fingerprint CHAR(64) NOT NULL COMMENT '请求内容指纹',
+    completed TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否已完成：0-处理中，1-已完成',
+    response MEDIUMBLOB DEFAULT NULL COMMENT '序列化的响应',
+    expires_at DATETIME(3) NOT NULL COMMENT '过期时间（UTC）',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (idem_key),
+    KEY idx_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='幂等键表';
+
+-- 创建角色表
+CREATE TABLE roles (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '角色ID',
+    name VARCHAR(50) NOT NULL COMMENT '角色名称',
+    description VARCHAR(255) DEFAULT NULL COMMENT '角色描述',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='角色表';
+
+-- 创建权限表
+CREATE TABLE permissions (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '权限ID',
+    name VARCHAR(100) NOT NULL COMMENT '权限名称，如 movies:write',
+    description VARCHAR(255) DEFAULT NULL COMMENT '权限描述',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='权限表';
+
+-- 创建角色权限关联表
+CREATE TABLE role_permissions (
+    role_id INT UNSIGNED NOT NULL COMMENT '角色ID',
+    permission_id INT UNSIGNED NOT NULL COMMENT '权限ID',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (role_id, permission_id),
+    KEY idx_permission_id (permission_id),
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE,
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_id) REFERENCES permissions (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='角色权限关联表';
+
+-- 创建用户角色关联表
+CREATE TABLE user_roles (
+    user_id BIGINT UNSIGNED NOT NULL COMMENT '用户ID',
+    role_id INT UNSIGNED NOT NULL COMMENT '角色ID',
+    granted_by BIGINT UNSIGNED DEFAULT NULL COMMENT '授予角色的管理员ID',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '授予时间',
+    PRIMARY KEY (user_id, role_id),
+    KEY idx_role_id (role_id),
+    CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_roles_role FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户角色关联表';
+
+-- 创建登录历史表
+CREATE TABLE user_login_history (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '记录ID',
+    user_id BIGINT UNSIGNED NOT NULL COMMENT '用户ID',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '' COMMENT '登录IP（IPv4或IPv6）',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '' COMMENT '客户端User-Agent',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '登录时间',
+    PRIMARY KEY (id),
+    KEY idx_user_created (user_id, created_at),
+    CONSTRAINT fk_user_login_history_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='登录历史表';
+
+-- 创建TOTP两步验证表
+CREATE TABLE user_totp (
+    user_id BIGINT UNSIGNED NOT NULL COMMENT '用户ID',
+    secret VARCHAR(64) NOT NULL COMMENT 'Base32编码的TOTP密钥',
+    enabled TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否已启用：0待确认 1已启用',
+    last_used_step BIGINT NOT NULL DEFAULT 0 COMMENT '最近一次使用的动态码周期序号',
+    enabled_at TIMESTAMP NULL DEFAULT NULL COMMENT '启用时间',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '登记时间',
+    PRIMARY KEY (user_id),
+    CONSTRAINT fk_user_totp_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='TOTP两步验证表';
+
+-- 创建两步验证恢复码表
+CREATE TABLE user_recovery_codes (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '记录ID',
+    user_id BIGINT UNSIGNED NOT NULL COMMENT '用户ID',
+    code_hash CHAR(64) NOT NULL COMMENT '恢复码的SHA-256哈希',
+    used_at TIMESTAMP NULL DEFAULT NULL COMMENT '使用时间，未使用为NULL',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '生成时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_user_code (user_id, code_hash),
+    CONSTRAINT fk_user_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='两步验证恢复码表';
+
+-- 创建第三方身份关联表
+CREATE TABLE user_identities (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '记录ID',
+    user_id BIGINT UNSIGNED NOT NULL COMMENT '用户ID',
+    provider VARCHAR(50) NOT NULL COMMENT '身份提供方名称，对应 oidc.providers 配置',
+    subject VARCHAR(255) NOT NULL COMMENT '用户在身份提供方的唯一标识（ID令牌的 sub）',
+    email VARCHAR(100) NOT NULL DEFAULT '' COMMENT '最近一次登录时身份提供方返回的邮箱',
+    last_login_at TIMESTAMP NULL DEFAULT NULL COMMENT '最近一次通过该身份登录的时间',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '关联时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_provider_subject (provider, subject),
+    KEY idx_user_id (user_id),
+    CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='第三方身份关联表';
+
+-- 创建登录会话表
+CREATE TABLE user_sessions (
+    id VARCHAR(64) NOT NULL COMMENT '会话ID，与刷新令牌中的会话ID相同',
+    user_id BIGINT UNSIGNED NOT NULL COMMENT '用户ID',
+    device VARCHAR(100) NOT NULL DEFAULT '' COMMENT '根据User-Agent识别的设备名称',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '' COMMENT '最近一次活动的IP（IPv4或IPv6）',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '' COMMENT '登录时的客户端User-Agent',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '登录时间',
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '最近一次登录或刷新令牌的时间',
+    expires_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '会话过期时间',
+    PRIMARY KEY (id),
+    KEY idx_user_expires (user_id, expires_at),
+    CONSTRAINT fk_user_sessions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='登录会话表';
+
+-- 创建API密钥表
+CREATE TABLE api_keys (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '密钥ID',
+    user_id BIGINT UNSIGNED NOT NULL COMMENT '用户ID',
+    name VARCHAR(100) NOT NULL COMMENT '密钥名称，同一用户内唯一',
+    prefix VARCHAR(20) NOT NULL COMMENT '密钥前缀，用于查找和展示',
+    key_hash CHAR(64) NOT NULL COMMENT '完整密钥的SHA-256哈希',
+    scopes VARCHAR(255) NOT NULL COMMENT '权限范围，逗号分隔：read-only, catalogue-write, ratings-write',
+    expires_at TIMESTAMP NULL DEFAULT NULL COMMENT '过期时间，NULL表示永不过期',
+    last_used_at TIMESTAMP NULL DEFAULT NULL COMMENT '最近一次使用时间',
+    last_used_ip VARCHAR(45) NOT NULL DEFAULT '' COMMENT '最近一次使用的IP',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_prefix (prefix),
+    UNIQUE KEY uk_user_name (user_id, name),
+    CONSTRAINT fk_api_keys_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='API密钥表';
@@ -21,11 +21,29 @@ INSERT INTO movies (title, original_title, description, director, actors, releas
                                                                                                                                               ('阿甘正传', 'Forrest Gump', '阿甘是一个智商只有75的低能儿，但他善良单纯，通过自己的努力创造了一个又一个奇迹。', '罗伯特·泽米吉斯', '["汤姆·汉克斯", "罗宾·怀特"]', '1994-07-06', 142, '美国', '英语', 3, 1),
                                                                                                                                               ('泰坦尼克号', 'Titanic', '1912年4月14日，载着1316号乘客和891名船员的豪华巨轮泰坦尼克号与冰山相撞而沉没，这场海难被认为是20世纪人间十大灾难之一。', '詹姆斯·卡梅隆', '["莱昂纳多·迪卡普里奥", "凯特·温斯莱特"]', '1997-12-19', 194, '美国', '英语', 6, 1);
 
--- 插入示例用户数据（密码为 'password123' 的哈希值，实际使用时应该用更安全的密码）
+-- 插入示例用户数据（密码为 'password123' 的bcrypt哈希值，实际使用时应该用更安全的密码，首次登录后自动升级为argon2id）
 INSERT INTO users (username, email, password_hash, nickname, status, email_verified) VALUES
                                                                                          ('admin', 'admin@movieinfo.com', '$2a$10$N9qo8uLOickgx2ZMRZoMye1VdLSnqpjLjMTYcYxZ8VQjLOqpOqrAu', '管理员', 1, true),
                                                                                          ('testuser', 'test@movieinfo.com', '$2a$10$N9qo8uLOickgx2ZMRZoMye1VdLSnqpjLjMTYcYxZ8VQjLOqpOqrAu', '测试用户', 1, true);
 
+-- 插入角色与权限数据，示例用户 admin 拥有管理员角色
+INSERT INTO roles (name, description) VALUES
+    ('admin', '管理员，拥有全部权限'),
+    ('editor', '编辑，维护电影信息');
+
+INSERT INTO permissions (name, description) VALUES
+    ('movies:write', '创建、修改和删除电影'),
+    ('users:read', '查看用户列表'),
+    ('users:write', '删除用户'),
+    ('roles:manage', '授予和撤销用户角色');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'admin' OR (r.name = 'editor' AND p.name = 'movies:write');
+
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id FROM users u, roles r WHERE u.username = 'admin' AND r.name = 'admin';
+
 -- 插入示例评分数据
 INSERT INTO user_ratings (user_id, movie_id, rating, comment) VALUES
                                                                   (1, 1, 10, '经典中的经典，值得反复观看'),
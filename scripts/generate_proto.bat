@@ -35,15 +35,43 @@ if %errorlevel% neq 0 (
     exit /b 1
 )
 
+REM Check if protoc-gen-grpc-gateway is installed
+protoc-gen-grpc-gateway --version >nul 2>&1
+if %errorlevel% neq 0 (
+    echo Error: protoc-gen-grpc-gateway not installed
+    echo Please run: go install github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway@latest
+    pause
+    exit /b 1
+)
+
+REM Check if protoc-gen-openapiv2 is installed
+protoc-gen-openapiv2 --version >nul 2>&1
+if %errorlevel% neq 0 (
+    echo Error: protoc-gen-openapiv2 not installed
+    echo Please run: go install github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2@latest
+    pause
+    exit /b 1
+)
+
+REM Check if protoc-gen-validate is installed
+where protoc-gen-validate >nul 2>&1
+if %errorlevel% neq 0 (
+    echo Error: protoc-gen-validate not installed
+    echo Please run: go install github.com/envoyproxy/protoc-gen-validate@v1.0.2
+    pause
+    exit /b 1
+)
+
 REM Create output directories
 if not exist "proto\gen" mkdir "proto\gen"
 if not exist "proto\gen\common" mkdir "proto\gen\common"
 if not exist "proto\gen\user" mkdir "proto\gen\user"
 if not exist "proto\gen\movie" mkdir "proto\gen\movie"
 if not exist "proto\gen\rating" mkdir "proto\gen\rating"
+if not exist "docs\openapi" mkdir "docs\openapi"
 
 echo Generating common module code...
-protoc --proto_path=proto --go_out=proto/gen --go_opt=paths=source_relative --go-grpc_out=proto/gen --go-grpc_opt=paths=source_relative common/common.proto common/error.proto
+protoc --proto_path=proto --proto_path=proto/third_party --go_out=proto/gen --go_opt=paths=source_relative --go-grpc_out=proto/gen --go-grpc_opt=paths=source_relative --validate_out="lang=go,paths=source_relative:proto/gen" common/common.proto common/error.proto
 
 if %errorlevel% neq 0 (
     echo Error: Failed to generate common module code
@@ -52,7 +80,7 @@ if %errorlevel% neq 0 (
 )
 
 echo Generating user service code...
-protoc --proto_path=proto --go_out=proto/gen --go_opt=paths=source_relative --go-grpc_out=proto/gen --go-grpc_opt=paths=source_relative user/user.proto user/user_service.proto
+protoc --proto_path=proto --proto_path=proto/third_party --go_out=proto/gen --go_opt=paths=source_relative --go-grpc_out=proto/gen --go-grpc_opt=paths=source_relative --grpc-gateway_out=proto/gen --grpc-gateway_opt=paths=source_relative --validate_out="lang=go,paths=source_relative:proto/gen" user/user.proto user/user_service.proto
 
 if %errorlevel% neq 0 (
     echo Error: Failed to generate user service code
@@ -61,7 +89,7 @@ if %errorlevel% neq 0 (
 )
 
 echo Generating movie service code...
-protoc --proto_path=proto --go_out=proto/gen --go_opt=paths=source_relative --go-grpc_out=proto/gen --go-grpc_opt=paths=source_relative movie/movie.proto movie/movie_service.proto
+protoc --proto_path=proto --proto_path=proto/third_party --go_out=proto/gen --go_opt=paths=source_relative --go-grpc_out=proto/gen --go-grpc_opt=paths=source_relative --grpc-gateway_out=proto/gen --grpc-gateway_opt=paths=source_relative --validate_out="lang=go,paths=source_relative:proto/gen" movie/movie.proto movie/movie_service.proto
 
 if %errorlevel% neq 0 (
     echo Error: Failed to generate movie service code
@@ -70,7 +98,7 @@ if %errorlevel% neq 0 (
 )
 
 echo Generating rating service code...
-protoc --proto_path=proto --go_out=proto/gen --go_opt=paths=source_relative --go-grpc_out=proto/gen --go-grpc_opt=paths=source_relative rating/rating.proto rating/rating_service.proto
+protoc --proto_path=proto --proto_path=proto/third_party --go_out=proto/gen --go_opt=paths=source_relative --go-grpc_out=proto/gen --go-grpc_opt=paths=source_relative --grpc-gateway_out=proto/gen --grpc-gateway_opt=paths=source_relative --validate_out="lang=go,paths=source_relative:proto/gen" rating/rating.proto rating/rating_service.proto
 
 if %errorlevel% neq 0 (
     echo Error: Failed to generate rating service code
@@ -78,6 +106,15 @@ if %errorlevel% neq 0 (
     exit /b 1
 )
 
+echo Generating OpenAPI document...
+protoc --proto_path=proto --proto_path=proto/third_party --openapiv2_out=docs/openapi --openapiv2_opt=allow_merge=true,merge_file_name=movieinfo user/user_service.proto movie/movie_service.proto rating/rating_service.proto
+
+if %errorlevel% neq 0 (
+    echo Error: Failed to generate OpenAPI document
+    pause
+    exit /b 1
+)
+
 echo.
 echo gRPC code generation completed!
 echo Generated files are located at: proto/gen/
@@ -87,5 +124,6 @@ echo   proto/gen/common/    - Common data types and error definitions
 echo   proto/gen/user/      - User service related code
 echo   proto/gen/movie/     - Movie service related code
 echo   proto/gen/rating/    - Rating service related code
+echo   docs/openapi/        - OpenAPI document for the REST API
 echo.
 pause
\ No newline at end of file
@@ -31,41 +31,78 @@ if ! command -v protoc-gen-go-grpc &> /dev/null; then
     exit 1
 fi
 
+# 检查protoc-gen-grpc-gateway和protoc-gen-openapiv2是否安装
+for plugin in protoc-gen-grpc-gateway protoc-gen-openapiv2; do
+    if ! command -v $plugin &> /dev/null; then
+        echo "错误: $plugin未安装"
+        echo "请运行: go install github.com/grpc-ecosystem/grpc-gateway/v2/$plugin@latest"
+        exit 1
+    fi
+done
+
+# 检查protoc-gen-validate是否安装
+if ! command -v protoc-gen-validate &> /dev/null; then
+    echo "错误: protoc-gen-validate未安装"
+    echo "请运行: go install github.com/envoyproxy/protoc-gen-validate@v1.0.2"
+    exit 1
+fi
+
 # 创建输出目录
-mkdir -p proto/gen/{common,user,movie,rating}
+mkdir -p proto/gen/{common,user,movie,rating} docs/openapi
 
 echo "生成通用模块代码..."
 protoc --proto_path=proto \
+    --proto_path=proto/third_party \
     --go_out=proto/gen \
     --go_opt=paths=source_relative \
     --go-grpc_out=proto/gen \
     --go-grpc_opt=paths=source_relative \
+    --validate_out="lang=go,paths=source_relative:proto/gen" \
     common/common.proto common/error.proto
 
 echo "生成用户服务代码..."
 protoc --proto_path=proto \
+    --proto_path=proto/third_party \
     --go_out=proto/gen \
     --go_opt=paths=source_relative \
     --go-grpc_out=proto/gen \
     --go-grpc_opt=paths=source_relative \
+    --grpc-gateway_out=proto/gen \
+    --grpc-gateway_opt=paths=source_relative \
+    --validate_out="lang=go,paths=source_relative:proto/gen" \
     user/user.proto user/user_service.proto
 
 echo "生成电影服务代码..."
 protoc --proto_path=proto \
+    --proto_path=proto/third_party \
     --go_out=proto/gen \
     --go_opt=paths=source_relative \
     --go-grpc_out=proto/gen \
     --go-grpc_opt=paths=source_relative \
+    --grpc-gateway_out=proto/gen \
+    --grpc-gateway_opt=paths=source_relative \
+    --validate_out="lang=go,paths=source_relative:proto/gen" \
     movie/movie.proto movie/movie_service.proto
 
 echo "生成评分服务代码..."
 protoc --proto_path=proto \
+    --proto_path=proto/third_party \
     --go_out=proto/gen \
     --go_opt=paths=source_relative \
     --go-grpc_out=proto/gen \
     --go-grpc_opt=paths=source_relative \
+    --grpc-gateway_out=proto/gen \
+    --grpc-gateway_opt=paths=source_relative \
+    --validate_out="lang=go,paths=source_relative:proto/gen" \
     rating/rating.proto rating/rating_service.proto
 
+echo "生成OpenAPI文档..."
+protoc --proto_path=proto \
+    --proto_path=proto/third_party \
+    --openapiv2_out=docs/openapi \
+    --openapiv2_opt=allow_merge=true,merge_file_name=movieinfo \
+    user/user_service.proto movie/movie_service.proto rating/rating_service.proto
+
 echo
 echo "gRPC代码生成完成！"
 echo "生成的文件位于: proto/gen/"
@@ -75,4 +112,5 @@ echo "  proto/gen/common/    - 通用数据类型和错误定义"
 echo "  proto/gen/user/      - 用户服务相关代码"
 echo "  proto/gen/movie/     - 电影服务相关代码"
 echo "  proto/gen/rating/    - 评分服务相关代码"
+echo "  docs/openapi/        - REST接口的OpenAPI文档"
 echo
\ No newline at end of file